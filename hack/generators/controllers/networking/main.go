@@ -227,6 +227,21 @@ var inputControllersNeeded = &typesNeeded{
 		AcceptsIngressClassNameSpec:       false,
 		RBACVerbs:                         []string{"get", "list", "watch"},
 	},
+	typeNeeded{
+		Group:                             "configuration.konghq.com",
+		Version:                           "v1alpha1",
+		Kind:                              "GatewayClassParameters",
+		PackageImportAlias:                "kongv1alpha1",
+		PackageAlias:                      "KongV1Alpha1",
+		Package:                           kongv1alpha1,
+		Plural:                            "gatewayclassparameterses",
+		CacheType:                         "GatewayClassParameters",
+		NeedsStatusPermissions:            false,
+		CapableOfStatusUpdates:            false,
+		AcceptsIngressClassNameAnnotation: false,
+		AcceptsIngressClassNameSpec:       false,
+		RBACVerbs:                         []string{"get", "list", "watch"},
+	},
 }
 
 var inputRBACPermissionsNeeded = &rbacsNeeded{
@@ -412,6 +427,7 @@ import (
 	ctrlref "github.com/kong/kubernetes-ingress-controller/v2/internal/controllers/reference"
 	ctrlutils "github.com/kong/kubernetes-ingress-controller/v2/internal/controllers/utils"
 	"github.com/kong/kubernetes-ingress-controller/v2/internal/dataplane"
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/tracing"
 	"github.com/kong/kubernetes-ingress-controller/v2/internal/util"
 	"github.com/kong/kubernetes-ingress-controller/v2/internal/util/kubernetes/object/status"
 	kongv1 "github.com/kong/kubernetes-ingress-controller/v2/pkg/apis/configuration/v1"
@@ -537,6 +553,8 @@ func (r *{{.PackageAlias}}{{.Kind}}Reconciler) listClassless(obj client.Object)
 // Reconcile processes the watched objects
 func (r *{{.PackageAlias}}{{.Kind}}Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	log := r.Log.WithValues("{{.PackageAlias}}{{.Kind}}", req.NamespacedName)
+	ctx, span := tracing.StartReconcile(ctx, "{{.Kind}}", req.NamespacedName)
+	defer span.End()
 
 	// get the relevant object
 	obj := new({{.PackageImportAlias}}.{{.Kind}})
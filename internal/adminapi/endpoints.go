@@ -0,0 +1,84 @@
+package adminapi
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+
+	discoveryv1 "k8s.io/api/discovery/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// DefaultAdminAPIServicePortNames are the names of the Service ports which are commonly
+// used to expose Kong Admin API.
+var DefaultAdminAPIServicePortNames = []string{"admin", "admin-tls", "kong-admin", "kong-admin-tls"}
+
+// GetURLsForService returns the Kong Admin API URLs of all the ready endpoints of the provided
+// Service, using only the ports with the provided names.
+func GetURLsForService(
+	ctx context.Context, c client.Client, service types.NamespacedName, portNames sets.Set[string],
+) (sets.Set[string], error) {
+	var endpointSlices discoveryv1.EndpointSliceList
+	if err := c.List(ctx, &endpointSlices,
+		client.InNamespace(service.Namespace),
+		client.MatchingLabels{discoveryv1.LabelServiceName: service.Name},
+	); err != nil {
+		return nil, fmt.Errorf("failed to list EndpointSlices of %s Service: %w", service, err)
+	}
+
+	urls := sets.New[string]()
+	for _, endpointSlice := range endpointSlices.Items {
+		urls = urls.Union(GetURLsForEndpointSlice(endpointSlice, portNames))
+	}
+	return urls, nil
+}
+
+// GetURLsForEndpointSlice returns the Kong Admin API URLs of all the ready endpoints in the
+// provided EndpointSlice, using only the ports with the provided names. Ports which names end
+// with "tls" are expected to serve HTTPS. Each endpoint is a single Kong instance, so a single
+// URL is returned per endpoint, using HTTPS when the Admin API is also exposed over HTTP.
+func GetURLsForEndpointSlice(endpointSlice discoveryv1.EndpointSlice, portNames sets.Set[string]) sets.Set[string] {
+	urls := sets.New[string]()
+	if endpointSlice.AddressType == discoveryv1.AddressTypeFQDN {
+		return urls
+	}
+
+	var (
+		port   *discoveryv1.EndpointPort
+		scheme string
+	)
+	for i := range endpointSlice.Ports {
+		p := &endpointSlice.Ports[i]
+		if p.Name == nil || !portNames.Has(*p.Name) || p.Port == nil {
+			continue
+		}
+		if strings.HasSuffix(*p.Name, "tls") {
+			port, scheme = p, "https"
+			break
+		}
+		if port == nil {
+			port, scheme = p, "http"
+		}
+	}
+	if port == nil {
+		return urls
+	}
+
+	for _, endpoint := range endpointSlice.Endpoints {
+		// a nil ready condition has to be interpreted as ready, as per the API documentation
+		if endpoint.Conditions.Ready != nil && !*endpoint.Conditions.Ready {
+			continue
+		}
+		if len(endpoint.Addresses) == 0 {
+			continue
+		}
+		// all the addresses of an endpoint are fungible, so using the first one is enough
+		host := net.JoinHostPort(endpoint.Addresses[0], strconv.Itoa(int(*port.Port)))
+		urls.Insert(scheme + "://" + host)
+	}
+	return urls
+}
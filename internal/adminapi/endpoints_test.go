@@ -0,0 +1,135 @@
+package adminapi
+
+import (
+	"context"
+	"testing"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestGetURLsForEndpointSlice(t *testing.T) {
+	portNames := sets.New(DefaultAdminAPIServicePortNames...)
+
+	testCases := []struct {
+		name          string
+		endpointSlice discoveryv1.EndpointSlice
+		expected      []string
+	}{
+		{
+			name: "ready endpoints on admin ports prefer TLS",
+			endpointSlice: discoveryv1.EndpointSlice{
+				AddressType: discoveryv1.AddressTypeIPv4,
+				Endpoints: []discoveryv1.Endpoint{
+					{Addresses: []string{"10.0.0.1"}, Conditions: discoveryv1.EndpointConditions{Ready: lo.ToPtr(true)}},
+					{Addresses: []string{"10.0.0.2"}},
+				},
+				Ports: []discoveryv1.EndpointPort{
+					{Name: lo.ToPtr("admin"), Port: lo.ToPtr(int32(8001))},
+					{Name: lo.ToPtr("admin-tls"), Port: lo.ToPtr(int32(8444))},
+					{Name: lo.ToPtr("proxy"), Port: lo.ToPtr(int32(8000))},
+				},
+			},
+			expected: []string{
+				"https://10.0.0.1:8444",
+				"https://10.0.0.2:8444",
+			},
+		},
+		{
+			name: "plain admin ports are used without TLS ones",
+			endpointSlice: discoveryv1.EndpointSlice{
+				AddressType: discoveryv1.AddressTypeIPv4,
+				Endpoints: []discoveryv1.Endpoint{
+					{Addresses: []string{"10.0.0.1"}},
+				},
+				Ports: []discoveryv1.EndpointPort{
+					{Name: lo.ToPtr("proxy"), Port: lo.ToPtr(int32(8000))},
+					{Name: lo.ToPtr("admin"), Port: lo.ToPtr(int32(8001))},
+					{Name: lo.ToPtr("kong-admin"), Port: lo.ToPtr(int32(8002))},
+				},
+			},
+			expected: []string{"http://10.0.0.1:8001"},
+		},
+		{
+			name: "endpoints which are not ready are skipped",
+			endpointSlice: discoveryv1.EndpointSlice{
+				AddressType: discoveryv1.AddressTypeIPv4,
+				Endpoints: []discoveryv1.Endpoint{
+					{Addresses: []string{"10.0.0.1"}, Conditions: discoveryv1.EndpointConditions{Ready: lo.ToPtr(false)}},
+					{Addresses: []string{"10.0.0.2"}, Conditions: discoveryv1.EndpointConditions{Ready: lo.ToPtr(true)}},
+				},
+				Ports: []discoveryv1.EndpointPort{
+					{Name: lo.ToPtr("kong-admin"), Port: lo.ToPtr(int32(8001))},
+				},
+			},
+			expected: []string{"http://10.0.0.2:8001"},
+		},
+		{
+			name: "IPv6 addresses are bracketed",
+			endpointSlice: discoveryv1.EndpointSlice{
+				AddressType: discoveryv1.AddressTypeIPv6,
+				Endpoints: []discoveryv1.Endpoint{
+					{Addresses: []string{"fd00::1"}},
+				},
+				Ports: []discoveryv1.EndpointPort{
+					{Name: lo.ToPtr("admin-tls"), Port: lo.ToPtr(int32(8444))},
+				},
+			},
+			expected: []string{"https://[fd00::1]:8444"},
+		},
+		{
+			name: "no admin ports",
+			endpointSlice: discoveryv1.EndpointSlice{
+				AddressType: discoveryv1.AddressTypeIPv4,
+				Endpoints: []discoveryv1.Endpoint{
+					{Addresses: []string{"10.0.0.1"}},
+				},
+				Ports: []discoveryv1.EndpointPort{
+					{Name: lo.ToPtr("proxy"), Port: lo.ToPtr(int32(8000))},
+				},
+			},
+			expected: []string{},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			urls := GetURLsForEndpointSlice(tc.endpointSlice, portNames)
+			assert.ElementsMatch(t, tc.expected, urls.UnsortedList())
+		})
+	}
+}
+
+func TestGetURLsForService(t *testing.T) {
+	newEndpointSlice := func(name, service, address string) *discoveryv1.EndpointSlice {
+		return &discoveryv1.EndpointSlice{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: "kong",
+				Labels:    map[string]string{discoveryv1.LabelServiceName: service},
+			},
+			AddressType: discoveryv1.AddressTypeIPv4,
+			Endpoints:   []discoveryv1.Endpoint{{Addresses: []string{address}}},
+			Ports:       []discoveryv1.EndpointPort{{Name: lo.ToPtr("admin"), Port: lo.ToPtr(int32(8001))}},
+		}
+	}
+
+	c := fake.NewClientBuilder().WithObjects(
+		newEndpointSlice("kong-admin-1", "kong-admin", "10.0.0.1"),
+		newEndpointSlice("kong-admin-2", "kong-admin", "10.0.0.2"),
+		newEndpointSlice("kong-proxy-1", "kong-proxy", "10.0.0.3"),
+	).Build()
+
+	urls, err := GetURLsForService(
+		context.Background(), c, types.NamespacedName{Namespace: "kong", Name: "kong-admin"}, sets.New("admin"),
+	)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"http://10.0.0.1:8001", "http://10.0.0.2:8001"}, urls.UnsortedList())
+}
@@ -112,9 +112,11 @@ func MakeHTTPClient(opts *HTTPClientOpts) (*http.Client, error) {
 	transport := http.DefaultTransport.(*http.Transport).Clone()
 	transport.TLSClientConfig = &tlsConfig
 	return &http.Client{
-		Transport: &HeaderRoundTripper{
-			headers: opts.Headers,
-			rt:      transport,
+		Transport: &TracingRoundTripper{
+			rt: &HeaderRoundTripper{
+				headers: opts.Headers,
+				rt:      transport,
+			},
 		},
 	}, nil
 }
@@ -0,0 +1,44 @@
+package adminapi
+
+import (
+	"fmt"
+	"net/http"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/propagation"
+
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/tracing"
+)
+
+// TracingRoundTripper traces the requests made via RT as children of the spans in their
+// contexts, and propagates the traces to the Admin API with the global propagator.
+type TracingRoundTripper struct {
+	rt http.RoundTripper
+}
+
+// RoundTrip satisfies the RoundTripper interface.
+func (t *TracingRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx, span := tracing.StartClient(req.Context(), "HTTP "+req.Method,
+		attribute.String("http.method", req.Method),
+		attribute.String("http.url", req.URL.String()),
+	)
+	if !span.IsRecording() {
+		span.End()
+		return t.rt.RoundTrip(req)
+	}
+	defer span.End()
+
+	newRequest := req.Clone(ctx)
+	otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(newRequest.Header))
+	resp, err := t.rt.RoundTrip(newRequest)
+	if err != nil {
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+	span.SetAttributes(attribute.Int("http.status_code", resp.StatusCode))
+	if resp.StatusCode >= http.StatusBadRequest {
+		tracing.RecordError(span, fmt.Errorf("responded with %s", resp.Status))
+	}
+	return resp, nil
+}
@@ -0,0 +1,64 @@
+package adminapi
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/tracing"
+)
+
+func TestTracingRoundTripper(t *testing.T) {
+	var traceparent string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		traceparent = r.Header.Get("traceparent")
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+	client := &http.Client{Transport: &TracingRoundTripper{rt: http.DefaultTransport}}
+
+	t.Log("verifying that requests are not traced when tracing is disabled")
+	resp, err := client.Get(server.URL)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Empty(t, traceparent)
+
+	exporter := tracetest.NewInMemoryExporter()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter)))
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	t.Cleanup(func() {
+		otel.SetTracerProvider(trace.NewNoopTracerProvider())
+		otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator())
+	})
+
+	t.Log("verifying that requests are traced as children of the spans in their contexts")
+	ctx, parent := tracing.Start(context.Background(), "parent")
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL+"/status", nil)
+	require.NoError(t, err)
+	resp, err = client.Do(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+	parent.End()
+
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 2)
+	span := spans[0]
+	assert.Equal(t, "HTTP GET", span.Name)
+	assert.Equal(t, trace.SpanKindClient, span.SpanKind)
+	assert.Equal(t, spans[1].SpanContext.SpanID(), span.Parent.SpanID())
+	assert.Equal(t, "00-"+span.SpanContext.TraceID().String()+"-"+span.SpanContext.SpanID().String()+"-01", traceparent)
+	assert.Contains(t, span.Attributes, attribute.String("http.url", server.URL+"/status"))
+	assert.Contains(t, span.Attributes, attribute.Int("http.status_code", http.StatusNotFound))
+	assert.Equal(t, sdktrace.Status{Code: codes.Error, Description: "responded with 404 Not Found"}, span.Status)
+}
@@ -0,0 +1,60 @@
+package admission
+
+import (
+	"github.com/kong/go-kong/kong"
+
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/adminapi"
+)
+
+// AdminAPIServicesProvider provides the Kong Admin API services used to validate entities.
+// The second return value is false when no service is available at the moment.
+type AdminAPIServicesProvider interface {
+	GetConsumersService() (kong.AbstractConsumerService, bool)
+	GetPluginsService() (kong.AbstractPluginService, bool)
+}
+
+// AdminAPIClientsProvider provides the Kong Admin API clients currently in use.
+type AdminAPIClientsProvider interface {
+	AdminAPIClients() []*adminapi.Client
+}
+
+// DefaultAdminAPIServicesProvider provides the services of the Kong Admin API clients returned
+// by an AdminAPIClientsProvider, so the validator follows the Kong instances as they come and go.
+type DefaultAdminAPIServicesProvider struct {
+	clientsProvider AdminAPIClientsProvider
+}
+
+// NewDefaultAdminAPIServicesProvider returns a DefaultAdminAPIServicesProvider using the given clients provider.
+func NewDefaultAdminAPIServicesProvider(clientsProvider AdminAPIClientsProvider) *DefaultAdminAPIServicesProvider {
+	return &DefaultAdminAPIServicesProvider{clientsProvider: clientsProvider}
+}
+
+func (p *DefaultAdminAPIServicesProvider) GetConsumersService() (kong.AbstractConsumerService, bool) {
+	c, ok := p.client()
+	if !ok {
+		return nil, false
+	}
+	return c.Consumers, true
+}
+
+func (p *DefaultAdminAPIServicesProvider) GetPluginsService() (kong.AbstractPluginService, bool) {
+	c, ok := p.client()
+	if !ok {
+		return nil, false
+	}
+	return c.Plugins, true
+}
+
+// client returns the first Kong Admin API client currently in use. Using Consumer and Plugin services
+// from the first client should return the same results as for all other clients. There might be
+// instances where configurations in different Kong Gateways are ever so slightly different but that
+// shouldn't cause a fatal failure.
+//
+// TODO: https://github.com/Kong/kubernetes-ingress-controller/issues/3363
+func (p *DefaultAdminAPIServicesProvider) client() (*adminapi.Client, bool) {
+	clients := p.clientsProvider.AdminAPIClients()
+	if len(clients) == 0 {
+		return nil, false
+	}
+	return clients[0], true
+}
@@ -0,0 +1,40 @@
+package admission
+
+import (
+	"testing"
+
+	"github.com/kong/go-kong/kong"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/adminapi"
+)
+
+type fakeClientsProvider struct {
+	clients []*adminapi.Client
+}
+
+func (f *fakeClientsProvider) AdminAPIClients() []*adminapi.Client {
+	return f.clients
+}
+
+func TestDefaultAdminAPIServicesProvider(t *testing.T) {
+	clientsProvider := &fakeClientsProvider{}
+	p := NewDefaultAdminAPIServicesProvider(clientsProvider)
+
+	_, ok := p.GetConsumersService()
+	require.False(t, ok, "no services expected without clients")
+	_, ok = p.GetPluginsService()
+	require.False(t, ok, "no services expected without clients")
+
+	kongClient, err := kong.NewClient(kong.String("http://localhost:8001"), nil)
+	require.NoError(t, err)
+	client := adminapi.NewClient(kongClient)
+	clientsProvider.clients = []*adminapi.Client{client}
+
+	consumers, ok := p.GetConsumersService()
+	require.True(t, ok, "services of the added client expected")
+	require.Equal(t, client.Consumers, consumers)
+	plugins, ok := p.GetPluginsService()
+	require.True(t, ok, "services of the added client expected")
+	require.Equal(t, client.Plugins, plugins)
+}
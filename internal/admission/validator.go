@@ -17,6 +17,7 @@ import (
 	"github.com/kong/kubernetes-ingress-controller/v2/internal/dataplane/kongstate"
 	credsvalidation "github.com/kong/kubernetes-ingress-controller/v2/internal/validation/consumers/credentials"
 	gatewayvalidators "github.com/kong/kubernetes-ingress-controller/v2/internal/validation/gateway"
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/versions"
 	kongv1 "github.com/kong/kubernetes-ingress-controller/v2/pkg/apis/configuration/v1"
 )
 
@@ -33,11 +34,14 @@ type KongValidator interface {
 // KongHTTPValidator implements KongValidator interface to validate Kong
 // entities using the Admin API of Kong.
 type KongHTTPValidator struct {
-	ConsumerSvc   kong.AbstractConsumerService
-	PluginSvc     kong.AbstractPluginService
-	Logger        logrus.FieldLogger
-	SecretGetter  kongstate.SecretGetter
-	ManagerClient client.Client
+	AdminAPIServicesProvider AdminAPIServicesProvider
+	Logger                   logrus.FieldLogger
+	SecretGetter             kongstate.SecretGetter
+	ManagerClient            client.Client
+
+	// ExpressionRoutesEnabled indicates that Kong runs the expressions router,
+	// which makes HTTPRoute features like query param matches supported.
+	ExpressionRoutesEnabled bool
 
 	ingressClassMatcher func(*metav1.ObjectMeta, string, annotations.ClassMatching) bool
 }
@@ -47,19 +51,19 @@ type KongHTTPValidator struct {
 // such as consumer credentials secrets. If you do not pass a cached client
 // here, the performance of this validator can get very poor at high scales.
 func NewKongHTTPValidator(
-	consumerSvc kong.AbstractConsumerService,
-	pluginSvc kong.AbstractPluginService,
+	servicesProvider AdminAPIServicesProvider,
 	logger logrus.FieldLogger,
 	managerClient client.Client,
 	ingressClass string,
+	expressionRoutesEnabled bool,
 ) KongHTTPValidator {
 	matcher := annotations.IngressClassValidatorFuncFromObjectMeta(ingressClass)
 	return KongHTTPValidator{
-		ConsumerSvc:   consumerSvc,
-		PluginSvc:     pluginSvc,
-		Logger:        logger,
-		SecretGetter:  &managerClientSecretGetter{managerClient: managerClient},
-		ManagerClient: managerClient,
+		AdminAPIServicesProvider: servicesProvider,
+		Logger:                   logger,
+		SecretGetter:             &managerClientSecretGetter{managerClient: managerClient},
+		ManagerClient:            managerClient,
+		ExpressionRoutesEnabled:  expressionRoutesEnabled,
 
 		ingressClassMatcher: matcher,
 	}
@@ -85,15 +89,19 @@ func (validator KongHTTPValidator) ValidateConsumer(
 	}
 
 	// verify that the consumer is not already otherwise present in the data-plane
-	c, err := validator.ConsumerSvc.Get(ctx, &consumer.Username)
-	if err != nil {
-		if !kong.IsNotFoundErr(err) {
-			validator.Logger.WithError(err).Error("failed to fetch consumer from kong")
-			return false, ErrTextConsumerUnretrievable, err
+	if consumerSvc, ok := validator.AdminAPIServicesProvider.GetConsumersService(); ok {
+		c, err := consumerSvc.Get(ctx, &consumer.Username)
+		if err != nil {
+			if !kong.IsNotFoundErr(err) {
+				validator.Logger.WithError(err).Error("failed to fetch consumer from kong")
+				return false, ErrTextConsumerUnretrievable, err
+			}
 		}
-	}
-	if c != nil {
-		return false, ErrTextConsumerExists, nil
+		if c != nil {
+			return false, ErrTextConsumerExists, nil
+		}
+	} else {
+		validator.Logger.Debug("no Kong Admin API available, skipping consumer lookup in the data-plane")
 	}
 
 	// if there are no credentials for this consumer, there's no need to move on
@@ -254,7 +262,12 @@ func (validator KongHTTPValidator) ValidatePlugin(
 	if len(k8sPlugin.Protocols) > 0 {
 		plugin.Protocols = kong.StringSlice(kongv1.KongProtocolsToStrings(k8sPlugin.Protocols)...)
 	}
-	isValid, msg, err := validator.PluginSvc.Validate(ctx, &plugin)
+	pluginSvc, ok := validator.AdminAPIServicesProvider.GetPluginsService()
+	if !ok {
+		validator.Logger.Debug("no Kong Admin API available, skipping plugin validation against the data-plane")
+		return true, "", nil
+	}
+	isValid, msg, err := pluginSvc.Validate(ctx, &plugin)
 	if err != nil {
 		return false, ErrTextPluginConfigValidationFailed, err
 	}
@@ -364,7 +377,9 @@ func (validator KongHTTPValidator) ValidateHTTPRoute(
 
 	// now that we know whether or not the HTTPRoute is linked to a managed
 	// Gateway we can run it through full validation.
-	return gatewayvalidators.ValidateHTTPRoute(&httproute, managedGateways...)
+	queryParamMatches := validator.ExpressionRoutesEnabled &&
+		versions.GetKongVersion().MajorMinorOnly().GTE(versions.QueryParamMatchVersionCutoff)
+	return gatewayvalidators.ValidateHTTPRoute(&httproute, queryParamMatches, managedGateways...)
 }
 
 // -----------------------------------------------------------------------------
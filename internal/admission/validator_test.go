@@ -26,6 +26,21 @@ func (f *fakePluginSvc) Validate(ctx context.Context, plugin *kong.Plugin) (bool
 	return f.valid, f.msg, f.err
 }
 
+type fakeServicesProvider struct {
+	pluginSvc kong.AbstractPluginService
+}
+
+func (f fakeServicesProvider) GetConsumersService() (kong.AbstractConsumerService, bool) {
+	return nil, false
+}
+
+func (f fakeServicesProvider) GetPluginsService() (kong.AbstractPluginService, bool) {
+	if f.pluginSvc == nil {
+		return nil, false
+	}
+	return f.pluginSvc, true
+}
+
 func TestKongHTTPValidator_ValidatePlugin(t *testing.T) {
 	store, _ := store.NewFakeStore(store.FakeObjects{})
 	type args struct {
@@ -137,9 +152,9 @@ func TestKongHTTPValidator_ValidatePlugin(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			validator := KongHTTPValidator{
-				SecretGetter:        store,
-				PluginSvc:           tt.PluginSvc,
-				ingressClassMatcher: fakeClassMatcher,
+				SecretGetter:             store,
+				AdminAPIServicesProvider: fakeServicesProvider{pluginSvc: tt.PluginSvc},
+				ingressClassMatcher:      fakeClassMatcher,
 			}
 			got, got1, err := validator.ValidatePlugin(context.Background(), tt.args.plugin)
 			if (err != nil) != tt.wantErr {
@@ -269,9 +284,9 @@ func TestKongHTTPValidator_ValidateClusterPlugin(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			validator := KongHTTPValidator{
-				SecretGetter:        store,
-				PluginSvc:           tt.PluginSvc,
-				ingressClassMatcher: fakeClassMatcher,
+				SecretGetter:             store,
+				AdminAPIServicesProvider: fakeServicesProvider{pluginSvc: tt.PluginSvc},
+				ingressClassMatcher:      fakeClassMatcher,
 			}
 			got, got1, err := validator.ValidateClusterPlugin(context.Background(), tt.args.plugin)
 			if (err != nil) != tt.wantErr {
@@ -34,14 +34,24 @@ func StartDiagnosticsServer(
 	logger.Info("starting diagnostics server")
 
 	s := diagnostics.Server{
-		Logger:           logger,
-		ProfilingEnabled: c.EnableProfiling,
-		ConfigLock:       &sync.RWMutex{},
+		Logger:            logger,
+		ProfilingEnabled:  c.EnableProfiling,
+		ConfigLock:        &sync.RWMutex{},
+		ConfigHistorySize: c.ConfigDumpHistorySize,
 	}
 	if c.EnableConfigDumps {
+		// every Kong instance is sent its own config, so the buffer has to fit more than
+		// a few configs for the history not to miss any of them.
+		configsBufferDepth := DiagnosticConfigBufferDepth
+		if c.ConfigDumpHistorySize > configsBufferDepth {
+			configsBufferDepth = c.ConfigDumpHistorySize
+		}
 		s.ConfigDumps = util.ConfigDumpDiagnostic{
 			DumpsIncludeSensitive: c.DumpSensitiveConfig,
-			Configs:               make(chan util.ConfigDump, DiagnosticConfigBufferDepth),
+			Configs:               make(chan util.ConfigDump, configsBufferDepth),
+			InstancesStatus:       make(chan []util.KongInstanceStatus, DiagnosticConfigBufferDepth),
+			DryRuns:               make(chan []util.KongInstanceDryRun, DiagnosticConfigBufferDepth),
+			Explanations:          make(chan util.KubernetesObjectExplanations, DiagnosticConfigBufferDepth),
 		}
 	}
 	go func() {
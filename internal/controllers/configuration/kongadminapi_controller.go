@@ -0,0 +1,147 @@
+package configuration
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-logr/logr"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/adminapi"
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/util"
+)
+
+// -----------------------------------------------------------------------------
+// Kong Admin API Service - Reconciler
+// -----------------------------------------------------------------------------
+
+// AdminAPIClientFactory creates a Kong Admin API client for the provided URL.
+type AdminAPIClientFactory func(ctx context.Context, url string) (*adminapi.Client, error)
+
+// AdminAPIClientsManager manages the Kong Admin API clients configuration is sent to.
+type AdminAPIClientsManager interface {
+	AdminAPIClients() []*adminapi.Client
+	SetAdminAPIClients(ctx context.Context, clients []*adminapi.Client)
+}
+
+// KongAdminAPIServiceReconciler reconciles the EndpointSlices of the Service exposing Kong Admin API,
+// to keep the set of Kong instances which configuration is sent to up to date with the ready endpoints.
+type KongAdminAPIServiceReconciler struct {
+	client.Client
+
+	Log              logr.Logger
+	CacheSyncTimeout time.Duration
+
+	// ServiceNN is the namespaced name of the Service exposing Kong Admin API.
+	ServiceNN types.NamespacedName
+	// PortNames are the names of the Service ports exposing Kong Admin API.
+	PortNames sets.Set[string]
+	// ClientFactory creates clients for the discovered Kong Admin API URLs.
+	ClientFactory AdminAPIClientFactory
+	// ClientsManager is provided with the clients for all the discovered Kong Admin API URLs.
+	ClientsManager AdminAPIClientsManager
+
+	// clients are the clients for the Kong Admin API URLs discovered so far.
+	clients map[string]*adminapi.Client
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *KongAdminAPIServiceReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	c, err := controller.New("KongAdminAPIService", mgr, controller.Options{
+		Reconciler: r,
+		LogConstructor: func(_ *reconcile.Request) logr.Logger {
+			return r.Log
+		},
+		CacheSyncTimeout: r.CacheSyncTimeout,
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.Watch(
+		&source.Kind{Type: &discoveryv1.EndpointSlice{}},
+		&handler.EnqueueRequestForObject{},
+		predicate.NewPredicateFuncs(r.shouldReconcileEndpointSlice),
+	)
+}
+
+// shouldReconcileEndpointSlice returns true for the EndpointSlices of the Kong Admin API Service.
+func (r *KongAdminAPIServiceReconciler) shouldReconcileEndpointSlice(obj client.Object) bool {
+	if obj.GetNamespace() != r.ServiceNN.Namespace {
+		return false
+	}
+	return obj.GetLabels()[discoveryv1.LabelServiceName] == r.ServiceNN.Name
+}
+
+//+kubebuilder:rbac:groups=discovery.k8s.io,resources=endpointslices,verbs=list;watch
+
+// Reconcile processes the watched objects
+func (r *KongAdminAPIServiceReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := r.Log.WithValues("KongAdminAPIService", r.ServiceNN)
+	log.V(util.DebugLevel).Info("reconciling resource", "namespace", req.Namespace, "name", req.Name)
+
+	// the clients which are already in use (e.g. the ones created on start) are reused when
+	// their URLs are discovered.
+	if r.clients == nil {
+		r.clients = make(map[string]*adminapi.Client)
+		for _, cl := range r.ClientsManager.AdminAPIClients() {
+			r.clients[cl.BaseRootURL()] = cl
+		}
+	}
+
+	// any change to any of the EndpointSlices of the Service can change the set of Kong instances,
+	// so the complete set of URLs is discovered every time.
+	urls, err := adminapi.GetURLsForService(ctx, r.Client, r.ServiceNN, r.PortNames)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	// a client which can't be created (e.g. because the instance isn't reachable yet) doesn't prevent
+	// the other discovered instances from being used, creating it is retried later on.
+	clients := make(map[string]*adminapi.Client, urls.Len())
+	var failed int
+	for _, url := range sets.List(urls) {
+		cl, ok := r.clients[url]
+		if !ok {
+			log.Info("discovered Kong Admin API", "url", url)
+			cl, err = r.ClientFactory(ctx, url)
+			if err != nil {
+				log.Error(err, "failed to create a client for the discovered Kong Admin API", "url", url)
+				failed++
+				continue
+			}
+		}
+		clients[url] = cl
+	}
+	for url := range r.clients {
+		if _, ok := clients[url]; !ok {
+			log.Info("Kong Admin API is gone", "url", url)
+		}
+	}
+	r.clients = clients
+
+	r.ClientsManager.SetAdminAPIClients(ctx, sortedClients(clients))
+	if failed > 0 {
+		log.V(util.DebugLevel).Info("requeueing to retry creating clients", "failed", failed)
+		return ctrl.Result{Requeue: true}, nil
+	}
+	return ctrl.Result{}, nil
+}
+
+// sortedClients returns the provided clients sorted by their URLs.
+func sortedClients(clients map[string]*adminapi.Client) []*adminapi.Client {
+	sorted := make([]*adminapi.Client, 0, len(clients))
+	for _, url := range sets.List(sets.KeySet(clients)) {
+		sorted = append(sorted, clients[url])
+	}
+	return sorted
+}
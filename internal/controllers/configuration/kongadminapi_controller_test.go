@@ -0,0 +1,123 @@
+package configuration
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/kong/go-kong/kong"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/adminapi"
+)
+
+type fakeAdminAPIClientsManager struct {
+	clients []*adminapi.Client
+}
+
+func (s *fakeAdminAPIClientsManager) AdminAPIClients() []*adminapi.Client {
+	return s.clients
+}
+
+func (s *fakeAdminAPIClientsManager) SetAdminAPIClients(_ context.Context, clients []*adminapi.Client) {
+	s.clients = clients
+}
+
+func TestKongAdminAPIServiceReconciler(t *testing.T) {
+	endpointSlice := &discoveryv1.EndpointSlice{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "kong-admin-abcde",
+			Namespace: "kong",
+			Labels:    map[string]string{discoveryv1.LabelServiceName: "kong-admin"},
+		},
+		AddressType: discoveryv1.AddressTypeIPv4,
+		Endpoints: []discoveryv1.Endpoint{
+			{Addresses: []string{"10.0.0.1"}},
+			{Addresses: []string{"10.0.0.2"}},
+		},
+		Ports: []discoveryv1.EndpointPort{{Name: lo.ToPtr("admin"), Port: lo.ToPtr(int32(8001))}},
+	}
+	fakeClient := fake.NewClientBuilder().WithObjects(endpointSlice).Build()
+
+	inUse, err := kong.NewClient(kong.String("http://10.0.0.1:8001"), nil)
+	require.NoError(t, err)
+	manager := &fakeAdminAPIClientsManager{clients: []*adminapi.Client{adminapi.NewClient(inUse)}}
+
+	var created []string
+	r := &KongAdminAPIServiceReconciler{
+		Client:    fakeClient,
+		Log:       logr.Discard(),
+		ServiceNN: types.NamespacedName{Namespace: "kong", Name: "kong-admin"},
+		PortNames: sets.New("admin"),
+		ClientFactory: func(_ context.Context, url string) (*adminapi.Client, error) {
+			created = append(created, url)
+			c, err := kong.NewClient(kong.String(url), nil)
+			if err != nil {
+				return nil, err
+			}
+			return adminapi.NewClient(c), nil
+		},
+		ClientsManager: manager,
+	}
+
+	t.Log("verifying that clients are created only for newly discovered Kong Admin APIs")
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Namespace: "kong", Name: "kong-admin-abcde"}}
+	_, err = r.Reconcile(context.Background(), req)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"http://10.0.0.2:8001"}, created)
+	require.Len(t, manager.clients, 2)
+	assert.Same(t, inUse, manager.clients[0].Client)
+	assert.Equal(t, "http://10.0.0.2:8001", manager.clients[1].BaseRootURL())
+
+	t.Log("verifying that clients of Kong Admin APIs which are not ready anymore are removed")
+	endpointSlice.Endpoints[0].Conditions.Ready = lo.ToPtr(false)
+	require.NoError(t, fakeClient.Update(context.Background(), endpointSlice))
+	_, err = r.Reconcile(context.Background(), req)
+	require.NoError(t, err)
+	require.Len(t, manager.clients, 1)
+	assert.Equal(t, "http://10.0.0.2:8001", manager.clients[0].BaseRootURL())
+	assert.Len(t, created, 1)
+
+	t.Log("verifying that a client failing to be created doesn't prevent the others from being added")
+	endpointSlice.Endpoints[0].Conditions.Ready = nil
+	endpointSlice.Endpoints = append(endpointSlice.Endpoints, discoveryv1.Endpoint{Addresses: []string{"10.0.0.3"}})
+	require.NoError(t, fakeClient.Update(context.Background(), endpointSlice))
+	factory := r.ClientFactory
+	r.ClientFactory = func(ctx context.Context, url string) (*adminapi.Client, error) {
+		if url == "http://10.0.0.1:8001" {
+			return nil, errors.New("unreachable")
+		}
+		return factory(ctx, url)
+	}
+	result, err := r.Reconcile(context.Background(), req)
+	require.NoError(t, err)
+	assert.True(t, result.Requeue, "creating the failed client should be retried")
+	require.Len(t, manager.clients, 2)
+	assert.Equal(t, "http://10.0.0.2:8001", manager.clients[0].BaseRootURL())
+	assert.Equal(t, "http://10.0.0.3:8001", manager.clients[1].BaseRootURL())
+
+	r.ClientFactory = factory
+	result, err = r.Reconcile(context.Background(), req)
+	require.NoError(t, err)
+	assert.False(t, result.Requeue)
+	require.Len(t, manager.clients, 3)
+	assert.Equal(t, "http://10.0.0.1:8001", manager.clients[0].BaseRootURL())
+
+	t.Log("verifying that only EndpointSlices of the Kong Admin API Service are reconciled")
+	assert.True(t, r.shouldReconcileEndpointSlice(endpointSlice))
+	other := endpointSlice.DeepCopy()
+	other.Labels[discoveryv1.LabelServiceName] = "kong-proxy"
+	assert.False(t, r.shouldReconcileEndpointSlice(other))
+	other = endpointSlice.DeepCopy()
+	other.Namespace = "default"
+	assert.False(t, r.shouldReconcileEndpointSlice(other))
+}
@@ -19,6 +19,7 @@ import (
 
 	ctrlref "github.com/kong/kubernetes-ingress-controller/v2/internal/controllers/reference"
 	"github.com/kong/kubernetes-ingress-controller/v2/internal/dataplane"
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/tracing"
 	"github.com/kong/kubernetes-ingress-controller/v2/internal/util"
 )
 
@@ -96,6 +97,8 @@ func (r *CoreV1SecretReconciler) shouldReconcileSecret(obj client.Object) bool {
 // Reconcile processes the watched objects
 func (r *CoreV1SecretReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	log := r.Log.WithValues("CoreV1Secret", req.NamespacedName)
+	ctx, span := tracing.StartReconcile(ctx, "Secret", req.NamespacedName)
+	defer span.End()
 
 	// get the relevant object
 	secret := new(corev1.Secret)
@@ -43,6 +43,7 @@ import (
 	ctrlref "github.com/kong/kubernetes-ingress-controller/v2/internal/controllers/reference"
 	ctrlutils "github.com/kong/kubernetes-ingress-controller/v2/internal/controllers/utils"
 	"github.com/kong/kubernetes-ingress-controller/v2/internal/dataplane"
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/tracing"
 	"github.com/kong/kubernetes-ingress-controller/v2/internal/util"
 	"github.com/kong/kubernetes-ingress-controller/v2/internal/util/kubernetes/object/status"
 	kongv1 "github.com/kong/kubernetes-ingress-controller/v2/pkg/apis/configuration/v1"
@@ -89,6 +90,8 @@ func (r *CoreV1ServiceReconciler) SetupWithManager(mgr ctrl.Manager) error {
 // Reconcile processes the watched objects
 func (r *CoreV1ServiceReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	log := r.Log.WithValues("CoreV1Service", req.NamespacedName)
+	ctx, span := tracing.StartReconcile(ctx, "Service", req.NamespacedName)
+	defer span.End()
 
 	// get the relevant object
 	obj := new(corev1.Service)
@@ -186,6 +189,8 @@ func (r *CoreV1EndpointsReconciler) SetupWithManager(mgr ctrl.Manager) error {
 // Reconcile processes the watched objects
 func (r *CoreV1EndpointsReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	log := r.Log.WithValues("CoreV1Endpoints", req.NamespacedName)
+	ctx, span := tracing.StartReconcile(ctx, "Endpoints", req.NamespacedName)
+	defer span.End()
 
 	// get the relevant object
 	obj := new(corev1.Endpoints)
@@ -316,6 +321,8 @@ func (r *NetV1IngressReconciler) listClassless(obj client.Object) []reconcile.Re
 // Reconcile processes the watched objects
 func (r *NetV1IngressReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	log := r.Log.WithValues("NetV1Ingress", req.NamespacedName)
+	ctx, span := tracing.StartReconcile(ctx, "Ingress", req.NamespacedName)
+	defer span.End()
 
 	// get the relevant object
 	obj := new(netv1.Ingress)
@@ -457,6 +464,8 @@ func (r *NetV1IngressClassReconciler) SetupWithManager(mgr ctrl.Manager) error {
 // Reconcile processes the watched objects
 func (r *NetV1IngressClassReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	log := r.Log.WithValues("NetV1IngressClass", req.NamespacedName)
+	ctx, span := tracing.StartReconcile(ctx, "IngressClass", req.NamespacedName)
+	defer span.End()
 
 	// get the relevant object
 	obj := new(netv1.IngressClass)
@@ -587,6 +596,8 @@ func (r *NetV1Beta1IngressReconciler) listClassless(obj client.Object) []reconci
 // Reconcile processes the watched objects
 func (r *NetV1Beta1IngressReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	log := r.Log.WithValues("NetV1Beta1Ingress", req.NamespacedName)
+	ctx, span := tracing.StartReconcile(ctx, "Ingress", req.NamespacedName)
+	defer span.End()
 
 	// get the relevant object
 	obj := new(netv1beta1.Ingress)
@@ -782,6 +793,8 @@ func (r *ExtV1Beta1IngressReconciler) listClassless(obj client.Object) []reconci
 // Reconcile processes the watched objects
 func (r *ExtV1Beta1IngressReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	log := r.Log.WithValues("ExtV1Beta1Ingress", req.NamespacedName)
+	ctx, span := tracing.StartReconcile(ctx, "Ingress", req.NamespacedName)
+	defer span.End()
 
 	// get the relevant object
 	obj := new(extv1beta1.Ingress)
@@ -924,6 +937,8 @@ func (r *KongV1KongIngressReconciler) SetupWithManager(mgr ctrl.Manager) error {
 // Reconcile processes the watched objects
 func (r *KongV1KongIngressReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	log := r.Log.WithValues("KongV1KongIngress", req.NamespacedName)
+	ctx, span := tracing.StartReconcile(ctx, "KongIngress", req.NamespacedName)
+	defer span.End()
 
 	// get the relevant object
 	obj := new(kongv1.KongIngress)
@@ -1002,6 +1017,8 @@ func (r *KongV1KongPluginReconciler) SetupWithManager(mgr ctrl.Manager) error {
 // Reconcile processes the watched objects
 func (r *KongV1KongPluginReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	log := r.Log.WithValues("KongV1KongPlugin", req.NamespacedName)
+	ctx, span := tracing.StartReconcile(ctx, "KongPlugin", req.NamespacedName)
+	defer span.End()
 
 	// get the relevant object
 	obj := new(kongv1.KongPlugin)
@@ -1136,6 +1153,8 @@ func (r *KongV1KongClusterPluginReconciler) listClassless(obj client.Object) []r
 // Reconcile processes the watched objects
 func (r *KongV1KongClusterPluginReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	log := r.Log.WithValues("KongV1KongClusterPlugin", req.NamespacedName)
+	ctx, span := tracing.StartReconcile(ctx, "KongClusterPlugin", req.NamespacedName)
+	defer span.End()
 
 	// get the relevant object
 	obj := new(kongv1.KongClusterPlugin)
@@ -1290,6 +1309,8 @@ func (r *KongV1KongConsumerReconciler) listClassless(obj client.Object) []reconc
 // Reconcile processes the watched objects
 func (r *KongV1KongConsumerReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	log := r.Log.WithValues("KongV1KongConsumer", req.NamespacedName)
+	ctx, span := tracing.StartReconcile(ctx, "KongConsumer", req.NamespacedName)
+	defer span.End()
 
 	// get the relevant object
 	obj := new(kongv1.KongConsumer)
@@ -1460,6 +1481,8 @@ func (r *KongV1Beta1TCPIngressReconciler) listClassless(obj client.Object) []rec
 // Reconcile processes the watched objects
 func (r *KongV1Beta1TCPIngressReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	log := r.Log.WithValues("KongV1Beta1TCPIngress", req.NamespacedName)
+	ctx, span := tracing.StartReconcile(ctx, "TCPIngress", req.NamespacedName)
+	defer span.End()
 
 	// get the relevant object
 	obj := new(kongv1beta1.TCPIngress)
@@ -1654,6 +1677,8 @@ func (r *KongV1Beta1UDPIngressReconciler) listClassless(obj client.Object) []rec
 // Reconcile processes the watched objects
 func (r *KongV1Beta1UDPIngressReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	log := r.Log.WithValues("KongV1Beta1UDPIngress", req.NamespacedName)
+	ctx, span := tracing.StartReconcile(ctx, "UDPIngress", req.NamespacedName)
+	defer span.End()
 
 	// get the relevant object
 	obj := new(kongv1beta1.UDPIngress)
@@ -1775,6 +1800,8 @@ func (r *KongV1Alpha1IngressClassParametersReconciler) SetupWithManager(mgr ctrl
 // Reconcile processes the watched objects
 func (r *KongV1Alpha1IngressClassParametersReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	log := r.Log.WithValues("KongV1Alpha1IngressClassParameters", req.NamespacedName)
+	ctx, span := tracing.StartReconcile(ctx, "IngressClassParameters", req.NamespacedName)
+	defer span.End()
 
 	// get the relevant object
 	obj := new(kongv1alpha1.IngressClassParameters)
@@ -1814,6 +1841,84 @@ func (r *KongV1Alpha1IngressClassParametersReconciler) Reconcile(ctx context.Con
 	return ctrl.Result{}, nil
 }
 
+// -----------------------------------------------------------------------------
+// KongV1Alpha1 GatewayClassParameters - Reconciler
+// -----------------------------------------------------------------------------
+
+// KongV1Alpha1GatewayClassParametersReconciler reconciles GatewayClassParameters resources
+type KongV1Alpha1GatewayClassParametersReconciler struct {
+	client.Client
+
+	Log              logr.Logger
+	Scheme           *runtime.Scheme
+	DataplaneClient  *dataplane.KongClient
+	CacheSyncTimeout time.Duration
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *KongV1Alpha1GatewayClassParametersReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	c, err := controller.New("KongV1Alpha1GatewayClassParameters", mgr, controller.Options{
+		Reconciler: r,
+		LogConstructor: func(_ *reconcile.Request) logr.Logger {
+			return r.Log
+		},
+		CacheSyncTimeout: r.CacheSyncTimeout,
+	})
+	if err != nil {
+		return err
+	}
+	return c.Watch(
+		&source.Kind{Type: &kongv1alpha1.GatewayClassParameters{}},
+		&handler.EnqueueRequestForObject{},
+	)
+}
+
+//+kubebuilder:rbac:groups=configuration.konghq.com,resources=gatewayclassparameterses,verbs=get;list;watch
+
+// Reconcile processes the watched objects
+func (r *KongV1Alpha1GatewayClassParametersReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := r.Log.WithValues("KongV1Alpha1GatewayClassParameters", req.NamespacedName)
+	ctx, span := tracing.StartReconcile(ctx, "GatewayClassParameters", req.NamespacedName)
+	defer span.End()
+
+	// get the relevant object
+	obj := new(kongv1alpha1.GatewayClassParameters)
+	if err := r.Get(ctx, req.NamespacedName, obj); err != nil {
+		if apierrors.IsNotFound(err) {
+			obj.Namespace = req.Namespace
+			obj.Name = req.Name
+
+			return ctrl.Result{}, r.DataplaneClient.DeleteObject(obj)
+		}
+		return ctrl.Result{}, err
+	}
+	log.V(util.DebugLevel).Info("reconciling resource", "namespace", req.Namespace, "name", req.Name)
+
+	// clean the object up if it's being deleted
+	if !obj.DeletionTimestamp.IsZero() && time.Now().After(obj.DeletionTimestamp.Time) {
+		log.V(util.DebugLevel).Info("resource is being deleted, its configuration will be removed", "type", "GatewayClassParameters", "namespace", req.Namespace, "name", req.Name)
+
+		objectExistsInCache, err := r.DataplaneClient.ObjectExists(obj)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		if objectExistsInCache {
+			if err := r.DataplaneClient.DeleteObject(obj); err != nil {
+				return ctrl.Result{}, err
+			}
+			return ctrl.Result{Requeue: true}, nil // wait until the object is no longer present in the cache
+		}
+		return ctrl.Result{}, nil
+	}
+
+	// update the kong Admin API with the changes
+	if err := r.DataplaneClient.UpdateObject(obj); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	return ctrl.Result{}, nil
+}
+
 // -----------------------------------------------------------------------------
 // API Group "" resource nodes
 // -----------------------------------------------------------------------------
@@ -5,11 +5,14 @@ import (
 	"errors"
 	"fmt"
 	"reflect"
+	"sync"
 	"time"
 
 	"github.com/go-logr/logr"
 	"github.com/kong/go-kong/kong"
+	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	netv1 "k8s.io/api/networking/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -27,6 +30,8 @@ import (
 	"github.com/kong/kubernetes-ingress-controller/v2/internal/annotations"
 	ctrlref "github.com/kong/kubernetes-ingress-controller/v2/internal/controllers/reference"
 	"github.com/kong/kubernetes-ingress-controller/v2/internal/dataplane"
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/tracing"
+	kongv1alpha1 "github.com/kong/kubernetes-ingress-controller/v2/pkg/apis/configuration/v1alpha1"
 )
 
 // -----------------------------------------------------------------------------
@@ -55,19 +60,32 @@ type GatewayReconciler struct { //nolint:revive
 	// If EnableReferenceGrant is true, controller will watch ReferenceGrants
 	// to invalidate or allow cross-namespace TLSConfigs in gateways.
 	EnableReferenceGrant bool
-	CacheSyncTimeout     time.Duration
+	// If EnableGatewayClassParameters is true, controller will watch GatewayClassParameters
+	// which may configure the publish service of unmanaged gateways.
+	EnableGatewayClassParameters bool
+	CacheSyncTimeout             time.Duration
 
 	ReferenceIndexers ctrlref.CacheIndexers
 
-	publishServiceRef types.NamespacedName
+	// ManagedGatewayClass is the name of the GatewayClass which Gateways are each provisioned their own Kong
+	// deployment, rather than being served by the one of PublishService. Managed Gateways are disabled when
+	// it's empty.
+	ManagedGatewayClass string
+	// ManagedGatewayKongImage is the Kong image of the deployments of managed Gateways.
+	ManagedGatewayKongImage string
+	// ControllerNamespace is the namespace of the controller's pods, the only ones the NetworkPolicies of
+	// managed Gateways let reach the admin API of their Kong deployments. All namespaces may when it's empty.
+	ControllerNamespace string
+
+	// managedGatewayAdminTLSFingerprints are the fingerprints of the admin API CAs of the managed Gateways
+	// the Kong Admin API clients were created with, keyed by the Gateways' namespaced names.
+	managedGatewayAdminTLSFingerprints sync.Map
 }
 
 // SetupWithManager sets up the controller with the Manager.
 func (r *GatewayReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	// verify that the PublishService was configured properly
-	var err error
-	r.publishServiceRef, err = getRefFromPublishService(r.PublishService)
-	if err != nil {
+	if _, err := getRefFromPublishService(r.PublishService); err != nil {
 		return err
 	}
 
@@ -93,6 +111,18 @@ func (r *GatewayReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		return err
 	}
 
+	// all Gateways of our GatewayClasses share the same Kong listens, so the listeners of a Gateway
+	// may conflict with the ones of any other. Whenever the spec of a Gateway changes, enqueue
+	// reconciliation for all the other supported Gateways to re-evaluate their listeners.
+	if err := c.Watch(
+		&source.Kind{Type: &gatewayv1beta1.Gateway{}},
+		handler.EnqueueRequestsFromMapFunc(r.listGatewaysSharingListens),
+		predicate.NewPredicateFuncs(r.gatewayHasMatchingGatewayClass),
+		predicate.GenerationChangedPredicate{},
+	); err != nil {
+		return err
+	}
+
 	// watch for updates to gatewayclasses, if any gateway classes change, enqueue
 	// reconciliation for all supported gateway objects which reference it.
 	if err := c.Watch(
@@ -114,6 +144,20 @@ func (r *GatewayReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		return err
 	}
 
+	// managed Gateways own their Kong Deployment and Services, whose readiness and addresses are
+	// reflected in the status of the Gateway, along with their admin API TLS Secret and NetworkPolicy,
+	// which are provisioned again when they're changed.
+	if r.ManagedGatewayClass != "" {
+		for _, owned := range []client.Object{&appsv1.Deployment{}, &corev1.Service{}, &corev1.Secret{}, &netv1.NetworkPolicy{}} {
+			if err := c.Watch(
+				&source.Kind{Type: owned},
+				&handler.EnqueueRequestForOwner{OwnerType: &gatewayv1beta1.Gateway{}, IsController: true},
+			); err != nil {
+				return err
+			}
+		}
+	}
+
 	// watch ReferenceGrants, which may invalidate or allow cross-namespace TLSConfigs
 	if r.EnableReferenceGrant {
 		if err := c.Watch(
@@ -125,12 +169,25 @@ func (r *GatewayReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		}
 	}
 
+	// watch GatewayClassParameters, which may configure the publish service of unmanaged gateways
+	if r.EnableGatewayClassParameters {
+		if err := c.Watch(
+			&source.Kind{Type: &kongv1alpha1.GatewayClassParameters{}},
+			handler.EnqueueRequestsFromMapFunc(r.listGatewaysForGatewayClassParameters),
+		); err != nil {
+			return err
+		}
+	}
+
 	// start the required gatewayclass controller as well
 	gwcCTRL := &GatewayClassReconciler{
-		Client:           r.Client,
-		Log:              r.Log.WithName("V1Beta1GatewayClass"),
-		Scheme:           r.Scheme,
-		CacheSyncTimeout: r.CacheSyncTimeout,
+		Client:                       r.Client,
+		Log:                          r.Log.WithName("V1Beta1GatewayClass"),
+		Scheme:                       r.Scheme,
+		CacheSyncTimeout:             r.CacheSyncTimeout,
+		DataplaneClient:              r.DataplaneClient,
+		ManagedGatewayClass:          r.ManagedGatewayClass,
+		EnableGatewayClassParameters: r.EnableGatewayClassParameters,
 	}
 
 	return gwcCTRL.SetupWithManager(mgr)
@@ -141,7 +198,7 @@ func (r *GatewayReconciler) SetupWithManager(mgr ctrl.Manager) error {
 // -----------------------------------------------------------------------------
 
 // gatewayHasMatchingGatewayClass is a watch predicate which filters out reconciliation events for
-// gateway objects which aren't supported by this controller or not using an unmanaged GatewayClass.
+// gateway objects which aren't supported by this controller or not using an unmanaged or the managed GatewayClass.
 func (r *GatewayReconciler) gatewayHasMatchingGatewayClass(obj client.Object) bool {
 	gateway, ok := obj.(*gatewayv1beta1.Gateway)
 	if !ok {
@@ -157,11 +214,11 @@ func (r *GatewayReconciler) gatewayHasMatchingGatewayClass(obj client.Object) bo
 		r.Log.Error(err, "could not retrieve gatewayclass", "gatewayclass", gateway.Spec.GatewayClassName)
 		return false
 	}
-	return isGatewayClassControlledAndUnmanaged(gatewayClass)
+	return isGatewayClassControlledAndUnmanaged(gatewayClass) || r.isGatewayManaged(gatewayClass)
 }
 
 // gatewayClassMatchesController is a watch predicate which filters out events for gatewayclasses which
-// aren't configured with the required ControllerName or not annotated as unmanaged.
+// aren't configured with the required ControllerName or neither annotated as unmanaged nor the managed one.
 func (r *GatewayReconciler) gatewayClassMatchesController(obj client.Object) bool {
 	gatewayClass, ok := obj.(*gatewayv1beta1.GatewayClass)
 	if !ok {
@@ -172,7 +229,7 @@ func (r *GatewayReconciler) gatewayClassMatchesController(obj client.Object) boo
 		)
 		return false
 	}
-	return isGatewayClassControlledAndUnmanaged(gatewayClass)
+	return isGatewayClassControlledAndUnmanaged(gatewayClass) || r.isGatewayManaged(gatewayClass)
 }
 
 // listGatewaysForGatewayClass is a watch predicate which finds all the gateway objects reference
@@ -187,6 +244,24 @@ func (r *GatewayReconciler) listGatewaysForGatewayClass(gatewayClass client.Obje
 	return reconcileGatewaysIfClassMatches(gatewayClass, gateways.Items)
 }
 
+// listGatewaysForGatewayClassParameters is a watch predicate which finds all the gateway objects of the
+// supported GatewayClasses which reference the provided GatewayClassParameters to enqueue them for reconciliation.
+func (r *GatewayReconciler) listGatewaysForGatewayClassParameters(params client.Object) []reconcile.Request {
+	gatewayClasses := &gatewayv1beta1.GatewayClassList{}
+	if err := r.Client.List(context.Background(), gatewayClasses); err != nil {
+		r.Log.Error(err, "failed to list gatewayclasses for gatewayclassparameters in watch", "gatewayclassparameters", params.GetName())
+		return nil
+	}
+	recs := []reconcile.Request{}
+	for i := range gatewayClasses.Items {
+		gatewayClass := &gatewayClasses.Items[i]
+		if gatewayClassReferencesParameters(gatewayClass, params.GetName()) && r.gatewayClassMatchesController(gatewayClass) {
+			recs = append(recs, r.listGatewaysForGatewayClass(gatewayClass)...)
+		}
+	}
+	return recs
+}
+
 // listReferenceGrantsForGateway is a watch predicate which finds all Gateways mentioned in a From clause for a
 // ReferenceGrant.
 func (r *GatewayReconciler) listReferenceGrantsForGateway(obj client.Object) []reconcile.Request {
@@ -250,10 +325,41 @@ func (r *GatewayReconciler) listGatewaysForService(svc client.Object) (recs []re
 	return
 }
 
+// listGatewaysSharingListens is a watch predicate which finds all the gateway objects other than the provided
+// one which use GatewayClasses supported by this controller in unmanaged mode, and thus share the same Kong
+// listens, and enqueues them for reconciliation. This is used to re-evaluate cross-Gateway listener conflicts.
+func (r *GatewayReconciler) listGatewaysSharingListens(obj client.Object) []reconcile.Request {
+	recs := []reconcile.Request{}
+	for _, rec := range r.listGatewaysForService(obj) {
+		if rec.Namespace == obj.GetNamespace() && rec.Name == obj.GetName() {
+			continue
+		}
+		recs = append(recs, rec)
+	}
+	return recs
+}
+
 // isGatewayService is a watch predicate that filters out events for objects that aren't
-// the gateway service referenced by --publish-service.
+// the gateway service referenced by --publish-service or by the publishService of GatewayClassParameters.
 func (r *GatewayReconciler) isGatewayService(obj client.Object) bool {
-	return fmt.Sprintf("%s/%s", obj.GetNamespace(), obj.GetName()) == r.PublishService
+	ref := fmt.Sprintf("%s/%s", obj.GetNamespace(), obj.GetName())
+	if ref == r.PublishService {
+		return true
+	}
+	if !r.EnableGatewayClassParameters {
+		return false
+	}
+	paramsList := &kongv1alpha1.GatewayClassParametersList{}
+	if err := r.Client.List(context.Background(), paramsList); err != nil {
+		r.Log.Error(err, "failed to list gatewayclassparameters in watch predicates", "service", ref)
+		return false
+	}
+	for _, params := range paramsList.Items {
+		if params.Spec.PublishService == ref {
+			return true
+		}
+	}
+	return false
 }
 
 func referenceGrantHasGatewayFrom(obj client.Object) bool {
@@ -280,6 +386,8 @@ func referenceGrantHasGatewayFrom(obj client.Object) bool {
 // move the current state of the cluster closer to the desired state.
 func (r *GatewayReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	log := r.Log.WithValues("GatewayV1Beta1Gateway", req.NamespacedName)
+	ctx, span := tracing.StartReconcile(ctx, "Gateway", req.NamespacedName)
+	defer span.End()
 
 	// gather the gateway object based on the reconciliation trigger. It's possible for the object
 	// to be gone at this point in which case it will be ignored.
@@ -288,6 +396,7 @@ func (r *GatewayReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ct
 		if apierrors.IsNotFound(err) {
 			gateway.Namespace = req.Namespace
 			gateway.Name = req.Name
+			r.unregisterManagedGateway(req.NamespacedName)
 			// delete reference relationships where the gateway is the referrer.
 			err := ctrlref.DeleteReferencesByReferrer(r.ReferenceIndexers, r.DataplaneClient, gateway)
 			if err != nil {
@@ -308,6 +417,7 @@ func (r *GatewayReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ct
 	gwc := &gatewayv1beta1.GatewayClass{}
 	if err := r.Client.Get(ctx, client.ObjectKey{Name: string(gateway.Spec.GatewayClassName)}, gwc); err != nil {
 		debug(log, gateway, "could not retrieve gatewayclass for gateway", "gatewayclass", string(gateway.Spec.GatewayClassName))
+		r.unregisterManagedGateway(req.NamespacedName)
 		// delete reference relationships where the gateway is the referrer, as we will not process the gateway.
 		err := ctrlref.DeleteReferencesByReferrer(r.ReferenceIndexers, r.DataplaneClient, gateway)
 		if err != nil {
@@ -322,6 +432,7 @@ func (r *GatewayReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ct
 	}
 	if gwc.Spec.ControllerName != ControllerName {
 		debug(log, gateway, "unsupported gatewayclass controllername, ignoring", "gatewayclass", gwc.Name, "controllername", gwc.Spec.ControllerName)
+		r.unregisterManagedGateway(req.NamespacedName)
 		// delete reference relationships where the gateway is the referrer, as we will not process the gateway.
 		err := ctrlref.DeleteReferencesByReferrer(r.ReferenceIndexers, r.DataplaneClient, gateway)
 		if err != nil {
@@ -344,18 +455,35 @@ func (r *GatewayReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ct
 		return ctrl.Result{Requeue: false}, nil
 	}
 
-	// ensure that the GatewayClass matches the ControllerName and is unmanaged.
+	// ensure that the GatewayClass matches the ControllerName and is either unmanaged or the managed one.
 	// This check has already been performed by predicates, but we need to ensure this condition
 	// as the reconciliation loop may be triggered by objects in which predicates we
-	// cannot check the ControllerName and the unmanaged mode (e.g., ReferenceGrants).
-	if !isGatewayClassControlledAndUnmanaged(gwc) {
+	// cannot check the ControllerName and the management mode (e.g., ReferenceGrants).
+	var (
+		result ctrl.Result
+		err    error
+	)
+	switch {
+	case r.isGatewayManaged(gwc):
+		result, err = r.reconcileManagedGateway(ctx, log, gateway)
+	case isGatewayClassControlledAndUnmanaged(gwc):
+		r.unregisterManagedGateway(req.NamespacedName)
+		var publishService string
+		publishService, err = r.publishServiceForGatewayClass(ctx, gwc)
+		if err != nil {
+			if errors.Is(err, errInvalidGatewayClassParameters) {
+				// the GatewayClass isn't accepted, which is reported in its status.
+				debug(log, gateway, "gatewayclass has invalid parameters, ignoring", "gatewayclass", gwc.Name, "reason", err.Error())
+				return ctrl.Result{}, nil
+			}
+			return ctrl.Result{}, err
+		}
+		result, err = r.reconcileUnmanagedGateway(ctx, log, gateway, publishService)
+	default:
+		r.unregisterManagedGateway(req.NamespacedName)
 		return reconcile.Result{}, nil
 	}
-
-	// reconciliation assumes unmanaged mode, in the future we may have a slot here for
-	// other gateway management modes.
-	result, err := r.reconcileUnmanagedGateway(ctx, log, gateway)
-	// reconcileUnmanagedGateway has side effects and modifies the referenced gateway object. dataplane updates must
+	// reconciling the gateway has side effects and modifies the referenced gateway object. dataplane updates must
 	// happen afterwards
 	if err == nil {
 		if err := r.DataplaneClient.UpdateObject(gateway); err != nil {
@@ -380,7 +508,12 @@ func (r *GatewayReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ct
 // reconcileUnmanagedGateway reconciles a Gateway that is configured for unmanaged mode,
 // this mode will extract the Addresses and Listeners for the Gateway from the Kubernetes Service
 // used for the Kong Gateway in the pre-existing deployment.
-func (r *GatewayReconciler) reconcileUnmanagedGateway(ctx context.Context, log logr.Logger, gateway *gatewayv1beta1.Gateway) (ctrl.Result, error) {
+func (r *GatewayReconciler) reconcileUnmanagedGateway(
+	ctx context.Context,
+	log logr.Logger,
+	gateway *gatewayv1beta1.Gateway,
+	publishService string,
+) (ctrl.Result, error) {
 	// currently this controller supports only unmanaged gateway mode, we need to verify
 	// any Gateway object that comes to us is configured appropriately, and if not reject it
 	// with a clear status condition and message.
@@ -389,19 +522,25 @@ func (r *GatewayReconciler) reconcileUnmanagedGateway(ctx context.Context, log l
 	// enforce the service reference as the annotation value for the key UnmanagedGateway.
 	debug(log, gateway, "initializing admin service annotation if unset")
 	if !isObjectUnmanaged(gateway.GetAnnotations()) {
-		debug(log, gateway, fmt.Sprintf("a placeholder value was provided for %s, adding the default service ref %s", annotations.GatewayClassUnmanagedAnnotation, r.PublishService))
+		debug(log, gateway, fmt.Sprintf("a placeholder value was provided for %s, adding the default service ref %s", annotations.GatewayClassUnmanagedAnnotation, publishService))
 		if gateway.Annotations == nil {
 			gateway.Annotations = map[string]string{}
 		}
-		annotations.UpdateUnmanagedAnnotation(gateway.Annotations, r.PublishService)
+		annotations.UpdateUnmanagedAnnotation(gateway.Annotations, publishService)
 		return ctrl.Result{}, r.Update(ctx, gateway)
 	}
 
 	serviceRef := annotations.ExtractUnmanagedGatewayClassMode(gateway.Annotations)
+	// the default service ref is replaced when the GatewayClassParameters of the gateway configure another one.
+	if serviceRef == r.PublishService && publishService != r.PublishService {
+		debug(log, gateway, fmt.Sprintf("replacing the default service ref of %s with the gatewayclass service ref %s", annotations.GatewayClassUnmanagedAnnotation, publishService))
+		annotations.UpdateUnmanagedAnnotation(gateway.Annotations, publishService)
+		return ctrl.Result{}, r.Update(ctx, gateway)
+	}
 	// validation check of the Gateway to ensure that the publish service is actually available
 	// in the cluster. If it is not the object will be requeued until it exists (or is otherwise retrievable).
 	debug(log, gateway, "gathering the gateway publish service") // this will also be done by the validating webhook, this is a fallback
-	svc, err := r.determineServiceForGateway(ctx, serviceRef)
+	svc, err := r.determineServiceForGateway(ctx, serviceRef, publishService)
 	if err != nil {
 		log.Error(err, "could not determine service for gateway", "namespace", gateway.Namespace, "name", gateway.Name)
 		return ctrl.Result{Requeue: true}, err
@@ -462,14 +601,13 @@ func (r *GatewayReconciler) reconcileUnmanagedGateway(ctx context.Context, log l
 		}
 	}
 
-	// TODO https://github.com/Kong/kubernetes-ingress-controller/issues/2559 check cross-Gateway compatibility
-	// When all Listeners on all Gateways were derived from Kong's configuration, they were guaranteed to be compatible
-	// because they were all identical, though there may have been some ambiguity re de facto merged Gateways that
-	// used different allowedRoutes. We only merged allowedRoutes within a single Gateway, but merged all Gateways into
-	// a single set of shared listens. We lack knowledge of whether this is compatible with user intent, and it may
-	// be incompatible with the spec, so we should consider evaluating cross-Gateway compatibility and raising error
-	// conditions in the event of a problem
-	listenerStatuses, err := getListenerStatus(ctx, gateway, kongListeners, referenceGrantList.Items, r.Client)
+	// all Gateways of our GatewayClasses are merged into a single set of shared Kong listens, so the listeners
+	// of this Gateway must also be compatible with the ones of the other Gateways: the oldest Gateway wins.
+	claimedListeners, err := r.listClaimedListeners(ctx, gateway)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	listenerStatuses, err := getListenerStatus(ctx, gateway, kongListeners, claimedListeners, referenceGrantList.Items, r.Client)
 	if err != nil {
 		return ctrl.Result{}, err
 	}
@@ -478,7 +616,7 @@ func (r *GatewayReconciler) reconcileUnmanagedGateway(ctx context.Context, log l
 	// Gateway status reflects the spec. As the status is simply a mirror of the Service, this is
 	// a given and we can simply update spec to status.
 	debug(log, gateway, "updating the gateway status if necessary")
-	isChanged, err := r.updateAddressesAndListenersStatus(ctx, gateway, listenerStatuses)
+	isChanged, err := r.updateAddressesAndListenersStatus(ctx, gateway, gateway.Spec.Addresses, listenerStatuses)
 	if err != nil {
 		if apierrors.IsConflict(err) {
 			// if there's a conflict that's normal just requeue to retry, no need to make noise.
@@ -523,19 +661,37 @@ func init() {
 	}
 }
 
+// publishServiceForGatewayClass provides the "publish service" reference of the unmanaged gateways of the
+// provided GatewayClass: the publishService of its GatewayClassParameters, or --publish-service by default.
+func (r *GatewayReconciler) publishServiceForGatewayClass(ctx context.Context, gwc *gatewayv1beta1.GatewayClass) (string, error) {
+	params, err := getGatewayClassParameters(ctx, r.Client, gwc)
+	if err != nil {
+		return "", err
+	}
+	if params.Spec.PublishService != "" {
+		return params.Spec.PublishService, nil
+	}
+	return r.PublishService, nil
+}
+
 // determineServiceForGateway provides the "publish service" (aka the proxy Service) object which
 // will be used to populate unmanaged gateways.
-func (r *GatewayReconciler) determineServiceForGateway(ctx context.Context, ref string) (*corev1.Service, error) {
+func (r *GatewayReconciler) determineServiceForGateway(ctx context.Context, ref, publishService string) (*corev1.Service, error) {
 	// currently the gateway controller ONLY supports service references that correspond with the --publish-service
-	// provided to the controller manager via flags when operating on unmanaged gateways. This constraint may
-	// be loosened in later iterations if there is need.
-	if ref != r.PublishService {
-		return nil, fmt.Errorf("service ref %s did not match controller manager ref %s", ref, r.PublishService)
+	// provided to the controller manager via flags, or with the publishService of the GatewayClassParameters of
+	// the gateway, when operating on unmanaged gateways. This constraint may be loosened in later iterations if
+	// there is need.
+	if ref != publishService {
+		return nil, fmt.Errorf("service ref %s did not match gatewayclass ref %s", ref, publishService)
+	}
+	publishServiceRef, err := getRefFromPublishService(publishService)
+	if err != nil {
+		return nil, err
 	}
 
 	// retrieve the service for the kong gateway
 	svc := &corev1.Service{}
-	return svc, r.Client.Get(ctx, r.publishServiceRef, svc)
+	return svc, r.Client.Get(ctx, publishServiceRef, svc)
 }
 
 // determineL4ListenersFromService generates L4 addresses and listeners for a
@@ -690,16 +846,45 @@ func (r *GatewayReconciler) determineListenersFromDataPlane(
 // Gateway Controller - Private Object Update Methods
 // -----------------------------------------------------------------------------
 
-// updateAddressesAndListenersStatus updates a unmanaged gateway's status with new addresses and listeners.
+// listClaimedListeners returns the listeners of the Gateways which share the Kong listens with the provided
+// Gateway and were created before it. These listeners take precedence over the ones of the provided Gateway.
+func (r *GatewayReconciler) listClaimedListeners(ctx context.Context, gateway *gatewayv1beta1.Gateway) ([]claimedListener, error) {
+	gateways := &gatewayv1beta1.GatewayList{}
+	if err := r.Client.List(ctx, gateways); err != nil {
+		return nil, err
+	}
+	var olderGateways []*gatewayv1beta1.Gateway
+	for i := range gateways.Items {
+		other := &gateways.Items[i]
+		if !other.DeletionTimestamp.IsZero() || !isGatewayOlder(other, gateway) {
+			continue
+		}
+		gatewayClass := &gatewayv1beta1.GatewayClass{}
+		if err := r.Client.Get(ctx, types.NamespacedName{Name: string(other.Spec.GatewayClassName)}, gatewayClass); err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return nil, err
+		}
+		if !isGatewayClassControlledAndUnmanaged(gatewayClass) {
+			continue
+		}
+		olderGateways = append(olderGateways, other)
+	}
+	return claimListeners(olderGateways), nil
+}
+
+// updateAddressesAndListenersStatus updates a gateway's status with new addresses and listeners.
 // If the addresses and listeners provided are the same as what exists, it is assumed that reconciliation is complete and a Ready condition is posted.
 func (r *GatewayReconciler) updateAddressesAndListenersStatus(
 	ctx context.Context,
 	gateway *gatewayv1beta1.Gateway,
+	addresses []gatewayv1beta1.GatewayAddress,
 	listenerStatuses []gatewayv1beta1.ListenerStatus,
 ) (bool, error) {
 	if !isGatewayReady(gateway) {
 		gateway.Status.Listeners = listenerStatuses
-		gateway.Status.Addresses = gateway.Spec.Addresses
+		gateway.Status.Addresses = addresses
 		readyCondition := metav1.Condition{
 			Type:               string(gatewayv1beta1.GatewayConditionReady),
 			Status:             metav1.ConditionTrue,
@@ -711,8 +896,9 @@ func (r *GatewayReconciler) updateAddressesAndListenersStatus(
 		setGatewayCondition(gateway, readyCondition)
 		return true, r.Status().Update(ctx, pruneGatewayStatusConds(gateway))
 	}
-	if !reflect.DeepEqual(gateway.Status.Listeners, listenerStatuses) {
+	if !reflect.DeepEqual(gateway.Status.Listeners, listenerStatuses) || !reflect.DeepEqual(gateway.Status.Addresses, addresses) {
 		gateway.Status.Listeners = listenerStatuses
+		gateway.Status.Addresses = addresses
 		return true, r.Status().Update(ctx, gateway)
 	}
 	return false, nil
@@ -0,0 +1,538 @@
+package gateway
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/go-logr/logr"
+	"github.com/samber/lo"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	netv1 "k8s.io/api/networking/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+	gatewayv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/adminapi"
+)
+
+// -----------------------------------------------------------------------------
+// Gateway Controller - Managed Gateways - Vars & Consts
+// -----------------------------------------------------------------------------
+
+const (
+	// DefaultManagedGatewayKongImage is the default Kong image of the deployments of managed Gateways.
+	DefaultManagedGatewayKongImage = "kong:3.1"
+
+	// managedGatewayLabelKey is the label identifying the objects provisioned for a managed Gateway,
+	// its value is the UID of the Gateway.
+	managedGatewayLabelKey = "konghq.com/managed-gateway"
+
+	// managedGatewayAdminPort is the port of the admin API of the Kong instances of managed Gateways.
+	managedGatewayAdminPort = 8444
+
+	// managedGatewayStatusPort is the port of the status API of the Kong instances of managed Gateways,
+	// used for their readiness probes.
+	managedGatewayStatusPort = 8100
+
+	// managedGatewayAdminTLSPath is the path the admin API TLS Secrets of managed Gateways are mounted at in
+	// their Kong pods.
+	managedGatewayAdminTLSPath = "/etc/kong/admin-tls"
+
+	// managedGatewayAdminTLSAnnotationKey is the annotation of the Kong pods of managed Gateways holding the
+	// fingerprint of their admin API CA, so that the pods are replaced when the certificates are issued again.
+	managedGatewayAdminTLSAnnotationKey = "konghq.com/admin-tls-fingerprint"
+
+	// managedGatewayPrivilegedPortOffset is added to the privileged ports of the listeners of managed Gateways
+	// to get the ports Kong listens on, as Kong doesn't run as root.
+	managedGatewayPrivilegedPortOffset = 8000
+)
+
+// -----------------------------------------------------------------------------
+// Gateway Controller - Managed Gateways - Reconciliation
+// -----------------------------------------------------------------------------
+
+// +kubebuilder:rbac:groups=apps,resources=deployments,verbs=get;list;watch;create;update
+// +kubebuilder:rbac:groups="",resources=services,verbs=get;list;watch;create;update
+// +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch;create;update
+// +kubebuilder:rbac:groups=networking.k8s.io,resources=networkpolicies,verbs=get;list;watch;create;update
+
+// reconcileManagedGateway reconciles a Gateway of the managed GatewayClass. Each such Gateway is served by its
+// own Kong deployment, provisioned along with a proxy Service exposing the ports of the Gateway listeners and
+// an admin Service which the Kong configuration of the routes attached to the Gateway is pushed through.
+// The admin API is served with mutual TLS, using certificates issued by a CA dedicated to the Gateway, and a
+// NetworkPolicy only lets the controller's namespace reach it.
+func (r *GatewayReconciler) reconcileManagedGateway(ctx context.Context, log logr.Logger, gateway *gatewayv1beta1.Gateway) (ctrl.Result, error) {
+	nn := client.ObjectKeyFromObject(gateway)
+
+	// the Gateway is registered before its Kong instance is ready, so that its routes are removed from the
+	// shared Kong instances straight away rather than being served by a Kong the Gateway doesn't belong to.
+	if _, ok := r.DataplaneClient.ManagedGatewayAdminAPIClient(nn); !ok {
+		r.DataplaneClient.SetManagedGatewayAdminAPIClient(nn, nil)
+	}
+
+	if !isGatewayScheduled(gateway) {
+		info(log, gateway, "marking gateway as scheduled")
+		scheduledCondition := metav1.Condition{
+			Type:               string(gatewayv1beta1.GatewayConditionScheduled),
+			Status:             metav1.ConditionTrue,
+			ObservedGeneration: gateway.Generation,
+			LastTransitionTime: metav1.Now(),
+			Reason:             string(gatewayv1beta1.GatewayReasonScheduled),
+			Message:            "this managed gateway has been picked up by the controller and will be provisioned",
+		}
+		setGatewayCondition(gateway, scheduledCondition)
+		return ctrl.Result{}, r.Status().Update(ctx, pruneGatewayStatusConds(gateway))
+	}
+
+	listens := managedGatewayListens(gateway)
+
+	debug(log, gateway, "ensuring the Kong deployment and services of the managed gateway")
+	adminService := &corev1.Service{ObjectMeta: metav1.ObjectMeta{Namespace: gateway.Namespace, Name: managedGatewayAdminServiceName(gateway)}}
+	adminTLSSecret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: gateway.Namespace, Name: managedGatewayAdminTLSSecretName(gateway)}}
+	if err := r.ensureManagedGatewayObject(ctx, gateway, adminTLSSecret, func() error {
+		return mutateManagedGatewayAdminTLSSecret(adminTLSSecret, gateway, managedGatewayAdminHost(adminService))
+	}); err != nil {
+		return ctrl.Result{}, err
+	}
+	adminTLSFingerprint := managedGatewayAdminTLSFingerprint(adminTLSSecret)
+	deployment := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Namespace: gateway.Namespace, Name: managedGatewayDeploymentName(gateway)}}
+	if err := r.ensureManagedGatewayObject(ctx, gateway, deployment, func() error {
+		mutateManagedGatewayDeployment(deployment, gateway, r.managedGatewayKongImage(), listens, adminTLSSecret.Name, adminTLSFingerprint)
+		return nil
+	}); err != nil {
+		return ctrl.Result{}, err
+	}
+	proxyService := &corev1.Service{ObjectMeta: metav1.ObjectMeta{Namespace: gateway.Namespace, Name: managedGatewayProxyServiceName(gateway)}}
+	if err := r.ensureManagedGatewayObject(ctx, gateway, proxyService, func() error {
+		mutateManagedGatewayProxyService(proxyService, gateway, listens)
+		return nil
+	}); err != nil {
+		return ctrl.Result{}, err
+	}
+	if err := r.ensureManagedGatewayObject(ctx, gateway, adminService, func() error {
+		mutateManagedGatewayAdminService(adminService, gateway)
+		return nil
+	}); err != nil {
+		return ctrl.Result{}, err
+	}
+	networkPolicy := &netv1.NetworkPolicy{ObjectMeta: metav1.ObjectMeta{Namespace: gateway.Namespace, Name: managedGatewayDeploymentName(gateway)}}
+	if err := r.ensureManagedGatewayObject(ctx, gateway, networkPolicy, func() error {
+		mutateManagedGatewayNetworkPolicy(networkPolicy, gateway, listens, r.ControllerNamespace)
+		return nil
+	}); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	// the Deployment and the Services are watched, so the Gateway is reconciled again once they're ready.
+	if deployment.Status.AvailableReplicas < 1 {
+		debug(log, gateway, "waiting for the Kong deployment of the managed gateway to become available")
+		return ctrl.Result{}, nil
+	}
+	if len(proxyService.Spec.ClusterIPs) < 1 {
+		debug(log, gateway, "waiting for the proxy service of the managed gateway to be provisioned")
+		return ctrl.Result{}, nil
+	}
+	addresses, _, err := r.determineL4ListenersFromService(log, proxyService)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	// the client is created again when the certificates are issued again, along with the Kong pods.
+	adminURL := managedGatewayAdminURL(adminService)
+	cl, ok := r.DataplaneClient.ManagedGatewayAdminAPIClient(nn)
+	if previousFingerprint, _ := r.managedGatewayAdminTLSFingerprints.Load(nn); !ok ||
+		cl.BaseRootURL() != adminURL || previousFingerprint != adminTLSFingerprint {
+		cl, err := newManagedGatewayAdminAPIClient(ctx, adminURL, adminTLSSecret)
+		if err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to create Kong Admin API client for %s: %w", adminURL, err)
+		}
+		r.DataplaneClient.SetManagedGatewayAdminAPIClient(nn, cl)
+		r.managedGatewayAdminTLSFingerprints.Store(nn, adminTLSFingerprint)
+	}
+
+	referenceGrantList := &gatewayv1alpha2.ReferenceGrantList{}
+	if r.EnableReferenceGrant {
+		if err := r.Client.List(ctx, referenceGrantList); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+	// the Kong instance of a managed Gateway is dedicated to it, so its listeners can't conflict with
+	// the ones of other Gateways.
+	listenerStatuses, err := getListenerStatus(ctx, gateway, managedGatewayKongListens(listens), nil, referenceGrantList.Items, r.Client)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	debug(log, gateway, "updating the gateway status if necessary")
+	isChanged, err := r.updateAddressesAndListenersStatus(ctx, gateway, addresses, listenerStatuses)
+	if err != nil {
+		if apierrors.IsConflict(err) {
+			return ctrl.Result{Requeue: true}, nil
+		}
+		return ctrl.Result{}, err
+	}
+	if isChanged {
+		debug(log, gateway, "gateways status updated")
+		return ctrl.Result{}, nil
+	}
+
+	info(log, gateway, "managed gateway provisioning complete")
+	return ctrl.Result{}, nil
+}
+
+// ensureManagedGatewayObject creates or updates an object provisioned for a managed Gateway, which is owned by
+// the Gateway so that it's garbage collected along with it.
+func (r *GatewayReconciler) ensureManagedGatewayObject(
+	ctx context.Context, gateway *gatewayv1beta1.Gateway, obj client.Object, mutate func() error,
+) error {
+	_, err := controllerutil.CreateOrUpdate(ctx, r.Client, obj, func() error {
+		if err := mutate(); err != nil {
+			return err
+		}
+		return controllerutil.SetControllerReference(gateway, obj, r.Scheme)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to ensure %s/%s for managed gateway: %w", obj.GetNamespace(), obj.GetName(), err)
+	}
+	return nil
+}
+
+// managedGatewayKongImage returns the Kong image of the deployments of managed Gateways.
+func (r *GatewayReconciler) managedGatewayKongImage() string {
+	if r.ManagedGatewayKongImage == "" {
+		return DefaultManagedGatewayKongImage
+	}
+	return r.ManagedGatewayKongImage
+}
+
+// isGatewayManaged returns true if the Gateway uses the managed GatewayClass.
+func (r *GatewayReconciler) isGatewayManaged(gatewayClass *gatewayv1beta1.GatewayClass) bool {
+	return isGatewayClassControlledAndManaged(gatewayClass, r.ManagedGatewayClass)
+}
+
+// unregisterManagedGateway stops pushing configuration to the Kong instance of a Gateway which isn't managed
+// (anymore), so that its routes are served by the shared Kong instances again.
+func (r *GatewayReconciler) unregisterManagedGateway(nn types.NamespacedName) {
+	if r.ManagedGatewayClass != "" {
+		r.DataplaneClient.DeleteManagedGateway(nn)
+		r.managedGatewayAdminTLSFingerprints.Delete(nn)
+	}
+}
+
+// newManagedGatewayAdminAPIClient creates a client of the admin API of the Kong instance of a managed Gateway,
+// authenticating with the client certificate of its admin API TLS Secret and only trusting the Secret's CA.
+func newManagedGatewayAdminAPIClient(ctx context.Context, adminURL string, adminTLSSecret *corev1.Secret) (*adminapi.Client, error) {
+	httpclient, err := adminapi.MakeHTTPClient(&adminapi.HTTPClientOpts{
+		CACert: string(adminTLSSecret.Data[managedGatewayAdminTLSCACertKey]),
+		TLSClient: adminapi.TLSClientConfig{
+			Cert: string(adminTLSSecret.Data[managedGatewayAdminTLSClientCertKey]),
+			Key:  string(adminTLSSecret.Data[managedGatewayAdminTLSClientKeyKey]),
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return adminapi.NewKongClientForWorkspace(ctx, adminURL, "", httpclient)
+}
+
+// -----------------------------------------------------------------------------
+// Gateway Controller - Managed Gateways - Listens
+// -----------------------------------------------------------------------------
+
+// managedGatewayListen is a Kong listen of the Kong instance of a managed Gateway, serving the listeners of
+// the Gateway on one of their ports.
+type managedGatewayListen struct {
+	protocol      ProtocolType
+	port          PortNumber
+	containerPort int32
+}
+
+// kongListen returns the listen address of the listen, as it's configured in Kong.
+func (l managedGatewayListen) kongListen() string {
+	switch l.protocol { //nolint:exhaustive
+	case gatewayv1beta1.HTTPSProtocolType:
+		return fmt.Sprintf("0.0.0.0:%d http2 ssl", l.containerPort)
+	case gatewayv1beta1.TLSProtocolType:
+		return fmt.Sprintf("0.0.0.0:%d ssl", l.containerPort)
+	case gatewayv1beta1.UDPProtocolType:
+		return fmt.Sprintf("0.0.0.0:%d udp", l.containerPort)
+	default:
+		return fmt.Sprintf("0.0.0.0:%d", l.containerPort)
+	}
+}
+
+// isProxyListen returns true for the listens configured as Kong proxy listens, rather than stream listens.
+func (l managedGatewayListen) isProxyListen() bool {
+	return l.protocol == gatewayv1beta1.HTTPProtocolType || l.protocol == gatewayv1beta1.HTTPSProtocolType
+}
+
+// serviceProtocol returns the protocol of the listen's port in the proxy Service.
+func (l managedGatewayListen) serviceProtocol() corev1.Protocol {
+	if l.protocol == gatewayv1beta1.UDPProtocolType {
+		return corev1.ProtocolUDP
+	}
+	return corev1.ProtocolTCP
+}
+
+// managedGatewayListens returns the Kong listens serving the listeners of a managed Gateway. Listeners sharing
+// a port with the same protocol share a listen. Listeners which can't be served are left out: the ones with an
+// unsupported protocol, the ones sharing a port with a listener of another protocol (Kong can't serve both on
+// a single port), and the ones which port would collide with the admin and status ports of Kong.
+func managedGatewayListens(gateway *gatewayv1beta1.Gateway) []managedGatewayListen {
+	var listens []managedGatewayListen
+	portProtocols := make(map[PortNumber]ProtocolType, len(gateway.Spec.Listeners))
+	containerPorts := map[int32]bool{managedGatewayAdminPort: true, managedGatewayStatusPort: true}
+	for _, listener := range gateway.Spec.Listeners {
+		switch listener.Protocol { //nolint:exhaustive
+		case gatewayv1beta1.HTTPProtocolType, gatewayv1beta1.HTTPSProtocolType, gatewayv1beta1.TLSProtocolType,
+			gatewayv1beta1.TCPProtocolType, gatewayv1beta1.UDPProtocolType:
+		default:
+			continue
+		}
+		if _, ok := portProtocols[listener.Port]; ok {
+			continue
+		}
+		containerPort := int32(listener.Port)
+		if containerPort < 1024 {
+			containerPort += managedGatewayPrivilegedPortOffset
+		}
+		if containerPorts[containerPort] {
+			continue
+		}
+		portProtocols[listener.Port] = listener.Protocol
+		containerPorts[containerPort] = true
+		listens = append(listens, managedGatewayListen{
+			protocol:      listener.Protocol,
+			port:          listener.Port,
+			containerPort: containerPort,
+		})
+	}
+	return listens
+}
+
+// managedGatewayKongListens returns the Listeners describing the Kong listens of a managed Gateway, which the
+// Gateway listeners are validated against.
+func managedGatewayKongListens(listens []managedGatewayListen) []Listener {
+	listeners := make([]Listener, 0, len(listens))
+	for _, listen := range listens {
+		listeners = append(listeners, Listener{
+			Name:     SectionName(fmt.Sprintf("%s-%d", strings.ToLower(string(listen.protocol)), listen.port)),
+			Protocol: listen.protocol,
+			Port:     listen.port,
+		})
+	}
+	return listeners
+}
+
+// -----------------------------------------------------------------------------
+// Gateway Controller - Managed Gateways - Objects
+// -----------------------------------------------------------------------------
+
+func managedGatewayDeploymentName(gateway *gatewayv1beta1.Gateway) string {
+	return gateway.Name + "-kong"
+}
+
+func managedGatewayProxyServiceName(gateway *gatewayv1beta1.Gateway) string {
+	return gateway.Name + "-kong-proxy"
+}
+
+func managedGatewayAdminServiceName(gateway *gatewayv1beta1.Gateway) string {
+	return gateway.Name + "-kong-admin"
+}
+
+func managedGatewayAdminTLSSecretName(gateway *gatewayv1beta1.Gateway) string {
+	return gateway.Name + "-kong-admin-tls"
+}
+
+// managedGatewayAdminHost returns the host name of the admin API of a managed Gateway, served by its admin
+// Service, which its admin API server certificate is issued for.
+func managedGatewayAdminHost(adminService *corev1.Service) string {
+	return fmt.Sprintf("%s.%s.svc", adminService.Name, adminService.Namespace)
+}
+
+// managedGatewayAdminURL returns the URL of the admin API of a managed Gateway, served by its admin Service.
+func managedGatewayAdminURL(adminService *corev1.Service) string {
+	return fmt.Sprintf("https://%s:%d", managedGatewayAdminHost(adminService), managedGatewayAdminPort)
+}
+
+// managedGatewayLabels returns the labels of the objects provisioned for a managed Gateway, which select its
+// Kong pods.
+func managedGatewayLabels(gateway *gatewayv1beta1.Gateway) map[string]string {
+	return map[string]string{managedGatewayLabelKey: string(gateway.UID)}
+}
+
+// mutateManagedGatewayDeployment sets the desired state of the Kong deployment of a managed Gateway, which runs
+// a DB-less Kong configured with a listen for each port of the Gateway listeners. Its admin API only accepts
+// the clients presenting a certificate issued by the CA of the admin API TLS Secret.
+func mutateManagedGatewayDeployment(
+	deployment *appsv1.Deployment, gateway *gatewayv1beta1.Gateway, image string, listens []managedGatewayListen,
+	adminTLSSecretName, adminTLSFingerprint string,
+) {
+	var (
+		proxyListens, streamListens, portMaps []string
+		containerPorts                        []corev1.ContainerPort
+	)
+	for _, listen := range listens {
+		if listen.isProxyListen() {
+			proxyListens = append(proxyListens, listen.kongListen())
+			portMaps = append(portMaps, fmt.Sprintf("%d:%d", listen.port, listen.containerPort))
+		} else {
+			streamListens = append(streamListens, listen.kongListen())
+		}
+		containerPorts = append(containerPorts, corev1.ContainerPort{
+			Name:          fmt.Sprintf("proxy-%d", listen.port),
+			ContainerPort: listen.containerPort,
+			Protocol:      listen.serviceProtocol(),
+		})
+	}
+	containerPorts = append(containerPorts,
+		corev1.ContainerPort{Name: "admin", ContainerPort: managedGatewayAdminPort, Protocol: corev1.ProtocolTCP},
+		corev1.ContainerPort{Name: "status", ContainerPort: managedGatewayStatusPort, Protocol: corev1.ProtocolTCP},
+	)
+	listenOrOff := func(listens []string) string {
+		if len(listens) == 0 {
+			return "off"
+		}
+		return strings.Join(listens, ", ")
+	}
+
+	labels := managedGatewayLabels(gateway)
+	replicas := int32(1)
+	deployment.Labels = labels
+	deployment.Spec.Replicas = &replicas
+	deployment.Spec.Selector = &metav1.LabelSelector{MatchLabels: labels}
+	deployment.Spec.Template.Labels = labels
+	deployment.Spec.Template.Annotations = map[string]string{managedGatewayAdminTLSAnnotationKey: adminTLSFingerprint}
+	deployment.Spec.Template.Spec.Volumes = []corev1.Volume{{
+		Name: "admin-tls",
+		VolumeSource: corev1.VolumeSource{
+			Secret: &corev1.SecretVolumeSource{
+				SecretName: adminTLSSecretName,
+				Items: []corev1.KeyToPath{
+					{Key: managedGatewayAdminTLSCACertKey, Path: managedGatewayAdminTLSCACertKey},
+					{Key: managedGatewayAdminTLSCertKey, Path: managedGatewayAdminTLSCertKey},
+					{Key: managedGatewayAdminTLSKeyKey, Path: managedGatewayAdminTLSKeyKey},
+				},
+			},
+		},
+	}}
+	deployment.Spec.Template.Spec.Containers = []corev1.Container{{
+		Name:  "proxy",
+		Image: image,
+		Env: []corev1.EnvVar{
+			{Name: "KONG_DATABASE", Value: "off"},
+			{Name: "KONG_PROXY_LISTEN", Value: listenOrOff(proxyListens)},
+			{Name: "KONG_STREAM_LISTEN", Value: listenOrOff(streamListens)},
+			{Name: "KONG_PORT_MAPS", Value: strings.Join(portMaps, ", ")},
+			{Name: "KONG_ADMIN_LISTEN", Value: fmt.Sprintf("0.0.0.0:%d http2 ssl", managedGatewayAdminPort)},
+			{Name: "KONG_ADMIN_SSL_CERT", Value: managedGatewayAdminTLSPath + "/" + managedGatewayAdminTLSCertKey},
+			{Name: "KONG_ADMIN_SSL_CERT_KEY", Value: managedGatewayAdminTLSPath + "/" + managedGatewayAdminTLSKeyKey},
+			{Name: "KONG_NGINX_ADMIN_SSL_CLIENT_CERTIFICATE", Value: managedGatewayAdminTLSPath + "/" + managedGatewayAdminTLSCACertKey},
+			{Name: "KONG_NGINX_ADMIN_SSL_VERIFY_CLIENT", Value: "on"},
+			{Name: "KONG_STATUS_LISTEN", Value: fmt.Sprintf("0.0.0.0:%d", managedGatewayStatusPort)},
+			{Name: "KONG_ADMIN_ACCESS_LOG", Value: "/dev/stdout"},
+			{Name: "KONG_ADMIN_ERROR_LOG", Value: "/dev/stderr"},
+			{Name: "KONG_PROXY_ERROR_LOG", Value: "/dev/stderr"},
+		},
+		Ports: containerPorts,
+		VolumeMounts: []corev1.VolumeMount{
+			{Name: "admin-tls", MountPath: managedGatewayAdminTLSPath, ReadOnly: true},
+		},
+		ReadinessProbe: &corev1.Probe{
+			ProbeHandler: corev1.ProbeHandler{
+				HTTPGet: &corev1.HTTPGetAction{
+					Path:   "/status",
+					Port:   intstr.FromInt(managedGatewayStatusPort),
+					Scheme: corev1.URISchemeHTTP,
+				},
+			},
+		},
+	}}
+}
+
+// mutateManagedGatewayProxyService sets the desired state of the proxy Service of a managed Gateway, which
+// exposes the ports of the Gateway listeners. The addresses of the Gateway are the ones of this Service.
+func mutateManagedGatewayProxyService(service *corev1.Service, gateway *gatewayv1beta1.Gateway, listens []managedGatewayListen) {
+	// node ports are allocated by the API server, keep them so that updates don't reallocate them.
+	nodePorts := make(map[string]int32, len(service.Spec.Ports))
+	for _, port := range service.Spec.Ports {
+		nodePorts[port.Name] = port.NodePort
+	}
+
+	ports := make([]corev1.ServicePort, 0, len(listens))
+	for _, listen := range listens {
+		name := fmt.Sprintf("proxy-%d", listen.port)
+		ports = append(ports, corev1.ServicePort{
+			Name:       name,
+			Protocol:   listen.serviceProtocol(),
+			Port:       int32(listen.port),
+			TargetPort: intstr.FromInt(int(listen.containerPort)),
+			NodePort:   nodePorts[name],
+		})
+	}
+
+	service.Labels = managedGatewayLabels(gateway)
+	service.Spec.Type = corev1.ServiceTypeLoadBalancer
+	service.Spec.Selector = managedGatewayLabels(gateway)
+	service.Spec.Ports = ports
+}
+
+// mutateManagedGatewayAdminService sets the desired state of the admin Service of a managed Gateway, which
+// the configuration of its Kong instance is pushed through.
+func mutateManagedGatewayAdminService(service *corev1.Service, gateway *gatewayv1beta1.Gateway) {
+	service.Labels = managedGatewayLabels(gateway)
+	service.Spec.Type = corev1.ServiceTypeClusterIP
+	service.Spec.Selector = managedGatewayLabels(gateway)
+	service.Spec.Ports = []corev1.ServicePort{{
+		Name:       "admin",
+		Protocol:   corev1.ProtocolTCP,
+		Port:       managedGatewayAdminPort,
+		TargetPort: intstr.FromInt(managedGatewayAdminPort),
+	}}
+}
+
+// mutateManagedGatewayNetworkPolicy sets the desired state of the NetworkPolicy of the Kong pods of a managed
+// Gateway, which lets anyone reach the ports of the Gateway listeners, but only the pods of the controller's
+// namespace reach the admin API. When the controller's namespace isn't known, the admin API is reachable from
+// all namespaces, still requiring the clients to present a certificate issued by the Gateway's admin CA.
+func mutateManagedGatewayNetworkPolicy(
+	networkPolicy *netv1.NetworkPolicy, gateway *gatewayv1beta1.Gateway, listens []managedGatewayListen, controllerNamespace string,
+) {
+	proxyPorts := make([]netv1.NetworkPolicyPort, 0, len(listens))
+	for _, listen := range listens {
+		proxyPorts = append(proxyPorts, netv1.NetworkPolicyPort{
+			Protocol: lo.ToPtr(listen.serviceProtocol()),
+			Port:     lo.ToPtr(intstr.FromInt(int(listen.containerPort))),
+		})
+	}
+	adminRule := netv1.NetworkPolicyIngressRule{
+		Ports: []netv1.NetworkPolicyPort{{
+			Protocol: lo.ToPtr(corev1.ProtocolTCP),
+			Port:     lo.ToPtr(intstr.FromInt(managedGatewayAdminPort)),
+		}},
+		From: []netv1.NetworkPolicyPeer{{NamespaceSelector: &metav1.LabelSelector{}}},
+	}
+	if controllerNamespace != "" {
+		adminRule.From = []netv1.NetworkPolicyPeer{{
+			NamespaceSelector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{corev1.LabelMetadataName: controllerNamespace},
+			},
+		}}
+	}
+
+	networkPolicy.Labels = managedGatewayLabels(gateway)
+	networkPolicy.Spec.PodSelector = metav1.LabelSelector{MatchLabels: managedGatewayLabels(gateway)}
+	networkPolicy.Spec.PolicyTypes = []netv1.PolicyType{netv1.PolicyTypeIngress}
+	networkPolicy.Spec.Ingress = []netv1.NetworkPolicyIngressRule{adminRule}
+	if len(proxyPorts) > 0 {
+		networkPolicy.Spec.Ingress = append(networkPolicy.Spec.Ingress, netv1.NetworkPolicyIngressRule{Ports: proxyPorts})
+	}
+}
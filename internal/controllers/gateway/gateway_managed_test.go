@@ -0,0 +1,268 @@
+package gateway
+
+import (
+	"testing"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	netv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	gatewayv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/annotations"
+)
+
+func TestIsGatewayClassControlledAndManaged(t *testing.T) {
+	testCases := []struct {
+		name                string
+		gatewayClass        *gatewayv1beta1.GatewayClass
+		managedGatewayClass string
+		expectedResult      bool
+	}{
+		{
+			name: "managed GatewayClass",
+			gatewayClass: &gatewayv1beta1.GatewayClass{
+				ObjectMeta: metav1.ObjectMeta{Name: "managed"},
+				Spec:       gatewayv1beta1.GatewayClassSpec{ControllerName: ControllerName},
+			},
+			managedGatewayClass: "managed",
+			expectedResult:      true,
+		},
+		{
+			name: "managed gateways disabled",
+			gatewayClass: &gatewayv1beta1.GatewayClass{
+				ObjectMeta: metav1.ObjectMeta{Name: ""},
+				Spec:       gatewayv1beta1.GatewayClassSpec{ControllerName: ControllerName},
+			},
+			managedGatewayClass: "",
+			expectedResult:      false,
+		},
+		{
+			name: "other GatewayClass",
+			gatewayClass: &gatewayv1beta1.GatewayClass{
+				ObjectMeta: metav1.ObjectMeta{Name: "other"},
+				Spec:       gatewayv1beta1.GatewayClassSpec{ControllerName: ControllerName},
+			},
+			managedGatewayClass: "managed",
+			expectedResult:      false,
+		},
+		{
+			name: "uncontrolled GatewayClass",
+			gatewayClass: &gatewayv1beta1.GatewayClass{
+				ObjectMeta: metav1.ObjectMeta{Name: "managed"},
+				Spec:       gatewayv1beta1.GatewayClassSpec{ControllerName: "acme.io/gateway-controller"},
+			},
+			managedGatewayClass: "managed",
+			expectedResult:      false,
+		},
+		{
+			name: "unmanaged GatewayClass",
+			gatewayClass: &gatewayv1beta1.GatewayClass{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "managed",
+					Annotations: map[string]string{
+						annotations.GatewayClassUnmanagedAnnotation: annotations.GatewayClassUnmanagedAnnotationValuePlaceholder,
+					},
+				},
+				Spec: gatewayv1beta1.GatewayClassSpec{ControllerName: ControllerName},
+			},
+			managedGatewayClass: "managed",
+			expectedResult:      false,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expectedResult, isGatewayClassControlledAndManaged(tc.gatewayClass, tc.managedGatewayClass))
+		})
+	}
+}
+
+func TestManagedGatewayListens(t *testing.T) {
+	testCases := []struct {
+		name            string
+		listeners       []gatewayv1beta1.Listener
+		expectedListens []managedGatewayListen
+	}{
+		{
+			name: "privileged ports are offset",
+			listeners: []gatewayv1beta1.Listener{
+				{Name: "http", Protocol: gatewayv1beta1.HTTPProtocolType, Port: 80},
+				{Name: "https", Protocol: gatewayv1beta1.HTTPSProtocolType, Port: 443},
+				{Name: "tcp", Protocol: gatewayv1beta1.TCPProtocolType, Port: 9000},
+			},
+			expectedListens: []managedGatewayListen{
+				{protocol: gatewayv1beta1.HTTPProtocolType, port: 80, containerPort: 8080},
+				{protocol: gatewayv1beta1.HTTPSProtocolType, port: 443, containerPort: 8443},
+				{protocol: gatewayv1beta1.TCPProtocolType, port: 9000, containerPort: 9000},
+			},
+		},
+		{
+			name: "listeners sharing a port share a listen",
+			listeners: []gatewayv1beta1.Listener{
+				{Name: "foo", Protocol: gatewayv1beta1.HTTPProtocolType, Port: 80, Hostname: lo.ToPtr(gatewayv1beta1.Hostname("foo.com"))},
+				{Name: "bar", Protocol: gatewayv1beta1.HTTPProtocolType, Port: 80, Hostname: lo.ToPtr(gatewayv1beta1.Hostname("bar.com"))},
+			},
+			expectedListens: []managedGatewayListen{
+				{protocol: gatewayv1beta1.HTTPProtocolType, port: 80, containerPort: 8080},
+			},
+		},
+		{
+			name: "listeners which can't be served are left out",
+			listeners: []gatewayv1beta1.Listener{
+				{Name: "https", Protocol: gatewayv1beta1.HTTPSProtocolType, Port: 443},
+				{Name: "tls", Protocol: gatewayv1beta1.TLSProtocolType, Port: 443},
+				{Name: "admin", Protocol: gatewayv1beta1.HTTPProtocolType, Port: 444},
+				{Name: "status", Protocol: gatewayv1beta1.TCPProtocolType, Port: 8100},
+				{Name: "offset-collision", Protocol: gatewayv1beta1.UDPProtocolType, Port: 8443},
+				{Name: "unsupported", Protocol: "GRPC", Port: 9000},
+				{Name: "udp", Protocol: gatewayv1beta1.UDPProtocolType, Port: 53},
+			},
+			expectedListens: []managedGatewayListen{
+				{protocol: gatewayv1beta1.HTTPSProtocolType, port: 443, containerPort: 8443},
+				{protocol: gatewayv1beta1.UDPProtocolType, port: 53, containerPort: 8053},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			gateway := &gatewayv1beta1.Gateway{Spec: gatewayv1beta1.GatewaySpec{Listeners: tc.listeners}}
+			assert.Equal(t, tc.expectedListens, managedGatewayListens(gateway))
+		})
+	}
+}
+
+func TestMutateManagedGatewayObjects(t *testing.T) {
+	gateway := &gatewayv1beta1.Gateway{
+		ObjectMeta: metav1.ObjectMeta{Name: "gw", Namespace: "team-a", UID: types.UID("gateway-uid")},
+		Spec: gatewayv1beta1.GatewaySpec{
+			Listeners: []gatewayv1beta1.Listener{
+				{Name: "http", Protocol: gatewayv1beta1.HTTPProtocolType, Port: 80},
+				{Name: "https", Protocol: gatewayv1beta1.HTTPSProtocolType, Port: 443},
+				{Name: "tls", Protocol: gatewayv1beta1.TLSProtocolType, Port: 9443},
+				{Name: "udp", Protocol: gatewayv1beta1.UDPProtocolType, Port: 9053},
+			},
+		},
+	}
+	listens := managedGatewayListens(gateway)
+	expectedLabels := map[string]string{managedGatewayLabelKey: "gateway-uid"}
+
+	t.Run("deployment", func(t *testing.T) {
+		deployment := &appsv1.Deployment{}
+		mutateManagedGatewayDeployment(deployment, gateway, "kong:3.1", listens, "gw-kong-admin-tls", "fingerprint")
+
+		assert.Equal(t, expectedLabels, deployment.Spec.Selector.MatchLabels)
+		assert.Equal(t, expectedLabels, deployment.Spec.Template.Labels)
+		require.Len(t, deployment.Spec.Template.Spec.Containers, 1)
+		container := deployment.Spec.Template.Spec.Containers[0]
+		assert.Equal(t, "kong:3.1", container.Image)
+		env := lo.SliceToMap(container.Env, func(e corev1.EnvVar) (string, string) { return e.Name, e.Value })
+		assert.Equal(t, "off", env["KONG_DATABASE"])
+		assert.Equal(t, "0.0.0.0:8080, 0.0.0.0:8443 http2 ssl", env["KONG_PROXY_LISTEN"])
+		assert.Equal(t, "0.0.0.0:9443 ssl, 0.0.0.0:9053 udp", env["KONG_STREAM_LISTEN"])
+		assert.Equal(t, "80:8080, 443:8443", env["KONG_PORT_MAPS"])
+		assert.Equal(t, "0.0.0.0:8444 http2 ssl", env["KONG_ADMIN_LISTEN"])
+		assert.Equal(t, []int32{8080, 8443, 9443, 9053, 8444, 8100},
+			lo.Map(container.Ports, func(p corev1.ContainerPort, _ int) int32 { return p.ContainerPort }))
+
+		assert.Equal(t, "/etc/kong/admin-tls/tls.crt", env["KONG_ADMIN_SSL_CERT"])
+		assert.Equal(t, "/etc/kong/admin-tls/tls.key", env["KONG_ADMIN_SSL_CERT_KEY"])
+		assert.Equal(t, "/etc/kong/admin-tls/ca.crt", env["KONG_NGINX_ADMIN_SSL_CLIENT_CERTIFICATE"])
+		assert.Equal(t, "on", env["KONG_NGINX_ADMIN_SSL_VERIFY_CLIENT"])
+		assert.Equal(t, "fingerprint", deployment.Spec.Template.Annotations[managedGatewayAdminTLSAnnotationKey])
+		require.Len(t, deployment.Spec.Template.Spec.Volumes, 1)
+		secretVolume := deployment.Spec.Template.Spec.Volumes[0].Secret
+		require.NotNil(t, secretVolume)
+		assert.Equal(t, "gw-kong-admin-tls", secretVolume.SecretName)
+		assert.Equal(t, []string{"ca.crt", "tls.crt", "tls.key"},
+			lo.Map(secretVolume.Items, func(i corev1.KeyToPath, _ int) string { return i.Key }),
+			"the client certificate must not be mounted in the Kong pods")
+	})
+
+	t.Run("deployment without stream listeners", func(t *testing.T) {
+		deployment := &appsv1.Deployment{}
+		mutateManagedGatewayDeployment(deployment, gateway, "kong:3.1", listens[:1], "gw-kong-admin-tls", "fingerprint")
+		env := lo.SliceToMap(deployment.Spec.Template.Spec.Containers[0].Env, func(e corev1.EnvVar) (string, string) {
+			return e.Name, e.Value
+		})
+		assert.Equal(t, "off", env["KONG_STREAM_LISTEN"])
+	})
+
+	t.Run("proxy service", func(t *testing.T) {
+		service := &corev1.Service{
+			Spec: corev1.ServiceSpec{
+				ClusterIP: "10.0.0.1",
+				Ports:     []corev1.ServicePort{{Name: "proxy-80", Port: 80, NodePort: 30080}},
+			},
+		}
+		mutateManagedGatewayProxyService(service, gateway, listens)
+
+		assert.Equal(t, corev1.ServiceTypeLoadBalancer, service.Spec.Type)
+		assert.Equal(t, "10.0.0.1", service.Spec.ClusterIP)
+		assert.Equal(t, expectedLabels, service.Spec.Selector)
+		assert.Equal(t, []corev1.ServicePort{
+			{Name: "proxy-80", Protocol: corev1.ProtocolTCP, Port: 80, TargetPort: intstr.FromInt(8080), NodePort: 30080},
+			{Name: "proxy-443", Protocol: corev1.ProtocolTCP, Port: 443, TargetPort: intstr.FromInt(8443)},
+			{Name: "proxy-9443", Protocol: corev1.ProtocolTCP, Port: 9443, TargetPort: intstr.FromInt(9443)},
+			{Name: "proxy-9053", Protocol: corev1.ProtocolUDP, Port: 9053, TargetPort: intstr.FromInt(9053)},
+		}, service.Spec.Ports)
+	})
+
+	t.Run("admin service", func(t *testing.T) {
+		service := &corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: managedGatewayAdminServiceName(gateway), Namespace: gateway.Namespace}}
+		mutateManagedGatewayAdminService(service, gateway)
+
+		assert.Equal(t, corev1.ServiceTypeClusterIP, service.Spec.Type)
+		assert.Equal(t, expectedLabels, service.Spec.Selector)
+		assert.Equal(t, "https://gw-kong-admin.team-a.svc:8444", managedGatewayAdminURL(service))
+	})
+
+	t.Run("network policy", func(t *testing.T) {
+		networkPolicy := &netv1.NetworkPolicy{}
+		mutateManagedGatewayNetworkPolicy(networkPolicy, gateway, listens, "kong")
+
+		assert.Equal(t, expectedLabels, networkPolicy.Spec.PodSelector.MatchLabels)
+		assert.Equal(t, []netv1.PolicyType{netv1.PolicyTypeIngress}, networkPolicy.Spec.PolicyTypes)
+		require.Len(t, networkPolicy.Spec.Ingress, 2)
+
+		adminRule := networkPolicy.Spec.Ingress[0]
+		require.Len(t, adminRule.Ports, 1)
+		assert.Equal(t, intstr.FromInt(8444), *adminRule.Ports[0].Port)
+		require.Len(t, adminRule.From, 1)
+		assert.Equal(t, map[string]string{corev1.LabelMetadataName: "kong"}, adminRule.From[0].NamespaceSelector.MatchLabels,
+			"only the controller's namespace must reach the admin API")
+
+		proxyRule := networkPolicy.Spec.Ingress[1]
+		assert.Empty(t, proxyRule.From, "anyone must reach the ports of the listeners")
+		assert.Equal(t, []int{8080, 8443, 9443, 9053},
+			lo.Map(proxyRule.Ports, func(p netv1.NetworkPolicyPort, _ int) int { return p.Port.IntValue() }))
+	})
+
+	t.Run("network policy without the controller's namespace", func(t *testing.T) {
+		networkPolicy := &netv1.NetworkPolicy{}
+		mutateManagedGatewayNetworkPolicy(networkPolicy, gateway, listens, "")
+
+		adminRule := networkPolicy.Spec.Ingress[0]
+		require.Len(t, adminRule.From, 1)
+		assert.Empty(t, adminRule.From[0].NamespaceSelector.MatchLabels)
+	})
+}
+
+func TestManagedGatewayKongListens(t *testing.T) {
+	listens := []managedGatewayListen{
+		{protocol: gatewayv1beta1.HTTPProtocolType, port: 80, containerPort: 8080},
+		{protocol: gatewayv1beta1.UDPProtocolType, port: 53, containerPort: 8053},
+	}
+	assert.Equal(t, []Listener{
+		{Name: "http-80", Protocol: gatewayv1beta1.HTTPProtocolType, Port: 80},
+		{Name: "udp-53", Protocol: gatewayv1beta1.UDPProtocolType, Port: 53},
+	}, managedGatewayKongListens(listens))
+}
@@ -0,0 +1,206 @@
+package gateway
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	gatewayv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+)
+
+// -----------------------------------------------------------------------------
+// Gateway Controller - Managed Gateways - Admin API TLS
+// -----------------------------------------------------------------------------
+
+// The keys of the admin API TLS Secret of a managed Gateway. The Kong instances of the Gateway serve their
+// admin API with the server certificate and only accept clients presenting a certificate issued by the CA,
+// which the controller's client certificate is. The client certificate isn't mounted in the Kong pods.
+const (
+	managedGatewayAdminTLSCACertKey     = "ca.crt"
+	managedGatewayAdminTLSCertKey       = corev1.TLSCertKey
+	managedGatewayAdminTLSKeyKey        = corev1.TLSPrivateKeyKey
+	managedGatewayAdminTLSClientCertKey = "client.crt"
+	managedGatewayAdminTLSClientKeyKey  = "client.key"
+)
+
+const (
+	// managedGatewayAdminTLSValidity is the validity of the certificates of the admin API TLS Secrets.
+	managedGatewayAdminTLSValidity = 10 * 365 * 24 * time.Hour
+
+	// managedGatewayAdminTLSRenewBefore is how long before their expiry the certificates are issued again.
+	managedGatewayAdminTLSRenewBefore = 30 * 24 * time.Hour
+)
+
+// mutateManagedGatewayAdminTLSSecret sets the desired state of the admin API TLS Secret of a managed Gateway.
+// A new CA and new certificates are issued unless the Secret already holds valid ones for the admin host.
+func mutateManagedGatewayAdminTLSSecret(secret *corev1.Secret, gateway *gatewayv1beta1.Gateway, adminHost string) error {
+	secret.Labels = managedGatewayLabels(gateway)
+	secret.Type = corev1.SecretTypeOpaque
+	if err := validateManagedGatewayAdminTLS(secret.Data, adminHost, time.Now()); err == nil {
+		return nil
+	}
+	data, err := issueManagedGatewayAdminTLS(adminHost, time.Now())
+	if err != nil {
+		return fmt.Errorf("failed to issue the admin API certificates of the managed gateway: %w", err)
+	}
+	secret.Data = data
+	return nil
+}
+
+// issueManagedGatewayAdminTLS issues a new CA, along with a server certificate for the admin host and a client
+// certificate signed by it. The CA key is discarded, the certificates are issued again along with a new CA.
+func issueManagedGatewayAdminTLS(adminHost string, now time.Time) (map[string][]byte, error) {
+	caKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	caTemplate, err := managedGatewayAdminTLSTemplate("Kong managed gateway admin API CA", now)
+	if err != nil {
+		return nil, err
+	}
+	caTemplate.IsCA = true
+	caTemplate.BasicConstraintsValid = true
+	caTemplate.KeyUsage = x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature
+	caDER, err := x509.CreateCertificate(rand.Reader, caTemplate, caTemplate, caKey.Public(), caKey)
+	if err != nil {
+		return nil, err
+	}
+	ca, err := x509.ParseCertificate(caDER)
+	if err != nil {
+		return nil, err
+	}
+
+	serverTemplate, err := managedGatewayAdminTLSTemplate(adminHost, now)
+	if err != nil {
+		return nil, err
+	}
+	serverTemplate.DNSNames = []string{adminHost}
+	serverTemplate.ExtKeyUsage = []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth}
+	serverCert, serverKey, err := issueManagedGatewayAdminTLSCert(serverTemplate, ca, caKey)
+	if err != nil {
+		return nil, err
+	}
+
+	clientTemplate, err := managedGatewayAdminTLSTemplate("kong-ingress-controller", now)
+	if err != nil {
+		return nil, err
+	}
+	clientTemplate.ExtKeyUsage = []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth}
+	clientCert, clientKey, err := issueManagedGatewayAdminTLSCert(clientTemplate, ca, caKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string][]byte{
+		managedGatewayAdminTLSCACertKey:     pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: caDER}),
+		managedGatewayAdminTLSCertKey:       serverCert,
+		managedGatewayAdminTLSKeyKey:        serverKey,
+		managedGatewayAdminTLSClientCertKey: clientCert,
+		managedGatewayAdminTLSClientKeyKey:  clientKey,
+	}, nil
+}
+
+// managedGatewayAdminTLSTemplate returns the template of a certificate of the admin API TLS Secrets.
+func managedGatewayAdminTLSTemplate(commonName string, now time.Time) (*x509.Certificate, error) {
+	serialNumber, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return nil, err
+	}
+	return &x509.Certificate{
+		SerialNumber: serialNumber,
+		Subject:      pkix.Name{CommonName: commonName},
+		NotBefore:    now.Add(-time.Hour),
+		NotAfter:     now.Add(managedGatewayAdminTLSValidity),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+	}, nil
+}
+
+// issueManagedGatewayAdminTLSCert issues a certificate from the template signed by the CA, and returns it
+// along with its key, PEM encoded.
+func issueManagedGatewayAdminTLSCert(template, ca *x509.Certificate, caKey *ecdsa.PrivateKey) ([]byte, []byte, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, nil, err
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, ca, key.Public(), caKey)
+	if err != nil {
+		return nil, nil, err
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		return nil, nil, err
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), nil
+}
+
+// validateManagedGatewayAdminTLS verifies that the data of an admin API TLS Secret holds a CA, and a server
+// certificate for the admin host and a client certificate issued by it, none of which expires soon.
+func validateManagedGatewayAdminTLS(data map[string][]byte, adminHost string, now time.Time) error {
+	caBlock, _ := pem.Decode(data[managedGatewayAdminTLSCACertKey])
+	if caBlock == nil {
+		return errors.New("no CA certificate")
+	}
+	ca, err := x509.ParseCertificate(caBlock.Bytes)
+	if err != nil {
+		return fmt.Errorf("invalid CA certificate: %w", err)
+	}
+	roots := x509.NewCertPool()
+	roots.AddCert(ca)
+
+	renewAt := now.Add(managedGatewayAdminTLSRenewBefore)
+	if renewAt.After(ca.NotAfter) {
+		return errors.New("the CA certificate expires soon")
+	}
+	for _, pair := range []struct {
+		certKey, keyKey string
+		opts            x509.VerifyOptions
+	}{
+		{
+			certKey: managedGatewayAdminTLSCertKey,
+			keyKey:  managedGatewayAdminTLSKeyKey,
+			opts:    x509.VerifyOptions{DNSName: adminHost, KeyUsages: []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth}},
+		},
+		{
+			certKey: managedGatewayAdminTLSClientCertKey,
+			keyKey:  managedGatewayAdminTLSClientKeyKey,
+			opts:    x509.VerifyOptions{KeyUsages: []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth}},
+		},
+	} {
+		keyPair, err := tls.X509KeyPair(data[pair.certKey], data[pair.keyKey])
+		if err != nil {
+			return fmt.Errorf("invalid %s: %w", pair.certKey, err)
+		}
+		cert, err := x509.ParseCertificate(keyPair.Certificate[0])
+		if err != nil {
+			return fmt.Errorf("invalid %s: %w", pair.certKey, err)
+		}
+		if renewAt.After(cert.NotAfter) {
+			return fmt.Errorf("%s expires soon", pair.certKey)
+		}
+		pair.opts.Roots = roots
+		pair.opts.CurrentTime = now
+		if _, err := cert.Verify(pair.opts); err != nil {
+			return fmt.Errorf("invalid %s: %w", pair.certKey, err)
+		}
+	}
+	return nil
+}
+
+// managedGatewayAdminTLSFingerprint returns the fingerprint of the CA of an admin API TLS Secret, which changes
+// when the certificates are issued again.
+func managedGatewayAdminTLSFingerprint(secret *corev1.Secret) string {
+	sum := sha256.Sum256(secret.Data[managedGatewayAdminTLSCACertKey])
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,101 @@
+package gateway
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	gatewayv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+)
+
+func TestMutateManagedGatewayAdminTLSSecret(t *testing.T) {
+	gateway := &gatewayv1beta1.Gateway{
+		ObjectMeta: metav1.ObjectMeta{Name: "gw", Namespace: "team-a", UID: types.UID("gateway-uid")},
+	}
+	const adminHost = "gw-kong-admin.team-a.svc"
+
+	secret := &corev1.Secret{}
+	require.NoError(t, mutateManagedGatewayAdminTLSSecret(secret, gateway, adminHost))
+	require.NoError(t, validateManagedGatewayAdminTLS(secret.Data, adminHost, time.Now()))
+	assert.Equal(t, map[string]string{managedGatewayLabelKey: "gateway-uid"}, secret.Labels)
+	fingerprint := managedGatewayAdminTLSFingerprint(secret)
+
+	t.Run("valid certificates are kept", func(t *testing.T) {
+		require.NoError(t, mutateManagedGatewayAdminTLSSecret(secret, gateway, adminHost))
+		assert.Equal(t, fingerprint, managedGatewayAdminTLSFingerprint(secret))
+	})
+
+	t.Run("certificates are issued again for another host", func(t *testing.T) {
+		moved := secret.DeepCopy()
+		require.NoError(t, mutateManagedGatewayAdminTLSSecret(moved, gateway, "gw-kong-admin.team-b.svc"))
+		assert.NotEqual(t, fingerprint, managedGatewayAdminTLSFingerprint(moved))
+		require.NoError(t, validateManagedGatewayAdminTLS(moved.Data, "gw-kong-admin.team-b.svc", time.Now()))
+	})
+
+	t.Run("certificates are issued again before they expire", func(t *testing.T) {
+		assert.Error(t, validateManagedGatewayAdminTLS(secret.Data, adminHost, time.Now().Add(managedGatewayAdminTLSValidity)))
+	})
+
+	t.Run("certificates are issued again when they don't match", func(t *testing.T) {
+		other, err := issueManagedGatewayAdminTLS(adminHost, time.Now())
+		require.NoError(t, err)
+		mismatched := secret.DeepCopy()
+		mismatched.Data[managedGatewayAdminTLSCACertKey] = other[managedGatewayAdminTLSCACertKey]
+		assert.Error(t, validateManagedGatewayAdminTLS(mismatched.Data, adminHost, time.Now()))
+		require.NoError(t, mutateManagedGatewayAdminTLSSecret(mismatched, gateway, adminHost))
+		require.NoError(t, validateManagedGatewayAdminTLS(mismatched.Data, adminHost, time.Now()))
+	})
+}
+
+func TestManagedGatewayAdminAPIMutualTLS(t *testing.T) {
+	// the test server listens on the loopback interface.
+	const adminHost = "localhost"
+	data, err := issueManagedGatewayAdminTLS(adminHost, time.Now())
+	require.NoError(t, err)
+	secret := &corev1.Secret{Data: data}
+
+	// the admin API of the Kong instances verifies the client certificates against the CA, as Kong does.
+	serverCert, err := tls.X509KeyPair(data[managedGatewayAdminTLSCertKey], data[managedGatewayAdminTLSKeyKey])
+	require.NoError(t, err)
+	clientCAs := x509.NewCertPool()
+	require.True(t, clientCAs.AppendCertsFromPEM(data[managedGatewayAdminTLSCACertKey]))
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"version": "3.1.0", "configuration": {"database": "off"}}`))
+	}))
+	server.TLS = &tls.Config{
+		Certificates: []tls.Certificate{serverCert},
+		ClientCAs:    clientCAs,
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+		MinVersion:   tls.VersionTLS12,
+	}
+	server.StartTLS()
+	defer server.Close()
+	_, port, err := net.SplitHostPort(server.Listener.Addr().String())
+	require.NoError(t, err)
+	adminURL := "https://" + net.JoinHostPort(adminHost, port)
+
+	cl, err := newManagedGatewayAdminAPIClient(context.Background(), adminURL, secret)
+	require.NoError(t, err)
+	_, err = cl.Root(context.Background())
+	require.NoError(t, err, "the controller must trust the admin API and be trusted by it")
+
+	t.Run("clients without the client certificate are rejected", func(t *testing.T) {
+		withoutClientCert := secret.DeepCopy()
+		delete(withoutClientCert.Data, managedGatewayAdminTLSClientCertKey)
+		delete(withoutClientCert.Data, managedGatewayAdminTLSClientKeyKey)
+		cl, err := newManagedGatewayAdminAPIClient(context.Background(), adminURL, withoutClientCert)
+		require.NoError(t, err)
+		_, err = cl.Root(context.Background())
+		require.Error(t, err)
+	})
+}
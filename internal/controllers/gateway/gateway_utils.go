@@ -2,6 +2,8 @@ package gateway
 
 import (
 	"context"
+	"crypto/x509"
+	"errors"
 	"fmt"
 	"reflect"
 	"sort"
@@ -11,6 +13,7 @@ import (
 	"github.com/samber/lo"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -22,6 +25,7 @@ import (
 	"github.com/kong/kubernetes-ingress-controller/v2/internal/annotations"
 	"github.com/kong/kubernetes-ingress-controller/v2/internal/util"
 	"github.com/kong/kubernetes-ingress-controller/v2/internal/util/builder"
+	kongv1alpha1 "github.com/kong/kubernetes-ingress-controller/v2/pkg/apis/configuration/v1alpha1"
 )
 
 // -----------------------------------------------------------------------------
@@ -85,6 +89,15 @@ func isGatewayClassControlledAndUnmanaged(gatewayClass *GatewayClass) bool {
 	return gatewayClass.Spec.ControllerName == ControllerName && isObjectUnmanaged(gatewayClass.Annotations)
 }
 
+// isGatewayClassControlledAndManaged returns boolean if the GatewayClass is controlled by this controller,
+// isn't configured for unmanaged mode and is the GatewayClass which Gateways are provisioned their own Kong.
+func isGatewayClassControlledAndManaged(gatewayClass *GatewayClass, managedGatewayClass string) bool {
+	return managedGatewayClass != "" &&
+		gatewayClass.Name == managedGatewayClass &&
+		gatewayClass.Spec.ControllerName == ControllerName &&
+		!isObjectUnmanaged(gatewayClass.Annotations)
+}
+
 // getRefFromPublishService splits a publish service string in the format namespace/name into a types.NamespacedName
 // and verifies the contents producing an error if they don't match namespace/name format.
 func getRefFromPublishService(publishService string) (types.NamespacedName, error) {
@@ -98,6 +111,49 @@ func getRefFromPublishService(publishService string) (types.NamespacedName, erro
 	}, nil
 }
 
+// errInvalidGatewayClassParameters is returned when the parametersRef of a GatewayClass can't be resolved
+// to valid GatewayClassParameters.
+var errInvalidGatewayClassParameters = errors.New("invalid gatewayclass parameters")
+
+// getGatewayClassParameters retrieves the GatewayClassParameters referenced by the parametersRef of the
+// provided GatewayClass. Empty parameters are returned when the GatewayClass doesn't reference any. Errors
+// which are caused by the parametersRef rather than by the API server wrap errInvalidGatewayClassParameters.
+func getGatewayClassParameters(
+	ctx context.Context,
+	cl client.Client,
+	gatewayClass *gatewayv1beta1.GatewayClass,
+) (*kongv1alpha1.GatewayClassParameters, error) {
+	ref := gatewayClass.Spec.ParametersRef
+	if ref == nil {
+		return &kongv1alpha1.GatewayClassParameters{}, nil
+	}
+	if string(ref.Group) != kongv1alpha1.GroupVersion.Group || string(ref.Kind) != kongv1alpha1.GatewayClassParametersKind {
+		return nil, fmt.Errorf("%w: should reference parameters with group:%s and kind:%s",
+			errInvalidGatewayClassParameters, kongv1alpha1.GroupVersion.Group, kongv1alpha1.GatewayClassParametersKind)
+	}
+	if ref.Namespace != nil {
+		return nil, fmt.Errorf("%w: should reference cluster-scoped parameters", errInvalidGatewayClassParameters)
+	}
+
+	params := &kongv1alpha1.GatewayClassParameters{}
+	if err := cl.Get(ctx, client.ObjectKey{Name: ref.Name}, params); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, fmt.Errorf("%w: GatewayClassParameters %s not found", errInvalidGatewayClassParameters, ref.Name)
+		}
+		if meta.IsNoMatchError(err) {
+			return nil, fmt.Errorf("%w: the GatewayClassParameters CRD isn't installed", errInvalidGatewayClassParameters)
+		}
+		return nil, err
+	}
+	if params.Spec.PublishService != "" {
+		if _, err := getRefFromPublishService(params.Spec.PublishService); err != nil {
+			return nil, fmt.Errorf("%w: publishService %s isn't in the namespace/name format",
+				errInvalidGatewayClassParameters, params.Spec.PublishService)
+		}
+	}
+	return params, nil
+}
+
 // pruneGatewayStatusConds cleans out old status conditions if the Gateway currently has more
 // status conditions set than the 8 maximum allowed by the Kubernetes API.
 func pruneGatewayStatusConds(gateway *Gateway) *Gateway {
@@ -155,17 +211,6 @@ func listSecretNamesReferredByGateway(gateway *gatewayv1beta1.Gateway) map[types
 	return nsNames
 }
 
-// extractListenerSpecFromGateway returns the spec of the listener with the given name.
-// returns nil if the listener with given name is not found.
-func extractListenerSpecFromGateway(gateway *gatewayv1beta1.Gateway, listenerName gatewayv1beta1.SectionName) *gatewayv1beta1.Listener {
-	for i, l := range gateway.Spec.Listeners {
-		if l.Name == listenerName {
-			return &gateway.Spec.Listeners[i]
-		}
-	}
-	return nil
-}
-
 type (
 	protocolPortMap     map[ProtocolType]map[PortNumber]bool
 	portProtocolMap     map[PortNumber]ProtocolType
@@ -249,6 +294,7 @@ func getListenerStatus(
 	ctx context.Context,
 	gateway *Gateway,
 	kongListens []Listener,
+	claimedListeners []claimedListener,
 	referenceGrants []gatewayv1alpha2.ReferenceGrant,
 	client client.Client,
 ) ([]ListenerStatus, error) {
@@ -416,7 +462,17 @@ func getListenerStatus(
 		// all the secrets it references
 		if listener.TLS != nil {
 			resolvedRefReason = string(gatewayv1alpha2.ListenerReasonResolvedRefs)
+			// listeners may reference a second certificate, served by Kong as the alternative of the first
+			// one, as long as their key types are different (e.g. RSA and ECDSA).
+			if len(listener.TLS.CertificateRefs) > 2 {
+				resolvedRefReason = string(gatewayv1alpha2.ListenerReasonInvalidCertificateRef)
+			}
+			keyAlgorithms := make(map[x509.PublicKeyAlgorithm]bool, len(listener.TLS.CertificateRefs))
 			for _, certRef := range listener.TLS.CertificateRefs {
+				if resolvedRefReason != string(gatewayv1alpha2.ListenerReasonResolvedRefs) {
+					break
+				}
+
 				// if the certificate is in the same namespace of the gateway, no ReferenceGrant is needed
 				if certRef.Namespace != nil && *certRef.Namespace != (Namespace)(gateway.Namespace) {
 					// get the result of the certificate reference. If the returned reason is not successful, the loop
@@ -443,6 +499,15 @@ func getListenerStatus(
 						return nil, err
 					}
 					resolvedRefReason = string(gatewayv1alpha2.ListenerReasonInvalidCertificateRef)
+					break
+				}
+				if len(listener.TLS.CertificateRefs) > 1 {
+					algorithm, err := util.GetCertificateKeyAlgorithm(secret.Data[corev1.TLSCertKey])
+					if err != nil || keyAlgorithms[algorithm] {
+						resolvedRefReason = string(gatewayv1alpha2.ListenerReasonInvalidCertificateRef)
+						break
+					}
+					keyAlgorithms[algorithm] = true
 				}
 			}
 		}
@@ -519,6 +584,15 @@ func getListenerStatus(
 			statuses[listener.Name] = status
 		}
 	}
+
+	// listeners conflicting with the ones of older Gateways can't become ready, regardless of the
+	// conditions computed above: the Kong listens are shared by all Gateways and the oldest one wins
+	for name, condition := range getCrossGatewayListenerConditions(gateway, claimedListeners) {
+		status := statuses[name]
+		status.Conditions = setCrossGatewayListenerCondition(status.Conditions, condition)
+		statuses[name] = status
+	}
+
 	statusArray := []ListenerStatus{}
 	for _, status := range statuses {
 		statusArray = append(statusArray, status)
@@ -527,6 +601,119 @@ func getListenerStatus(
 	return statusArray, nil
 }
 
+// claimedListener is a listener of another Gateway sharing the Kong listens with the reconciled Gateway.
+type claimedListener struct {
+	gateway  types.NamespacedName
+	listener Listener
+}
+
+// isGatewayOlder returns true if the Gateway a was created before the Gateway b. Gateways created at
+// the same time are ordered by namespace and name so that the outcome is deterministic.
+func isGatewayOlder(a, b *Gateway) bool {
+	if !a.CreationTimestamp.Equal(&b.CreationTimestamp) {
+		return a.CreationTimestamp.Before(&b.CreationTimestamp)
+	}
+	if a.Namespace != b.Namespace {
+		return a.Namespace < b.Namespace
+	}
+	return a.Name < b.Name
+}
+
+// claimListeners returns the listeners claimed by the provided Gateways sharing the Kong listens. The
+// Gateways claim their listeners from the oldest to the newest, so the listeners conflicting with the ones
+// claimed by older Gateways are not claimed.
+func claimListeners(gateways []*Gateway) []claimedListener {
+	sorted := make([]*Gateway, len(gateways))
+	copy(sorted, gateways)
+	sort.Slice(sorted, func(i, j int) bool {
+		return isGatewayOlder(sorted[i], sorted[j])
+	})
+
+	var claimed []claimedListener
+	for _, gateway := range sorted {
+		conflicts := getCrossGatewayListenerConditions(gateway, claimed)
+		for _, listener := range gateway.Spec.Listeners {
+			if _, conflicted := conflicts[listener.Name]; conflicted {
+				continue
+			}
+			claimed = append(claimed, claimedListener{
+				gateway:  client.ObjectKeyFromObject(gateway),
+				listener: listener,
+			})
+		}
+	}
+	return claimed
+}
+
+// getCrossGatewayListenerConditions returns the conditions of the listeners of the provided Gateway
+// which can't be served because their port is already claimed by an incompatible listener of another
+// Gateway. Listeners which don't conflict with any of the claimed listeners are not included.
+func getCrossGatewayListenerConditions(gateway *Gateway, claimedListeners []claimedListener) map[SectionName]metav1.Condition {
+	conditions := make(map[SectionName]metav1.Condition)
+	for _, listener := range gateway.Spec.Listeners {
+		for _, claimed := range claimedListeners {
+			if claimed.listener.Port != listener.Port {
+				continue
+			}
+			conditionType := gatewayv1beta1.ListenerConditionConflicted
+			var reason gatewayv1beta1.ListenerConditionReason
+			switch {
+			case claimed.listener.Protocol == listener.Protocol && !canSharePort(listener.Protocol, claimed.listener.Protocol):
+				conditionType = gatewayv1beta1.ListenerConditionDetached
+				reason = gatewayv1beta1.ListenerReasonPortUnavailable
+			case !canSharePort(listener.Protocol, claimed.listener.Protocol):
+				reason = gatewayv1beta1.ListenerReasonProtocolConflict
+			case lo.FromPtr(listener.Hostname) == lo.FromPtr(claimed.listener.Hostname):
+				reason = gatewayv1beta1.ListenerReasonHostnameConflict
+			default:
+				continue
+			}
+			conditions[listener.Name] = metav1.Condition{
+				Type:               string(conditionType),
+				Status:             metav1.ConditionTrue,
+				ObservedGeneration: gateway.Generation,
+				LastTransitionTime: metav1.Now(),
+				Reason:             string(reason),
+				Message: fmt.Sprintf("port %d is already claimed by listener %s of Gateway %s",
+					listener.Port, claimed.listener.Name, claimed.gateway),
+			}
+			break
+		}
+	}
+	return conditions
+}
+
+// setCrossGatewayListenerCondition replaces the conditions of the same type as the provided cross-Gateway
+// condition and marks the listener as not ready.
+func setCrossGatewayListenerCondition(conditions []metav1.Condition, condition metav1.Condition) []metav1.Condition {
+	newConditions := []metav1.Condition{}
+	for _, cond := range conditions {
+		if cond.Type == condition.Type || cond.Type == string(gatewayv1beta1.ListenerConditionReady) {
+			continue
+		}
+		newConditions = append(newConditions, cond)
+	}
+	newConditions = append(newConditions,
+		condition,
+		metav1.Condition{
+			Type:               string(gatewayv1beta1.ListenerConditionReady),
+			Status:             metav1.ConditionFalse,
+			ObservedGeneration: condition.ObservedGeneration,
+			LastTransitionTime: metav1.Now(),
+			Reason:             string(gatewayv1beta1.ListenerReasonPending),
+			Message:            "the listener is not ready and cannot route requests",
+		},
+	)
+	// consistent sort statuses to allow equality comparisons
+	sort.Slice(newConditions, func(i, j int) bool {
+		a := newConditions[i]
+		b := newConditions[j]
+		return fmt.Sprintf("%s%s%s%s", a.Type, a.Status, a.Reason, a.Message) <
+			fmt.Sprintf("%s%s%s%s", b.Type, b.Status, b.Reason, b.Message)
+	})
+	return newConditions
+}
+
 // getReferenceGrantConditionReason gets a certRef belonging to a specific listener and a slice of referenceGrants.
 func getReferenceGrantConditionReason(
 	gatewayNamespace string,
@@ -2,16 +2,23 @@ package gateway
 
 import (
 	"context"
+	"crypto/x509"
 	"testing"
+	"time"
 
 	"github.com/samber/lo"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 	gatewayv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
 
 	"github.com/kong/kubernetes-ingress-controller/v2/internal/util/builder"
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/util/test/certificate"
+	kongv1alpha1 "github.com/kong/kubernetes-ingress-controller/v2/pkg/apis/configuration/v1alpha1"
+	"github.com/kong/kubernetes-ingress-controller/v2/pkg/clientset/scheme"
 )
 
 func TestGetListenerSupportedRouteKinds(t *testing.T) {
@@ -103,13 +110,214 @@ func TestGetListenerStatus_no_duplicated_Detached_condition(t *testing.T) {
 				},
 			},
 		},
-	}, nil, nil, client)
+	}, nil, nil, nil, client)
 	require.NoError(t, err)
 	require.Len(t, statuses, 1, "only one listener status expected as only one listener was defined")
 	listenerStatus := statuses[0]
 	assertOnlyOneConditionOfType(t, listenerStatus.Conditions, gatewayv1beta1.ListenerConditionDetached)
 }
 
+func TestGetListenerStatus_cross_Gateway_conflicts(t *testing.T) {
+	ctx := context.Background()
+	client := fake.NewClientBuilder().Build()
+
+	kongListens := []Listener{
+		{Port: 80, Protocol: HTTPProtocolType},
+		{Port: 443, Protocol: HTTPSProtocolType},
+		{Port: 9000, Protocol: TCPProtocolType},
+		{Port: 9001, Protocol: TCPProtocolType},
+	}
+	olderGateway := types.NamespacedName{Namespace: "default", Name: "older"}
+	claimedListeners := []claimedListener{
+		{gateway: olderGateway, listener: Listener{Name: "http", Port: 80, Protocol: HTTPProtocolType, Hostname: lo.ToPtr(Hostname("bar.com"))}},
+		{gateway: olderGateway, listener: Listener{Name: "https", Port: 443, Protocol: HTTPSProtocolType, Hostname: lo.ToPtr(Hostname("foo.com"))}},
+		{gateway: olderGateway, listener: Listener{Name: "tcp", Port: 9000, Protocol: TCPProtocolType}},
+		{gateway: olderGateway, listener: Listener{Name: "udp", Port: 9001, Protocol: UDPProtocolType}},
+	}
+	gateway := &Gateway{
+		Spec: gatewayv1beta1.GatewaySpec{
+			GatewayClassName: "kong",
+			Listeners: []gatewayv1beta1.Listener{
+				{Name: "http", Port: 80, Protocol: HTTPProtocolType, Hostname: lo.ToPtr(Hostname("foo.com"))},
+				{Name: "https", Port: 443, Protocol: HTTPSProtocolType, Hostname: lo.ToPtr(Hostname("foo.com"))},
+				{Name: "tcp", Port: 9000, Protocol: TCPProtocolType},
+				{Name: "tcp-on-udp", Port: 9001, Protocol: TCPProtocolType},
+			},
+		},
+	}
+
+	statuses, err := getListenerStatus(ctx, gateway, kongListens, claimedListeners, nil, client)
+	require.NoError(t, err)
+	require.Len(t, statuses, 4)
+
+	conditions := make(map[SectionName]map[string]metav1.Condition, len(statuses))
+	for _, status := range statuses {
+		conditions[status.Name] = make(map[string]metav1.Condition, len(status.Conditions))
+		for _, condition := range status.Conditions {
+			conditions[status.Name][condition.Type] = condition
+		}
+	}
+	ready := string(gatewayv1beta1.ListenerConditionReady)
+	conflicted := string(gatewayv1beta1.ListenerConditionConflicted)
+	detached := string(gatewayv1beta1.ListenerConditionDetached)
+
+	t.Log("verifying that listeners with distinct hostnames can share ports across Gateways")
+	assert.Equal(t, metav1.ConditionTrue, conditions["http"][ready].Status)
+	assert.Equal(t, metav1.ConditionFalse, conditions["http"][conflicted].Status)
+
+	t.Log("verifying that listeners with the hostname of an older Gateway's listener are conflicted")
+	assert.Equal(t, metav1.ConditionFalse, conditions["https"][ready].Status)
+	assert.Equal(t, metav1.ConditionTrue, conditions["https"][conflicted].Status)
+	assert.Equal(t, string(gatewayv1beta1.ListenerReasonHostnameConflict), conditions["https"][conflicted].Reason)
+	assert.Contains(t, conditions["https"][conflicted].Message, "default/older")
+
+	t.Log("verifying that listeners requesting a port exclusively used by an older Gateway are detached")
+	assert.Equal(t, metav1.ConditionFalse, conditions["tcp"][ready].Status)
+	assert.Equal(t, metav1.ConditionTrue, conditions["tcp"][detached].Status)
+	assert.Equal(t, string(gatewayv1beta1.ListenerReasonPortUnavailable), conditions["tcp"][detached].Reason)
+	assert.Equal(t, metav1.ConditionFalse, conditions["tcp"][conflicted].Status)
+
+	t.Log("verifying that listeners with a protocol conflicting with an older Gateway's listener are conflicted")
+	assert.Equal(t, metav1.ConditionFalse, conditions["tcp-on-udp"][ready].Status)
+	assert.Equal(t, metav1.ConditionTrue, conditions["tcp-on-udp"][conflicted].Status)
+	assert.Equal(t, string(gatewayv1beta1.ListenerReasonProtocolConflict), conditions["tcp-on-udp"][conflicted].Reason)
+
+	for _, status := range statuses {
+		assertOnlyOneConditionOfType(t, status.Conditions, gatewayv1beta1.ListenerConditionReady)
+	}
+}
+
+func TestClaimListeners(t *testing.T) {
+	now := time.Now()
+	newGateway := func(name string, created time.Time, listeners ...gatewayv1beta1.Listener) *Gateway {
+		return &Gateway{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:         "default",
+				Name:              name,
+				CreationTimestamp: metav1.NewTime(created),
+			},
+			Spec: gatewayv1beta1.GatewaySpec{
+				GatewayClassName: "kong",
+				Listeners:        listeners,
+			},
+		}
+	}
+	oldest := newGateway("oldest", now.Add(-2*time.Hour),
+		Listener{Name: "https", Port: 443, Protocol: HTTPSProtocolType, Hostname: lo.ToPtr(Hostname("foo.com"))},
+	)
+	older := newGateway("older", now.Add(-time.Hour),
+		Listener{Name: "http", Port: 443, Protocol: HTTPProtocolType},
+		Listener{Name: "tcp", Port: 9000, Protocol: TCPProtocolType},
+	)
+	newest := newGateway("newest", now,
+		Listener{Name: "tls", Port: 443, Protocol: TLSProtocolType, Hostname: lo.ToPtr(Hostname("bar.com"))},
+		Listener{Name: "tcp", Port: 9000, Protocol: TCPProtocolType},
+	)
+
+	claimed := claimListeners([]*Gateway{older, oldest})
+	assert.Equal(t, []claimedListener{
+		{gateway: types.NamespacedName{Namespace: "default", Name: "oldest"}, listener: oldest.Spec.Listeners[0]},
+		{gateway: types.NamespacedName{Namespace: "default", Name: "older"}, listener: older.Spec.Listeners[1]},
+	}, claimed, "the listeners conflicting with the ones of older Gateways must not be claimed")
+
+	t.Log("verifying that the listeners of a newer Gateway only conflict with the listeners claimed by older Gateways")
+	conditions := getCrossGatewayListenerConditions(newest, claimed)
+	require.Len(t, conditions, 1)
+	assert.NotContains(t, conditions, SectionName("tls"), "the listener only conflicts with a listener which isn't claimed")
+	assert.Equal(t, string(gatewayv1beta1.ListenerReasonPortUnavailable), conditions["tcp"].Reason)
+	assert.Contains(t, conditions["tcp"].Message, "default/older")
+}
+
+func TestGetListenerStatus_certificateRefs(t *testing.T) {
+	ctx := context.Background()
+	newSecret := func(name string, algorithm x509.PublicKeyAlgorithm) *corev1.Secret {
+		cert, key := certificate.SelfSigned(t, "example.com", algorithm)
+		return &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: name},
+			Data: map[string][]byte{
+				corev1.TLSCertKey:       cert,
+				corev1.TLSPrivateKeyKey: key,
+			},
+		}
+	}
+	client := fake.NewClientBuilder().WithObjects(
+		newSecret("rsa", x509.RSA),
+		newSecret("another-rsa", x509.RSA),
+		newSecret("ecdsa", x509.ECDSA),
+	).Build()
+	newListener := func(name string, secrets ...string) gatewayv1beta1.Listener {
+		listener := gatewayv1beta1.Listener{
+			Name:     SectionName(name),
+			Port:     443,
+			Protocol: HTTPSProtocolType,
+			Hostname: lo.ToPtr(Hostname(name + ".example.com")),
+			TLS:      &gatewayv1beta1.GatewayTLSConfig{},
+		}
+		for _, secret := range secrets {
+			listener.TLS.CertificateRefs = append(listener.TLS.CertificateRefs, gatewayv1beta1.SecretObjectReference{
+				Name: gatewayv1beta1.ObjectName(secret),
+			})
+		}
+		return listener
+	}
+
+	gateway := &Gateway{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "gateway"},
+		Spec: gatewayv1beta1.GatewaySpec{
+			GatewayClassName: "kong",
+			Listeners: []gatewayv1beta1.Listener{
+				newListener("single", "rsa"),
+				newListener("dual", "rsa", "ecdsa"),
+				newListener("same-key-type", "rsa", "another-rsa"),
+				newListener("too-many", "rsa", "ecdsa", "another-rsa"),
+				newListener("missing", "ecdsa", "missing"),
+			},
+		},
+	}
+	kongListens := []Listener{{Port: 443, Protocol: HTTPSProtocolType}}
+	statuses, err := getListenerStatus(ctx, gateway, kongListens, nil, nil, client)
+	require.NoError(t, err)
+	require.Len(t, statuses, 5)
+
+	expectedReasons := map[SectionName]gatewayv1beta1.ListenerConditionReason{
+		"single":        gatewayv1beta1.ListenerReasonResolvedRefs,
+		"dual":          gatewayv1beta1.ListenerReasonResolvedRefs,
+		"same-key-type": gatewayv1beta1.ListenerReasonInvalidCertificateRef,
+		"too-many":      gatewayv1beta1.ListenerReasonInvalidCertificateRef,
+		"missing":       gatewayv1beta1.ListenerReasonInvalidCertificateRef,
+	}
+	for _, status := range statuses {
+		condition, ok := lo.Find(status.Conditions, func(c metav1.Condition) bool {
+			return c.Type == string(gatewayv1beta1.ListenerConditionResolvedRefs)
+		})
+		require.True(t, ok, "listener %s must have a ResolvedRefs condition", status.Name)
+		assert.Equal(t, string(expectedReasons[status.Name]), condition.Reason, "listener %s", status.Name)
+		expectedStatus := metav1.ConditionTrue
+		if expectedReasons[status.Name] != gatewayv1beta1.ListenerReasonResolvedRefs {
+			expectedStatus = metav1.ConditionFalse
+		}
+		assert.Equal(t, expectedStatus, condition.Status, "listener %s", status.Name)
+	}
+}
+
+func TestIsGatewayOlder(t *testing.T) {
+	now := metav1.Now()
+	newGateway := func(namespace, name string, created metav1.Time) *Gateway {
+		return &Gateway{ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name, CreationTimestamp: created}}
+	}
+
+	older := newGateway("default", "b", metav1.NewTime(now.Add(-time.Hour)))
+	newer := newGateway("default", "a", now)
+	assert.True(t, isGatewayOlder(older, newer))
+	assert.False(t, isGatewayOlder(newer, older))
+
+	a := newGateway("default", "a", now)
+	b := newGateway("default", "b", now)
+	assert.True(t, isGatewayOlder(a, b), "Gateways created at the same time must be ordered by name")
+	assert.False(t, isGatewayOlder(b, a), "Gateways created at the same time must be ordered by name")
+	assert.False(t, isGatewayOlder(a, a), "a Gateway is never older than itself")
+}
+
 func assertOnlyOneConditionOfType(t *testing.T, conditions []metav1.Condition, typ gatewayv1beta1.ListenerConditionType) {
 	conditionNum := 0
 	for _, condition := range conditions {
@@ -119,3 +327,90 @@ func assertOnlyOneConditionOfType(t *testing.T, conditions []metav1.Condition, t
 	}
 	assert.Equal(t, 1, conditionNum)
 }
+
+func TestGetGatewayClassParameters(t *testing.T) {
+	ctx := context.Background()
+	client := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(
+		&kongv1alpha1.GatewayClassParameters{
+			ObjectMeta: metav1.ObjectMeta{Name: "params"},
+			Spec:       kongv1alpha1.GatewayClassParametersSpec{PublishService: "kong/proxy"},
+		},
+		&kongv1alpha1.GatewayClassParameters{
+			ObjectMeta: metav1.ObjectMeta{Name: "invalid-publish-service"},
+			Spec:       kongv1alpha1.GatewayClassParametersSpec{PublishService: "proxy"},
+		},
+	).Build()
+	paramsRef := func(name string) *gatewayv1beta1.ParametersReference {
+		return &gatewayv1beta1.ParametersReference{
+			Group: gatewayv1beta1.Group(kongv1alpha1.GroupVersion.Group),
+			Kind:  kongv1alpha1.GatewayClassParametersKind,
+			Name:  name,
+		}
+	}
+
+	testCases := []struct {
+		name                   string
+		parametersRef          *gatewayv1beta1.ParametersReference
+		expectedPublishService string
+		expectedErr            string
+	}{
+		{
+			name:          "no parametersRef",
+			parametersRef: nil,
+		},
+		{
+			name:                   "valid parametersRef",
+			parametersRef:          paramsRef("params"),
+			expectedPublishService: "kong/proxy",
+		},
+		{
+			name: "unsupported kind",
+			parametersRef: &gatewayv1beta1.ParametersReference{
+				Group: gatewayv1beta1.Group(kongv1alpha1.GroupVersion.Group),
+				Kind:  "IngressClassParameters",
+				Name:  "params",
+			},
+			expectedErr: "should reference parameters with group:configuration.konghq.com and kind:GatewayClassParameters",
+		},
+		{
+			name: "namespaced parametersRef",
+			parametersRef: func() *gatewayv1beta1.ParametersReference {
+				ref := paramsRef("params")
+				ref.Namespace = lo.ToPtr(gatewayv1beta1.Namespace("default"))
+				return ref
+			}(),
+			expectedErr: "should reference cluster-scoped parameters",
+		},
+		{
+			name:          "missing parameters",
+			parametersRef: paramsRef("missing"),
+			expectedErr:   "GatewayClassParameters missing not found",
+		},
+		{
+			name:          "invalid publishService",
+			parametersRef: paramsRef("invalid-publish-service"),
+			expectedErr:   "publishService proxy isn't in the namespace/name format",
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			gatewayClass := &gatewayv1beta1.GatewayClass{
+				ObjectMeta: metav1.ObjectMeta{Name: "kong"},
+				Spec: gatewayv1beta1.GatewayClassSpec{
+					ControllerName: ControllerName,
+					ParametersRef:  tc.parametersRef,
+				},
+			}
+			params, err := getGatewayClassParameters(ctx, client, gatewayClass)
+			if tc.expectedErr != "" {
+				require.ErrorIs(t, err, errInvalidGatewayClassParameters)
+				assert.Contains(t, err.Error(), tc.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedPublishService, params.Spec.PublishService)
+		})
+	}
+}
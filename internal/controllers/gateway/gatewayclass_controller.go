@@ -2,6 +2,7 @@ package gateway
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"reflect"
 	"time"
@@ -10,6 +11,7 @@ import (
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
@@ -19,7 +21,9 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/source"
 	gatewayv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
 
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/dataplane"
 	"github.com/kong/kubernetes-ingress-controller/v2/internal/util"
+	kongv1alpha1 "github.com/kong/kubernetes-ingress-controller/v2/pkg/apis/configuration/v1alpha1"
 )
 
 // -----------------------------------------------------------------------------
@@ -40,7 +44,15 @@ type GatewayClassReconciler struct { //nolint:revive
 	client.Client
 	Log              logr.Logger
 	Scheme           *runtime.Scheme
+	DataplaneClient  *dataplane.KongClient
 	CacheSyncTimeout time.Duration
+
+	// ManagedGatewayClass is the name of the GatewayClass which Gateways are provisioned their own Kong
+	// deployment. It's accepted along with the unmanaged GatewayClasses.
+	ManagedGatewayClass string
+	// If EnableGatewayClassParameters is true, controller will watch GatewayClassParameters
+	// to re-evaluate the GatewayClasses referencing them.
+	EnableGatewayClassParameters bool
 }
 
 // SetupWithManager sets up the controller with the Manager.
@@ -55,11 +67,22 @@ func (r *GatewayClassReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	if err != nil {
 		return err
 	}
-	return c.Watch(
+	if err := c.Watch(
 		&source.Kind{Type: &gatewayv1beta1.GatewayClass{}},
 		&handler.EnqueueRequestForObject{},
 		predicate.NewPredicateFuncs(r.GatewayClassIsUnmanaged),
-	)
+	); err != nil {
+		return err
+	}
+
+	// the acceptance of GatewayClasses depends on the GatewayClassParameters they reference.
+	if r.EnableGatewayClassParameters {
+		return c.Watch(
+			&source.Kind{Type: &kongv1alpha1.GatewayClassParameters{}},
+			handler.EnqueueRequestsFromMapFunc(r.listGatewayClassesForParameters),
+		)
+	}
+	return nil
 }
 
 // -----------------------------------------------------------------------------
@@ -67,7 +90,7 @@ func (r *GatewayClassReconciler) SetupWithManager(mgr ctrl.Manager) error {
 // -----------------------------------------------------------------------------
 
 // GatewayClassIsUnmanaged is a watch predicate which filters out reconciliation events for
-// gateway objects which aren't annotated as unmanaged.
+// gateway objects which aren't annotated as unmanaged, nor the managed GatewayClass.
 func (r *GatewayClassReconciler) GatewayClassIsUnmanaged(obj client.Object) bool {
 	gatewayClass, ok := obj.(*gatewayv1beta1.GatewayClass)
 	if !ok {
@@ -79,7 +102,29 @@ func (r *GatewayClassReconciler) GatewayClassIsUnmanaged(obj client.Object) bool
 		return false
 	}
 
-	return isGatewayClassControlledAndUnmanaged(gatewayClass)
+	return isGatewayClassControlledAndUnmanaged(gatewayClass) ||
+		isGatewayClassControlledAndManaged(gatewayClass, r.ManagedGatewayClass)
+}
+
+// listGatewayClassesForParameters is a watch predicate which finds all the GatewayClasses supported by this
+// controller which reference the provided GatewayClassParameters to enqueue them for reconciliation.
+func (r *GatewayClassReconciler) listGatewayClassesForParameters(params client.Object) []reconcile.Request {
+	gatewayClasses := &gatewayv1beta1.GatewayClassList{}
+	if err := r.Client.List(context.Background(), gatewayClasses); err != nil {
+		r.Log.Error(err, "failed to list gatewayclasses for gatewayclassparameters in watch", "gatewayclassparameters", params.GetName())
+		return nil
+	}
+	recs := []reconcile.Request{}
+	for i := range gatewayClasses.Items {
+		gatewayClass := &gatewayClasses.Items[i]
+		if !gatewayClassReferencesParameters(gatewayClass, params.GetName()) || !r.GatewayClassIsUnmanaged(gatewayClass) {
+			continue
+		}
+		recs = append(recs, reconcile.Request{
+			NamespacedName: types.NamespacedName{Name: gatewayClass.Name},
+		})
+	}
+	return recs
 }
 
 // -----------------------------------------------------------------------------
@@ -88,6 +133,7 @@ func (r *GatewayClassReconciler) GatewayClassIsUnmanaged(obj client.Object) bool
 
 //+kubebuilder:rbac:groups=gateway.networking.k8s.io,resources=gatewayclasses,verbs=get;list;watch
 //+kubebuilder:rbac:groups=gateway.networking.k8s.io,resources=gatewayclasses/status,verbs=get;update
+//+kubebuilder:rbac:groups=configuration.konghq.com,resources=gatewayclassparameterses,verbs=get;list;watch
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
 // move the current state of the cluster closer to the desired state.
@@ -97,32 +143,41 @@ func (r *GatewayClassReconciler) Reconcile(ctx context.Context, req ctrl.Request
 	gwc := new(gatewayv1beta1.GatewayClass)
 	if err := r.Client.Get(ctx, req.NamespacedName, gwc); err != nil {
 		if apierrors.IsNotFound(err) {
-			log.V(util.DebugLevel).Info("object enqueued no longer exists, skipping", "name", req.Name)
-			return ctrl.Result{}, nil
+			log.V(util.DebugLevel).Info("object enqueued no longer exists, deleting it in dataplane", "name", req.Name)
+			gwc.Name = req.Name
+			return ctrl.Result{}, r.DataplaneClient.DeleteObject(gwc)
 		}
 		return ctrl.Result{}, err
 	}
 	log.V(util.DebugLevel).Info("processing gatewayclass", "name", req.Name)
 
-	if isGatewayClassControlledAndUnmanaged(gwc) {
-		alreadyAccepted := util.CheckCondition(
-			gwc.Status.Conditions,
-			util.ConditionType(gatewayv1beta1.GatewayClassConditionStatusAccepted),
-			util.ConditionReason(gatewayv1beta1.GatewayClassReasonAccepted),
-			metav1.ConditionTrue,
-			gwc.Generation,
-		)
+	if isGatewayClassControlledAndUnmanaged(gwc) || isGatewayClassControlledAndManaged(gwc, r.ManagedGatewayClass) {
+		// the parser resolves the GatewayClassParameters of the routes through the GatewayClasses.
+		if err := r.DataplaneClient.UpdateObject(gwc); err != nil {
+			log.V(util.DebugLevel).Info("failed to update object in data-plane, requeueing", "name", req.Name)
+			return ctrl.Result{}, err
+		}
 
-		if !alreadyAccepted {
-			acceptedCondtion := metav1.Condition{
-				Type:               string(gatewayv1beta1.GatewayClassConditionStatusAccepted),
-				Status:             metav1.ConditionTrue,
-				ObservedGeneration: gwc.Generation,
-				LastTransitionTime: metav1.Now(),
-				Reason:             string(gatewayv1beta1.GatewayClassReasonAccepted),
-				Message:            "the gatewayclass has been accepted by the controller",
+		acceptedCondition := metav1.Condition{
+			Type:               string(gatewayv1beta1.GatewayClassConditionStatusAccepted),
+			Status:             metav1.ConditionTrue,
+			ObservedGeneration: gwc.Generation,
+			LastTransitionTime: metav1.Now(),
+			Reason:             string(gatewayv1beta1.GatewayClassReasonAccepted),
+			Message:            "the gatewayclass has been accepted by the controller",
+		}
+		if _, err := getGatewayClassParameters(ctx, r.Client, gwc); err != nil {
+			if !errors.Is(err, errInvalidGatewayClassParameters) {
+				return ctrl.Result{}, err
 			}
-			setGatewayClassCondition(gwc, acceptedCondtion)
+			log.Info("gatewayclass has invalid parameters", "name", req.Name, "reason", err.Error())
+			acceptedCondition.Status = metav1.ConditionFalse
+			acceptedCondition.Reason = string(gatewayv1beta1.GatewayClassReasonInvalidParameters)
+			acceptedCondition.Message = err.Error()
+		}
+
+		if !util.IsConditionSet(gwc.Status.Conditions, acceptedCondition, gwc.Generation) {
+			setGatewayClassCondition(gwc, acceptedCondition)
 			return ctrl.Result{}, r.Status().Update(ctx, pruneGatewayClassStatusConds(gwc))
 		}
 	}
@@ -144,6 +199,16 @@ func pruneGatewayClassStatusConds(gwc *gatewayv1beta1.GatewayClass) *gatewayv1be
 	return gwc
 }
 
+// gatewayClassReferencesParameters returns true if the parametersRef of the gatewayclass references the
+// GatewayClassParameters with the provided name.
+func gatewayClassReferencesParameters(gwc *gatewayv1beta1.GatewayClass, name string) bool {
+	ref := gwc.Spec.ParametersRef
+	return ref != nil &&
+		string(ref.Group) == kongv1alpha1.GroupVersion.Group &&
+		string(ref.Kind) == kongv1alpha1.GatewayClassParametersKind &&
+		ref.Name == name
+}
+
 // setGatewayClassCondition sets the condition with specified type in gatewayclass status
 // to expected condition in newCondition.
 // if the gatewayclass status does not contain a condition with that type, add one more condition.
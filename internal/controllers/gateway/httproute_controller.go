@@ -25,8 +25,11 @@ import (
 	gatewayv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
 
 	"github.com/kong/kubernetes-ingress-controller/v2/internal/dataplane"
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/tracing"
 	"github.com/kong/kubernetes-ingress-controller/v2/internal/util"
 	k8sobj "github.com/kong/kubernetes-ingress-controller/v2/internal/util/kubernetes/object"
+	gatewayvalidation "github.com/kong/kubernetes-ingress-controller/v2/internal/validation/gateway"
+	configurationv1 "github.com/kong/kubernetes-ingress-controller/v2/pkg/apis/configuration/v1"
 )
 
 // -----------------------------------------------------------------------------
@@ -242,6 +245,8 @@ func (r *HTTPRouteReconciler) listHTTPRoutesForGateway(obj client.Object) []reco
 // move the current state of the cluster closer to the desired state.
 func (r *HTTPRouteReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	log := r.Log.WithValues("GatewayV1Beta1HTTPRoute", req.NamespacedName)
+	ctx, span := tracing.StartReconcile(ctx, "HTTPRoute", req.NamespacedName)
+	defer span.End()
 
 	httproute := new(gatewayv1beta1.HTTPRoute)
 	if err := r.Get(ctx, req.NamespacedName, httproute); err != nil {
@@ -385,7 +390,14 @@ func (r *HTTPRouteReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 
 		if configurationStatus == k8sobj.ConfigurationStatusFailed {
 			debug(log, httproute, "httproute configuration failed")
-			statusUpdated, err := r.ensureParentsProgrammedCondition(ctx, httproute, gateways, metav1.ConditionFalse, ConditionReasonTranslationError, "")
+			// explain the failure when it's caused by unsupported filters or matches.
+			var message string
+			if err := gatewayvalidation.ValidateHTTPRouteFilters(httproute); err != nil {
+				message = err.Error()
+			} else if err := gatewayvalidation.ValidateHTTPRouteHeaderMatches(httproute); err != nil {
+				message = err.Error()
+			}
+			statusUpdated, err := r.ensureParentsProgrammedCondition(ctx, httproute, gateways, metav1.ConditionFalse, ConditionReasonTranslationError, message)
 			if err != nil {
 				// don't proceed until the statuses can be updated appropriately
 				debug(log, httproute, "failed to update programmed condition")
@@ -439,6 +451,7 @@ func (r *HTTPRouteReconciler) ensureGatewayReferenceStatusAdded(ctx context.Cont
 				Kind:      util.StringToGatewayAPIKindPtr(httprouteParentKind),
 				Namespace: (*gatewayv1beta1.Namespace)(&gateway.gateway.Namespace),
 				Name:      gatewayv1beta1.ObjectName(gateway.gateway.Name),
+				Port:      gateway.parentRefPort(),
 			},
 			ControllerName: ControllerName,
 			Conditions: []metav1.Condition{{
@@ -453,7 +466,7 @@ func (r *HTTPRouteReconciler) ensureGatewayReferenceStatusAdded(ctx context.Cont
 			gatewayParentStatus.ParentRef.SectionName = lo.ToPtr(SectionName(gateway.listenerName))
 		}
 
-		key := fmt.Sprintf("%s/%s/%s", gateway.gateway.Namespace, gateway.gateway.Name, gateway.listenerName)
+		key := gateway.parentRefKey()
 
 		// if the reference already exists and doesn't require any changes
 		// then just leave it alone.
@@ -592,6 +605,32 @@ func (r *HTTPRouteReconciler) setRouteConditionResolvedRefsCondition(
 
 func (r *HTTPRouteReconciler) getHTTPRouteRuleReason(ctx context.Context, httpRoute gatewayv1beta1.HTTPRoute) (gatewayv1beta1.RouteConditionReason, error) {
 	for _, rule := range httpRoute.Spec.Rules {
+		// Check if the plugins referenced by ExtensionRef filters exist
+		for _, filter := range rule.Filters {
+			if filter.Type != gatewayv1beta1.HTTPRouteFilterExtensionRef || filter.ExtensionRef == nil {
+				continue
+			}
+			var (
+				plugin client.Object
+				key    = types.NamespacedName{Name: string(filter.ExtensionRef.Name)}
+			)
+			switch filter.ExtensionRef.Kind {
+			case "KongPlugin":
+				plugin = &configurationv1.KongPlugin{}
+				key.Namespace = httpRoute.Namespace
+			case "KongClusterPlugin":
+				plugin = &configurationv1.KongClusterPlugin{}
+			default:
+				return gatewayv1beta1.RouteReasonInvalidKind, nil
+			}
+			if err := r.Client.Get(ctx, key, plugin); err != nil {
+				if !apierrors.IsNotFound(err) {
+					return "", err
+				}
+				return RouteReasonPluginNotFound, nil
+			}
+		}
+
 		for _, backendRef := range rule.BackendRefs {
 			backendNamespace := httpRoute.Namespace
 			if backendRef.Namespace != nil && *backendRef.Namespace != "" {
@@ -656,24 +695,12 @@ func (r *HTTPRouteReconciler) ensureParentsAcceptedCondition(
 	conditionMessage string,
 ) (bool, error) {
 	// map the existing parentStatues to avoid duplications
-	parentStatuses := make(map[string]*gatewayv1beta1.RouteParentStatus)
-	for _, existingParent := range httproute.Status.Parents {
-		namespace := httproute.Namespace
-		if existingParent.ParentRef.Namespace != nil {
-			namespace = string(*existingParent.ParentRef.Namespace)
-		}
-		existingParentCopy := existingParent
-		var sectionName string
-		if existingParent.ParentRef.SectionName != nil {
-			sectionName = string(*existingParent.ParentRef.SectionName)
-		}
-		parentStatuses[fmt.Sprintf("%s/%s/%s", namespace, existingParent.ParentRef.Name, sectionName)] = &existingParentCopy
-	}
+	parentStatuses := getParentStatuses(httproute, httproute.Status.Parents)
 
 	statusChanged := false
 	for _, g := range gateways {
 		gateway := g.gateway
-		parentRefKey := fmt.Sprintf("%s/%s/%s", gateway.Namespace, gateway.Name, g.listenerName)
+		parentRefKey := g.parentRefKey()
 		parentStatus, ok := parentStatuses[parentRefKey]
 		if ok {
 			// update existing parent in status.
@@ -683,10 +710,12 @@ func (r *HTTPRouteReconciler) ensureParentsAcceptedCondition(
 			// add a new parent if the parent is not found in status.
 			newParentStatus := &gatewayv1beta1.RouteParentStatus{
 				ParentRef: gatewayv1beta1.ParentReference{
+					Group:       (*gatewayv1beta1.Group)(&gatewayv1beta1.GroupVersion.Group),
+					Kind:        util.StringToGatewayAPIKindPtr(httprouteParentKind),
 					Namespace:   lo.ToPtr(gatewayv1beta1.Namespace(gateway.Namespace)),
 					Name:        gatewayv1beta1.ObjectName(gateway.Name),
-					SectionName: lo.ToPtr(gatewayv1beta1.SectionName(g.listenerName)),
-					// TODO: set port after gateway port matching implemented: https://github.com/Kong/kubernetes-ingress-controller/issues/3016
+					SectionName: g.parentRefSectionName(),
+					Port:        g.parentRefPort(),
 				},
 				Conditions: []metav1.Condition{
 					{
@@ -768,7 +797,7 @@ func (r *HTTPRouteReconciler) ensureParentsProgrammedCondition(
 	statusChanged := false
 	for _, g := range gateways {
 		gateway := g.gateway
-		parentRefKey := fmt.Sprintf("%s/%s/%s", gateway.Namespace, gateway.Name, g.listenerName)
+		parentRefKey := g.parentRefKey()
 		parentStatus, ok := parentStatuses[parentRefKey]
 		if ok {
 			// update existing parent in status.
@@ -778,10 +807,12 @@ func (r *HTTPRouteReconciler) ensureParentsProgrammedCondition(
 			// add a new parent if the parent is not found in status.
 			newParentStatus := &gatewayv1beta1.RouteParentStatus{
 				ParentRef: gatewayv1beta1.ParentReference{
+					Group:       (*gatewayv1beta1.Group)(&gatewayv1beta1.GroupVersion.Group),
+					Kind:        util.StringToGatewayAPIKindPtr(httprouteParentKind),
 					Namespace:   lo.ToPtr(gatewayv1beta1.Namespace(gateway.Namespace)),
 					Name:        gatewayv1beta1.ObjectName(gateway.Name),
-					SectionName: lo.ToPtr(gatewayv1beta1.SectionName(g.listenerName)),
-					// TODO: set port after gateway port matching implemented: https://github.com/Kong/kubernetes-ingress-controller/issues/3016
+					SectionName: g.parentRefSectionName(),
+					Port:        g.parentRefPort(),
 				},
 				ControllerName: ControllerName,
 				Conditions: []metav1.Condition{
@@ -0,0 +1,86 @@
+package gateway
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	gatewayv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/util/builder"
+	configurationv1 "github.com/kong/kubernetes-ingress-controller/v2/pkg/apis/configuration/v1"
+	"github.com/kong/kubernetes-ingress-controller/v2/pkg/clientset/scheme"
+)
+
+func TestGetHTTPRouteRuleReason_ExtensionRef(t *testing.T) {
+	r := &HTTPRouteReconciler{
+		Client: fakeclient.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(
+			&corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "backend", Namespace: "default"}},
+			&configurationv1.KongPlugin{ObjectMeta: metav1.ObjectMeta{Name: "auth", Namespace: "default"}, PluginName: "key-auth"},
+			&configurationv1.KongPlugin{ObjectMeta: metav1.ObjectMeta{Name: "other-auth", Namespace: "other"}, PluginName: "key-auth"},
+			&configurationv1.KongClusterPlugin{ObjectMeta: metav1.ObjectMeta{Name: "rate-limit"}, PluginName: "rate-limiting"},
+		).Build(),
+	}
+
+	for _, tc := range []struct {
+		name           string
+		kind           gatewayv1beta1.Kind
+		pluginName     string
+		expectedReason gatewayv1beta1.RouteConditionReason
+	}{
+		{
+			name:           "KongPlugin",
+			kind:           "KongPlugin",
+			pluginName:     "auth",
+			expectedReason: gatewayv1beta1.RouteReasonResolvedRefs,
+		},
+		{
+			name:           "KongClusterPlugin",
+			kind:           "KongClusterPlugin",
+			pluginName:     "rate-limit",
+			expectedReason: gatewayv1beta1.RouteReasonResolvedRefs,
+		},
+		{
+			name:           "KongPlugin in another namespace",
+			kind:           "KongPlugin",
+			pluginName:     "other-auth",
+			expectedReason: RouteReasonPluginNotFound,
+		},
+		{
+			name:           "missing KongClusterPlugin",
+			kind:           "KongClusterPlugin",
+			pluginName:     "auth",
+			expectedReason: RouteReasonPluginNotFound,
+		},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			httproute := gatewayv1beta1.HTTPRoute{
+				ObjectMeta: metav1.ObjectMeta{Name: "httproute", Namespace: "default"},
+				Spec: gatewayv1beta1.HTTPRouteSpec{
+					Rules: []gatewayv1beta1.HTTPRouteRule{{
+						Filters: []gatewayv1beta1.HTTPRouteFilter{{
+							Type: gatewayv1beta1.HTTPRouteFilterExtensionRef,
+							ExtensionRef: &gatewayv1beta1.LocalObjectReference{
+								Group: gatewayv1beta1.Group(configurationv1.GroupVersion.Group),
+								Kind:  tc.kind,
+								Name:  gatewayv1beta1.ObjectName(tc.pluginName),
+							},
+						}},
+						BackendRefs: []gatewayv1beta1.HTTPBackendRef{
+							builder.NewHTTPBackendRef("backend").WithPort(80).Build(),
+						},
+					}},
+				},
+			}
+
+			reason, err := r.getHTTPRouteRuleReason(context.Background(), httproute)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedReason, reason)
+		})
+	}
+}
@@ -0,0 +1,243 @@
+package gateway
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+	gatewayv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/util"
+	configurationv1 "github.com/kong/kubernetes-ingress-controller/v2/pkg/apis/configuration/v1"
+)
+
+// -----------------------------------------------------------------------------
+// KongPlugin Policy Controller - Reconciler
+// -----------------------------------------------------------------------------
+
+// KongPluginPolicyReconciler reports the status of the policy attachment of the KongPlugins which have a
+// targetRef. The plugins themselves are pushed to the data-plane by the KongPlugin controller.
+type KongPluginPolicyReconciler struct {
+	client.Client
+
+	Log              logr.Logger
+	Scheme           *runtime.Scheme
+	CacheSyncTimeout time.Duration
+
+	// If EnableGatewayAPI is true, the policies can target Gateways and HTTPRoutes. Otherwise only
+	// the policies targeting Services are accepted.
+	EnableGatewayAPI bool
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *KongPluginPolicyReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	c, err := controller.New("kongplugin-policy-controller", mgr, controller.Options{
+		Reconciler: r,
+		LogConstructor: func(_ *reconcile.Request) logr.Logger {
+			return r.Log
+		},
+		CacheSyncTimeout: r.CacheSyncTimeout,
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := c.Watch(
+		&source.Kind{Type: &configurationv1.KongPlugin{}},
+		&handler.EnqueueRequestForObject{},
+		predicate.NewPredicateFuncs(kongPluginHasTargetRef),
+	); err != nil {
+		return err
+	}
+
+	// the targets of the policies may be created or deleted after them.
+	targets := []client.Object{&corev1.Service{}}
+	if r.EnableGatewayAPI {
+		targets = append(targets, &gatewayv1beta1.Gateway{}, &gatewayv1beta1.HTTPRoute{})
+	}
+	for _, target := range targets {
+		if err := c.Watch(
+			&source.Kind{Type: target},
+			handler.EnqueueRequestsFromMapFunc(r.listPoliciesForTarget),
+		); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// -----------------------------------------------------------------------------
+// KongPlugin Policy Controller - Watch Predicates
+// -----------------------------------------------------------------------------
+
+// kongPluginHasTargetRef is a watch predicate which filters out the KongPlugins which aren't attached as policies.
+func kongPluginHasTargetRef(obj client.Object) bool {
+	plugin, ok := obj.(*configurationv1.KongPlugin)
+	return ok && plugin.TargetRef != nil
+}
+
+// listPoliciesForTarget is a watch predicate which finds all the KongPlugins targeting the provided object
+// to enqueue them for reconciliation.
+func (r *KongPluginPolicyReconciler) listPoliciesForTarget(obj client.Object) []reconcile.Request {
+	var group, kind string
+	switch obj.(type) {
+	case *gatewayv1beta1.Gateway:
+		group, kind = gatewayv1beta1.GroupName, "Gateway"
+	case *gatewayv1beta1.HTTPRoute:
+		group, kind = gatewayv1beta1.GroupName, "HTTPRoute"
+	case *corev1.Service:
+		group, kind = corev1.GroupName, "Service"
+	default:
+		r.Log.Error(
+			fmt.Errorf("unexpected object type"),
+			"kongplugin policy watch predicate received unexpected object type",
+			"found", reflect.TypeOf(obj),
+		)
+		return nil
+	}
+
+	plugins := &configurationv1.KongPluginList{}
+	if err := r.Client.List(context.Background(), plugins, client.InNamespace(obj.GetNamespace())); err != nil {
+		r.Log.Error(err, "failed to list kongplugins in watch", "namespace", obj.GetNamespace(), "name", obj.GetName())
+		return nil
+	}
+	recs := []reconcile.Request{}
+	for _, plugin := range plugins.Items {
+		if plugin.TargetRef == nil ||
+			plugin.TargetRef.Group != group ||
+			plugin.TargetRef.Kind != kind ||
+			plugin.TargetRef.Name != obj.GetName() {
+			continue
+		}
+		recs = append(recs, reconcile.Request{
+			NamespacedName: types.NamespacedName{
+				Namespace: plugin.Namespace,
+				Name:      plugin.Name,
+			},
+		})
+	}
+	return recs
+}
+
+// -----------------------------------------------------------------------------
+// KongPlugin Policy Controller - Reconciliation
+// -----------------------------------------------------------------------------
+
+//+kubebuilder:rbac:groups=configuration.konghq.com,resources=kongplugins,verbs=get;list;watch
+//+kubebuilder:rbac:groups=configuration.konghq.com,resources=kongplugins/status,verbs=get;update;patch
+
+// Reconcile is part of the main kubernetes reconciliation loop which aims to
+// move the current state of the cluster closer to the desired state.
+func (r *KongPluginPolicyReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := r.Log.WithValues("KongV1KongPluginPolicy", req.NamespacedName)
+
+	plugin := new(configurationv1.KongPlugin)
+	if err := r.Get(ctx, req.NamespacedName, plugin); err != nil {
+		if apierrors.IsNotFound(err) {
+			log.V(util.DebugLevel).Info("object enqueued no longer exists, skipping", "name", req.Name)
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, err
+	}
+	if plugin.TargetRef == nil {
+		return ctrl.Result{}, nil
+	}
+	log.V(util.DebugLevel).Info("processing kongplugin policy", "name", req.Name)
+
+	acceptedCondition := metav1.Condition{
+		Type:               configurationv1.KongPluginConditionAccepted,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: plugin.Generation,
+		LastTransitionTime: metav1.Now(),
+		Reason:             configurationv1.KongPluginReasonAccepted,
+		Message:            "the policy has been attached to its target",
+	}
+	reason, err := r.resolvePolicyTarget(ctx, plugin)
+	if err != nil {
+		if reason == "" {
+			return ctrl.Result{}, err
+		}
+		acceptedCondition.Status = metav1.ConditionFalse
+		acceptedCondition.Reason = reason
+		acceptedCondition.Message = err.Error()
+	}
+
+	if !util.IsConditionSet(plugin.Status.Conditions, acceptedCondition, plugin.Generation) {
+		setKongPluginCondition(plugin, acceptedCondition)
+		return ctrl.Result{}, r.Status().Update(ctx, plugin)
+	}
+	return ctrl.Result{}, nil
+}
+
+// -----------------------------------------------------------------------------
+// KongPlugin Policy Controller - Private
+// -----------------------------------------------------------------------------
+
+// resolvePolicyTarget verifies that the target of the provided KongPlugin exists. When it doesn't, or the
+// targetRef isn't supported, it returns an error along with the reason to report in the Accepted condition
+// of the KongPlugin. Other errors are returned without reason.
+func (r *KongPluginPolicyReconciler) resolvePolicyTarget(ctx context.Context, plugin *configurationv1.KongPlugin) (string, error) {
+	target := plugin.TargetRef
+	key := types.NamespacedName{Namespace: plugin.Namespace, Name: target.Name}
+
+	var obj client.Object
+	switch {
+	case target.Group == gatewayv1beta1.GroupName && target.Kind == "Gateway":
+		obj = &gatewayv1beta1.Gateway{}
+	case target.Group == gatewayv1beta1.GroupName && target.Kind == "HTTPRoute":
+		obj = &gatewayv1beta1.HTTPRoute{}
+	case target.Group == corev1.GroupName && target.Kind == "Service":
+		obj = &corev1.Service{}
+	default:
+		return configurationv1.KongPluginReasonInvalid, fmt.Errorf("targetRef group %q and kind %q aren't supported", target.Group, target.Kind)
+	}
+	if target.Group == gatewayv1beta1.GroupName && !r.EnableGatewayAPI {
+		return configurationv1.KongPluginReasonInvalid, fmt.Errorf("targetRef kind %q requires the Gateway API support to be enabled", target.Kind)
+	}
+	if target.SectionName != nil && target.Kind != "Gateway" {
+		return configurationv1.KongPluginReasonInvalid, fmt.Errorf("targetRef sectionName is only supported for Gateways")
+	}
+
+	if err := r.Get(ctx, key, obj); err != nil {
+		if apierrors.IsNotFound(err) {
+			return configurationv1.KongPluginReasonTargetNotFound, fmt.Errorf("%s %s not found", target.Kind, key)
+		}
+		return "", err
+	}
+	if gateway, ok := obj.(*gatewayv1beta1.Gateway); ok && target.SectionName != nil {
+		for _, listener := range gateway.Spec.Listeners {
+			if string(listener.Name) == *target.SectionName {
+				return "", nil
+			}
+		}
+		return configurationv1.KongPluginReasonTargetNotFound, fmt.Errorf("listener %s of Gateway %s not found", *target.SectionName, key)
+	}
+	return "", nil
+}
+
+// setKongPluginCondition sets the condition with specified type in kongplugin status
+// to expected condition in newCondition.
+func setKongPluginCondition(plugin *configurationv1.KongPlugin, newCondition metav1.Condition) {
+	newConditions := []metav1.Condition{}
+	for _, condition := range plugin.Status.Conditions {
+		if condition.Type != newCondition.Type {
+			newConditions = append(newConditions, condition)
+		}
+	}
+	newConditions = append(newConditions, newCondition)
+	plugin.Status.Conditions = newConditions
+}
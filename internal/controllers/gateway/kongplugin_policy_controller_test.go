@@ -0,0 +1,129 @@
+package gateway
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	gatewayv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+
+	configurationv1 "github.com/kong/kubernetes-ingress-controller/v2/pkg/apis/configuration/v1"
+	"github.com/kong/kubernetes-ingress-controller/v2/pkg/clientset/scheme"
+)
+
+func TestKongPluginPolicyReconciler_Reconcile(t *testing.T) {
+	gateway := &gatewayv1beta1.Gateway{
+		ObjectMeta: metav1.ObjectMeta{Name: "gateway", Namespace: "default"},
+		Spec: gatewayv1beta1.GatewaySpec{
+			GatewayClassName: "kong",
+			Listeners: []gatewayv1beta1.Listener{
+				{Name: "http", Protocol: gatewayv1beta1.HTTPProtocolType, Port: 80},
+			},
+		},
+	}
+	service := &corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "backend", Namespace: "default"}}
+
+	testCases := []struct {
+		name           string
+		targetRef      configurationv1.PolicyTargetReference
+		disableGateway bool
+		expectedStatus metav1.ConditionStatus
+		expectedReason string
+	}{
+		{
+			name:           "Gateway",
+			targetRef:      configurationv1.PolicyTargetReference{Group: gatewayv1beta1.GroupName, Kind: "Gateway", Name: "gateway"},
+			expectedStatus: metav1.ConditionTrue,
+			expectedReason: configurationv1.KongPluginReasonAccepted,
+		},
+		{
+			name: "Gateway listener",
+			targetRef: configurationv1.PolicyTargetReference{
+				Group: gatewayv1beta1.GroupName, Kind: "Gateway", Name: "gateway", SectionName: lo.ToPtr("http"),
+			},
+			expectedStatus: metav1.ConditionTrue,
+			expectedReason: configurationv1.KongPluginReasonAccepted,
+		},
+		{
+			name:           "Service",
+			targetRef:      configurationv1.PolicyTargetReference{Kind: "Service", Name: "backend"},
+			expectedStatus: metav1.ConditionTrue,
+			expectedReason: configurationv1.KongPluginReasonAccepted,
+		},
+		{
+			name:           "Service without Gateway API support",
+			targetRef:      configurationv1.PolicyTargetReference{Kind: "Service", Name: "backend"},
+			disableGateway: true,
+			expectedStatus: metav1.ConditionTrue,
+			expectedReason: configurationv1.KongPluginReasonAccepted,
+		},
+		{
+			name:           "Gateway without Gateway API support",
+			targetRef:      configurationv1.PolicyTargetReference{Group: gatewayv1beta1.GroupName, Kind: "Gateway", Name: "gateway"},
+			disableGateway: true,
+			expectedStatus: metav1.ConditionFalse,
+			expectedReason: configurationv1.KongPluginReasonInvalid,
+		},
+		{
+			name: "missing Gateway listener",
+			targetRef: configurationv1.PolicyTargetReference{
+				Group: gatewayv1beta1.GroupName, Kind: "Gateway", Name: "gateway", SectionName: lo.ToPtr("https"),
+			},
+			expectedStatus: metav1.ConditionFalse,
+			expectedReason: configurationv1.KongPluginReasonTargetNotFound,
+		},
+		{
+			name:           "missing HTTPRoute",
+			targetRef:      configurationv1.PolicyTargetReference{Group: gatewayv1beta1.GroupName, Kind: "HTTPRoute", Name: "httproute"},
+			expectedStatus: metav1.ConditionFalse,
+			expectedReason: configurationv1.KongPluginReasonTargetNotFound,
+		},
+		{
+			name:           "Service in the Gateway API group",
+			targetRef:      configurationv1.PolicyTargetReference{Group: gatewayv1beta1.GroupName, Kind: "Service", Name: "backend"},
+			expectedStatus: metav1.ConditionFalse,
+			expectedReason: configurationv1.KongPluginReasonInvalid,
+		},
+		{
+			name:           "sectionName of a Service",
+			targetRef:      configurationv1.PolicyTargetReference{Kind: "Service", Name: "backend", SectionName: lo.ToPtr("http")},
+			expectedStatus: metav1.ConditionFalse,
+			expectedReason: configurationv1.KongPluginReasonInvalid,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			plugin := &configurationv1.KongPlugin{
+				ObjectMeta: metav1.ObjectMeta{Name: "policy", Namespace: "default", Generation: 1},
+				PluginName: "rate-limiting",
+				TargetRef:  tc.targetRef.DeepCopy(),
+			}
+			r := &KongPluginPolicyReconciler{
+				Client:           fakeclient.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(gateway, service, plugin).Build(),
+				Log:              logr.Discard(),
+				EnableGatewayAPI: !tc.disableGateway,
+			}
+
+			_, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: types.NamespacedName{Namespace: "default", Name: "policy"}})
+			require.NoError(t, err)
+
+			updated := &configurationv1.KongPlugin{}
+			require.NoError(t, r.Get(ctx, types.NamespacedName{Namespace: "default", Name: "policy"}, updated))
+			require.Len(t, updated.Status.Conditions, 1)
+			assert.Equal(t, configurationv1.KongPluginConditionAccepted, updated.Status.Conditions[0].Type)
+			assert.Equal(t, tc.expectedStatus, updated.Status.Conditions[0].Status)
+			assert.Equal(t, tc.expectedReason, updated.Status.Conditions[0].Reason)
+		})
+	}
+}
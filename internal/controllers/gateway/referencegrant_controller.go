@@ -32,6 +32,7 @@ import (
 	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
 
 	"github.com/kong/kubernetes-ingress-controller/v2/internal/dataplane"
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/tracing"
 )
 
 // ReferenceGrantReconciler reconciles a ReferenceGrant object.
@@ -70,6 +71,8 @@ func (r *ReferenceGrantReconciler) SetupWithManager(mgr ctrl.Manager) error {
 // move the current state of the cluster closer to the desired state.
 func (r *ReferenceGrantReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	log := r.Log.WithValues("GatewayV1Alpha2ReferenceGrant", req.NamespacedName)
+	ctx, span := tracing.StartReconcile(ctx, "ReferenceGrant", req.NamespacedName)
+	defer span.End()
 	grant := new(gatewayv1alpha2.ReferenceGrant)
 	if err := r.Get(ctx, req.NamespacedName, grant); err != nil {
 		// if the queued object is no longer present in the proxy cache we need
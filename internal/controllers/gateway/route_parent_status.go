@@ -17,18 +17,15 @@ type namespacedObjectT interface {
 }
 
 // getParentStatuses creates a parent status map for the provided route given the
-// route parent status slice.
+// route parent status slice, keyed by routeParentStatusKey.
 func getParentStatuses[routeT namespacedObjectT, parentStatusT RouteParentStatusT](
 	route routeT, parentStatuses []parentStatusT,
 ) map[string]*parentStatusT {
-	var (
-		namespace = route.GetNamespace()
-		m         = make(map[string]*parentStatusT)
-	)
-
+	m := make(map[string]*parentStatusT)
 	for _, existingParent := range parentStatuses {
 		parentRef := getParentRef(existingParent)
 
+		namespace := route.GetNamespace()
 		if parentRef.Namespace != nil {
 			namespace = *parentRef.Namespace
 		}
@@ -36,50 +33,68 @@ func getParentStatuses[routeT namespacedObjectT, parentStatusT RouteParentStatus
 		if parentRef.SectionName != nil {
 			sectionName = *parentRef.SectionName
 		}
-
-		var key string
-		switch any(route).(type) {
-		case *gatewayv1beta1.HTTPRoute:
-			key = fmt.Sprintf("%s/%s/%s", namespace, parentRef.Name, sectionName)
-		default:
-			key = fmt.Sprintf("%s/%s", namespace, parentRef.Name)
+		var port PortNumber
+		if parentRef.Port != nil {
+			port = PortNumber(*parentRef.Port)
 		}
 
 		existingParentCopy := existingParent
-		m[key] = &existingParentCopy
+		m[routeParentStatusKey(namespace, parentRef.Name, sectionName, port)] = &existingParentCopy
 	}
 	return m
 }
 
+// routeParentStatusKey returns the key identifying the status of a route for the parent Gateway
+// with the provided namespace and name, and the listeners with the provided name and port.
+func routeParentStatusKey(namespace, name, sectionName string, port PortNumber) string {
+	key := fmt.Sprintf("%s/%s/%s", namespace, name, sectionName)
+	if port != 0 {
+		key += fmt.Sprintf(":%d", port)
+	}
+	return key
+}
+
 type parentRef struct {
 	Namespace   *string
 	Name        string
 	SectionName *string
+	Port        *int32
 }
 
 // getParentRef serves as glue code to generically get parentRef from either
 // gatewayv1alpha2.RouteParentStatus or gatewayv1beta1.RouteParentStatus.
 func getParentRef[T RouteParentStatusT](parentStatus T) parentRef {
-	var sectionName *string
+	var (
+		sectionName *string
+		port        *int32
+	)
 
 	switch ps := any(parentStatus).(type) {
 	case gatewayv1beta1.RouteParentStatus:
 		if ps.ParentRef.SectionName != nil {
 			sectionName = lo.ToPtr(string(*ps.ParentRef.SectionName))
 		}
+		if ps.ParentRef.Port != nil {
+			port = lo.ToPtr(int32(*ps.ParentRef.Port))
+		}
 		return parentRef{
 			Namespace:   lo.ToPtr(string(*ps.ParentRef.Namespace)),
 			Name:        string(ps.ParentRef.Name),
 			SectionName: sectionName,
+			Port:        port,
 		}
 	case gatewayv1alpha2.RouteParentStatus:
 		if ps.ParentRef.SectionName != nil {
 			sectionName = lo.ToPtr(string(*ps.ParentRef.SectionName))
 		}
+		if ps.ParentRef.Port != nil {
+			port = lo.ToPtr(int32(*ps.ParentRef.Port))
+		}
 		return parentRef{
 			Namespace:   lo.ToPtr(string(*ps.ParentRef.Namespace)),
 			Name:        string(ps.ParentRef.Name),
 			SectionName: sectionName,
+			Port:        port,
 		}
 	}
 	return parentRef{}
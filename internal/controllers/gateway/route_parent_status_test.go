@@ -53,6 +53,51 @@ func TestGetParentStatuses(t *testing.T) {
 					},
 				},
 			},
+			{
+				name: "parentRefs with ports",
+				route: &gatewayv1beta1.HTTPRoute{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      uuid.NewString(),
+						Namespace: uuid.NewString(),
+					},
+					Status: gatewayv1beta1.HTTPRouteStatus{
+						RouteStatus: gatewayv1beta1.RouteStatus{
+							Parents: []gatewayv1beta1.RouteParentStatus{
+								{
+									ParentRef: gatewayv1beta1.ParentReference{
+										Namespace: lo.ToPtr(gatewayv1beta1.Namespace("namespace")),
+										Name:      gatewayv1beta1.ObjectName("name"),
+										Port:      lo.ToPtr(gatewayv1beta1.PortNumber(80)),
+									},
+								},
+								{
+									ParentRef: gatewayv1beta1.ParentReference{
+										Namespace: lo.ToPtr(gatewayv1beta1.Namespace("namespace")),
+										Name:      gatewayv1beta1.ObjectName("name"),
+										Port:      lo.ToPtr(gatewayv1beta1.PortNumber(443)),
+									},
+								},
+							},
+						},
+					},
+				},
+				want: map[string]*gatewayv1beta1.RouteParentStatus{
+					"namespace/name/:80": {
+						ParentRef: gatewayv1beta1.ParentReference{
+							Namespace: lo.ToPtr(gatewayv1beta1.Namespace("namespace")),
+							Name:      gatewayv1beta1.ObjectName("name"),
+							Port:      lo.ToPtr(gatewayv1beta1.PortNumber(80)),
+						},
+					},
+					"namespace/name/:443": {
+						ParentRef: gatewayv1beta1.ParentReference{
+							Namespace: lo.ToPtr(gatewayv1beta1.Namespace("namespace")),
+							Name:      gatewayv1beta1.ObjectName("name"),
+							Port:      lo.ToPtr(gatewayv1beta1.PortNumber(443)),
+						},
+					},
+				},
+			},
 		}
 
 		for _, tt := range tests {
@@ -91,7 +136,7 @@ func TestGetParentStatuses(t *testing.T) {
 					},
 				},
 				want: map[string]*gatewayv1alpha2.RouteParentStatus{
-					"namespace/name": {
+					"namespace/name/": {
 						ParentRef: gatewayv1alpha2.ParentReference{
 							Group:     lo.ToPtr(gatewayv1alpha2.Group("group")),
 							Kind:      lo.ToPtr(gatewayv1alpha2.Kind("kind")),
@@ -139,7 +184,7 @@ func TestGetParentStatuses(t *testing.T) {
 					},
 				},
 				want: map[string]*gatewayv1alpha2.RouteParentStatus{
-					"namespace/name": {
+					"namespace/name/": {
 						ParentRef: gatewayv1alpha2.ParentReference{
 							Group:     lo.ToPtr(gatewayv1alpha2.Group("group")),
 							Kind:      lo.ToPtr(gatewayv1alpha2.Kind("kind")),
@@ -187,7 +232,7 @@ func TestGetParentStatuses(t *testing.T) {
 					},
 				},
 				want: map[string]*gatewayv1alpha2.RouteParentStatus{
-					"namespace/name": {
+					"namespace/name/": {
 						ParentRef: gatewayv1alpha2.ParentReference{
 							Group:     lo.ToPtr(gatewayv1alpha2.Group("group")),
 							Kind:      lo.ToPtr(gatewayv1alpha2.Kind("kind")),
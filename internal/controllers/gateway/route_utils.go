@@ -42,6 +42,37 @@ type supportedGatewayWithCondition struct {
 	gateway      *Gateway
 	condition    metav1.Condition
 	listenerName string
+	// listenerPort is the port of the listeners the route is attached to, 0 if the
+	// ParentRef doesn't specify one.
+	listenerPort PortNumber
+}
+
+// matchesListener returns true if the provided listener of the gateway is selected by the
+// listener name and port of the ParentRef.
+func (g supportedGatewayWithCondition) matchesListener(listener Listener) bool {
+	return (g.listenerName == "" || SectionName(g.listenerName) == listener.Name) &&
+		(g.listenerPort == 0 || g.listenerPort == listener.Port)
+}
+
+// parentRefKey returns the key identifying the route parent status of the ParentRef.
+func (g supportedGatewayWithCondition) parentRefKey() string {
+	return routeParentStatusKey(g.gateway.Namespace, g.gateway.Name, g.listenerName, g.listenerPort)
+}
+
+// parentRefSectionName returns the section name of the ParentRef to be set in the route parent status.
+func (g supportedGatewayWithCondition) parentRefSectionName() *SectionName {
+	if g.listenerName == "" {
+		return nil
+	}
+	return lo.ToPtr(SectionName(g.listenerName))
+}
+
+// parentRefPort returns the port of the ParentRef to be set in the route parent status.
+func (g supportedGatewayWithCondition) parentRefPort() *PortNumber {
+	if g.listenerPort == 0 {
+		return nil
+	}
+	return lo.ToPtr(g.listenerPort)
 }
 
 // parentRefsForRoute provides a list of the parentRefs given a Gateway APIs route object
@@ -91,6 +122,10 @@ const (
 	// https://github.com/kubernetes-sigs/gateway-api/pull/1516
 	// TODO: swap this out with upstream const when released.
 	RouteReasonNoMatchingParent gatewayv1beta1.RouteConditionReason = "NoMatchingParent"
+
+	// This reason is used with the "ResolvedRefs" condition when the KongPlugin
+	// or KongClusterPlugin referenced by an ExtensionRef filter doesn't exist.
+	RouteReasonPluginNotFound gatewayv1beta1.RouteConditionReason = "PluginNotFound"
 )
 
 // getSupportedGatewayForRoute will retrieve the Gateway and GatewayClass object for any
@@ -154,8 +189,8 @@ func getSupportedGatewayForRoute[T types.RouteT](ctx context.Context, mgrc clien
 
 		var (
 			// Set to true if there exists a listener which wasn't filtered by:
+			// - listener name and port matching
 			// - AlowedRoutes
-			// - listener name matching
 			// - listener status checks
 			// - listener and route type checks
 			matched = false
@@ -170,6 +205,23 @@ func getSupportedGatewayForRoute[T types.RouteT](ctx context.Context, mgrc clien
 		)
 
 		for _, listener := range gateway.Spec.Listeners {
+			// The listeners a route attaches to are selected by the SectionName and the Port
+			// of its ParentRef, as described in GEP-957, before any other check.
+			if parentRef.SectionName != nil {
+				if *parentRef.SectionName != "" && *parentRef.SectionName != listener.Name {
+					continue
+				}
+				allowedByListenerName = true
+			}
+			if parentRef.Port != nil {
+				if *parentRef.Port != listener.Port {
+					// This ParentRef has a port specified and it's different
+					// than current listener's port.
+					continue
+				}
+				portMatched = true
+			}
+
 			// Check if the route matches listener's AllowedRoutes.
 			if ok, err := routeMatchesListenerAllowedRoutes(ctx, mgrc, route, listener, gateway.Namespace, parentRef.Namespace); err != nil {
 				return nil, fmt.Errorf("failed matching listener %s to a route %s for gateway %s: %w",
@@ -191,24 +243,6 @@ func getSupportedGatewayForRoute[T types.RouteT](ctx context.Context, mgrc clien
 				allowedBySupportedKinds = true
 			}
 
-			// Check if listener name matches.
-			if parentRef.SectionName != nil {
-				if *parentRef.SectionName != "" && *parentRef.SectionName != listener.Name {
-					continue
-				}
-				allowedByListenerName = true
-			}
-
-			// Perform the port matching as described in GEP-957.
-			if parentRef.Port != nil {
-				if *parentRef.Port != listener.Port {
-					// This ParentRef has a port specified and it's different
-					// than current listener's port.
-					continue
-				}
-				portMatched = true
-			}
-
 			if !routeTypeMatchesListenerType(route, listener) {
 				continue
 			}
@@ -225,58 +259,47 @@ func getSupportedGatewayForRoute[T types.RouteT](ctx context.Context, mgrc clien
 			matched = true
 		}
 
-		if matched {
-			var listenerName string
-			if parentRef.SectionName != nil && *parentRef.SectionName != "" {
-				listenerName = string(*parentRef.SectionName)
-			}
+		supportedGateway := supportedGatewayWithCondition{
+			gateway: &gateway,
+			condition: metav1.Condition{
+				Type:   string(gatewayv1beta1.RouteConditionAccepted),
+				Status: metav1.ConditionTrue,
+				Reason: string(gatewayv1beta1.RouteReasonAccepted),
+			},
+		}
+		if parentRef.SectionName != nil {
+			supportedGateway.listenerName = string(*parentRef.SectionName)
+		}
+		if parentRef.Port != nil {
+			supportedGateway.listenerPort = *parentRef.Port
+		}
 
-			gateways = append(gateways, supportedGatewayWithCondition{
-				gateway:      &gateway,
-				listenerName: listenerName,
-				condition: metav1.Condition{
-					Type:   string(gatewayv1beta1.RouteConditionAccepted),
-					Status: metav1.ConditionTrue,
-					Reason: string(gatewayv1beta1.RouteReasonAccepted),
-				},
-			})
-		} else {
+		if !matched {
 			// We failed to match a listener with this route
 
 			// This will also catch a case of not matching listener/section name.
 			reason := RouteReasonNoMatchingParent
 
-			if matchingHostname != nil && *matchingHostname == metav1.ConditionFalse {
-				// If there is no matchingHostname, the gateway Status Condition Accepted
-				// must be set to False with reason NoMatchingListenerHostname
-				reason = gatewayv1beta1.RouteReasonNoMatchingListenerHostname
-			} else if (parentRef.SectionName) != nil && !allowedByListenerName {
+			if parentRef.SectionName != nil && !allowedByListenerName {
 				// If ParentRef specified listener names but none of the listeners matches the name,
 				// the gateway Status Condition Accepted must be set to False with reason RouteReasonNoMatchingParent.
 				reason = RouteReasonNoMatchingParent
-			} else if (parentRef.Port != nil) && !portMatched {
+			} else if parentRef.Port != nil && !portMatched {
 				// If ParentRef specified a Port but none of the listeners matched, the gateway Status
-				// Condition Accepted must be set to False with reason NoMatchingListenerPort
+				// Condition Accepted must be set to False with reason NoMatchingParent.
 				reason = RouteReasonNoMatchingParent
+			} else if matchingHostname != nil && *matchingHostname == metav1.ConditionFalse {
+				// If there is no matchingHostname, the gateway Status Condition Accepted
+				// must be set to False with reason NoMatchingListenerHostname
+				reason = gatewayv1beta1.RouteReasonNoMatchingListenerHostname
 			} else if !allowedByAllowedRoutes || !allowedBySupportedKinds {
 				reason = gatewayv1beta1.RouteReasonNotAllowedByListeners
 			}
 
-			var listenerName string
-			if parentRef.SectionName != nil && *parentRef.SectionName != "" {
-				listenerName = string(*parentRef.SectionName)
-			}
-
-			gateways = append(gateways, supportedGatewayWithCondition{
-				gateway:      &gateway,
-				listenerName: listenerName,
-				condition: metav1.Condition{
-					Type:   string(gatewayv1beta1.RouteConditionAccepted),
-					Status: metav1.ConditionFalse,
-					Reason: string(reason),
-				},
-			})
+			supportedGateway.condition.Status = metav1.ConditionFalse
+			supportedGateway.condition.Reason = string(reason)
 		}
+		gateways = append(gateways, supportedGateway)
 	}
 
 	if len(gateways) == 0 {
@@ -546,29 +569,21 @@ func filterHostnames(gateways []supportedGatewayWithCondition, httpRoute *gatewa
 func getUnionOfGatewayHostnames(gateways []supportedGatewayWithCondition) ([]gatewayv1beta1.Hostname, bool) {
 	hostnames := make([]gatewayv1beta1.Hostname, 0)
 	for _, gateway := range gateways {
-		if gateway.listenerName != "" {
-			if listener := extractListenerSpecFromGateway(
-				gateway.gateway,
-				gatewayv1beta1.SectionName(gateway.listenerName),
-			); listener != nil {
-				// return true if the listener has not specified hostname to match any hostname.
-				if listener.Hostname == nil {
-					return nil, true
-				}
-				hostnames = append(hostnames, *listener.Hostname)
+		for _, listener := range gateway.gateway.Spec.Listeners {
+			if !gateway.matchesListener(listener) {
+				continue
 			}
-		} else {
-			for _, listener := range gateway.gateway.Spec.Listeners {
-				// here we consider ALL listeners that are able to configure a hostname if no listener attached.
-				// may be changed if there is a conclusion on the upstream discussion about it:
-				// https://github.com/kubernetes-sigs/gateway-api/discussions/1563
-				if isListenerHostnameEffective(listener) {
-					if listener.Hostname == nil {
-						return nil, true
-					}
-					hostnames = append(hostnames, *listener.Hostname)
-				}
+			// here we consider ALL listeners that are able to configure a hostname if no listener attached.
+			// may be changed if there is a conclusion on the upstream discussion about it:
+			// https://github.com/kubernetes-sigs/gateway-api/discussions/1563
+			if gateway.listenerName == "" && !isListenerHostnameEffective(listener) {
+				continue
+			}
+			// return true if the listener has not specified hostname to match any hostname.
+			if listener.Hostname == nil {
+				return nil, true
 			}
+			hostnames = append(hostnames, *listener.Hostname)
 		}
 	}
 	return hostnames, false
@@ -584,9 +599,8 @@ func getUnionOfGatewayHostnames(gateways []supportedGatewayWithCondition) ([]gat
 func getMinimumHostnameIntersection(gateways []supportedGatewayWithCondition, hostname gatewayv1beta1.Hostname) gatewayv1beta1.Hostname {
 	for _, gateway := range gateways {
 		for _, listener := range gateway.gateway.Spec.Listeners {
-			// if the listenerName is specified and matches the name of the gateway listener proceed
-			if (SectionName)(gateway.listenerName) == "" ||
-				(SectionName)(gateway.listenerName) == (listener.Name) {
+			// if the listenerName and port are specified and match the gateway listener proceed
+			if gateway.matchesListener(listener) {
 				if listener.Hostname == nil || *listener.Hostname == "" {
 					return hostname
 				}
@@ -43,6 +43,7 @@ func TestFilterHostnames(t *testing.T) {
 				{
 					Name:     "listener-2",
 					Hostname: util.StringToGatewayAPIHostnamePtr("*.wildcard.io"),
+					Port:     8443,
 				},
 				{
 					Name:     "listener-3",
@@ -141,6 +142,30 @@ func TestFilterHostnames(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "listener port",
+			gateways: []supportedGatewayWithCondition{
+				{
+					gateway:      commonGateway,
+					listenerPort: 8443,
+				},
+			},
+			httpRoute: &gatewayv1beta1.HTTPRoute{
+				Spec: gatewayv1beta1.HTTPRouteSpec{
+					Hostnames: []gatewayv1beta1.Hostname{
+						util.StringToGatewayAPIHostnameV1Beta1("very.specific.com"),
+						util.StringToGatewayAPIHostnameV1Beta1("foo.wildcard.io"),
+					},
+				},
+			},
+			expectedHTTPRoute: &gatewayv1beta1.HTTPRoute{
+				Spec: gatewayv1beta1.HTTPRouteSpec{
+					Hostnames: []gatewayv1beta1.Hostname{
+						util.StringToGatewayAPIHostnameV1Beta1("foo.wildcard.io"),
+					},
+				},
+			},
+		},
 		{
 			name: "listener 3 - wildcard",
 			gateways: []supportedGatewayWithCondition{
@@ -247,6 +272,7 @@ func Test_getSupportedGatewayForRoute(t *testing.T) {
 	type expected struct {
 		condition    metav1.Condition
 		listenerName string
+		listenerPort PortNumber
 	}
 
 	t.Run("HTTPRoute", func(t *testing.T) {
@@ -310,6 +336,17 @@ func Test_getSupportedGatewayForRoute(t *testing.T) {
 			}
 		}
 
+		gatewayWithHTTP80AndHTTPS443Ready := func() *Gateway {
+			gw := gatewayWithHTTP80Ready()
+			gw.Spec.Listeners = append(gw.Spec.Listeners, builder.NewListener("https").WithPort(443).HTTPS().Build())
+			gw.Status.Listeners = append(gw.Status.Listeners, gatewayv1beta1.ListenerStatus{
+				Name:           "https",
+				Conditions:     gw.Status.Listeners[0].Conditions,
+				SupportedKinds: supportedRouteGroupKinds,
+			})
+			return gw
+		}
+
 		tests := []struct {
 			name     string
 			route    *HTTPRoute
@@ -386,7 +423,8 @@ func Test_getSupportedGatewayForRoute(t *testing.T) {
 				},
 				expected: []expected{
 					{
-						condition: routeConditionAccepted(metav1.ConditionTrue, gatewayv1beta1.RouteReasonAccepted),
+						listenerPort: 80,
+						condition:    routeConditionAccepted(metav1.ConditionTrue, gatewayv1beta1.RouteReasonAccepted),
 					},
 				},
 			},
@@ -408,7 +446,79 @@ func Test_getSupportedGatewayForRoute(t *testing.T) {
 				},
 				expected: []expected{
 					{
-						condition: routeConditionAccepted(metav1.ConditionFalse, RouteReasonNoMatchingParent),
+						listenerPort: 80,
+						condition:    routeConditionAccepted(metav1.ConditionFalse, RouteReasonNoMatchingParent),
+					},
+				},
+			},
+			{
+				name: "basic HTTPRoute specifying the port of one of the listeners gets Accepted",
+				route: func() *HTTPRoute {
+					r := basicHTTPRoute()
+					r.Spec.CommonRouteSpec.ParentRefs[0].Port = lo.ToPtr(PortNumber(443))
+					return r
+				}(),
+				objects: []client.Object{
+					gatewayWithHTTP80AndHTTPS443Ready(),
+					gatewayClass,
+					namespace,
+				},
+				expected: []expected{
+					{
+						listenerPort: 443,
+						condition:    routeConditionAccepted(metav1.ConditionTrue, gatewayv1beta1.RouteReasonAccepted),
+					},
+				},
+			},
+			{
+				name: "basic HTTPRoute specifying a section name and the port of another listener does not get Accepted",
+				route: func() *HTTPRoute {
+					r := basicHTTPRoute()
+					r.Spec.CommonRouteSpec.ParentRefs[0].SectionName = lo.ToPtr(SectionName("http"))
+					r.Spec.CommonRouteSpec.ParentRefs[0].Port = lo.ToPtr(PortNumber(443))
+					return r
+				}(),
+				objects: []client.Object{
+					gatewayWithHTTP80AndHTTPS443Ready(),
+					gatewayClass,
+					namespace,
+				},
+				expected: []expected{
+					{
+						listenerName: "http",
+						listenerPort: 443,
+						condition:    routeConditionAccepted(metav1.ConditionFalse, RouteReasonNoMatchingParent),
+					},
+				},
+			},
+			{
+				name: "basic HTTPRoute specifying a section name does not get accepted if the listener doesn't allow it",
+				route: func() *HTTPRoute {
+					r := basicHTTPRoute()
+					r.Spec.CommonRouteSpec.ParentRefs[0].SectionName = lo.ToPtr(SectionName("http"))
+					return r
+				}(),
+				objects: []client.Object{
+					func() *Gateway {
+						gw := gatewayWithHTTP80Ready()
+						gw.Spec.Listeners = builder.NewListener("http").
+							WithPort(80).
+							HTTP().
+							WithAllowedRoutes(
+								&gatewayv1beta1.AllowedRoutes{
+									Kinds: builder.NewRouteGroupKind().TCPRoute().IntoSlice(),
+								},
+							).
+							IntoSlice()
+						return gw
+					}(),
+					gatewayClass,
+					namespace,
+				},
+				expected: []expected{
+					{
+						listenerName: "http",
+						condition:    routeConditionAccepted(metav1.ConditionFalse, gatewayv1beta1.RouteReasonNotAllowedByListeners),
 					},
 				},
 			},
@@ -548,6 +658,7 @@ func Test_getSupportedGatewayForRoute(t *testing.T) {
 					assert.Equalf(t, "test-namespace", got[i].gateway.Namespace, "gateway namespace #%d", i)
 					assert.Equalf(t, "test-gateway", got[i].gateway.Name, "gateway name #%d", i)
 					assert.Equalf(t, tt.expected[i].listenerName, got[i].listenerName, "listenerName #%d", i)
+					assert.Equalf(t, tt.expected[i].listenerPort, got[i].listenerPort, "listenerPort #%d", i)
 					assert.Equalf(t, tt.expected[i].condition, got[i].condition, "condition #%d", i)
 				}
 			})
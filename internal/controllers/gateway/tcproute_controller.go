@@ -24,6 +24,7 @@ import (
 	gatewayv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
 
 	"github.com/kong/kubernetes-ingress-controller/v2/internal/dataplane"
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/tracing"
 	"github.com/kong/kubernetes-ingress-controller/v2/internal/util"
 	k8sobj "github.com/kong/kubernetes-ingress-controller/v2/internal/util/kubernetes/object"
 )
@@ -237,6 +238,8 @@ func (r *TCPRouteReconciler) listTCPRoutesForGateway(obj client.Object) []reconc
 // move the current state of the cluster closer to the desired state.
 func (r *TCPRouteReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	log := r.Log.WithValues("GatewayV1Alpha2TCPRoute", req.NamespacedName)
+	ctx, span := tracing.StartReconcile(ctx, "TCPRoute", req.NamespacedName)
+	defer span.End()
 
 	tcproute := new(gatewayv1alpha2.TCPRoute)
 	if err := r.Get(ctx, req.NamespacedName, tcproute); err != nil {
@@ -427,20 +430,26 @@ func (r *TCPRouteReconciler) ensureGatewayReferenceStatusAdded(
 				Kind:      (*gatewayv1alpha2.Kind)(util.StringToGatewayAPIKindPtr(tcprouteParentKind)),
 				Namespace: (*gatewayv1alpha2.Namespace)(&gateway.gateway.Namespace),
 				Name:      (gatewayv1alpha2.ObjectName)(gateway.gateway.Name),
+				Port:      (*gatewayv1alpha2.PortNumber)(gateway.parentRefPort()),
 			},
 			ControllerName: (gatewayv1alpha2.GatewayController)(ControllerName),
 			Conditions: []metav1.Condition{{
-				Type:               string(gatewayv1beta1.RouteConditionAccepted),
-				Status:             metav1.ConditionTrue,
+				Type:               gateway.condition.Type,
+				Status:             gateway.condition.Status,
 				ObservedGeneration: tcproute.Generation,
 				LastTransitionTime: metav1.Now(),
-				Reason:             string(gatewayv1beta1.RouteReasonAccepted),
+				Reason:             gateway.condition.Reason,
 			}},
 		}
 
+		if gateway.listenerName != "" {
+			sectionName := gatewayv1alpha2.SectionName(gateway.listenerName)
+			gatewayParentStatus.ParentRef.SectionName = &sectionName
+		}
+
 		// if the reference already exists and doesn't require any changes
 		// then just leave it alone.
-		parentRefKey := gateway.gateway.Namespace + "/" + gateway.gateway.Name
+		parentRefKey := gateway.parentRefKey()
 		if existingGatewayParentStatus, exists := parentStatuses[parentRefKey]; exists {
 			//  check if the parentRef and controllerName are equal, and whether the new condition is present in existing conditions
 			if reflect.DeepEqual(existingGatewayParentStatus.ParentRef, gatewayParentStatus.ParentRef) &&
@@ -544,7 +553,7 @@ func (r *TCPRouteReconciler) ensureParentsProgrammedCondition(
 	statusChanged := false
 	for _, g := range gateways {
 		gateway := g.gateway
-		parentRefKey := gateway.Namespace + "/" + gateway.Name
+		parentRefKey := g.parentRefKey()
 		parentStatus, ok := parentStatuses[parentRefKey]
 		if ok {
 			// update existing parent in status.
@@ -554,9 +563,12 @@ func (r *TCPRouteReconciler) ensureParentsProgrammedCondition(
 			// add a new parent if the parent is not found in status.
 			newParentStatus := &gatewayv1alpha2.RouteParentStatus{
 				ParentRef: gatewayv1alpha2.ParentReference{
-					Namespace: lo.ToPtr(gatewayv1alpha2.Namespace(gateway.Namespace)),
-					Name:      gatewayv1alpha2.ObjectName(gateway.Name),
-					// TODO: set port after gateway port matching implemented: https://github.com/Kong/kubernetes-ingress-controller/issues/3016
+					Group:       (*gatewayv1alpha2.Group)(&gatewayv1beta1.GroupVersion.Group),
+					Kind:        (*gatewayv1alpha2.Kind)(util.StringToGatewayAPIKindPtr(tcprouteParentKind)),
+					Namespace:   lo.ToPtr(gatewayv1alpha2.Namespace(gateway.Namespace)),
+					Name:        gatewayv1alpha2.ObjectName(gateway.Name),
+					SectionName: (*gatewayv1alpha2.SectionName)(g.parentRefSectionName()),
+					Port:        (*gatewayv1alpha2.PortNumber)(g.parentRefPort()),
 				},
 				ControllerName: gatewayv1alpha2.GatewayController(ControllerName),
 				Conditions: []metav1.Condition{
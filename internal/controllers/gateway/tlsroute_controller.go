@@ -24,6 +24,7 @@ import (
 	gatewayv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
 
 	"github.com/kong/kubernetes-ingress-controller/v2/internal/dataplane"
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/tracing"
 	"github.com/kong/kubernetes-ingress-controller/v2/internal/util"
 	k8sobj "github.com/kong/kubernetes-ingress-controller/v2/internal/util/kubernetes/object"
 )
@@ -237,6 +238,8 @@ func (r *TLSRouteReconciler) listTLSRoutesForGateway(obj client.Object) []reconc
 // move the current state of the cluster closer to the desired state.
 func (r *TLSRouteReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	log := r.Log.WithValues("GatewayV1Alpha2TLSRoute", req.NamespacedName)
+	ctx, span := tracing.StartReconcile(ctx, "TLSRoute", req.NamespacedName)
+	defer span.End()
 
 	tlsroute := new(gatewayv1alpha2.TLSRoute)
 	if err := r.Get(ctx, req.NamespacedName, tlsroute); err != nil {
@@ -413,14 +416,15 @@ func (r *TLSRouteReconciler) ensureGatewayReferenceStatusAdded(ctx context.Conte
 				Kind:      (*gatewayv1alpha2.Kind)(util.StringToGatewayAPIKindPtr(tlsrouteParentKind)),
 				Namespace: (*gatewayv1alpha2.Namespace)(&gateway.gateway.Namespace),
 				Name:      gatewayv1alpha2.ObjectName(gateway.gateway.Name),
+				Port:      (*gatewayv1alpha2.PortNumber)(gateway.parentRefPort()),
 			},
 			ControllerName: (gatewayv1alpha2.GatewayController)(ControllerName),
 			Conditions: []metav1.Condition{{
-				Type:               string(gatewayv1alpha2.RouteConditionAccepted),
-				Status:             metav1.ConditionTrue,
+				Type:               gateway.condition.Type,
+				Status:             gateway.condition.Status,
 				ObservedGeneration: tlsroute.Generation,
 				LastTransitionTime: metav1.Now(),
-				Reason:             string(gatewayv1alpha2.RouteReasonAccepted),
+				Reason:             gateway.condition.Reason,
 			}},
 		}
 
@@ -431,7 +435,7 @@ func (r *TLSRouteReconciler) ensureGatewayReferenceStatusAdded(ctx context.Conte
 
 		// if the reference already exists and doesn't require any changes
 		// then just leave it alone.
-		parentRefKey := gateway.gateway.Namespace + "/" + gateway.gateway.Name
+		parentRefKey := gateway.parentRefKey()
 		if existingGatewayParentStatus, exists := parentStatuses[parentRefKey]; exists {
 			//  check if the parentRef and controllerName are equal, and whether the new condition is present in existing conditions
 			if reflect.DeepEqual(existingGatewayParentStatus.ParentRef, gatewayParentStatus.ParentRef) &&
@@ -535,7 +539,7 @@ func (r *TLSRouteReconciler) ensureParentsProgrammedCondition(
 	statusChanged := false
 	for _, g := range gateways {
 		gateway := g.gateway
-		parentRefKey := gateway.Namespace + "/" + gateway.Name
+		parentRefKey := g.parentRefKey()
 		parentStatus, ok := parentStatuses[parentRefKey]
 		if ok {
 			// update existing parent in status.
@@ -545,9 +549,12 @@ func (r *TLSRouteReconciler) ensureParentsProgrammedCondition(
 			// add a new parent if the parent is not found in status.
 			newParentStatus := &gatewayv1alpha2.RouteParentStatus{
 				ParentRef: gatewayv1alpha2.ParentReference{
-					Namespace: lo.ToPtr(gatewayv1alpha2.Namespace(gateway.Namespace)),
-					Name:      gatewayv1alpha2.ObjectName(gateway.Name),
-					// TODO: set port after gateway port matching implemented: https://github.com/Kong/kubernetes-ingress-controller/issues/3016
+					Group:       (*gatewayv1alpha2.Group)(&gatewayv1beta1.GroupVersion.Group),
+					Kind:        (*gatewayv1alpha2.Kind)(util.StringToGatewayAPIKindPtr(tlsrouteParentKind)),
+					Namespace:   lo.ToPtr(gatewayv1alpha2.Namespace(gateway.Namespace)),
+					Name:        gatewayv1alpha2.ObjectName(gateway.Name),
+					SectionName: (*gatewayv1alpha2.SectionName)(g.parentRefSectionName()),
+					Port:        (*gatewayv1alpha2.PortNumber)(g.parentRefPort()),
 				},
 				ControllerName: gatewayv1alpha2.GatewayController(ControllerName),
 				Conditions: []metav1.Condition{
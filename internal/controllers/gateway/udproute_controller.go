@@ -24,6 +24,7 @@ import (
 	gatewayv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
 
 	"github.com/kong/kubernetes-ingress-controller/v2/internal/dataplane"
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/tracing"
 	"github.com/kong/kubernetes-ingress-controller/v2/internal/util"
 	k8sobj "github.com/kong/kubernetes-ingress-controller/v2/internal/util/kubernetes/object"
 )
@@ -237,6 +238,8 @@ func (r *UDPRouteReconciler) listUDPRoutesForGateway(obj client.Object) []reconc
 // move the current state of the cluster closer to the desired state.
 func (r *UDPRouteReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	log := r.Log.WithValues("GatewayV1Alpha2UDPRoute", req.NamespacedName)
+	ctx, span := tracing.StartReconcile(ctx, "UDPRoute", req.NamespacedName)
+	defer span.End()
 
 	udproute := new(gatewayv1alpha2.UDPRoute)
 	if err := r.Get(ctx, req.NamespacedName, udproute); err != nil {
@@ -404,20 +407,26 @@ func (r *UDPRouteReconciler) ensureGatewayReferenceStatusAdded(ctx context.Conte
 				Kind:      (*gatewayv1alpha2.Kind)(util.StringToGatewayAPIKindPtr(udprouteParentKind)),
 				Namespace: (*gatewayv1alpha2.Namespace)(&gateway.gateway.Namespace),
 				Name:      gatewayv1alpha2.ObjectName(gateway.gateway.Name),
+				Port:      (*gatewayv1alpha2.PortNumber)(gateway.parentRefPort()),
 			},
 			ControllerName: (gatewayv1alpha2.GatewayController)(ControllerName),
 			Conditions: []metav1.Condition{{
-				Type:               string(gatewayv1alpha2.RouteConditionAccepted),
-				Status:             metav1.ConditionTrue,
+				Type:               gateway.condition.Type,
+				Status:             gateway.condition.Status,
 				ObservedGeneration: udproute.Generation,
 				LastTransitionTime: metav1.Now(),
-				Reason:             string(gatewayv1alpha2.RouteReasonAccepted),
+				Reason:             gateway.condition.Reason,
 			}},
 		}
 
+		if gateway.listenerName != "" {
+			sectionName := gatewayv1alpha2.SectionName(gateway.listenerName)
+			gatewayParentStatus.ParentRef.SectionName = &sectionName
+		}
+
 		// if the reference already exists and doesn't require any changes
 		// then just leave it alone.
-		parentRefKey := gateway.gateway.Namespace + "/" + gateway.gateway.Name
+		parentRefKey := gateway.parentRefKey()
 		if existingGatewayParentStatus, exists := parentStatuses[parentRefKey]; exists {
 			//  check if the parentRef and controllerName are equal, and whether the new condition is present in existing conditions
 			if reflect.DeepEqual(existingGatewayParentStatus.ParentRef, gatewayParentStatus.ParentRef) &&
@@ -521,7 +530,7 @@ func (r *UDPRouteReconciler) ensureParentsProgrammedCondition(
 	statusChanged := false
 	for _, g := range gateways {
 		gateway := g.gateway
-		parentRefKey := fmt.Sprintf("%s/%s", gateway.Namespace, gateway.Name)
+		parentRefKey := g.parentRefKey()
 		parentStatus, ok := parentStatuses[parentRefKey]
 		if ok {
 			// update existing parent in status.
@@ -531,10 +540,12 @@ func (r *UDPRouteReconciler) ensureParentsProgrammedCondition(
 			// add a new parent if the parent is not found in status.
 			newParentStatus := &gatewayv1alpha2.RouteParentStatus{
 				ParentRef: gatewayv1alpha2.ParentReference{
+					Group:       (*gatewayv1alpha2.Group)(&gatewayv1beta1.GroupVersion.Group),
+					Kind:        (*gatewayv1alpha2.Kind)(util.StringToGatewayAPIKindPtr(udprouteParentKind)),
 					Namespace:   lo.ToPtr(gatewayv1alpha2.Namespace(gateway.Namespace)),
 					Name:        gatewayv1alpha2.ObjectName(gateway.Name),
-					SectionName: lo.ToPtr(gatewayv1alpha2.SectionName(g.listenerName)),
-					// TODO: set port after gateway port matching implemented: https://github.com/Kong/kubernetes-ingress-controller/issues/3016
+					SectionName: (*gatewayv1alpha2.SectionName)(g.parentRefSectionName()),
+					Port:        (*gatewayv1alpha2.PortNumber)(g.parentRefPort()),
 				},
 				Conditions: []metav1.Condition{
 					programmedCondition,
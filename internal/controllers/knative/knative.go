@@ -25,6 +25,7 @@ import (
 	ctrlref "github.com/kong/kubernetes-ingress-controller/v2/internal/controllers/reference"
 	ctrlutils "github.com/kong/kubernetes-ingress-controller/v2/internal/controllers/utils"
 	"github.com/kong/kubernetes-ingress-controller/v2/internal/dataplane"
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/tracing"
 	"github.com/kong/kubernetes-ingress-controller/v2/internal/util"
 	"github.com/kong/kubernetes-ingress-controller/v2/internal/util/kubernetes/object/status"
 )
@@ -121,6 +122,8 @@ func (r *Knativev1alpha1IngressReconciler) listClassless(obj client.Object) []re
 // Reconcile processes the watched objects.
 func (r *Knativev1alpha1IngressReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	log := r.Log.WithValues("Knativev1alpha1Ingress", req.NamespacedName)
+	ctx, span := tracing.StartReconcile(ctx, "Ingress", req.NamespacedName)
+	defer span.End()
 
 	// get the relevant object
 	obj := new(knativev1alpha1.Ingress)
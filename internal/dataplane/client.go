@@ -30,3 +30,12 @@ type Client interface {
 	// it to the backend API.
 	Update(ctx context.Context) error
 }
+
+// ChangeNotifier is implemented by the clients which can notify about changes to
+// the configuration they apply to the data-plane, so that updates can be triggered
+// by those changes.
+type ChangeNotifier interface {
+	// Changes returns a channel which receives a value whenever the configuration
+	// to apply to the data-plane has changed.
+	Changes() <-chan struct{}
+}
@@ -21,44 +21,71 @@ func GenerateSHA(targetContent *file.Content) ([]byte, error) {
 	return shaSum[:], nil
 }
 
-// CleanUpNullsInPluginConfigs modifies `state` by deleting plugin config map keys that have nil as their value.
-func CleanUpNullsInPluginConfigs(state *file.Content) {
-	for _, s := range state.Services {
-		for _, p := range s.Plugins {
-			for k, v := range p.Config {
-				if v == nil {
-					delete(p.Config, k)
-				}
-			}
-		}
-		for _, r := range state.Routes {
-			for _, p := range r.Plugins {
-				for k, v := range p.Config {
-					if v == nil {
-						delete(p.Config, k)
-					}
-				}
-			}
+// WithoutNullsInPluginConfigs returns a copy of `state` in which plugin configs have no keys with nil as their value.
+// `state` isn't modified, as it might be in use elsewhere (e.g. pushed to other Kong instances at the same time):
+// the entities holding plugins are copied, while everything else is shared with it.
+func WithoutNullsInPluginConfigs(state *file.Content) *file.Content {
+	content := *state
+
+	content.Services = make([]file.FService, len(state.Services))
+	for i, s := range state.Services {
+		s.Plugins = pluginsWithoutNulls(s.Plugins)
+		routes := make([]*file.FRoute, len(s.Routes))
+		for j, r := range s.Routes {
+			route := *r
+			route.Plugins = pluginsWithoutNulls(r.Plugins)
+			routes[j] = &route
 		}
+		s.Routes = routes
+		content.Services[i] = s
 	}
 
-	for _, c := range state.Consumers {
-		for _, p := range c.Plugins {
-			for k, v := range p.Config {
-				if v == nil {
-					delete(p.Config, k)
-				}
-			}
-		}
+	content.Routes = make([]file.FRoute, len(state.Routes))
+	for i, r := range state.Routes {
+		r.Plugins = pluginsWithoutNulls(r.Plugins)
+		content.Routes[i] = r
+	}
+
+	content.Consumers = make([]file.FConsumer, len(state.Consumers))
+	for i, c := range state.Consumers {
+		c.Plugins = pluginsWithoutNulls(c.Plugins)
+		content.Consumers[i] = c
+	}
+
+	content.Plugins = make([]file.FPlugin, len(state.Plugins))
+	for i, p := range state.Plugins {
+		p.Config = configWithoutNulls(p.Config)
+		content.Plugins[i] = p
 	}
+	return &content
+}
 
-	for _, p := range state.Plugins {
-		for k, v := range p.Config {
-			if v == nil {
-				delete(p.Config, k)
-			}
+// pluginsWithoutNulls returns copies of the plugins whose configs have no keys with nil as their value.
+func pluginsWithoutNulls(plugins []*file.FPlugin) []*file.FPlugin {
+	if plugins == nil {
+		return nil
+	}
+	result := make([]*file.FPlugin, len(plugins))
+	for i, p := range plugins {
+		plugin := *p
+		plugin.Config = configWithoutNulls(p.Config)
+		result[i] = &plugin
+	}
+	return result
+}
+
+// configWithoutNulls returns a copy of the plugin config without the keys with nil as their value.
+func configWithoutNulls(config kong.Configuration) kong.Configuration {
+	if config == nil {
+		return nil
+	}
+	result := make(kong.Configuration, len(config))
+	for k, v := range config {
+		if v != nil {
+			result[k] = v
 		}
 	}
+	return result
 }
 
 // GetFCertificateFromKongCert converts a kong.Certificate to a file.FCertificate.
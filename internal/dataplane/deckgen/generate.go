@@ -9,8 +9,10 @@ import (
 	"github.com/kong/deck/file"
 	"github.com/kong/go-kong/kong"
 	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/attribute"
 
 	"github.com/kong/kubernetes-ingress-controller/v2/internal/dataplane/kongstate"
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/tracing"
 	"github.com/kong/kubernetes-ingress-controller/v2/internal/util"
 )
 
@@ -23,16 +25,22 @@ func ToDeckContent(
 	selectorTags []string,
 	formatVersion string,
 ) *file.Content {
+	ctx, span := tracing.Start(ctx, "deckgen.ToDeckContent")
+	defer span.End()
+
 	var content file.Content
 	content.FormatVersion = formatVersion
 	var err error
 
 	for _, s := range k8sState.Services {
 		service := file.FService{Service: s.Service}
+		serviceTags := util.GenerateTagsForObject(s.Parent)
+		service.Tags = appendTags(service.Tags, serviceTags)
 		for _, p := range s.Plugins {
 			plugin := file.FPlugin{
 				Plugin: *p.DeepCopy(),
 			}
+			plugin.Tags = appendTags(plugin.Tags, serviceTags)
 			err = fillPlugin(ctx, &plugin, schemas)
 			if err != nil {
 				log.Errorf("failed to fill-in defaults for plugin: %s", *plugin.Name)
@@ -46,11 +54,14 @@ func ToDeckContent(
 		for _, r := range s.Routes {
 			route := file.FRoute{Route: r.Route}
 			fillRoute(&route.Route)
+			routeTags := r.Ingress.Tags()
+			route.Tags = appendTags(route.Tags, routeTags)
 
 			for _, p := range r.Plugins {
 				plugin := file.FPlugin{
 					Plugin: *p.DeepCopy(),
 				}
+				plugin.Tags = appendTags(plugin.Tags, routeTags)
 				err = fillPlugin(ctx, &plugin, schemas)
 				if err != nil {
 					log.Errorf("failed to fill-in defaults for plugin: %s", *plugin.Name)
@@ -71,10 +82,11 @@ func ToDeckContent(
 		return strings.Compare(*content.Services[i].Name, *content.Services[j].Name) > 0
 	})
 
-	for _, plugin := range k8sState.Plugins {
+	for _, p := range k8sState.Plugins {
 		plugin := file.FPlugin{
-			Plugin: plugin.Plugin,
+			Plugin: *p.Plugin.DeepCopy(),
 		}
+		plugin.Tags = appendTags(plugin.Tags, util.GenerateTagsForObject(p.K8sParent))
 		err = fillPlugin(ctx, &plugin, schemas)
 		if err != nil {
 			log.Errorf("failed to fill-in defaults for plugin: %s", *plugin.Name)
@@ -89,8 +101,11 @@ func ToDeckContent(
 	for _, u := range k8sState.Upstreams {
 		fillUpstream(&u.Upstream)
 		upstream := file.FUpstream{Upstream: u.Upstream}
+		upstreamTags := util.GenerateTagsForObject(u.Service.Parent)
+		upstream.Tags = appendTags(upstream.Tags, upstreamTags)
 		for _, t := range u.Targets {
 			target := file.FTarget{Target: t.Target}
+			target.Tags = appendTags(target.Tags, upstreamTags)
 			upstream.Targets = append(upstream.Targets, &target)
 		}
 		sort.SliceStable(upstream.Targets, func(i, j int) bool {
@@ -120,6 +135,7 @@ func ToDeckContent(
 
 	for _, c := range k8sState.Consumers {
 		consumer := file.FConsumer{Consumer: c.Consumer}
+		consumer.Tags = appendTags(consumer.Tags, util.GenerateTagsForObject(&c.K8sKongConsumer))
 
 		// if a consumer with no username is provided deck wont be able to process it, but we shouldn't
 		// fail the rest of the deckgen either or this will result in one bad consumer being capable of
@@ -165,9 +181,28 @@ func ToDeckContent(
 		}
 	}
 
+	span.SetAttributes(
+		attribute.Int("deck.services", len(content.Services)),
+		attribute.Int("deck.upstreams", len(content.Upstreams)),
+		attribute.Int("deck.plugins", len(content.Plugins)),
+		attribute.Int("deck.certificates", len(content.Certificates)),
+		attribute.Int("deck.ca_certificates", len(content.CACertificates)),
+		attribute.Int("deck.consumers", len(content.Consumers)),
+	)
 	return &content
 }
 
+// appendTags returns a new slice with the provided tags appended to the existing ones. A new slice is always
+// allocated so that entities copied from the Kong state never share tags with the state itself.
+func appendTags(existing []*string, tags []*string) []*string {
+	if len(tags) == 0 {
+		return existing
+	}
+	result := make([]*string, 0, len(existing)+len(tags))
+	result = append(result, existing...)
+	return append(result, tags...)
+}
+
 func fillRoute(route *kong.Route) {
 	if route.HTTPSRedirectStatusCode == nil {
 		route.HTTPSRedirectStatusCode = kong.Int(426)
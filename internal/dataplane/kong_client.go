@@ -2,9 +2,12 @@ package dataplane
 
 import (
 	"context"
+	"encoding/hex"
+	"errors"
 	"fmt"
 	"reflect"
 	"sort"
+	"strings"
 	"sync"
 	"time"
 
@@ -13,13 +16,16 @@ import (
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/samber/lo"
 	"github.com/sirupsen/logrus"
-	"github.com/sourcegraph/conc/iter"
+	"go.opentelemetry.io/otel/attribute"
+	"go.uber.org/multierr"
 	"golang.org/x/exp/slices"
 	"golang.org/x/sync/errgroup"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/adminapi"
 	"github.com/kong/kubernetes-ingress-controller/v2/internal/dataplane/deckgen"
 	"github.com/kong/kubernetes-ingress-controller/v2/internal/dataplane/failures"
 	"github.com/kong/kubernetes-ingress-controller/v2/internal/dataplane/kongstate"
@@ -27,6 +33,7 @@ import (
 	"github.com/kong/kubernetes-ingress-controller/v2/internal/dataplane/sendconfig"
 	"github.com/kong/kubernetes-ingress-controller/v2/internal/metrics"
 	"github.com/kong/kubernetes-ingress-controller/v2/internal/store"
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/tracing"
 	"github.com/kong/kubernetes-ingress-controller/v2/internal/util"
 	k8sobj "github.com/kong/kubernetes-ingress-controller/v2/internal/util/kubernetes/object"
 	"github.com/kong/kubernetes-ingress-controller/v2/internal/util/kubernetes/object/status"
@@ -65,6 +72,18 @@ type KongClient struct {
 	// the newer logic which combines them.
 	enableCombinedServiceRoutes bool
 
+	// enableExpressionRoutes indicates that Kong runs the expressions router, so
+	// HTTPRoutes are translated into routes matching requests with expressions.
+	enableExpressionRoutes bool
+
+	// enablePreFunctionFilters indicates that Kong runs untrusted Lua code, so the
+	// HTTPRoute filters which Kong has no plugin for are translated into pre-function plugins.
+	enablePreFunctionFilters bool
+
+	// enableRequestMirrorFilters indicates that the untrusted Lua code run by Kong may
+	// send requests in the background, so HTTPRoute RequestMirror filters are translated.
+	enableRequestMirrorFilters bool
+
 	// skipCACertificates disables CA certificates, to avoid fighting over configuration in multi-workspace
 	// environments. See https://github.com/Kong/deck/pull/617
 	skipCACertificates bool
@@ -80,6 +99,11 @@ type KongClient struct {
 	// kongConfig is the client configuration for the Kong Admin API
 	kongConfig sendconfig.Kong
 
+	// managedGateways are the Gateways served by their own Kong instances, along with
+	// the clients of their admin APIs. A Gateway which Kong instance isn't ready yet
+	// has a nil client.
+	managedGateways map[types.NamespacedName]*sendconfig.ClientWithPluginStore
+
 	// dbmode indicates the current database mode of the backend Kong Admin API
 	dbmode string
 
@@ -123,8 +147,41 @@ type KongClient struct {
 	// eventRecorder is used to record warning events for resource failures.
 	eventRecorder record.EventRecorder
 
+	// translationCache keeps the translations of the Kubernetes objects across updates,
+	// so that only the objects which have changed are translated. It's nil unless enabled.
+	translationCache *parser.TranslationCache
+
+	// dryRun indicates whether the configuration is never applied, but only the changes
+	// it would make to the data-plane are computed.
+	dryRun bool
+
 	// SHAs is a slice is configuration hashes send in last batch send.
 	SHAs []string
+
+	// lastValidConfig is the most recent configuration successfully applied to
+	// any of the data-plane instances. It is used to configure instances which
+	// have no configuration when the current one can't be applied.
+	lastValidConfig *file.Content
+
+	// lastValidConfigLock is a mutex for thread-safety of lastValidConfig, as
+	// configuration is sent to the data-plane instances concurrently.
+	lastValidConfigLock sync.RWMutex
+
+	// readinessQuorum is the fraction of the data-plane instances which have to
+	// be configured for a configuration update to be considered successful.
+	readinessQuorum float64
+
+	// clientsStatus is the state of the configuration updates of each of the
+	// data-plane instances, as of the most recent update.
+	clientsStatus []util.KongInstanceStatus
+
+	// clientsStatusLock is a mutex for thread-safety of clientsStatus, which
+	// is read without waiting for the ongoing update to finish.
+	clientsStatusLock sync.RWMutex
+
+	// changes receives a value whenever objects in the configuration cache
+	// are updated or deleted.
+	changes chan struct{}
 }
 
 // NewKongClient provides a new KongClient object after connecting to the
@@ -154,6 +211,8 @@ func NewKongClient(
 		kongConfig:         kongConfig,
 		eventRecorder:      eventRecorder,
 		dbmode:             dbMode,
+		readinessQuorum:    DefaultReadinessQuorum,
+		changes:            make(chan struct{}, 1),
 	}
 
 	return c, nil
@@ -169,7 +228,11 @@ func NewKongClient(
 func (c *KongClient) UpdateObject(obj client.Object) error {
 	// we do a deep copy of the object here so that the caller can continue to use
 	// the original object in a threadsafe manner.
-	return c.cache.Add(obj.DeepCopyObject())
+	if err := c.cache.Add(obj.DeepCopyObject()); err != nil {
+		return err
+	}
+	c.notifyChange()
+	return nil
 }
 
 // DeleteObject accepts a Kubernetes controller-runtime client.Object and removes it from the configuration cache.
@@ -179,7 +242,26 @@ func (c *KongClient) UpdateObject(obj client.Object) error {
 // under the hood the cache implementation will ignore deletions on objects
 // that are not present in the cache, so in those cases this is a no-op.
 func (c *KongClient) DeleteObject(obj client.Object) error {
-	return c.cache.Delete(obj)
+	if err := c.cache.Delete(obj); err != nil {
+		return err
+	}
+	c.notifyChange()
+	return nil
+}
+
+// Changes returns a channel which receives a value whenever objects in the configuration cache
+// are updated or deleted. Changes made while a previous one has not been received yet are coalesced.
+func (c *KongClient) Changes() <-chan struct{} {
+	return c.changes
+}
+
+// notifyChange notifies the receiver of Changes() about a change to the configuration cache
+// without blocking.
+func (c *KongClient) notifyChange() {
+	select {
+	case c.changes <- struct{}{}:
+	default:
+	}
 }
 
 // ObjectExists indicates whether or not any version of the provided object is already present in the proxy.
@@ -188,6 +270,84 @@ func (c *KongClient) ObjectExists(obj client.Object) (bool, error) {
 	return exists, err
 }
 
+// AdminAPIClients returns the Kong Admin API clients which configuration is sent to.
+func (c *KongClient) AdminAPIClients() []*adminapi.Client {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	return lo.Map(c.kongConfig.Clients, func(cl sendconfig.ClientWithPluginStore, _ int) *adminapi.Client {
+		return cl.Client
+	})
+}
+
+// SetAdminAPIClients replaces the Kong Admin API clients which configuration is sent to. Clients are
+// identified by their base URL, so the state of the clients which are already in use is preserved
+// (e.g. the hash of the last configuration they were sent). Newly added DB-less instances are
+// immediately sent the last valid configuration, so they can serve traffic without waiting for
+// the next update. They're sent it before being added, so that updates aren't blocked meanwhile.
+func (c *KongClient) SetAdminAPIClients(ctx context.Context, clients []*adminapi.Client) {
+	existing := make(map[string]struct{}, len(clients))
+	for _, cl := range c.AdminAPIClients() {
+		existing[cl.BaseRootURL()] = struct{}{}
+	}
+
+	added := make(map[string]sendconfig.ClientWithPluginStore)
+	for _, cl := range clients {
+		if _, ok := existing[cl.BaseRootURL()]; ok {
+			continue
+		}
+		added[cl.BaseRootURL()] = c.newClientWithPluginStore(ctx, cl)
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	current := make(map[string]sendconfig.ClientWithPluginStore, len(c.kongConfig.Clients))
+	for _, cl := range c.kongConfig.Clients {
+		current[cl.BaseRootURL()] = cl
+	}
+
+	updated := make([]sendconfig.ClientWithPluginStore, 0, len(clients))
+	for _, cl := range clients {
+		if currentClient, ok := current[cl.BaseRootURL()]; ok {
+			updated = append(updated, currentClient)
+			delete(current, cl.BaseRootURL())
+			continue
+		}
+		addedClient, ok := added[cl.BaseRootURL()]
+		if !ok {
+			// the client was removed concurrently after the current clients were listed.
+			addedClient = sendconfig.ClientWithPluginStore{Client: cl, PluginSchemaStore: util.NewPluginSchemaStore(cl.Client)}
+		}
+		c.logger.WithField("kong_url", cl.BaseRootURL()).Info("adding Kong Admin API client")
+		updated = append(updated, addedClient)
+	}
+	for url := range current {
+		c.logger.WithField("kong_url", url).Info("removing Kong Admin API client")
+	}
+	c.kongConfig.Clients = updated
+	c.publishClientsStatus()
+}
+
+// newClientWithPluginStore wraps a Kong Admin API client to be added to the clients configuration is sent
+// to. DB-less instances are sent the last valid configuration, instances backed by a database share their
+// configuration with the instances already in use.
+func (c *KongClient) newClientWithPluginStore(ctx context.Context, cl *adminapi.Client) sendconfig.ClientWithPluginStore {
+	client := sendconfig.ClientWithPluginStore{
+		Client:            cl,
+		PluginSchemaStore: util.NewPluginSchemaStore(cl.Client),
+	}
+
+	config := c.getLastValidConfig()
+	if config == nil || !c.kongConfig.InMemory {
+		return client
+	}
+	if err := c.applyLastValidConfig(ctx, &client, config); err != nil {
+		c.logger.WithError(err).WithField("kong_url", client.BaseRootURL()).
+			Error("failed to apply the last valid configuration to the added Kong Admin API client")
+	}
+	return client
+}
+
 // allEqual returns true if all provided objects are equal.
 func allEqual[T any](objs ...T) bool {
 	l := len(objs)
@@ -356,6 +516,75 @@ func (c *KongClient) AreCombinedServiceRoutesEnabled() bool {
 	return c.enableCombinedServiceRoutes
 }
 
+// EnableExpressionRoutes makes the Kong Dataplane client translate HTTPRoutes
+// into routes matching requests with expressions. It must only be enabled when
+// Kong runs the expressions router.
+func (c *KongClient) EnableExpressionRoutes() {
+	c.additionalFeaturesLock.Lock()
+	defer c.additionalFeaturesLock.Unlock()
+	c.enableExpressionRoutes = true
+}
+
+// AreExpressionRoutesEnabled determines whether HTTPRoutes are translated into
+// routes matching requests with expressions.
+func (c *KongClient) AreExpressionRoutesEnabled() bool {
+	c.additionalFeaturesLock.RLock()
+	defer c.additionalFeaturesLock.RUnlock()
+	return c.enableExpressionRoutes
+}
+
+// EnablePreFunctionFilters makes the Kong Dataplane client translate the HTTPRoute
+// filters which Kong has no plugin for into pre-function plugins. It must only be
+// enabled when Kong's untrusted_lua setting is not off.
+func (c *KongClient) EnablePreFunctionFilters() {
+	c.additionalFeaturesLock.Lock()
+	defer c.additionalFeaturesLock.Unlock()
+	c.enablePreFunctionFilters = true
+}
+
+// ArePreFunctionFiltersEnabled determines whether the HTTPRoute filters which Kong
+// has no plugin for are translated into pre-function plugins.
+func (c *KongClient) ArePreFunctionFiltersEnabled() bool {
+	c.additionalFeaturesLock.RLock()
+	defer c.additionalFeaturesLock.RUnlock()
+	return c.enablePreFunctionFilters
+}
+
+// EnableRequestMirrorFilters makes the Kong Dataplane client translate HTTPRoute
+// RequestMirror filters into pre-function plugins. It must only be enabled when the
+// untrusted Lua code run by Kong may require resty.http and access ngx.timer.
+func (c *KongClient) EnableRequestMirrorFilters() {
+	c.additionalFeaturesLock.Lock()
+	defer c.additionalFeaturesLock.Unlock()
+	c.enableRequestMirrorFilters = true
+}
+
+// AreRequestMirrorFiltersEnabled determines whether HTTPRoute RequestMirror filters
+// are translated into pre-function plugins.
+func (c *KongClient) AreRequestMirrorFiltersEnabled() bool {
+	c.additionalFeaturesLock.RLock()
+	defer c.additionalFeaturesLock.RUnlock()
+	return c.enableRequestMirrorFilters
+}
+
+// EnableTranslationCache makes the Kong Dataplane client translate only the
+// Kubernetes objects which have changed since the previous update, reusing the
+// translations of the unchanged ones.
+func (c *KongClient) EnableTranslationCache() {
+	c.additionalFeaturesLock.Lock()
+	defer c.additionalFeaturesLock.Unlock()
+	if c.translationCache == nil {
+		c.translationCache = parser.NewTranslationCache()
+	}
+}
+
+// getTranslationCache returns the translation cache of the client, or nil if it's not enabled.
+func (c *KongClient) getTranslationCache() *parser.TranslationCache {
+	c.additionalFeaturesLock.RLock()
+	defer c.additionalFeaturesLock.RUnlock()
+	return c.translationCache
+}
+
 // -----------------------------------------------------------------------------
 // Dataplane Client - Kong - Interface Implementation
 // -----------------------------------------------------------------------------
@@ -370,35 +599,33 @@ func (c *KongClient) DBMode() string {
 // Update parses the Cache present in the client and converts current
 // Kubernetes state into Kong objects and state, and then ships the
 // resulting configuration to the data-plane (Kong Admin API).
-func (c *KongClient) Update(ctx context.Context) error {
+func (c *KongClient) Update(ctx context.Context) (err error) {
 	c.lock.Lock()
 	defer c.lock.Unlock()
 
-	// build the kongstate object from the Kubernetes objects in the storer
-	storer := store.New(*c.cache, c.ingressClass, false, false, false, c.logger)
+	ctx, span := tracing.Start(ctx, "KongClient.Update")
+	defer func() {
+		tracing.RecordError(span, err)
+		span.End()
+	}()
 
-	// initialize a parser
+	// parse the Kubernetes objects from the cache into Kong configuration
 	c.logger.Debug("parsing kubernetes objects into data-plane configuration")
-
-	p, err := parser.NewParser(c.logger, storer)
+	p, formatVersion, err := c.newParser(*c.cache)
 	if err != nil {
-		return fmt.Errorf("failed to create parser: %w", err)
-	}
-
-	if c.AreKubernetesObjectReportsEnabled() {
-		p.EnableKubernetesObjectReports()
+		return err
 	}
-	if c.AreCombinedServiceRoutesEnabled() {
-		p.EnableCombinedServiceRoutes()
+	if scope, ok := c.sharedGatewayScope(); ok {
+		p.EnableGatewayScope(scope)
 	}
-	formatVersion := "1.1"
-	if versions.GetKongVersion().MajorMinorOnly().GTE(versions.ExplicitRegexPathVersionCutoff) {
-		p.EnableRegexPathPrefix()
-		formatVersion = "3.0"
+	if translationCache := c.getTranslationCache(); translationCache != nil {
+		translationCache.Invalidate(c.cache.TakeChanges())
+		p.EnableTranslationCache(translationCache)
 	}
-
-	// parse the Kubernetes objects from the storer into Kong configuration
+	_, buildSpan := tracing.Start(ctx, "parser.Build")
 	kongstate, translationFailures := p.Build()
+	buildSpan.SetAttributes(kongStateAttributes(kongstate, translationFailures)...)
+	buildSpan.End()
 	if failuresCount := len(translationFailures); failuresCount > 0 {
 		c.prometheusMetrics.TranslationCount.With(prometheus.Labels{
 			metrics.SuccessKey: metrics.SuccessFalse,
@@ -411,20 +638,58 @@ func (c *KongClient) Update(ctx context.Context) error {
 		}).Inc()
 		c.logger.Debug("successfully built data-plane configuration")
 	}
+	c.publishKubernetesObjectExplanations(p.GenerateKubernetesObjectExplanations())
 
-	shas, err := c.sendOutToClients(ctx, kongstate, formatVersion, c.kongConfig.FilterTags)
-	if err != nil {
+	if c.IsDryRunEnabled() {
+		return c.dryRunOnClients(ctx, kongstate, formatVersion, c.kongConfig.FilterTags)
+	}
+
+	managedGatewaysObjects, managedGatewaysFailures, managedGatewaysChanged := c.updateManagedGateways(ctx)
+
+	shas, applyFailures, err := c.sendOutToClients(ctx, kongstate, formatVersion, c.kongConfig.FilterTags)
+	if err != nil && len(applyFailures) == 0 {
+		c.seedUnconfiguredClients(ctx)
 		return err
 	}
+	if err == nil && len(applyFailures) > 0 {
+		// the readiness quorum of the instances applied the complete configuration, so it's not replaced
+		// with one built without the objects the other instances rejected: these keep their previous
+		// configuration until they accept the current one.
+		c.logger.Warnf("configuration was rejected by some of the Kong instances because of %d objects", len(applyFailures))
+		c.recordResourceFailureEvents(applyFailures, KongConfigurationApplyFailedEventReason)
+	}
+	if err != nil {
+		// the data-plane told us which objects caused the configuration to be rejected, so
+		// instead of leaving it with stale configuration, we try to apply the configuration
+		// generated without them.
+		fallback, fallbackErr := c.applyFallbackConfig(ctx, applyFailures)
+		if fallbackErr != nil {
+			c.logger.WithError(fallbackErr).Error("could not apply configuration without the objects that caused it to be rejected")
+			c.recordResourceFailureEvents(applyFailures, KongConfigurationApplyFailedEventReason)
+			if c.AreKubernetesObjectReportsEnabled() {
+				c.triggerKubernetesObjectFailureReport(p.GenerateKubernetesObjectReport(), applyFailures)
+			}
+			c.seedUnconfiguredClients(ctx)
+			return err
+		}
+
+		c.logger.Warnf(
+			"configuration was rejected, applied configuration without %d objects that caused it", len(fallback.excludedObjects),
+		)
+		c.recordResourceFailureEvents(fallback.exclusionFailures, KongConfigurationTranslationFailedEventReason)
+		p = fallback.parser
+		shas = fallback.previousSHAs
+		translationFailures = append(fallback.translationFailures, fallback.exclusionFailures...)
+	}
 
 	// report on configured Kubernetes objects if enabled
 	if c.AreKubernetesObjectReportsEnabled() {
 		// if the configuration SHAs that have just been pushed are different than
 		// what's been previously pushed.
-		if !slices.Equal(shas, c.SHAs) {
-			report := p.GenerateKubernetesObjectReport()
+		if !slices.Equal(shas, c.SHAs) || managedGatewaysChanged {
+			report := append(p.GenerateKubernetesObjectReport(), managedGatewaysObjects...)
 			c.logger.Debugf("triggering report for %d configured Kubernetes objects", len(report))
-			c.triggerKubernetesObjectReport(report, translationFailures)
+			c.triggerKubernetesObjectReport(report, append(translationFailures, managedGatewaysFailures...))
 		} else {
 			c.logger.Debug("no configuration change, skipping kubernetes object report")
 		}
@@ -432,24 +697,161 @@ func (c *KongClient) Update(ctx context.Context) error {
 	return nil
 }
 
+// kongStateAttributes returns the tracing attributes describing the provided state and the
+// translation failures which occurred when building it.
+func kongStateAttributes(s *kongstate.KongState, translationFailures []failures.ResourceFailure) []attribute.KeyValue {
+	var routes, targets int
+	for _, service := range s.Services {
+		routes += len(service.Routes)
+	}
+	for _, upstream := range s.Upstreams {
+		targets += len(upstream.Targets)
+	}
+	reasons := lo.Uniq(lo.Map(translationFailures, func(f failures.ResourceFailure, _ int) string {
+		return f.Message()
+	}))
+	sort.Strings(reasons)
+
+	attrs := []attribute.KeyValue{
+		attribute.Int("kong.services", len(s.Services)),
+		attribute.Int("kong.routes", routes),
+		attribute.Int("kong.upstreams", len(s.Upstreams)),
+		attribute.Int("kong.targets", targets),
+		attribute.Int("kong.plugins", len(s.Plugins)),
+		attribute.Int("kong.consumers", len(s.Consumers)),
+		attribute.Int("kong.certificates", len(s.Certificates)),
+		tracing.TranslationFailuresKey.Int(len(translationFailures)),
+	}
+	if len(reasons) > 0 {
+		attrs = append(attrs, tracing.TranslationFailureReasonsKey.StringSlice(reasons))
+	}
+	return attrs
+}
+
+// publishKubernetesObjectExplanations ships the explanations of the Kubernetes objects translated by the
+// last build to the diagnostic server, if it's enabled.
+func (c *KongClient) publishKubernetesObjectExplanations(explanations util.KubernetesObjectExplanations) {
+	if c.diagnostic.Explanations == nil {
+		return
+	}
+	select {
+	case c.diagnostic.Explanations <- explanations:
+		c.logger.Debug("shipping kubernetes object explanations to diagnostic server")
+	default:
+		c.logger.Error("kubernetes object explanations diagnostic buffer full, dropping explanations")
+	}
+}
+
+// newParser provides a parser of the Kubernetes objects in the provided cache, configured according to
+// the features enabled for the client, along with the format version of the configuration it builds.
+func (c *KongClient) newParser(cache store.CacheStores) (*parser.Parser, string, error) {
+	storer := store.New(cache, c.ingressClass, false, false, false, c.logger)
+
+	p, err := parser.NewParser(c.logger, storer)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to create parser: %w", err)
+	}
+
+	if c.AreKubernetesObjectReportsEnabled() {
+		p.EnableKubernetesObjectReports()
+	}
+	if c.AreCombinedServiceRoutesEnabled() {
+		p.EnableCombinedServiceRoutes()
+	}
+	if c.AreExpressionRoutesEnabled() {
+		p.EnableExpressionRoutes()
+		if versions.GetKongVersion().MajorMinorOnly().GTE(versions.QueryParamMatchVersionCutoff) {
+			p.EnableQueryParamMatches()
+		}
+	}
+	if c.ArePreFunctionFiltersEnabled() {
+		p.EnablePreFunctionFilters()
+	}
+	if c.AreRequestMirrorFiltersEnabled() {
+		p.EnableRequestMirrorFilters()
+	}
+	if c.diagnostic.Explanations != nil {
+		p.EnableKubernetesObjectExplanations()
+	}
+	if versions.GetKongVersion().MajorMinorOnly().GTE(versions.DualCertificateVersionCutoff) {
+		p.EnableDualCertificates()
+	}
+	formatVersion := "1.1"
+	if versions.GetKongVersion().MajorMinorOnly().GTE(versions.ExplicitRegexPathVersionCutoff) {
+		p.EnableRegexPathPrefix()
+		formatVersion = "3.0"
+	}
+
+	return p, formatVersion, nil
+}
+
 // sendOutToClients will generate deck content (config) from the provided kong state
-// and send it out to each of the configured clients.
+// and send it out to each of the configured clients. Clients are updated independently
+// of each other: a client failing doesn't prevent the others from being updated, and a
+// client which failed is not sent the same configuration again before its backoff elapses.
+// If any of the clients rejects the configuration and reports which Kubernetes objects
+// caused it, the failures of those objects are returned. An error is returned when the
+// configuration could not be applied to the readiness quorum of the clients.
 func (c *KongClient) sendOutToClients(
 	ctx context.Context, s *kongstate.KongState, formatVersion string, filterTags []string,
-) ([]string, error) {
-	shas, err := iter.MapErr(c.kongConfig.Clients, func(client *sendconfig.ClientWithPluginStore) (string, error) {
-		return c.sendToClient(ctx, client, s, formatVersion, filterTags)
-	},
+) ([]string, []failures.ResourceFailure, error) {
+	var (
+		wg   sync.WaitGroup
+		shas = make([]string, len(c.kongConfig.Clients))
+		errs = make([]error, len(c.kongConfig.Clients))
 	)
-	if err != nil {
-		return nil, err
+	for i := range c.kongConfig.Clients {
+		i := i
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			var config *file.Content
+			shas[i], config, errs[i] = c.sendToClient(ctx, &c.kongConfig.Clients[i], s, formatVersion, filterTags)
+			if errs[i] == nil {
+				c.setLastValidConfig(config)
+			}
+		}()
+	}
+	wg.Wait()
+
+	var (
+		applyFailures  []failures.ResourceFailure
+		configuredSHAs []string
+		clientErrs     []error
+	)
+	for i, err := range errs {
+		if err == nil {
+			configuredSHAs = append(configuredSHAs, shas[i])
+			continue
+		}
+		var updateErr sendconfig.UpdateError
+		if errors.As(err, &updateErr) {
+			applyFailures = append(applyFailures, updateErr.ResourceFailures()...)
+		}
+		clientErrs = append(clientErrs, err)
+	}
+	c.publishClientsStatus()
+
+	if quorum := c.readinessQuorumSize(); len(configuredSHAs) < quorum {
+		return nil, uniqueResourceFailures(applyFailures), fmt.Errorf(
+			"configuration applied to %d out of %d Kong instances, required at least %d: %w",
+			len(configuredSHAs), len(c.kongConfig.Clients), quorum, multierr.Combine(clientErrs...),
+		)
+	}
+	for _, err := range clientErrs {
+		if !errors.Is(err, errClientBackingOff) {
+			c.logger.WithError(err).Error("failed to apply configuration to one of the Kong instances")
+		}
 	}
 	previousSHAs := c.SHAs
 
-	sort.Strings(shas)
-	c.SHAs = shas
+	// instances which were not updated keep their previous configuration, so only the
+	// hashes of the configuration which was just applied are tracked.
+	configuredSHAs = lo.Uniq(configuredSHAs)
+	sort.Strings(configuredSHAs)
+	c.SHAs = configuredSHAs
 
-	return previousSHAs, nil
+	return previousSHAs, uniqueResourceFailures(applyFailures), nil
 }
 
 func (c *KongClient) sendToClient(
@@ -458,12 +860,26 @@ func (c *KongClient) sendToClient(
 	s *kongstate.KongState,
 	formatVersion string,
 	filterTags []string,
-) (string, error) {
+) (string, *file.Content, error) {
+	ctx, span := tracing.Start(ctx, "KongClient.sendToClient", tracing.KongURLKey.String(client.BaseRootURL()))
+	defer span.End()
+
 	var (
 		logger         = c.logger.WithField("kong_url", client.BaseRootURL())
 		sendDiagnostic = func(
 			log logrus.FieldLogger, failed bool, ch chan<- util.ConfigDump, diagnosticConfig *file.Content,
+			configSHA []byte, updateErr error,
 		) {
+			dump := util.ConfigDump{
+				Failed: failed,
+				Config: *diagnosticConfig,
+				Time:   time.Now(),
+				URL:    client.BaseRootURL(),
+				SHA:    hex.EncodeToString(configSHA),
+			}
+			if updateErr != nil {
+				dump.Error = updateErr.Error()
+			}
 			// Given that we can send multiple configs to this channel and
 			// the fact that the API that exposes that can only expose 1 config
 			// at a time it means that users utilizing the diagnostics API
@@ -471,7 +887,7 @@ func (c *KongClient) sendToClient(
 			// or successfully send configs might be covered by those send
 			// later on but we're OK with this limitation of said API.
 			select {
-			case ch <- util.ConfigDump{Failed: failed, Config: *diagnosticConfig}:
+			case ch <- dump:
 				log.Debug("shipping config to diagnostic server")
 			default:
 				log.Error("config diagnostic buffer full, dropping diagnostic config")
@@ -489,6 +905,17 @@ func (c *KongClient) sendToClient(
 		formatVersion,
 	)
 
+	// a client which failed is given some time to recover before it's sent the same configuration again.
+	if client.ConsecutiveFailures() > 0 {
+		configSHA, err := deckgen.GenerateSHA(targetConfig)
+		if err == nil && client.IsBackingOff(configSHA, time.Now()) {
+			logger.Debug("skipping configuration update, backing off after previous failures")
+			err := fmt.Errorf("updating %s: %w", client.BaseRootURL(), errClientBackingOff)
+			tracing.RecordError(span, err)
+			return "", nil, err
+		}
+	}
+
 	// generate diagnostic configuration if enabled
 	// "diagnostic" will be empty if --dump-config is not set
 	var diagnosticConfig *file.Content
@@ -528,20 +955,29 @@ func (c *KongClient) sendToClient(
 		if expired, ok := timedCtx.Deadline(); ok && time.Now().After(expired) {
 			logger.Warn("exceeded Kong API timeout, consider increasing --proxy-timeout-seconds")
 		}
+		err = fmt.Errorf("performing update for %s failed: %w", client.BaseRootURL(), err)
+		failedConfigSHA, shaErr := deckgen.GenerateSHA(targetConfig)
+		if shaErr != nil {
+			logger.WithError(shaErr).Debug("failed to generate the checksum of the configuration that failed to apply")
+		}
 		if c.diagnostic != (util.ConfigDumpDiagnostic{}) {
-			sendDiagnostic(logger, true, c.diagnostic.Configs, diagnosticConfig)
+			sendDiagnostic(logger, true, c.diagnostic.Configs, diagnosticConfig, failedConfigSHA, err)
 		}
-		return "", fmt.Errorf("performing update for %s failed: %w", client.BaseRootURL(), err)
+		client.RecordUpdateFailure(failedConfigSHA, err, time.Now(), clientBackoff(client.ConsecutiveFailures()+1))
+		span.SetAttributes(tracing.ConfigSHAKey.String(hex.EncodeToString(failedConfigSHA)))
+		tracing.RecordError(span, err)
+		return "", nil, err
 	}
 
 	if c.diagnostic != (util.ConfigDumpDiagnostic{}) {
-		sendDiagnostic(logger, false, c.diagnostic.Configs, diagnosticConfig)
+		sendDiagnostic(logger, false, c.diagnostic.Configs, diagnosticConfig, newConfigSHA, nil)
 	}
 
 	// update the lastConfigSHA with the new updated checksum
-	client.SetLastConfigSHA(newConfigSHA)
+	span.SetAttributes(tracing.ConfigSHAKey.String(hex.EncodeToString(newConfigSHA)))
+	client.RecordUpdateSuccess(newConfigSHA, time.Now())
 
-	return string(newConfigSHA), nil
+	return string(newConfigSHA), targetConfig, nil
 }
 
 // -----------------------------------------------------------------------------
@@ -580,17 +1016,58 @@ func (c *KongClient) triggerKubernetesObjectReport(reportedObjects []client.Obje
 	}
 }
 
+// triggerKubernetesObjectFailureReport marks the objects which caused a configuration to be rejected
+// by the data-plane as failed, and queues them for reconciliation so that their statuses can reflect it.
+// Objects which were not the cause of the rejection keep their previously reported status, as the
+// data-plane keeps serving the previously applied configuration.
+func (c *KongClient) triggerKubernetesObjectFailureReport(reportedObjects []client.Object, applyFailures []failures.ResourceFailure) {
+	// the causing objects are reconstructed from Kong entity tags, so they're replaced by
+	// their complete counterparts parsed in this round (e.g. to have the generation populated).
+	reportedObjectsByKey := make(map[string]client.Object, len(reportedObjects))
+	for _, obj := range reportedObjects {
+		reportedObjectsByKey[objectKey(obj)] = obj
+	}
+
+	var failedObjects []client.Object
+	c.kubernetesObjectReportLock.Lock()
+	for _, obj := range uniqueObjects(nil, applyFailures) {
+		if reportedObj, ok := reportedObjectsByKey[objectKey(obj)]; ok {
+			obj = reportedObj
+		}
+		c.kubernetesObjectReportsFilter.Insert(obj, false)
+		failedObjects = append(failedObjects, obj)
+	}
+	c.kubernetesObjectReportLock.Unlock()
+
+	for _, obj := range failedObjects {
+		c.kubernetesObjectStatusQueue.Publish(obj)
+	}
+}
+
 func uniqueObjects(reportedObjects []client.Object, resourceFailures []failures.ResourceFailure) []client.Object {
 	allCausingObjects := lo.FlatMap(resourceFailures, func(f failures.ResourceFailure, _ int) []client.Object {
 		return f.CausingObjects()
 	})
 	allObjects := append(reportedObjects, allCausingObjects...)
-	return lo.UniqBy(allObjects, func(obj client.Object) string {
-		return obj.GetObjectKind().GroupVersionKind().String() + "/" +
-			obj.GetNamespace() + "/" + obj.GetName()
+	return lo.UniqBy(allObjects, objectKey)
+}
+
+// uniqueResourceFailures de-duplicates resource failures reported by multiple data-plane
+// instances rejecting the same configuration.
+func uniqueResourceFailures(resourceFailures []failures.ResourceFailure) []failures.ResourceFailure {
+	return lo.UniqBy(resourceFailures, func(f failures.ResourceFailure) string {
+		keys := lo.Map(f.CausingObjects(), func(obj client.Object, _ int) string {
+			return objectKey(obj)
+		})
+		return strings.Join(keys, ",") + ":" + f.Message()
 	})
 }
 
+func objectKey(obj client.Object) string {
+	return obj.GetObjectKind().GroupVersionKind().String() + "/" +
+		obj.GetNamespace() + "/" + obj.GetName()
+}
+
 // updateKubernetesObjectReportFilter overrides the internal object set with
 // a new provided set.
 func (c *KongClient) updateKubernetesObjectReportFilter(set k8sobj.ConfigurationStatusSet) {
@@ -0,0 +1,151 @@
+package dataplane
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/multierr"
+
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/dataplane/deckgen"
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/dataplane/kongstate"
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/dataplane/sendconfig"
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/metrics"
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/util"
+)
+
+// -----------------------------------------------------------------------------
+// Dataplane Client - Kong - Dry Run
+// -----------------------------------------------------------------------------
+
+// EnableDryRun makes the Kong Dataplane client only compute the changes the
+// configuration would make to each of the data-plane instances, without ever
+// applying it. The changes are exposed through metrics and the diagnostic server.
+func (c *KongClient) EnableDryRun() {
+	c.additionalFeaturesLock.Lock()
+	defer c.additionalFeaturesLock.Unlock()
+	c.dryRun = true
+}
+
+// IsDryRunEnabled determines whether the Kong Dataplane client runs in dry-run mode.
+func (c *KongClient) IsDryRunEnabled() bool {
+	c.additionalFeaturesLock.RLock()
+	defer c.additionalFeaturesLock.RUnlock()
+	return c.dryRun
+}
+
+// dryRunOnClients computes the changes the configuration generated from the provided kong state would
+// make to each of the configured clients. An error is returned when the changes could not be computed
+// for the readiness quorum of the clients.
+func (c *KongClient) dryRunOnClients(
+	ctx context.Context, s *kongstate.KongState, formatVersion string, filterTags []string,
+) error {
+	var (
+		wg      sync.WaitGroup
+		reports = make([]util.KongInstanceDryRun, len(c.kongConfig.Clients))
+		errs    = make([]error, len(c.kongConfig.Clients))
+	)
+	for i := range c.kongConfig.Clients {
+		i := i
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			reports[i], errs[i] = c.dryRunOnClient(ctx, &c.kongConfig.Clients[i], s, formatVersion, filterTags)
+		}()
+	}
+	wg.Wait()
+	c.publishDryRuns(reports)
+
+	succeeded := 0
+	for _, err := range errs {
+		if err == nil {
+			succeeded++
+		}
+	}
+	if quorum := c.readinessQuorumSize(); succeeded < quorum {
+		return fmt.Errorf(
+			"dry run succeeded for %d out of %d Kong instances, required at least %d: %w",
+			succeeded, len(c.kongConfig.Clients), quorum, multierr.Combine(errs...),
+		)
+	}
+	for _, err := range errs {
+		if err != nil {
+			c.logger.WithError(err).Error("failed to compute configuration changes for one of the Kong instances")
+		}
+	}
+	return nil
+}
+
+func (c *KongClient) dryRunOnClient(
+	ctx context.Context,
+	client *sendconfig.ClientWithPluginStore,
+	s *kongstate.KongState,
+	formatVersion string,
+	filterTags []string,
+) (util.KongInstanceDryRun, error) {
+	logger := c.logger.WithField("kong_url", client.BaseRootURL())
+	report := util.KongInstanceDryRun{
+		URL:  client.BaseRootURL(),
+		Time: time.Now(),
+	}
+
+	targetConfig := deckgen.ToDeckContent(ctx,
+		logger,
+		s,
+		client.PluginSchemaStore,
+		filterTags,
+		formatVersion,
+	)
+
+	timedCtx, cancel := context.WithTimeout(ctx, c.requestTimeout)
+	defer cancel()
+	result, err := sendconfig.PerformDryRun(
+		timedCtx,
+		logger,
+		client.Client,
+		c.kongConfig.Version,
+		c.kongConfig.Concurrency,
+		c.kongConfig.InMemory,
+		c.skipCACertificates,
+		targetConfig,
+		filterTags,
+	)
+	report.ConfigSHA = hex.EncodeToString(result.ConfigSHA)
+	report.LiveConfigHash = result.LiveConfigHash
+	report.PayloadSize = len(result.Payload)
+	report.Changes = result.Changes
+	if err != nil {
+		err = fmt.Errorf("performing dry run for %s failed: %w", client.BaseRootURL(), err)
+		report.Error = err.Error()
+		return report, err
+	}
+	return report, nil
+}
+
+// publishDryRuns exposes the changes the last configuration would make to each of the data-plane instances
+// through metrics and the diagnostic server.
+func (c *KongClient) publishDryRuns(reports []util.KongInstanceDryRun) {
+	c.prometheusMetrics.DryRunEntityChanges.Reset()
+	for _, report := range reports {
+		for _, change := range report.Changes {
+			c.prometheusMetrics.DryRunEntityChanges.With(prometheus.Labels{
+				metrics.InstanceURLKey: report.URL,
+				metrics.OperationKey:   change.Operation,
+				metrics.EntityKindKey:  change.Kind,
+			}).Inc()
+		}
+	}
+
+	if c.diagnostic.DryRuns == nil {
+		return
+	}
+	select {
+	case c.diagnostic.DryRuns <- reports:
+		c.logger.Debug("shipping dry run results to diagnostic server")
+	default:
+		c.logger.Error("dry run diagnostic buffer full, dropping dry run results")
+	}
+}
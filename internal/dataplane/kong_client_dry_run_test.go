@@ -0,0 +1,69 @@
+package dataplane
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/store"
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/util"
+)
+
+func TestKongClientDryRun(t *testing.T) {
+	fakeKong := &fakeDBLessKong{}
+	server := httptest.NewServer(fakeKong)
+	defer server.Close()
+
+	c, _ := newTestKongClient(t, server)
+	c.diagnostic = util.ConfigDumpDiagnostic{DryRuns: make(chan []util.KongInstanceDryRun, 1)}
+	c.kongConfig.Concurrency = 1
+	c.EnableDryRun()
+	cache, err := store.NewCacheStoresFromObjYAML([]byte(`---
+apiVersion: v1
+kind: Service
+metadata:
+  name: httpbin
+  namespace: default
+spec:
+  ports:
+  - port: 80
+`), []byte(`---
+apiVersion: networking.k8s.io/v1
+kind: Ingress
+metadata:
+  name: httpbin
+  namespace: default
+  annotations:
+    kubernetes.io/ingress.class: kong
+spec:
+  defaultBackend:
+    service:
+      name: httpbin
+      port:
+        number: 80
+`))
+	require.NoError(t, err)
+	c.cache = &cache
+
+	require.NoError(t, c.Update(context.Background()))
+
+	t.Log("verifying that the configuration is not applied")
+	assert.Zero(t, fakeKong.configAttempts())
+	assert.Nil(t, c.getLastValidConfig())
+
+	t.Log("verifying that the changes the configuration would make are reported")
+	reports := <-c.diagnostic.DryRuns
+	require.Len(t, reports, 1)
+	assert.Equal(t, server.URL, reports[0].URL)
+	assert.Empty(t, reports[0].Error)
+	assert.NotEmpty(t, reports[0].ConfigSHA)
+	assert.Positive(t, reports[0].PayloadSize)
+	assert.Contains(t, reports[0].Changes, util.KongEntityChange{
+		Operation: "create",
+		Kind:      "service",
+		Entity:    "default.httpbin.80",
+	})
+}
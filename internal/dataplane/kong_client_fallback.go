@@ -0,0 +1,215 @@
+package dataplane
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/kong/deck/file"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/dataplane/failures"
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/dataplane/parser"
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/dataplane/sendconfig"
+)
+
+// maxFallbackAttempts is the maximum number of times objects which caused a configuration
+// to be rejected are excluded from it during a single update. Kong might report further
+// problems only once those it has already reported are gone.
+const maxFallbackAttempts = 3
+
+// -----------------------------------------------------------------------------
+// Dataplane Client - Kong - Fallback Configuration
+// -----------------------------------------------------------------------------
+
+// fallbackConfig describes a configuration which was successfully applied to the
+// data-plane after excluding the objects which caused the data-plane to reject the
+// complete configuration.
+type fallbackConfig struct {
+	// parser is the parser the configuration was built with.
+	parser *parser.Parser
+
+	// previousSHAs are the configuration hashes that were sent before this configuration.
+	previousSHAs []string
+
+	// translationFailures are the failures that occurred when building the configuration.
+	translationFailures []failures.ResourceFailure
+
+	// excludedObjects are the objects which were left out of the configuration.
+	excludedObjects []client.Object
+
+	// exclusionFailures explain why the excluded objects were left out of the configuration.
+	exclusionFailures []failures.ResourceFailure
+}
+
+// applyFallbackConfig builds the configuration without the objects causing the provided apply failures
+// and sends it to the data-plane. It's meant to be used only when the complete configuration couldn't be
+// applied to the readiness quorum of the instances. In case the quorum isn't reached with the fallback
+// configuration either and the data-plane reports further objects causing it, those are excluded as well
+// and the process is repeated, up to maxFallbackAttempts times.
+func (c *KongClient) applyFallbackConfig(
+	ctx context.Context, applyFailures []failures.ResourceFailure,
+) (fallbackConfig, error) {
+	var excludedObjects []client.Object
+	for attempt := 1; attempt <= maxFallbackAttempts; attempt++ {
+		cache, leftOut, err := c.cache.CopyWithout(uniqueObjects(nil, applyFailures)...)
+		if err != nil {
+			return fallbackConfig{}, fmt.Errorf("failed to exclude objects from the configuration: %w", err)
+		}
+		if len(leftOut) == len(excludedObjects) {
+			return fallbackConfig{}, errors.New("objects that caused the configuration to be rejected are not present in the cache")
+		}
+		excludedObjects = leftOut
+
+		c.logger.Debugf("building configuration without %d objects that caused it to be rejected (attempt %d)", len(excludedObjects), attempt)
+		p, formatVersion, err := c.newParser(cache)
+		if err != nil {
+			return fallbackConfig{}, err
+		}
+		kongstate, translationFailures := p.Build()
+
+		shas, newApplyFailures, err := c.sendOutToClients(ctx, kongstate, formatVersion, c.kongConfig.FilterTags)
+		if err != nil && len(newApplyFailures) > 0 {
+			applyFailures = append(applyFailures, newApplyFailures...)
+			continue
+		}
+		if err != nil {
+			return fallbackConfig{}, err
+		}
+		return fallbackConfig{
+			parser:              p,
+			previousSHAs:        shas,
+			translationFailures: translationFailures,
+			excludedObjects:     excludedObjects,
+			exclusionFailures:   c.exclusionFailures(applyFailures, excludedObjects),
+		}, nil
+	}
+
+	return fallbackConfig{}, fmt.Errorf("configuration still rejected after excluding %d objects", len(excludedObjects))
+}
+
+// exclusionFailures turns the failures which caused the objects to be excluded from the configuration into
+// translation failures of the excluded objects. The causing objects of the apply failures are reconstructed
+// from Kong entity tags, so they're replaced by their complete counterparts. The objects which were excluded
+// because a rejected plugin is attached to them get a failure of their own.
+func (c *KongClient) exclusionFailures(
+	applyFailures []failures.ResourceFailure, excludedObjects []client.Object,
+) []failures.ResourceFailure {
+	excludedObjectsByKey := make(map[string]client.Object, len(excludedObjects))
+	for _, obj := range excludedObjects {
+		excludedObjectsByKey[objectKey(obj)] = obj
+	}
+
+	exclusionFailures := make([]failures.ResourceFailure, 0, len(applyFailures))
+	for _, applyFailure := range uniqueResourceFailures(applyFailures) {
+		causingObjects := make([]client.Object, 0, len(applyFailure.CausingObjects()))
+		for _, obj := range applyFailure.CausingObjects() {
+			if excludedObj, ok := excludedObjectsByKey[objectKey(obj)]; ok {
+				obj = excludedObj
+				delete(excludedObjectsByKey, objectKey(obj))
+			}
+			causingObjects = append(causingObjects, obj)
+		}
+
+		message := "object excluded from the configuration applied to Kong: " + applyFailure.Message()
+		exclusionFailure, err := failures.NewResourceFailure(message, causingObjects...)
+		if err != nil {
+			c.logger.WithError(err).Warn("failed to create resource failure for excluded objects")
+			continue
+		}
+		exclusionFailures = append(exclusionFailures, exclusionFailure)
+	}
+
+	for _, obj := range excludedObjects {
+		if _, ok := excludedObjectsByKey[objectKey(obj)]; !ok {
+			continue
+		}
+		message := "object excluded from the configuration applied to Kong: a plugin attached to it was rejected"
+		exclusionFailure, err := failures.NewResourceFailure(message, obj)
+		if err != nil {
+			c.logger.WithError(err).Warn("failed to create resource failure for excluded objects")
+			continue
+		}
+		exclusionFailures = append(exclusionFailures, exclusionFailure)
+	}
+	return exclusionFailures
+}
+
+// -----------------------------------------------------------------------------
+// Dataplane Client - Kong - Last Valid Configuration
+// -----------------------------------------------------------------------------
+
+// setLastValidConfig stores the configuration that was just successfully applied to the data-plane.
+func (c *KongClient) setLastValidConfig(config *file.Content) {
+	c.lastValidConfigLock.Lock()
+	defer c.lastValidConfigLock.Unlock()
+	c.lastValidConfig = config
+}
+
+// getLastValidConfig returns the configuration that was most recently successfully applied to the data-plane.
+func (c *KongClient) getLastValidConfig() *file.Content {
+	c.lastValidConfigLock.RLock()
+	defer c.lastValidConfigLock.RUnlock()
+	return c.lastValidConfig
+}
+
+// seedUnconfiguredClients applies the last valid configuration to the DB-less data-plane instances which have no
+// configuration at all (e.g. because they have just been restarted), so they don't have to wait for the current
+// configuration to become valid before they can serve traffic.
+func (c *KongClient) seedUnconfiguredClients(ctx context.Context) {
+	config := c.getLastValidConfig()
+	if config == nil || !c.kongConfig.InMemory {
+		return
+	}
+
+	for i := range c.kongConfig.Clients {
+		client := &c.kongConfig.Clients[i]
+		logger := c.logger.WithField("kong_url", client.BaseRootURL())
+
+		configured, err := sendconfig.HasConfiguration(ctx, client.Client)
+		if err != nil {
+			logger.WithError(err).Error("failed to check whether Kong has been configured")
+			continue
+		}
+		if configured {
+			continue
+		}
+
+		logger.Info("Kong has no configuration, applying the last valid configuration")
+		if err := c.applyLastValidConfig(ctx, client, config); err != nil {
+			logger.WithError(err).Error("failed to apply the last valid configuration")
+		}
+	}
+}
+
+// applyLastValidConfig sends the provided last valid configuration to the data-plane instance.
+func (c *KongClient) applyLastValidConfig(
+	ctx context.Context, client *sendconfig.ClientWithPluginStore, config *file.Content,
+) error {
+	timedCtx, cancel := context.WithTimeout(ctx, c.requestTimeout)
+	defer cancel()
+	newConfigSHA, err := sendconfig.PerformUpdate(
+		timedCtx,
+		c.logger.WithField("kong_url", client.BaseRootURL()),
+		client.Client,
+		c.kongConfig.Version,
+		c.kongConfig.Concurrency,
+		c.kongConfig.InMemory,
+		c.enableReverseSync,
+		c.skipCACertificates,
+		config,
+		c.kongConfig.FilterTags,
+		nil,
+		c.prometheusMetrics,
+	)
+	if err != nil {
+		if expired, ok := timedCtx.Deadline(); ok && time.Now().After(expired) {
+			c.logger.Warn("exceeded Kong API timeout, consider increasing --proxy-timeout-seconds")
+		}
+		return fmt.Errorf("performing update for %s failed: %w", client.BaseRootURL(), err)
+	}
+
+	client.RecordUpdateSuccess(newConfigSHA, time.Now())
+	return nil
+}
@@ -0,0 +1,458 @@
+package dataplane
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/kong/go-kong/kong"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	netv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/adminapi"
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/dataplane/sendconfig"
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/metrics"
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/store"
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/util"
+)
+
+const rejectedPluginResponse = `{
+	"code": 14,
+	"name": "invalid declarative configuration",
+	"message": "declarative config is invalid: {}",
+	"flattened_errors": [
+		{
+			"entity_type": "plugin",
+			"entity_name": "key-auth",
+			"entity_tags": [
+				"k8s-name:broken",
+				"k8s-namespace:default",
+				"k8s-kind:KongPlugin",
+				"k8s-group:configuration.konghq.com",
+				"k8s-version:v1"
+			],
+			"errors": [
+				{"field": "config.key_names", "message": "expected a set", "type": "field"}
+			]
+		}
+	]
+}`
+
+// fakeDBLessKong is a minimal DB-less Kong Admin API which rejects configurations
+// containing entities generated for objects named "broken" (reporting the objects), and
+// fails to apply configurations containing entities generated for objects named "poison".
+// When unavailable, it fails to apply any configuration. When lenient, it accepts the
+// configurations containing entities generated for objects named "broken".
+type fakeDBLessKong struct {
+	lock        sync.Mutex
+	configured  bool
+	configs     []string
+	attempts    int
+	unavailable bool
+	lenient     bool
+}
+
+func (k *fakeDBLessKong) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	k.lock.Lock()
+	defer k.lock.Unlock()
+
+	switch {
+	case r.Method == http.MethodGet && strings.HasPrefix(r.URL.Path, "/schemas/plugins/"):
+		_, _ = w.Write([]byte(`{"fields": []}`))
+	case r.Method == http.MethodGet && r.URL.Path == "/status":
+		hash := "00000000000000000000000000000000"
+		if k.configured {
+			hash = "8f2c5d0a3c1b4e7f9a6d2b1c0e3f4a5b"
+		}
+		_, _ = w.Write([]byte(`{"configuration_hash": "` + hash + `"}`))
+	case r.Method == http.MethodPost && r.URL.Path == "/config":
+		body, _ := io.ReadAll(r.Body)
+		k.attempts++
+		switch {
+		case k.unavailable:
+			w.WriteHeader(http.StatusServiceUnavailable)
+			_, _ = w.Write([]byte(`{"message": "Service Unavailable"}`))
+		case strings.Contains(string(body), "k8s-name:broken") && !k.lenient:
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(rejectedPluginResponse))
+		case strings.Contains(string(body), "k8s-name:poison"):
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte(`{"message": "An unexpected error occurred"}`))
+		default:
+			k.configured = true
+			k.configs = append(k.configs, string(body))
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{}`))
+		}
+	case r.Method == http.MethodGet && strings.HasPrefix(r.URL.Path, "/schemas/"):
+		w.WriteHeader(http.StatusNotFound)
+	case r.Method == http.MethodGet:
+		// DB-less Kong serves the entities of its configuration, but none are tracked here.
+		_, _ = w.Write([]byte(`{"data": [], "next": null}`))
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func (k *fakeDBLessKong) appliedConfigs() []string {
+	k.lock.Lock()
+	defer k.lock.Unlock()
+	return append([]string{}, k.configs...)
+}
+
+func (k *fakeDBLessKong) configAttempts() int {
+	k.lock.Lock()
+	defer k.lock.Unlock()
+	return k.attempts
+}
+
+func (k *fakeDBLessKong) setUnavailable(unavailable bool) {
+	k.lock.Lock()
+	defer k.lock.Unlock()
+	k.unavailable = unavailable
+}
+
+func (k *fakeDBLessKong) restart() {
+	k.lock.Lock()
+	defer k.lock.Unlock()
+	k.configured = false
+}
+
+var (
+	testMetricsOnce sync.Once
+	testMetrics     *metrics.CtrlFuncMetrics
+)
+
+// newTestKongClient returns a KongClient sending configuration to the provided DB-less Kong.
+func newTestKongClient(t *testing.T, server *httptest.Server) (*KongClient, *record.FakeRecorder) {
+	// metrics are registered globally, so they can be created only once.
+	testMetricsOnce.Do(func() {
+		testMetrics = metrics.NewCtrlFuncMetrics()
+	})
+
+	eventRecorder := record.NewFakeRecorder(100)
+	c := &KongClient{
+		logger:            logrus.New(),
+		ingressClass:      "kong",
+		requestTimeout:    time.Second * 5,
+		prometheusMetrics: testMetrics,
+		eventRecorder:     eventRecorder,
+		kongConfig: sendconfig.Kong{
+			InMemory: true,
+		},
+	}
+	if server != nil {
+		c.kongConfig.Clients = []sendconfig.ClientWithPluginStore{newTestClientWithPluginStore(t, server)}
+	}
+	return c, eventRecorder
+}
+
+func newTestAdminAPIClient(t *testing.T, server *httptest.Server) *adminapi.Client {
+	kongClient, err := kong.NewClient(kong.String(server.URL), server.Client())
+	require.NoError(t, err)
+	return adminapi.NewClient(kongClient)
+}
+
+func newTestClientWithPluginStore(t *testing.T, server *httptest.Server) sendconfig.ClientWithPluginStore {
+	client := newTestAdminAPIClient(t, server)
+	return sendconfig.ClientWithPluginStore{
+		Client:            client,
+		PluginSchemaStore: util.NewPluginSchemaStore(client.Client),
+	}
+}
+
+// newFallbackTestCache returns a cache holding an Ingress which gets applied, and an Ingress
+// along with the KongPlugin attached to it which cause the configuration to be rejected.
+func newFallbackTestCache(t *testing.T) *store.CacheStores {
+	cache, err := store.NewCacheStoresFromObjYAML(
+		[]byte(`---
+apiVersion: v1
+kind: Service
+metadata:
+  name: httpbin
+  namespace: default
+spec:
+  ports:
+  - port: 80
+`),
+		[]byte(`---
+apiVersion: networking.k8s.io/v1
+kind: Ingress
+metadata:
+  name: httpbin
+  namespace: default
+  annotations:
+    kubernetes.io/ingress.class: kong
+spec:
+  rules:
+  - http:
+      paths:
+      - path: /httpbin
+        pathType: Prefix
+        backend:
+          service:
+            name: httpbin
+            port:
+              number: 80
+`),
+		[]byte(`---
+apiVersion: networking.k8s.io/v1
+kind: Ingress
+metadata:
+  name: protected
+  namespace: default
+  annotations:
+    kubernetes.io/ingress.class: kong
+    konghq.com/plugins: broken
+spec:
+  rules:
+  - http:
+      paths:
+      - path: /protected
+        pathType: Prefix
+        backend:
+          service:
+            name: httpbin
+            port:
+              number: 80
+`),
+		[]byte(`---
+apiVersion: configuration.konghq.com/v1
+kind: KongPlugin
+metadata:
+  name: broken
+  namespace: default
+plugin: key-auth
+`),
+	)
+	require.NoError(t, err)
+	return &cache
+}
+
+func TestKongClientFallbackConfiguration(t *testing.T) {
+	fakeKong := &fakeDBLessKong{}
+	server := httptest.NewServer(fakeKong)
+	defer server.Close()
+
+	c, eventRecorder := newTestKongClient(t, server)
+	c.cache = newFallbackTestCache(t)
+
+	t.Log("verifying that a configuration without the objects causing it to be rejected gets applied")
+	require.NoError(t, c.Update(context.Background()))
+	configs := fakeKong.appliedConfigs()
+	require.Len(t, configs, 1)
+	assert.Contains(t, configs[0], "k8s-name:httpbin")
+	assert.NotContains(t, configs[0], "k8s-name:broken")
+	assert.NotContains(t, configs[0], "k8s-name:protected", "objects the rejected plugin is attached to must be excluded")
+	require.NotNil(t, c.getLastValidConfig())
+
+	t.Log("verifying that the excluded objects are reported as translation failures")
+	var events []string
+	for len(eventRecorder.Events) > 0 {
+		events = append(events, <-eventRecorder.Events)
+	}
+	require.Len(t, events, 2)
+	for _, event := range events {
+		assert.Contains(t, event, KongConfigurationTranslationFailedEventReason)
+	}
+	assert.Contains(t, strings.Join(events, "\n"), `object excluded from the configuration applied to Kong: invalid plugin "key-auth"`)
+	assert.Contains(t, strings.Join(events, "\n"), "object excluded from the configuration applied to Kong: a plugin attached to it was rejected")
+
+	t.Log("verifying that a restarted Kong gets the last valid configuration when the current one fails to apply")
+	require.NoError(t, c.cache.Add(&netv1.Ingress{
+		TypeMeta: metav1.TypeMeta{APIVersion: "networking.k8s.io/v1", Kind: "Ingress"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "poison",
+			Namespace:   "default",
+			Annotations: map[string]string{"kubernetes.io/ingress.class": "kong"},
+		},
+		Spec: netv1.IngressSpec{
+			DefaultBackend: &netv1.IngressBackend{
+				Service: &netv1.IngressServiceBackend{Name: "httpbin", Port: netv1.ServiceBackendPort{Number: 80}},
+			},
+		},
+	}))
+	fakeKong.restart()
+	require.Error(t, c.Update(context.Background()))
+	configs = fakeKong.appliedConfigs()
+	require.Len(t, configs, 2)
+	assert.Contains(t, configs[1], "k8s-name:httpbin")
+	assert.NotContains(t, configs[1], "k8s-name:poison")
+}
+
+func TestKongClientNoFallbackConfigurationWhenQuorumIsReached(t *testing.T) {
+	strictKong, lenientKong := &fakeDBLessKong{}, &fakeDBLessKong{lenient: true}
+	strictServer, lenientServer := httptest.NewServer(strictKong), httptest.NewServer(lenientKong)
+	defer strictServer.Close()
+	defer lenientServer.Close()
+
+	c, eventRecorder := newTestKongClient(t, strictServer)
+	c.kongConfig.Clients = append(c.kongConfig.Clients, newTestClientWithPluginStore(t, lenientServer))
+	c.SetReadinessQuorum(0.5)
+	c.cache = newFallbackTestCache(t)
+
+	t.Log("verifying that the complete configuration is kept when the quorum applied it")
+	require.NoError(t, c.Update(context.Background()))
+	require.Len(t, lenientKong.appliedConfigs(), 1, "the instances which applied the configuration must not be sent a fallback")
+	assert.Contains(t, lenientKong.appliedConfigs()[0], "k8s-name:broken")
+	assert.Empty(t, strictKong.appliedConfigs())
+	assert.Equal(t, 1, strictKong.configAttempts(), "the instances which rejected the configuration must not be sent a fallback")
+
+	t.Log("verifying that the objects rejected by the other instances are reported")
+	var events []string
+	for len(eventRecorder.Events) > 0 {
+		events = append(events, <-eventRecorder.Events)
+	}
+	require.NotEmpty(t, events)
+	for _, event := range events {
+		assert.Contains(t, event, KongConfigurationApplyFailedEventReason)
+	}
+}
+
+func TestKongClientSetAdminAPIClients(t *testing.T) {
+	fakeKongs := []*fakeDBLessKong{{}, {}, {}}
+	servers := make([]*httptest.Server, 0, len(fakeKongs))
+	for _, fakeKong := range fakeKongs {
+		server := httptest.NewServer(fakeKong)
+		defer server.Close()
+		servers = append(servers, server)
+	}
+
+	c, _ := newTestKongClient(t, servers[0])
+	cache, err := store.NewCacheStoresFromObjYAML([]byte(`---
+apiVersion: v1
+kind: Service
+metadata:
+  name: httpbin
+  namespace: default
+spec:
+  ports:
+  - port: 80
+`), []byte(`---
+apiVersion: networking.k8s.io/v1
+kind: Ingress
+metadata:
+  name: httpbin
+  namespace: default
+  annotations:
+    kubernetes.io/ingress.class: kong
+spec:
+  defaultBackend:
+    service:
+      name: httpbin
+      port:
+        number: 80
+`))
+	require.NoError(t, err)
+	c.cache = &cache
+
+	t.Log("verifying that clients added before any configuration was applied are not sent anything")
+	c.SetAdminAPIClients(context.Background(), []*adminapi.Client{
+		c.kongConfig.Clients[0].Client,
+		newTestAdminAPIClient(t, servers[1]),
+	})
+	require.Len(t, c.kongConfig.Clients, 2)
+	assert.Empty(t, fakeKongs[1].appliedConfigs())
+
+	require.NoError(t, c.Update(context.Background()))
+	require.Len(t, fakeKongs[0].appliedConfigs(), 1)
+	require.Len(t, fakeKongs[1].appliedConfigs(), 1)
+
+	t.Log("verifying that added clients are immediately sent the last valid configuration")
+	c.SetAdminAPIClients(context.Background(), []*adminapi.Client{
+		newTestAdminAPIClient(t, servers[1]),
+		newTestAdminAPIClient(t, servers[2]),
+	})
+	require.Len(t, c.kongConfig.Clients, 2)
+	assert.Equal(t, servers[1].URL, c.kongConfig.Clients[0].BaseRootURL())
+	assert.Equal(t, servers[2].URL, c.kongConfig.Clients[1].BaseRootURL())
+	assert.NotEmpty(t, c.kongConfig.Clients[0].LastConfigSHA(), "state of the clients in use should be preserved")
+	require.Len(t, fakeKongs[2].appliedConfigs(), 1)
+	assert.Equal(t, fakeKongs[1].appliedConfigs()[0], fakeKongs[2].appliedConfigs()[0])
+
+	t.Log("verifying that removed clients are not sent configuration anymore")
+	require.NoError(t, c.Update(context.Background()))
+	assert.Len(t, fakeKongs[0].appliedConfigs(), 1)
+}
+
+// TestKongClientConcurrentSeeding verifies that the last valid configuration can be sent to several Kong instances
+// at once, it's meant to be run with the race detector enabled.
+func TestKongClientConcurrentSeeding(t *testing.T) {
+	fakeKongs := []*fakeDBLessKong{{}, {}}
+	servers := make([]*httptest.Server, 0, len(fakeKongs))
+	for _, fakeKong := range fakeKongs {
+		server := httptest.NewServer(fakeKong)
+		defer server.Close()
+		servers = append(servers, server)
+	}
+
+	c, _ := newTestKongClient(t, servers[0])
+	c.kongConfig.FilterTags = []string{"managed-by-ingress-controller"}
+	c.cache = newFallbackTestCache(t)
+	require.NoError(t, c.Update(context.Background()))
+	require.NotNil(t, c.getLastValidConfig())
+	require.NotNil(t, c.getLastValidConfig().Info, "sending the configuration must not modify it")
+	lastValidConfig, err := json.Marshal(c.getLastValidConfig())
+	require.NoError(t, err)
+
+	t.Log("making the current configuration fail to apply, so the unconfigured instances get the last valid one")
+	require.NoError(t, c.cache.Add(&netv1.Ingress{
+		TypeMeta: metav1.TypeMeta{APIVersion: "networking.k8s.io/v1", Kind: "Ingress"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "poison",
+			Namespace:   "default",
+			Annotations: map[string]string{"kubernetes.io/ingress.class": "kong"},
+		},
+		Spec: netv1.IngressSpec{
+			DefaultBackend: &netv1.IngressBackend{
+				Service: &netv1.IngressServiceBackend{Name: "httpbin", Port: netv1.ServiceBackendPort{Number: 80}},
+			},
+		},
+	}))
+
+	t.Log("verifying that seeding the added instances and updating the existing ones can happen at the same time")
+	for i := 0; i < 5; i++ {
+		for _, fakeKong := range fakeKongs {
+			fakeKong.restart()
+		}
+		var wg sync.WaitGroup
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			assert.Error(t, c.Update(context.Background()))
+		}()
+		go func() {
+			defer wg.Done()
+			c.SetAdminAPIClients(context.Background(), []*adminapi.Client{
+				newTestAdminAPIClient(t, servers[0]),
+				newTestAdminAPIClient(t, servers[1]),
+			})
+		}()
+		wg.Wait()
+		c.SetAdminAPIClients(context.Background(), []*adminapi.Client{newTestAdminAPIClient(t, servers[0])})
+	}
+
+	for _, fakeKong := range fakeKongs {
+		configs := fakeKong.appliedConfigs()
+		require.NotEmpty(t, configs)
+		for _, config := range configs {
+			assert.NotContains(t, config, "k8s-name:poison")
+		}
+	}
+	assert.Len(t, fakeKongs[1].appliedConfigs(), 5, "the added instance should be seeded each time it's added")
+
+	t.Log("verifying that sending the last valid configuration doesn't modify it")
+	sentConfig, err := json.Marshal(c.getLastValidConfig())
+	require.NoError(t, err)
+	assert.JSONEq(t, string(lastValidConfig), string(sentConfig))
+}
@@ -0,0 +1,114 @@
+package dataplane
+
+import (
+	"errors"
+	"math"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/metrics"
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/util"
+)
+
+const (
+	// DefaultReadinessQuorum is the default fraction of the data-plane instances which have to be configured
+	// for a configuration update to be considered successful.
+	DefaultReadinessQuorum = 1.0
+
+	// clientBackoffBase is the time a data-plane instance is not sent the configuration it failed with
+	// after its first failure. It doubles with each consecutive failure, up to clientBackoffMax.
+	clientBackoffBase = time.Second
+
+	// clientBackoffMax is the maximum time a data-plane instance is not sent the configuration it failed with.
+	clientBackoffMax = time.Minute
+)
+
+// errClientBackingOff is returned for the data-plane instances which were not sent the configuration because
+// they failed to apply it recently.
+var errClientBackingOff = errors.New("backing off after previous failures")
+
+// -----------------------------------------------------------------------------
+// Dataplane Client - Kong - Instances
+// -----------------------------------------------------------------------------
+
+// SetReadinessQuorum sets the fraction (between 0 and 1) of the data-plane instances which have to be configured
+// for a configuration update to be considered successful. At least one instance is always required.
+func (c *KongClient) SetReadinessQuorum(quorum float64) {
+	c.additionalFeaturesLock.Lock()
+	defer c.additionalFeaturesLock.Unlock()
+	c.readinessQuorum = quorum
+}
+
+// readinessQuorumSize returns the number of the data-plane instances which have to be configured for
+// a configuration update to be considered successful.
+func (c *KongClient) readinessQuorumSize() int {
+	c.additionalFeaturesLock.RLock()
+	quorum := c.readinessQuorum
+	c.additionalFeaturesLock.RUnlock()
+
+	clients := len(c.kongConfig.Clients)
+	if clients == 0 {
+		return 0
+	}
+	size := int(math.Ceil(quorum * float64(clients)))
+	if size < 1 {
+		return 1
+	}
+	if size > clients {
+		return clients
+	}
+	return size
+}
+
+// KongInstancesStatus returns the state of the configuration updates of each of the data-plane instances.
+func (c *KongClient) KongInstancesStatus() []util.KongInstanceStatus {
+	c.clientsStatusLock.RLock()
+	defer c.clientsStatusLock.RUnlock()
+	return append([]util.KongInstanceStatus{}, c.clientsStatus...)
+}
+
+// publishClientsStatus captures the state of the configuration updates of each of the data-plane instances
+// and exposes it through metrics and the diagnostic server.
+func (c *KongClient) publishClientsStatus() {
+	statuses := make([]util.KongInstanceStatus, 0, len(c.kongConfig.Clients))
+	for i := range c.kongConfig.Clients {
+		statuses = append(statuses, c.kongConfig.Clients[i].Status())
+	}
+
+	c.clientsStatusLock.Lock()
+	c.clientsStatus = statuses
+	c.clientsStatusLock.Unlock()
+
+	// the metrics are reset so that the instances which are gone are not reported anymore.
+	c.prometheusMetrics.InstanceConsecutiveFailures.Reset()
+	c.prometheusMetrics.InstanceLastSuccess.Reset()
+	for _, status := range statuses {
+		labels := prometheus.Labels{metrics.InstanceURLKey: status.URL}
+		c.prometheusMetrics.InstanceConsecutiveFailures.With(labels).Set(float64(status.ConsecutiveFailures))
+		if status.LastSuccess != nil {
+			c.prometheusMetrics.InstanceLastSuccess.With(labels).Set(float64(status.LastSuccess.Unix()))
+		}
+	}
+
+	if c.diagnostic.InstancesStatus != nil {
+		select {
+		case c.diagnostic.InstancesStatus <- statuses:
+		default:
+			c.logger.Debug("instances status diagnostic buffer full, dropping diagnostic instances status")
+		}
+	}
+}
+
+// clientBackoff returns the time a data-plane instance is not sent the configuration it failed with
+// after the provided number of consecutive failures.
+func clientBackoff(consecutiveFailures int) time.Duration {
+	backoff := clientBackoffBase
+	for i := 1; i < consecutiveFailures && backoff < clientBackoffMax; i++ {
+		backoff *= 2
+	}
+	if backoff > clientBackoffMax {
+		return clientBackoffMax
+	}
+	return backoff
+}
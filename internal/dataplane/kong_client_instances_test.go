@@ -0,0 +1,138 @@
+package dataplane
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	netv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/dataplane/sendconfig"
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/store"
+)
+
+func TestKongClientIsolatesFailingInstances(t *testing.T) {
+	healthyKong, failingKong := &fakeDBLessKong{}, &fakeDBLessKong{unavailable: true}
+	healthyServer, failingServer := httptest.NewServer(healthyKong), httptest.NewServer(failingKong)
+	defer healthyServer.Close()
+	defer failingServer.Close()
+
+	c, _ := newTestKongClient(t, healthyServer)
+	c.kongConfig.Clients = append(c.kongConfig.Clients, newTestClientWithPluginStore(t, failingServer))
+	cache, err := store.NewCacheStoresFromObjYAML([]byte(`---
+apiVersion: networking.k8s.io/v1
+kind: Ingress
+metadata:
+  name: httpbin
+  namespace: default
+  annotations:
+    kubernetes.io/ingress.class: kong
+spec:
+  defaultBackend:
+    service:
+      name: httpbin
+      port:
+        number: 80
+`), []byte(`---
+apiVersion: v1
+kind: Service
+metadata:
+  name: httpbin
+  namespace: default
+spec:
+  ports:
+  - port: 80
+`))
+	require.NoError(t, err)
+	c.cache = &cache
+
+	t.Log("verifying that the update fails when the readiness quorum is not met, but the healthy instance is configured")
+	c.SetReadinessQuorum(1)
+	require.ErrorContains(t, c.Update(context.Background()), "configuration applied to 1 out of 2 Kong instances, required at least 2")
+	require.Len(t, healthyKong.appliedConfigs(), 1)
+
+	t.Log("verifying that the state of each instance is tracked")
+	statuses := c.KongInstancesStatus()
+	require.Len(t, statuses, 2)
+	assert.Equal(t, healthyServer.URL, statuses[0].URL)
+	assert.Zero(t, statuses[0].ConsecutiveFailures)
+	assert.NotEmpty(t, statuses[0].LastConfigSHA)
+	assert.NotNil(t, statuses[0].LastSuccess)
+	assert.Equal(t, failingServer.URL, statuses[1].URL)
+	assert.Equal(t, 1, statuses[1].ConsecutiveFailures)
+	assert.Contains(t, statuses[1].LastError, "503")
+	assert.NotNil(t, statuses[1].BackoffUntil)
+	assert.Nil(t, statuses[1].LastSuccess)
+
+	t.Log("verifying that the update succeeds when the readiness quorum is met")
+	attempts := failingKong.configAttempts()
+	c.SetReadinessQuorum(0.5)
+	require.NoError(t, c.Update(context.Background()))
+	assert.Len(t, c.SHAs, 1)
+
+	t.Log("verifying that the failing instance is not sent the same configuration while backing off")
+	assert.Equal(t, attempts, failingKong.configAttempts())
+
+	t.Log("verifying that the failing instance is sent a different configuration right away")
+	failingKong.setUnavailable(false)
+	require.NoError(t, c.cache.Add(&corev1.Service{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "Service"},
+		ObjectMeta: metav1.ObjectMeta{Name: "echo", Namespace: "default"},
+		Spec:       corev1.ServiceSpec{Ports: []corev1.ServicePort{{Port: 80}}},
+	}))
+	require.NoError(t, c.cache.Add(&netv1.Ingress{
+		TypeMeta: metav1.TypeMeta{APIVersion: "networking.k8s.io/v1", Kind: "Ingress"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "echo",
+			Namespace:   "default",
+			Annotations: map[string]string{"kubernetes.io/ingress.class": "kong"},
+		},
+		Spec: netv1.IngressSpec{
+			DefaultBackend: &netv1.IngressBackend{
+				Service: &netv1.IngressServiceBackend{Name: "echo", Port: netv1.ServiceBackendPort{Number: 80}},
+			},
+		},
+	}))
+	require.NoError(t, c.Update(context.Background()))
+	require.Len(t, failingKong.appliedConfigs(), 1)
+	assert.Equal(t, healthyKong.appliedConfigs()[1], failingKong.appliedConfigs()[0])
+	assert.Zero(t, c.KongInstancesStatus()[1].ConsecutiveFailures)
+}
+
+func TestKongClientReadinessQuorumSize(t *testing.T) {
+	testCases := []struct {
+		name     string
+		quorum   float64
+		clients  int
+		expected int
+	}{
+		{name: "all instances", quorum: 1, clients: 3, expected: 3},
+		{name: "majority of instances", quorum: 0.5, clients: 3, expected: 2},
+		{name: "at least one instance", quorum: 0, clients: 3, expected: 1},
+		{name: "no instances", quorum: 1, clients: 0, expected: 0},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			c := &KongClient{
+				readinessQuorum: tc.quorum,
+				kongConfig:      sendconfig.Kong{Clients: make([]sendconfig.ClientWithPluginStore, tc.clients)},
+			}
+			assert.Equal(t, tc.expected, c.readinessQuorumSize())
+		})
+	}
+}
+
+func TestClientBackoff(t *testing.T) {
+	assert.Equal(t, time.Second, clientBackoff(1))
+	assert.Equal(t, 2*time.Second, clientBackoff(2))
+	assert.Equal(t, 8*time.Second, clientBackoff(4))
+	assert.Equal(t, time.Minute, clientBackoff(10))
+	assert.Equal(t, time.Minute, clientBackoff(1000))
+}
@@ -0,0 +1,135 @@
+package dataplane
+
+import (
+	"context"
+	"errors"
+	"sort"
+
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/adminapi"
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/dataplane/failures"
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/dataplane/parser"
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/dataplane/sendconfig"
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/util"
+)
+
+// -----------------------------------------------------------------------------
+// Dataplane Client - Kong - Managed Gateways
+// -----------------------------------------------------------------------------
+
+// SetManagedGatewayAdminAPIClient registers a Gateway served by its own Kong instance rather than by the shared
+// ones, along with the client of the admin API of that instance. The Gateway and the routes attached only to it
+// are excluded from the configuration of the shared instances as soon as it's registered, even when the client
+// is nil because its Kong instance isn't ready yet. The state of the client of the Gateway is preserved as long
+// as the same client is registered again, a new client (e.g. with new credentials) is sent the configuration anew.
+func (c *KongClient) SetManagedGatewayAdminAPIClient(gateway types.NamespacedName, cl *adminapi.Client) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if c.managedGateways == nil {
+		c.managedGateways = make(map[types.NamespacedName]*sendconfig.ClientWithPluginStore)
+	}
+	if cl == nil {
+		c.managedGateways[gateway] = nil
+		return
+	}
+	if existing := c.managedGateways[gateway]; existing != nil && existing.Client == cl {
+		return
+	}
+	c.logger.WithField("gateway", gateway.String()).WithField("kong_url", cl.BaseRootURL()).
+		Info("adding Kong Admin API client for managed Gateway")
+	c.managedGateways[gateway] = &sendconfig.ClientWithPluginStore{
+		Client:            cl,
+		PluginSchemaStore: util.NewPluginSchemaStore(cl.Client),
+	}
+}
+
+// ManagedGatewayAdminAPIClient returns the client of the admin API of the Kong instance serving a managed
+// Gateway, if the Gateway is registered and its Kong instance is ready.
+func (c *KongClient) ManagedGatewayAdminAPIClient(gateway types.NamespacedName) (*adminapi.Client, bool) {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	cl := c.managedGateways[gateway]
+	if cl == nil {
+		return nil, false
+	}
+	return cl.Client, true
+}
+
+// DeleteManagedGateway unregisters a managed Gateway, so that its routes are served by the shared Kong
+// instances again.
+func (c *KongClient) DeleteManagedGateway(gateway types.NamespacedName) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if _, ok := c.managedGateways[gateway]; ok {
+		c.logger.WithField("gateway", gateway.String()).Info("removing managed Gateway")
+		delete(c.managedGateways, gateway)
+	}
+}
+
+// sharedGatewayScope returns the scope of the configuration of the shared Kong instances, which excludes the
+// managed Gateways. It returns false when there are no managed Gateways, so the scope isn't needed.
+func (c *KongClient) sharedGatewayScope() (parser.GatewayScope, bool) {
+	if len(c.managedGateways) == 0 {
+		return parser.GatewayScope{}, false
+	}
+	managed := sets.New[types.NamespacedName]()
+	for gateway := range c.managedGateways {
+		managed.Insert(gateway)
+	}
+	return parser.GatewayScope{ManagedGateways: managed}, true
+}
+
+// updateManagedGateways builds the configuration of each managed Gateway which Kong instance is ready and sends
+// it to that instance. The managed Gateways are updated independently of each other and of the shared instances:
+// failing to configure one of them is logged, but doesn't fail the update. It returns the Kubernetes objects which
+// were configured, the translation failures which occurred, and whether the configuration of any of the instances
+// changed.
+func (c *KongClient) updateManagedGateways(ctx context.Context) ([]client.Object, []failures.ResourceFailure, bool) {
+	gateways := make([]types.NamespacedName, 0, len(c.managedGateways))
+	for gateway, cl := range c.managedGateways {
+		if cl != nil {
+			gateways = append(gateways, gateway)
+		}
+	}
+	sort.Slice(gateways, func(i, j int) bool {
+		return gateways[i].String() < gateways[j].String()
+	})
+
+	var (
+		configuredObjects   []client.Object
+		translationFailures []failures.ResourceFailure
+		changed             bool
+	)
+	for _, gateway := range gateways {
+		gateway := gateway
+		logger := c.logger.WithField("gateway", gateway.String())
+		p, formatVersion, err := c.newParser(*c.cache)
+		if err != nil {
+			logger.WithError(err).Error("failed to build the configuration of the managed Gateway")
+			continue
+		}
+		p.EnableGatewayScope(parser.GatewayScope{Gateway: &gateway})
+		kongstate, gatewayFailures := p.Build()
+		if len(gatewayFailures) > 0 {
+			c.recordResourceFailureEvents(gatewayFailures, KongConfigurationTranslationFailedEventReason)
+			translationFailures = append(translationFailures, gatewayFailures...)
+		}
+
+		cl := c.managedGateways[gateway]
+		previousSHA := string(cl.LastConfigSHA())
+		sha, _, err := c.sendToClient(ctx, cl, kongstate, formatVersion, c.kongConfig.FilterTags)
+		if err != nil {
+			if !errors.Is(err, errClientBackingOff) {
+				logger.WithError(err).Error("failed to apply configuration to the Kong instance of the managed Gateway")
+			}
+			continue
+		}
+		changed = changed || sha != previousSHA
+		configuredObjects = append(configuredObjects, p.GenerateKubernetesObjectReport()...)
+	}
+	return configuredObjects, translationFailures, changed
+}
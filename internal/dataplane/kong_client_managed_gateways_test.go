@@ -0,0 +1,131 @@
+package dataplane
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	gatewayv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/store"
+)
+
+func TestKongClientManagedGateways(t *testing.T) {
+	sharedKong, managedKong := &fakeDBLessKong{}, &fakeDBLessKong{}
+	sharedServer, managedServer := httptest.NewServer(sharedKong), httptest.NewServer(managedKong)
+	defer sharedServer.Close()
+	defer managedServer.Close()
+
+	c, _ := newTestKongClient(t, sharedServer)
+	cache, err := store.NewCacheStoresFromObjYAML([]byte(`---
+apiVersion: networking.k8s.io/v1
+kind: Ingress
+metadata:
+  name: shared-ingress
+  namespace: default
+  annotations:
+    kubernetes.io/ingress.class: kong
+spec:
+  defaultBackend:
+    service:
+      name: httpbin
+      port:
+        number: 80
+`), []byte(`---
+apiVersion: v1
+kind: Service
+metadata:
+  name: httpbin
+  namespace: default
+spec:
+  ports:
+  - port: 80
+`))
+	require.NoError(t, err)
+	require.NoError(t, cache.Add(&gatewayv1beta1.Gateway{
+		TypeMeta:   metav1.TypeMeta{APIVersion: gatewayv1beta1.GroupVersion.String(), Kind: "Gateway"},
+		ObjectMeta: metav1.ObjectMeta{Name: "managed", Namespace: "default"},
+		Spec: gatewayv1beta1.GatewaySpec{
+			GatewayClassName: "kong-managed",
+			Listeners: []gatewayv1beta1.Listener{
+				{Name: "http", Protocol: gatewayv1beta1.HTTPProtocolType, Port: 80},
+			},
+		},
+	}))
+	require.NoError(t, cache.Add(&gatewayv1beta1.HTTPRoute{
+		TypeMeta:   metav1.TypeMeta{APIVersion: gatewayv1beta1.GroupVersion.String(), Kind: "HTTPRoute"},
+		ObjectMeta: metav1.ObjectMeta{Name: "managed-route", Namespace: "default"},
+		Spec: gatewayv1beta1.HTTPRouteSpec{
+			CommonRouteSpec: gatewayv1beta1.CommonRouteSpec{
+				ParentRefs: []gatewayv1beta1.ParentReference{{Name: "managed"}},
+			},
+			Rules: []gatewayv1beta1.HTTPRouteRule{{
+				Matches: []gatewayv1beta1.HTTPRouteMatch{{
+					Path: &gatewayv1beta1.HTTPPathMatch{
+						Type:  lo.ToPtr(gatewayv1beta1.PathMatchPathPrefix),
+						Value: lo.ToPtr("/managed"),
+					},
+				}},
+				BackendRefs: []gatewayv1beta1.HTTPBackendRef{{
+					BackendRef: gatewayv1beta1.BackendRef{
+						BackendObjectReference: gatewayv1beta1.BackendObjectReference{
+							Name: "httpbin",
+							Port: lo.ToPtr(gatewayv1beta1.PortNumber(80)),
+						},
+					},
+				}},
+			}},
+		},
+	}))
+	c.cache = &cache
+	managed := types.NamespacedName{Namespace: "default", Name: "managed"}
+
+	t.Log("verifying that the routes of a gateway are sent to the shared instances as long as it isn't managed")
+	require.NoError(t, c.Update(context.Background()))
+	require.Len(t, sharedKong.appliedConfigs(), 1)
+	assert.Contains(t, sharedKong.appliedConfigs()[0], "shared-ingress")
+	assert.Contains(t, sharedKong.appliedConfigs()[0], "managed-route")
+
+	t.Log("verifying that the routes of a managed gateway are removed from the shared instances before its instance is ready")
+	c.SetManagedGatewayAdminAPIClient(managed, nil)
+	_, ok := c.ManagedGatewayAdminAPIClient(managed)
+	require.False(t, ok)
+	require.NoError(t, c.Update(context.Background()))
+	require.Len(t, sharedKong.appliedConfigs(), 2)
+	assert.Contains(t, sharedKong.appliedConfigs()[1], "shared-ingress")
+	assert.NotContains(t, sharedKong.appliedConfigs()[1], "managed-route")
+	assert.Empty(t, managedKong.appliedConfigs())
+
+	t.Log("verifying that only the routes of a managed gateway are sent to its instance once it's ready")
+	c.SetManagedGatewayAdminAPIClient(managed, newTestAdminAPIClient(t, managedServer))
+	cl, ok := c.ManagedGatewayAdminAPIClient(managed)
+	require.True(t, ok)
+	require.Equal(t, managedServer.URL, cl.BaseRootURL())
+	require.NoError(t, c.Update(context.Background()))
+	require.Len(t, managedKong.appliedConfigs(), 1)
+	assert.Contains(t, managedKong.appliedConfigs()[0], "managed-route")
+	assert.NotContains(t, managedKong.appliedConfigs()[0], "shared-ingress")
+
+	t.Log("verifying that the configuration is only sent again to a new client of the managed instance")
+	c.SetManagedGatewayAdminAPIClient(managed, cl)
+	require.NoError(t, c.Update(context.Background()))
+	require.Len(t, managedKong.appliedConfigs(), 1)
+	c.SetManagedGatewayAdminAPIClient(managed, newTestAdminAPIClient(t, managedServer))
+	require.NoError(t, c.Update(context.Background()))
+	require.Len(t, managedKong.appliedConfigs(), 2)
+
+	t.Log("verifying that a failing managed instance doesn't fail the update of the shared instances")
+	managedKong.setUnavailable(true)
+	require.NoError(t, c.Update(context.Background()))
+
+	t.Log("verifying that the routes of a gateway which is no longer managed are sent to the shared instances again")
+	c.DeleteManagedGateway(managed)
+	require.NoError(t, c.Update(context.Background()))
+	configs := sharedKong.appliedConfigs()
+	assert.Contains(t, configs[len(configs)-1], "managed-route")
+}
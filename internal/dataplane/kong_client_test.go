@@ -97,6 +97,33 @@ func TestUniqueObjects(t *testing.T) {
 	}
 }
 
+func TestUniqueResourceFailures(t *testing.T) {
+	ing1 := &netv1.Ingress{ObjectMeta: metav1.ObjectMeta{Namespace: corev1.NamespaceDefault, Name: "test-ingress-1"}}
+	ing1.SetGroupVersionKind(ingGVK)
+	ing2 := &netv1.Ingress{ObjectMeta: metav1.ObjectMeta{Namespace: corev1.NamespaceDefault, Name: "test-ingress-2"}}
+	ing2.SetGroupVersionKind(ingGVK)
+
+	newFailure := func(message string, objs ...client.Object) failures.ResourceFailure {
+		f, err := failures.NewResourceFailure(message, objs...)
+		require.NoError(t, err)
+		return f
+	}
+
+	t.Log("failures reported by multiple data-plane instances for the same objects are de-duplicated")
+	unique := uniqueResourceFailures([]failures.ResourceFailure{
+		newFailure("invalid route", ing1),
+		newFailure("invalid route", ing1),
+		newFailure("invalid route", ing2),
+		newFailure("invalid service", ing1),
+		newFailure("invalid route", ing1),
+	})
+	require.Len(t, unique, 3)
+	require.Equal(t, "invalid route", unique[0].Message())
+	require.Equal(t, []client.Object{ing1}, unique[0].CausingObjects())
+	require.Equal(t, []client.Object{ing2}, unique[1].CausingObjects())
+	require.Equal(t, "invalid service", unique[2].Message())
+}
+
 // initialized objects don't have GVK's, so we fake those for unit tests.
 var (
 	ingGVK = schema.GroupVersionKind{
@@ -8,8 +8,10 @@ import (
 	"github.com/blang/semver/v4"
 	"github.com/kong/go-kong/kong"
 	"github.com/sirupsen/logrus"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/kong/kubernetes-ingress-controller/v2/internal/annotations"
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/dataplane/failures"
 	"github.com/kong/kubernetes-ingress-controller/v2/internal/store"
 	"github.com/kong/kubernetes-ingress-controller/v2/internal/util"
 	"github.com/kong/kubernetes-ingress-controller/v2/internal/validation/consumers/credentials"
@@ -264,7 +266,10 @@ func buildPlugins(log logrus.FieldLogger, s store.Storer, pluginRels map[string]
 		}
 
 		for _, rel := range relations.GetCombinations() {
-			plugin := *plugin.DeepCopy()
+			plugin := Plugin{
+				Plugin:    *plugin.Plugin.DeepCopy(),
+				K8sParent: plugin.K8sParent,
+			}
 			// ID is populated because that is read by decK and in_memory
 			// translator too
 			if rel.Service != "" {
@@ -276,7 +281,7 @@ func buildPlugins(log logrus.FieldLogger, s store.Storer, pluginRels map[string]
 			if rel.Consumer != "" {
 				plugin.Consumer = &kong.Consumer{ID: kong.String(rel.Consumer)}
 			}
-			plugins = append(plugins, Plugin{plugin})
+			plugins = append(plugins, plugin)
 		}
 	}
 
@@ -315,6 +320,7 @@ func globalPlugins(log logrus.FieldLogger, s store.Storer) ([]Plugin, error) {
 	}
 	for i := 0; i < len(globalClusterPlugins); i++ {
 		k8sPlugin := *globalClusterPlugins[i]
+		k8sParent := globalClusterPlugins[i]
 		pluginName := k8sPlugin.PluginName
 		// empty pluginName skip it
 		if pluginName == "" {
@@ -332,7 +338,8 @@ func globalPlugins(log logrus.FieldLogger, s store.Storer) ([]Plugin, error) {
 		}
 		if plugin, err := kongPluginFromK8SClusterPlugin(s, k8sPlugin); err == nil {
 			res[pluginName] = Plugin{
-				Plugin: plugin,
+				Plugin:    plugin,
+				K8sParent: k8sParent,
 			}
 		} else {
 			log.WithFields(logrus.Fields{
@@ -350,6 +357,118 @@ func globalPlugins(log logrus.FieldLogger, s store.Storer) ([]Plugin, error) {
 	return plugins, nil
 }
 
-func (ks *KongState) FillPlugins(log logrus.FieldLogger, s store.Storer) {
-	ks.Plugins = buildPlugins(log, s, ks.getPluginRelations())
+func (ks *KongState) FillPlugins(
+	log logrus.FieldLogger,
+	s store.Storer,
+	failuresCollector *failures.ResourceFailuresCollector,
+) {
+	pluginRels := ks.resolvePluginPolicies(log, s, ks.getPluginRelations())
+	ks.Plugins = ks.mergeConflictingRoutePlugins(buildPlugins(log, s, pluginRels), failuresCollector)
+}
+
+// mergeConflictingRoutePlugins merges the KongPlugins attached to routes which already have a plugin of
+// the same type, generated when translating their parent objects (e.g. from HTTPRoute filters), with the
+// generated plugins: Kong accepts a single plugin of each type per route, and would reject the whole
+// configuration. The configuration of the generated plugin is merged into the KongPlugin's, which replaces
+// it on the route. KongPlugins whose configuration can't be merged with the generated one are dropped,
+// which is reported as a translation failure of the KongPlugins and the routes' parents.
+func (ks *KongState) mergeConflictingRoutePlugins(
+	plugins []Plugin,
+	failuresCollector *failures.ResourceFailuresCollector,
+) []Plugin {
+	type routeWithPlugins struct {
+		parent  client.Object
+		plugins map[string]kong.Plugin
+	}
+	routes := make(map[string]routeWithPlugins)
+	for _, service := range ks.Services {
+		for _, route := range service.Routes {
+			if len(route.Plugins) == 0 {
+				continue
+			}
+			r := routeWithPlugins{parent: service.Parent, plugins: make(map[string]kong.Plugin, len(route.Plugins))}
+			for _, plugin := range route.Plugins {
+				r.plugins[*plugin.Name] = plugin
+			}
+			routes[*route.Name] = r
+		}
+	}
+
+	// the plugins generated for each route which were merged into KongPlugins.
+	merged := make(map[string]map[string]struct{})
+	filtered := make([]Plugin, 0, len(plugins))
+	for _, plugin := range plugins {
+		// plugins attached to a consumer on a route don't conflict with the plugins of the route.
+		if plugin.Route == nil || plugin.Route.ID == nil || plugin.Name == nil || plugin.Consumer != nil {
+			filtered = append(filtered, plugin)
+			continue
+		}
+		route := routes[*plugin.Route.ID]
+		generated, conflict := route.plugins[*plugin.Name]
+		if !conflict {
+			filtered = append(filtered, plugin)
+			continue
+		}
+		// a disabled KongPlugin would disable the generated plugin too.
+		if plugin.Enabled != nil && !*plugin.Enabled {
+			continue
+		}
+
+		config, err := mergePluginConfigs(plugin.Config, generated.Config)
+		if err != nil {
+			// the failures of cluster-scoped objects (KongClusterPlugins) can't be collected.
+			var causingObjects []client.Object
+			if plugin.K8sParent.GetNamespace() != "" {
+				causingObjects = append(causingObjects, plugin.K8sParent)
+			}
+			if route.parent != nil {
+				causingObjects = append(causingObjects, route.parent)
+			}
+			failuresCollector.PushResourceFailure(fmt.Sprintf(
+				"route %s already has a %s plugin generated from its configuration (e.g. HTTPRoute filters), "+
+					"which the configuration of %s can't be merged with: %s",
+				*plugin.Route.ID, *plugin.Name, plugin.K8sParent.GetName(), err,
+			), causingObjects...)
+			continue
+		}
+		plugin.Config = config
+		filtered = append(filtered, plugin)
+		if merged[*plugin.Route.ID] == nil {
+			merged[*plugin.Route.ID] = make(map[string]struct{})
+		}
+		merged[*plugin.Route.ID][*plugin.Name] = struct{}{}
+	}
+
+	if len(merged) > 0 {
+		ks.removeRoutePlugins(merged)
+	}
+	return filtered
+}
+
+// removeRoutePlugins removes the plugins of the provided types, indexed by route name, from the routes.
+// The routes and their plugins are copied, as they may be shared with cached translations.
+func (ks *KongState) removeRoutePlugins(pluginsByRoute map[string]map[string]struct{}) {
+	for i, service := range ks.Services {
+		var routes []Route
+		for j, route := range service.Routes {
+			names, ok := pluginsByRoute[*route.Name]
+			if !ok {
+				continue
+			}
+			if routes == nil {
+				routes = make([]Route, len(service.Routes))
+				copy(routes, service.Routes)
+			}
+			routePlugins := make([]kong.Plugin, 0, len(route.Plugins))
+			for _, plugin := range route.Plugins {
+				if _, removed := names[*plugin.Name]; !removed {
+					routePlugins = append(routePlugins, plugin)
+				}
+			}
+			routes[j].Plugins = routePlugins
+		}
+		if routes != nil {
+			ks.Services[i].Routes = routes
+		}
+	}
 }
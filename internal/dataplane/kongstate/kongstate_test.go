@@ -8,10 +8,14 @@ import (
 	"github.com/kong/go-kong/kong"
 	"github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	gatewayv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
 
 	"github.com/kong/kubernetes-ingress-controller/v2/internal/annotations"
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/dataplane/failures"
 	"github.com/kong/kubernetes-ingress-controller/v2/internal/store"
 	"github.com/kong/kubernetes-ingress-controller/v2/internal/util"
 	configurationv1 "github.com/kong/kubernetes-ingress-controller/v2/pkg/apis/configuration/v1"
@@ -396,3 +400,133 @@ func TestFillConsumersAndCredentials(t *testing.T) {
 		assert.Equal(t, want.Consumers[0].Oauth2Creds[0].RedirectURIs, state.Consumers[0].Oauth2Creds[0].RedirectURIs)
 	})
 }
+
+func TestFillPlugins_RoutePluginConflicts(t *testing.T) {
+	kongPlugins := []*configurationv1.KongPlugin{
+		{
+			TypeMeta:   metav1.TypeMeta{APIVersion: configurationv1.GroupVersion.String(), Kind: "KongPlugin"},
+			ObjectMeta: metav1.ObjectMeta{Name: "add-header", Namespace: "default"},
+			PluginName: "request-transformer",
+			Config: apiextensionsv1.JSON{
+				Raw: []byte(`{"add":{"headers":["x-user:foo","x-both:baz"]},"http_method":"POST"}`),
+			},
+		},
+		{
+			TypeMeta:   metav1.TypeMeta{APIVersion: configurationv1.GroupVersion.String(), Kind: "KongPlugin"},
+			ObjectMeta: metav1.ObjectMeta{Name: "rate-limit", Namespace: "default"},
+			PluginName: "rate-limiting",
+		},
+		{
+			TypeMeta:   metav1.TypeMeta{APIVersion: configurationv1.GroupVersion.String(), Kind: "KongPlugin"},
+			ObjectMeta: metav1.ObjectMeta{Name: "disabled", Namespace: "default"},
+			PluginName: "request-transformer",
+			Disabled:   true,
+		},
+	}
+	s, err := store.NewFakeStore(store.FakeObjects{KongPlugins: kongPlugins})
+	require.NoError(t, err)
+
+	httpRoute := &gatewayv1beta1.HTTPRoute{
+		TypeMeta:   metav1.TypeMeta{APIVersion: gatewayv1beta1.GroupVersion.String(), Kind: "HTTPRoute"},
+		ObjectMeta: metav1.ObjectMeta{Name: "httproute", Namespace: "default"},
+	}
+	newIngress := func(plugins string) util.K8sObjectInfo {
+		return util.K8sObjectInfo{
+			Name:      "httproute",
+			Namespace: "default",
+			Annotations: map[string]string{
+				annotations.AnnotationPrefix + annotations.PluginsKey: plugins,
+			},
+		}
+	}
+	newRequestTransformer := func(config kong.Configuration) []kong.Plugin {
+		return []kong.Plugin{{Name: kong.String("request-transformer"), Config: config}}
+	}
+	routes := []Route{
+		{
+			Route: kong.Route{Name: kong.String("filtered")},
+			Plugins: newRequestTransformer(kong.Configuration{
+				"add": map[string][]string{"headers": {"x-generated:bar", "x-both:baz"}},
+			}),
+			Ingress: newIngress("add-header,rate-limit"),
+		},
+		{
+			Route:   kong.Route{Name: kong.String("unfiltered")},
+			Ingress: newIngress("add-header,rate-limit"),
+		},
+		{
+			Route: kong.Route{Name: kong.String("conflicting")},
+			Plugins: newRequestTransformer(kong.Configuration{
+				"http_method": "GET",
+			}),
+			Ingress: newIngress("add-header"),
+		},
+		{
+			Route: kong.Route{Name: kong.String("disabled")},
+			Plugins: newRequestTransformer(kong.Configuration{
+				"remove": map[string][]string{"headers": {"x-foo"}},
+			}),
+			Ingress: newIngress("disabled"),
+		},
+	}
+	ks := KongState{
+		Services: []Service{
+			{
+				Service: kong.Service{Name: kong.String("service")},
+				Parent:  httpRoute,
+				Routes:  routes,
+			},
+		},
+	}
+	failuresCollector, err := failures.NewResourceFailuresCollector(logrus.New())
+	require.NoError(t, err)
+	ks.FillPlugins(logrus.New(), s, failuresCollector)
+
+	attached := make(map[string]map[string]kong.Configuration)
+	for _, plugin := range ks.Plugins {
+		if attached[*plugin.Route.ID] == nil {
+			attached[*plugin.Route.ID] = make(map[string]kong.Configuration)
+		}
+		attached[*plugin.Route.ID][*plugin.Name] = plugin.Config
+	}
+	routePlugins := make(map[string][]string)
+	for _, route := range ks.Services[0].Routes {
+		for _, plugin := range route.Plugins {
+			routePlugins[*route.Name] = append(routePlugins[*route.Name], *plugin.Name)
+		}
+	}
+
+	t.Log("verifying that KongPlugins of the type of a plugin generated for a route are merged with it")
+	require.Len(t, attached["filtered"], 2)
+	assert.Equal(t, kong.Configuration{
+		"add":         map[string]interface{}{"headers": []interface{}{"x-user:foo", "x-both:baz", "x-generated:bar"}},
+		"http_method": "POST",
+	}, attached["filtered"]["request-transformer"])
+	assert.Contains(t, attached["filtered"], "rate-limiting")
+	assert.Empty(t, routePlugins["filtered"], "the merged generated plugin must be removed from the route")
+	assert.Len(t, routes[0].Plugins, 1, "the routes the state was built from must not be modified")
+
+	t.Log("verifying that KongPlugins are attached as is to routes without generated plugins")
+	require.Len(t, attached["unfiltered"], 2)
+	assert.Equal(t, kong.Configuration{
+		"add":         map[string]interface{}{"headers": []interface{}{"x-user:foo", "x-both:baz"}},
+		"http_method": "POST",
+	}, attached["unfiltered"]["request-transformer"])
+
+	t.Log("verifying that KongPlugins which can't be merged with the generated plugin are dropped and reported")
+	assert.Empty(t, attached["conflicting"])
+	assert.Equal(t, []string{"request-transformer"}, routePlugins["conflicting"])
+	translationFailures := failuresCollector.PopResourceFailures()
+	require.Len(t, translationFailures, 1, "the dropped KongPlugin must be reported")
+	assert.Equal(t, "route conflicting already has a request-transformer plugin generated from its configuration "+
+		"(e.g. HTTPRoute filters), which the configuration of add-header can't be merged with: "+
+		"config.http_method is set to different values", translationFailures[0].Message())
+	causingObjects := translationFailures[0].CausingObjects()
+	require.Len(t, causingObjects, 2)
+	assert.Equal(t, "add-header", causingObjects[0].GetName())
+	assert.Equal(t, httpRoute, causingObjects[1])
+
+	t.Log("verifying that disabled KongPlugins don't disable the generated plugins")
+	assert.Empty(t, attached["disabled"])
+	assert.Equal(t, []string{"request-transformer"}, routePlugins["disabled"])
+}
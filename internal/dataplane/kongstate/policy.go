@@ -0,0 +1,428 @@
+package kongstate
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/kong/go-kong/kong"
+	"github.com/sirupsen/logrus"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+	gatewayv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/store"
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/util"
+	configurationv1 "github.com/kong/kubernetes-ingress-controller/v2/pkg/apis/configuration/v1"
+)
+
+// pluginAttachmentLevel is the level of the Gateway > listener > HTTPRoute > Service hierarchy
+// a plugin is attached to, from the most general one.
+type pluginAttachmentLevel int
+
+const (
+	gatewayAttachmentLevel pluginAttachmentLevel = iota
+	listenerAttachmentLevel
+	routeAttachmentLevel
+	serviceAttachmentLevel
+)
+
+// pluginAttachment is a plugin attached to a Kong route or service, either through the konghq.com/plugins
+// annotation of the Kubernetes object the Kong entity was generated from, or as a policy by the targetRef of
+// a KongPlugin.
+type pluginAttachment struct {
+	// pluginKey is the key of the plugin in the plugin relations (KongPlugin's namespace:name).
+	pluginKey  string
+	pluginType string
+	level      pluginAttachmentLevel
+	policy     bool
+	override   bool
+}
+
+// precedes returns true if the attachment takes precedence over the other one, when both attach a plugin of
+// the same type to the same Kong entity. Overrides take precedence over everything else, the ones attached
+// higher in the hierarchy first. Otherwise, the plugins attached lower in the hierarchy take precedence, and
+// the plugins attached with annotations take precedence over the policies of the same level.
+func (a pluginAttachment) precedes(b pluginAttachment) bool {
+	switch {
+	case a.override != b.override:
+		return a.override
+	case a.level != b.level && a.override:
+		return a.level < b.level
+	case a.level != b.level:
+		return a.level > b.level
+	default:
+		return !a.policy && b.policy
+	}
+}
+
+// pluginRelationsChanges tracks the changes to apply to the plugin relations generated from annotations
+// after resolving the plugins attached as policies.
+type pluginRelationsChanges struct {
+	added          map[string]util.ForeignRelations
+	removedRoutes  map[string]map[string]struct{}
+	removedService map[string]map[string]struct{}
+}
+
+func (c *pluginRelationsChanges) keep(a pluginAttachment, entity string, isRoute bool) {
+	if !a.policy {
+		return
+	}
+	relations := c.added[a.pluginKey]
+	if isRoute {
+		relations.Route = append(relations.Route, entity)
+	} else {
+		relations.Service = append(relations.Service, entity)
+	}
+	c.added[a.pluginKey] = relations
+}
+
+func (c *pluginRelationsChanges) drop(a pluginAttachment, entity string, isRoute bool) {
+	if a.policy {
+		return
+	}
+	removed := c.removedService
+	if isRoute {
+		removed = c.removedRoutes
+	}
+	if _, ok := removed[a.pluginKey]; !ok {
+		removed[a.pluginKey] = make(map[string]struct{})
+	}
+	removed[a.pluginKey][entity] = struct{}{}
+}
+
+// resolvePluginPolicies attaches the KongPlugins which have a targetRef to the Kong entities generated for
+// their target: the routes generated for the Gateway API routes attached to a Gateway or one of its
+// listeners, the routes generated for an HTTPRoute, or the services generated for a Service. When several
+// plugins of the same type are attached to the same Kong entity, or to a route and its service, only the one
+// which takes precedence according to pluginAttachment.precedes is kept. Plugins generated from the
+// configuration of the routes and services themselves (e.g. HTTPRoute filters) always take precedence over
+// the policies.
+func (ks *KongState) resolvePluginPolicies(
+	log logrus.FieldLogger,
+	s store.Storer,
+	pluginRels map[string]util.ForeignRelations,
+) map[string]util.ForeignRelations {
+	policies := listPluginPolicies(s)
+	if len(policies) == 0 {
+		return pluginRels
+	}
+
+	pluginTypes := make(map[string]string)
+	pluginType := func(pluginKey string) string {
+		if t, ok := pluginTypes[pluginKey]; ok {
+			return t
+		}
+		identifier := strings.Split(pluginKey, ":")
+		plugin, err := getPlugin(s, identifier[0], identifier[1])
+		if err != nil || plugin.Name == nil {
+			// the failure is reported when building the plugins.
+			pluginTypes[pluginKey] = ""
+			return ""
+		}
+		pluginTypes[pluginKey] = *plugin.Name
+		return *plugin.Name
+	}
+
+	routeAttachments := make(map[string][]pluginAttachment)
+	serviceAttachments := make(map[string][]pluginAttachment)
+	for _, pluginKey := range sortedKeys(pluginRels) {
+		relations := pluginRels[pluginKey]
+		t := pluginType(pluginKey)
+		if t == "" {
+			continue
+		}
+		for _, route := range relations.Route {
+			routeAttachments[route] = append(routeAttachments[route], pluginAttachment{
+				pluginKey: pluginKey, pluginType: t, level: routeAttachmentLevel,
+			})
+		}
+		for _, service := range relations.Service {
+			serviceAttachments[service] = append(serviceAttachments[service], pluginAttachment{
+				pluginKey: pluginKey, pluginType: t, level: serviceAttachmentLevel,
+			})
+		}
+	}
+	for _, policy := range policies {
+		ks.attachPluginPolicy(policy, routeAttachments, serviceAttachments)
+	}
+
+	changes := &pluginRelationsChanges{
+		added:          make(map[string]util.ForeignRelations),
+		removedRoutes:  make(map[string]map[string]struct{}),
+		removedService: make(map[string]map[string]struct{}),
+	}
+	for _, service := range ks.Services {
+		serviceName := *service.Name
+		generated := pluginTypesOf(service.Plugins)
+		serviceWinners := make(map[string]pluginAttachment)
+		attachmentsByType := groupByPluginType(serviceAttachments[serviceName])
+		for _, pluginType := range sortedKeys(attachmentsByType) {
+			attachments := attachmentsByType[pluginType]
+			if !hasPolicyAttachment(attachments) {
+				continue
+			}
+			if _, ok := generated[pluginType]; ok {
+				logDroppedPolicies(log, attachments, pluginType, serviceName)
+				continue
+			}
+			serviceWinners[pluginType] = resolvePluginAttachments(attachments, serviceName, false, changes)
+		}
+
+		for _, route := range service.Routes {
+			routeName := *route.Name
+			generated := pluginTypesOf(route.Plugins)
+			attachmentsByType := groupByPluginType(routeAttachments[routeName])
+			for _, pluginType := range sortedKeys(attachmentsByType) {
+				attachments := attachmentsByType[pluginType]
+				serviceWinner, hasServiceWinner := serviceWinners[pluginType]
+				if !hasPolicyAttachment(attachments) && !(hasServiceWinner && serviceWinner.policy) {
+					continue
+				}
+				if _, ok := generated[pluginType]; ok {
+					// the conflicts with annotations are handled by dropConflictingRoutePlugins.
+					logDroppedPolicies(log, attachments, pluginType, routeName)
+					continue
+				}
+				if hasServiceWinner {
+					attachments = append(attachments, serviceWinner)
+				}
+				resolvePluginAttachments(attachments, routeName, true, changes)
+			}
+		}
+	}
+
+	return changes.apply(pluginRels)
+}
+
+// resolvePluginAttachments keeps the attachment which takes precedence among the provided ones, which attach
+// plugins of the same type to the same Kong entity, and drops the others. It returns the kept attachment.
+// The service attachments competing with the attachments of a route are left unchanged: they're kept on the
+// service, where the route attachments take precedence over them in Kong if they're kept.
+func resolvePluginAttachments(
+	attachments []pluginAttachment,
+	entity string,
+	isRoute bool,
+	changes *pluginRelationsChanges,
+) pluginAttachment {
+	winner := attachments[0]
+	for _, a := range attachments[1:] {
+		if a.precedes(winner) {
+			winner = a
+		}
+	}
+	for _, a := range attachments {
+		if isRoute && a.level == serviceAttachmentLevel {
+			continue
+		}
+		if a == winner {
+			changes.keep(a, entity, isRoute)
+		} else {
+			changes.drop(a, entity, isRoute)
+		}
+	}
+	return winner
+}
+
+// apply returns the plugin relations with the changes applied. The plugins which lose all the routes and
+// services they were attached to are removed altogether, rather than being left attached to their consumers
+// only, which would apply them to all the requests of the consumers.
+func (c *pluginRelationsChanges) apply(pluginRels map[string]util.ForeignRelations) map[string]util.ForeignRelations {
+	result := make(map[string]util.ForeignRelations, len(pluginRels))
+	for pluginKey, relations := range pluginRels {
+		filtered := util.ForeignRelations{Consumer: relations.Consumer}
+		for _, route := range relations.Route {
+			if _, removed := c.removedRoutes[pluginKey][route]; !removed {
+				filtered.Route = append(filtered.Route, route)
+			}
+		}
+		for _, service := range relations.Service {
+			if _, removed := c.removedService[pluginKey][service]; !removed {
+				filtered.Service = append(filtered.Service, service)
+			}
+		}
+		if len(relations.Route)+len(relations.Service) > 0 && len(filtered.Route)+len(filtered.Service) == 0 {
+			continue
+		}
+		result[pluginKey] = filtered
+	}
+	for _, pluginKey := range sortedKeys(c.added) {
+		added := c.added[pluginKey]
+		relations := result[pluginKey]
+		relations.Route = append(relations.Route, added.Route...)
+		relations.Service = append(relations.Service, added.Service...)
+		result[pluginKey] = relations
+	}
+	return result
+}
+
+// attachPluginPolicy records the attachments of the provided KongPlugin to the Kong routes and services
+// generated for its target.
+func (ks *KongState) attachPluginPolicy(
+	policy *configurationv1.KongPlugin,
+	routeAttachments map[string][]pluginAttachment,
+	serviceAttachments map[string][]pluginAttachment,
+) {
+	target := policy.TargetRef
+	attachment := pluginAttachment{
+		pluginKey:  policy.Namespace + ":" + policy.Name,
+		pluginType: policy.PluginName,
+		policy:     true,
+		override:   policy.Override,
+	}
+
+	switch {
+	case target.SectionName != nil && target.Kind != "Gateway":
+		// invalid targetRef, which is reported in the status of the KongPlugin.
+		return
+	case target.Group == "" && target.Kind == "Service":
+		attachment.level = serviceAttachmentLevel
+		for _, service := range ks.Services {
+			for _, k8sService := range service.K8sServices {
+				if k8sService.Namespace == policy.Namespace && k8sService.Name == target.Name {
+					serviceAttachments[*service.Name] = append(serviceAttachments[*service.Name], attachment)
+					break
+				}
+			}
+		}
+	case target.Group == gatewayv1beta1.GroupName && target.Kind == "HTTPRoute":
+		attachment.level = routeAttachmentLevel
+		for _, service := range ks.Services {
+			httproute, ok := service.Parent.(*gatewayv1beta1.HTTPRoute)
+			if !ok || httproute.Namespace != policy.Namespace || httproute.Name != target.Name {
+				continue
+			}
+			for _, route := range service.Routes {
+				routeAttachments[*route.Name] = append(routeAttachments[*route.Name], attachment)
+			}
+		}
+	case target.Group == gatewayv1beta1.GroupName && target.Kind == "Gateway":
+		attachment.level = gatewayAttachmentLevel
+		if target.SectionName != nil {
+			attachment.level = listenerAttachmentLevel
+		}
+		for _, service := range ks.Services {
+			if service.Parent == nil || !isAttachedToGateway(service.Parent, policy.Namespace, target.Name, target.SectionName) {
+				continue
+			}
+			for _, route := range service.Routes {
+				routeAttachments[*route.Name] = append(routeAttachments[*route.Name], attachment)
+			}
+		}
+	}
+}
+
+// isAttachedToGateway returns true if the provided Gateway API route has a parentRef to the Gateway, and to
+// its listener with the provided name if it's not nil.
+func isAttachedToGateway(route client.Object, namespace, name string, sectionName *string) bool {
+	for _, parentRef := range gatewayRouteParentRefs(route) {
+		if (parentRef.Group != nil && *parentRef.Group != gatewayv1beta1.GroupName) ||
+			(parentRef.Kind != nil && *parentRef.Kind != "Gateway") {
+			continue
+		}
+		parentNamespace := route.GetNamespace()
+		if parentRef.Namespace != nil {
+			parentNamespace = string(*parentRef.Namespace)
+		}
+		if parentNamespace != namespace || string(parentRef.Name) != name {
+			continue
+		}
+		if sectionName == nil || (parentRef.SectionName != nil && string(*parentRef.SectionName) == *sectionName) {
+			return true
+		}
+	}
+	return false
+}
+
+// gatewayRouteParentRefs returns the parentRefs of the provided object if it's a Gateway API route.
+func gatewayRouteParentRefs(route client.Object) []gatewayv1beta1.ParentReference {
+	var v1alpha2ParentRefs []gatewayv1alpha2.ParentReference
+	switch r := route.(type) {
+	case *gatewayv1beta1.HTTPRoute:
+		return r.Spec.ParentRefs
+	case *gatewayv1alpha2.TCPRoute:
+		v1alpha2ParentRefs = r.Spec.ParentRefs
+	case *gatewayv1alpha2.UDPRoute:
+		v1alpha2ParentRefs = r.Spec.ParentRefs
+	case *gatewayv1alpha2.TLSRoute:
+		v1alpha2ParentRefs = r.Spec.ParentRefs
+	}
+	parentRefs := make([]gatewayv1beta1.ParentReference, 0, len(v1alpha2ParentRefs))
+	for _, parentRef := range v1alpha2ParentRefs {
+		parentRefs = append(parentRefs, gatewayv1beta1.ParentReference{
+			Group:       (*gatewayv1beta1.Group)(parentRef.Group),
+			Kind:        (*gatewayv1beta1.Kind)(parentRef.Kind),
+			Namespace:   (*gatewayv1beta1.Namespace)(parentRef.Namespace),
+			Name:        gatewayv1beta1.ObjectName(parentRef.Name),
+			SectionName: (*gatewayv1beta1.SectionName)(parentRef.SectionName),
+		})
+	}
+	return parentRefs
+}
+
+// listPluginPolicies returns the KongPlugins which have a targetRef, from the oldest one, so that the oldest
+// policy takes precedence over the newer ones when they're otherwise equivalent.
+func listPluginPolicies(s store.Storer) []*configurationv1.KongPlugin {
+	var policies []*configurationv1.KongPlugin
+	for _, plugin := range s.ListKongPlugins() {
+		if plugin.TargetRef != nil && plugin.PluginName != "" {
+			policies = append(policies, plugin)
+		}
+	}
+	sort.SliceStable(policies, func(i, j int) bool {
+		if !policies[i].CreationTimestamp.Equal(&policies[j].CreationTimestamp) {
+			return policies[i].CreationTimestamp.Before(&policies[j].CreationTimestamp)
+		}
+		if policies[i].Namespace != policies[j].Namespace {
+			return policies[i].Namespace < policies[j].Namespace
+		}
+		return policies[i].Name < policies[j].Name
+	})
+	return policies
+}
+
+func sortedKeys[T any](m map[string]T) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func groupByPluginType(attachments []pluginAttachment) map[string][]pluginAttachment {
+	grouped := make(map[string][]pluginAttachment)
+	for _, a := range attachments {
+		grouped[a.pluginType] = append(grouped[a.pluginType], a)
+	}
+	return grouped
+}
+
+func hasPolicyAttachment(attachments []pluginAttachment) bool {
+	for _, a := range attachments {
+		if a.policy {
+			return true
+		}
+	}
+	return false
+}
+
+func pluginTypesOf(plugins []kong.Plugin) map[string]struct{} {
+	types := make(map[string]struct{}, len(plugins))
+	for _, plugin := range plugins {
+		if plugin.Name != nil {
+			types[*plugin.Name] = struct{}{}
+		}
+	}
+	return types
+}
+
+func logDroppedPolicies(log logrus.FieldLogger, attachments []pluginAttachment, pluginType, entity string) {
+	for _, a := range attachments {
+		if a.policy {
+			log.WithFields(logrus.Fields{
+				"kongplugin": a.pluginKey,
+				"entity":     entity,
+			}).Debugf("%s already has a %s plugin generated from its configuration, ignoring KongPlugin policy", entity, pluginType)
+		}
+	}
+}
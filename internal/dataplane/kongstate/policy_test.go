@@ -0,0 +1,171 @@
+package kongstate
+
+import (
+	"testing"
+
+	"github.com/kong/go-kong/kong"
+	"github.com/samber/lo"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	gatewayv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/annotations"
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/dataplane/failures"
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/store"
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/util"
+	configurationv1 "github.com/kong/kubernetes-ingress-controller/v2/pkg/apis/configuration/v1"
+)
+
+func TestFillPlugins_Policies(t *testing.T) {
+	plugin := func(name, pluginName string) *configurationv1.KongPlugin {
+		return &configurationv1.KongPlugin{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+			PluginName: pluginName,
+		}
+	}
+	policy := func(name, pluginName, kind, targetName string, sectionName *string, override bool) *configurationv1.KongPlugin {
+		p := plugin(name, pluginName)
+		p.TargetRef = &configurationv1.PolicyTargetReference{
+			Group:       gatewayv1beta1.GroupName,
+			Kind:        kind,
+			Name:        targetName,
+			SectionName: sectionName,
+		}
+		if kind == "Service" {
+			p.TargetRef.Group = ""
+		}
+		p.Override = override
+		return p
+	}
+	httpRoute := func(name string, sectionName *string) *gatewayv1beta1.HTTPRoute {
+		return &gatewayv1beta1.HTTPRoute{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+			Spec: gatewayv1beta1.HTTPRouteSpec{
+				CommonRouteSpec: gatewayv1beta1.CommonRouteSpec{
+					ParentRefs: []gatewayv1beta1.ParentReference{{
+						Name:        "gateway",
+						SectionName: (*gatewayv1beta1.SectionName)(sectionName),
+					}},
+				},
+			},
+		}
+	}
+	routeInfo := func(name string, plugins ...string) util.K8sObjectInfo {
+		info := util.K8sObjectInfo{Name: name, Namespace: "default"}
+		if len(plugins) > 0 {
+			info.Annotations = map[string]string{
+				annotations.AnnotationPrefix + annotations.PluginsKey: plugins[0],
+			}
+		}
+		return info
+	}
+
+	s, err := store.NewFakeStore(store.FakeObjects{
+		KongPlugins: []*configurationv1.KongPlugin{
+			plugin("annotation-rate-limit", "rate-limiting"),
+			plugin("annotation-key-auth", "key-auth"),
+			policy("gateway-rate-limit", "rate-limiting", "Gateway", "gateway", nil, false),
+			policy("gateway-key-auth", "key-auth", "Gateway", "gateway", nil, true),
+			policy("gateway-acl", "acl", "Gateway", "gateway", nil, false),
+			policy("listener-cors", "cors", "Gateway", "gateway", lo.ToPtr("http"), false),
+			policy("httproute-cors", "cors", "HTTPRoute", "without-section", nil, false),
+			policy("httproute-request-transformer", "request-transformer", "HTTPRoute", "with-section", nil, false),
+			policy("service-acl", "acl", "Service", "backend", nil, false),
+			policy("other-gateway-ip-restriction", "ip-restriction", "Gateway", "other", nil, false),
+		},
+	})
+	require.NoError(t, err)
+
+	ks := KongState{
+		Services: []Service{
+			{
+				Service: kong.Service{Name: kong.String("with-section")},
+				K8sServices: map[string]*corev1.Service{
+					"default/backend": {ObjectMeta: metav1.ObjectMeta{Name: "backend", Namespace: "default"}},
+				},
+				Parent: httpRoute("with-section", lo.ToPtr("http")),
+				Routes: []Route{{
+					Route:   kong.Route{Name: kong.String("with-section.0")},
+					Ingress: routeInfo("with-section"),
+					Plugins: []kong.Plugin{{Name: kong.String("request-transformer")}},
+				}},
+			},
+			{
+				Service: kong.Service{Name: kong.String("without-section")},
+				Parent:  httpRoute("without-section", nil),
+				Routes: []Route{{
+					Route:   kong.Route{Name: kong.String("without-section.0")},
+					Ingress: routeInfo("without-section", "annotation-rate-limit,annotation-key-auth"),
+				}},
+			},
+		},
+	}
+	failuresCollector, err := failures.NewResourceFailuresCollector(logrus.New())
+	require.NoError(t, err)
+	ks.FillPlugins(logrus.New(), s, failuresCollector)
+
+	attached := make(map[string][]string)
+	for _, plugin := range ks.Plugins {
+		switch {
+		case plugin.Route != nil:
+			attached["route "+*plugin.Route.ID] = append(attached["route "+*plugin.Route.ID], plugin.K8sParent.GetName())
+		case plugin.Service != nil:
+			attached["service "+*plugin.Service.ID] = append(attached["service "+*plugin.Service.ID], plugin.K8sParent.GetName())
+		}
+	}
+
+	assert.ElementsMatch(t, []string{
+		// Gateway defaults apply when nothing else is attached lower in the hierarchy.
+		"gateway-rate-limit",
+		// Gateway overrides apply.
+		"gateway-key-auth",
+		// listener policies apply to the routes attached to the listener.
+		"listener-cors",
+		// gateway-acl is dropped as the Service has an acl plugin, lower in the hierarchy.
+		// httproute-request-transformer is dropped as the route has a plugin generated from its filters.
+	}, attached["route with-section.0"])
+	assert.ElementsMatch(t, []string{"service-acl"}, attached["service with-section"])
+	assert.ElementsMatch(t, []string{
+		// annotations take precedence over Gateway defaults.
+		"annotation-rate-limit",
+		// Gateway overrides take precedence over annotations.
+		"gateway-key-auth",
+		// routes attached to the whole Gateway aren't affected by listener policies.
+		"httproute-cors",
+		"gateway-acl",
+	}, attached["route without-section.0"])
+	assert.Len(t, attached, 3, "no plugin must be attached to other entities")
+}
+
+func TestPluginAttachmentPrecedes(t *testing.T) {
+	gatewayDefault := pluginAttachment{level: gatewayAttachmentLevel, policy: true}
+	gatewayOverride := pluginAttachment{level: gatewayAttachmentLevel, policy: true, override: true}
+	routeDefault := pluginAttachment{level: routeAttachmentLevel, policy: true}
+	routeOverride := pluginAttachment{level: routeAttachmentLevel, policy: true, override: true}
+	routeAnnotation := pluginAttachment{level: routeAttachmentLevel}
+	serviceDefault := pluginAttachment{level: serviceAttachmentLevel, policy: true}
+
+	testCases := []struct {
+		name     string
+		a, b     pluginAttachment
+		expected bool
+	}{
+		{name: "lower defaults precede higher defaults", a: routeDefault, b: gatewayDefault, expected: true},
+		{name: "higher defaults don't precede lower defaults", a: gatewayDefault, b: serviceDefault, expected: false},
+		{name: "overrides precede defaults", a: gatewayOverride, b: serviceDefault, expected: true},
+		{name: "overrides precede annotations", a: gatewayOverride, b: routeAnnotation, expected: true},
+		{name: "higher overrides precede lower overrides", a: gatewayOverride, b: routeOverride, expected: true},
+		{name: "annotations precede defaults of the same level", a: routeAnnotation, b: routeDefault, expected: true},
+		{name: "defaults don't precede annotations of the same level", a: routeDefault, b: routeAnnotation, expected: false},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, tc.a.precedes(tc.b))
+		})
+	}
+}
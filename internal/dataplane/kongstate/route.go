@@ -33,6 +33,27 @@ var (
 	validHosts = regexp.MustCompile(`^(\*\.)?([a-zA-Z0-9]+(-[a-zA-Z0-9]+)*)+(\.([a-zA-Z0-9]+(-[a-zA-Z0-9]+)*))*?(\.\*)?$`)
 )
 
+// DeepCopy returns a deep copy of the Route.
+func (r *Route) DeepCopy() *Route {
+	out := &Route{
+		Route:   *r.Route.DeepCopy(),
+		Ingress: r.Ingress,
+	}
+	if r.Ingress.Annotations != nil {
+		out.Ingress.Annotations = make(map[string]string, len(r.Ingress.Annotations))
+		for k, v := range r.Ingress.Annotations {
+			out.Ingress.Annotations[k] = v
+		}
+	}
+	if r.Plugins != nil {
+		out.Plugins = make([]kong.Plugin, 0, len(r.Plugins))
+		for i := range r.Plugins {
+			out.Plugins = append(out.Plugins, *r.Plugins[i].DeepCopy())
+		}
+	}
+	return out
+}
+
 // normalizeProtocols prevents users from mismatching grpc/http.
 func (r *Route) normalizeProtocols() {
 	protocols := r.Protocols
@@ -5,6 +5,7 @@ import (
 	"strings"
 
 	"github.com/kong/go-kong/kong"
+	"github.com/samber/lo"
 	"github.com/sirupsen/logrus"
 	corev1 "k8s.io/api/core/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -55,6 +56,44 @@ type Service struct {
 	Parent      client.Object
 }
 
+// DeepCopy returns a deep copy of the Service. The Kubernetes objects it refers to are
+// shared with the copy, as they're not supposed to be modified.
+func (s *Service) DeepCopy() *Service {
+	out := &Service{
+		Service:   *s.Service.DeepCopy(),
+		Namespace: s.Namespace,
+		Parent:    s.Parent,
+	}
+	if s.Routes != nil {
+		out.Routes = make([]Route, 0, len(s.Routes))
+		for i := range s.Routes {
+			out.Routes = append(out.Routes, *s.Routes[i].DeepCopy())
+		}
+	}
+	if s.Plugins != nil {
+		out.Plugins = make([]kong.Plugin, 0, len(s.Plugins))
+		for i := range s.Plugins {
+			out.Plugins = append(out.Plugins, *s.Plugins[i].DeepCopy())
+		}
+	}
+	if s.Backends != nil {
+		out.Backends = make([]ServiceBackend, 0, len(s.Backends))
+		for _, backend := range s.Backends {
+			if backend.Weight != nil {
+				backend.Weight = lo.ToPtr(*backend.Weight)
+			}
+			out.Backends = append(out.Backends, backend)
+		}
+	}
+	if s.K8sServices != nil {
+		out.K8sServices = make(map[string]*corev1.Service, len(s.K8sServices))
+		for k, v := range s.K8sServices {
+			out.K8sServices[k] = v
+		}
+	}
+	return out
+}
+
 // overrideByKongIngress sets Service fields by KongIngress.
 func (s *Service) overrideByKongIngress(kongIngress *configurationv1.KongIngress) {
 	if kongIngress == nil || kongIngress.Proxy == nil {
@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	"github.com/kong/go-kong/kong"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 type PortMode int
@@ -78,4 +79,7 @@ func (c *Certificate) SanitizedCopy() *Certificate {
 // Plugin represetns a plugin Object in Kong.
 type Plugin struct {
 	kong.Plugin
+
+	// K8sParent is the KongPlugin or KongClusterPlugin the plugin was generated from.
+	K8sParent client.Object
 }
@@ -4,8 +4,10 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"reflect"
 
 	"github.com/kong/go-kong/kong"
+	"github.com/samber/lo"
 	corev1 "k8s.io/api/core/v1"
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	"sigs.k8s.io/yaml"
@@ -16,6 +18,24 @@ import (
 	configurationv1 "github.com/kong/kubernetes-ingress-controller/v2/pkg/apis/configuration/v1"
 )
 
+// KongIngressForServices returns the KongIngress overriding the Kong Service or Upstream generated for
+// the provided Kubernetes Services, or nil if there's none.
+func KongIngressForServices(
+	s store.Storer,
+	services map[string]*corev1.Service,
+) (*configurationv1.KongIngress, error) {
+	return getKongIngressForServices(s, services)
+}
+
+// KongIngressForObject returns the KongIngress overriding the Kong Routes generated for the provided
+// Kubernetes object, or nil if there's none.
+func KongIngressForObject(
+	s store.Storer,
+	obj util.K8sObjectInfo,
+) (*configurationv1.KongIngress, error) {
+	return getKongIngressFromObjectMeta(s, obj)
+}
+
 func getKongIngressForServices(
 	s store.Storer,
 	services map[string]*corev1.Service,
@@ -78,9 +98,36 @@ func getKongIngressFromObjAnnotations(
 	return nil, nil
 }
 
+// PluginForKind returns the plugin configured by the KongPlugin in the provided namespace, or by the
+// KongClusterPlugin, with the provided name, depending on the provided kind.
+func PluginForKind(s store.Storer, kind, namespace, name string) (Plugin, error) {
+	switch kind {
+	case "KongPlugin":
+		k8sPlugin, err := s.GetKongPlugin(namespace, name)
+		if err != nil {
+			return Plugin{}, err
+		}
+		if k8sPlugin.PluginName == "" {
+			return Plugin{}, fmt.Errorf("invalid empty 'plugin' property")
+		}
+		plugin, err := kongPluginFromK8SPlugin(s, *k8sPlugin)
+		return Plugin{Plugin: plugin, K8sParent: k8sPlugin}, err
+	case "KongClusterPlugin":
+		clusterPlugin, err := s.GetKongClusterPlugin(name)
+		if err != nil {
+			return Plugin{}, err
+		}
+		if clusterPlugin.PluginName == "" {
+			return Plugin{}, fmt.Errorf("invalid empty 'plugin' property")
+		}
+		plugin, err := kongPluginFromK8SClusterPlugin(s, *clusterPlugin)
+		return Plugin{Plugin: plugin, K8sParent: clusterPlugin}, err
+	}
+	return Plugin{}, fmt.Errorf("unsupported plugin kind %s", kind)
+}
+
 // getPlugin constructs a plugins from a KongPlugin resource.
-func getPlugin(s store.Storer, namespace, name string) (kong.Plugin, error) {
-	var plugin kong.Plugin
+func getPlugin(s store.Storer, namespace, name string) (Plugin, error) {
 	k8sPlugin, err := s.GetKongPlugin(namespace, name)
 	if err != nil {
 		// if no namespaced plugin definition, then
@@ -89,26 +136,26 @@ func getPlugin(s store.Storer, namespace, name string) (kong.Plugin, error) {
 			clusterPlugin, err := s.GetKongClusterPlugin(name)
 			// not found
 			if errors.As(err, &store.ErrNotFound{}) {
-				return plugin, errors.New(
+				return Plugin{}, errors.New(
 					"no KongPlugin or KongClusterPlugin was found")
 			}
 			if err != nil {
-				return plugin, err
+				return Plugin{}, err
 			}
 			if clusterPlugin.PluginName == "" {
-				return plugin, fmt.Errorf("invalid empty 'plugin' property")
+				return Plugin{}, fmt.Errorf("invalid empty 'plugin' property")
 			}
-			plugin, err = kongPluginFromK8SClusterPlugin(s, *clusterPlugin)
-			return plugin, err
+			plugin, err := kongPluginFromK8SClusterPlugin(s, *clusterPlugin)
+			return Plugin{Plugin: plugin, K8sParent: clusterPlugin}, err
 		}
 	}
 	// ignore plugins with no name
 	if k8sPlugin.PluginName == "" {
-		return plugin, fmt.Errorf("invalid empty 'plugin' property")
+		return Plugin{}, fmt.Errorf("invalid empty 'plugin' property")
 	}
 
-	plugin, err = kongPluginFromK8SPlugin(s, *k8sPlugin)
-	return plugin, err
+	plugin, err := kongPluginFromK8SPlugin(s, *k8sPlugin)
+	return Plugin{Plugin: plugin, K8sParent: k8sPlugin}, err
 }
 
 func kongPluginFromK8SClusterPlugin(
@@ -213,6 +260,77 @@ func RawConfigToConfiguration(config apiextensionsv1.JSON) (kong.Configuration,
 	return kongConfig, nil
 }
 
+// mergePluginConfigs returns the union of the provided plugin configurations: nested objects are merged,
+// and the lists concatenated (e.g. the headers added by request-transformer plugins, or the functions run
+// by pre-function plugins, the ones of a first). Scalar values set differently in the configurations can't
+// be merged.
+func mergePluginConfigs(a, b kong.Configuration) (kong.Configuration, error) {
+	normalized := make([]map[string]interface{}, 0, 2)
+	for _, config := range []kong.Configuration{a, b} {
+		// the configurations are normalized to the types of decoded JSON, as the generated ones are typed.
+		raw, err := json.Marshal(config)
+		if err != nil {
+			return nil, err
+		}
+		var m map[string]interface{}
+		if err := json.Unmarshal(raw, &m); err != nil {
+			return nil, err
+		}
+		normalized = append(normalized, m)
+	}
+	merged, err := mergeConfigValues("config", normalized[0], normalized[1])
+	if err != nil {
+		return nil, err
+	}
+	return merged.(map[string]interface{}), nil
+}
+
+// mergeConfigValues merges the values of the field at the provided path of two plugin configurations.
+func mergeConfigValues(path string, a, b interface{}) (interface{}, error) {
+	switch {
+	case a == nil:
+		return b, nil
+	case b == nil:
+		return a, nil
+	}
+	switch aValue := a.(type) {
+	case map[string]interface{}:
+		bValue, ok := b.(map[string]interface{})
+		if !ok {
+			break
+		}
+		merged := make(map[string]interface{}, len(aValue)+len(bValue))
+		for k, v := range aValue {
+			merged[k] = v
+		}
+		for k, v := range bValue {
+			value, err := mergeConfigValues(path+"."+k, merged[k], v)
+			if err != nil {
+				return nil, err
+			}
+			merged[k] = value
+		}
+		return merged, nil
+	case []interface{}:
+		bValue, ok := b.([]interface{})
+		if !ok {
+			break
+		}
+		merged := make([]interface{}, 0, len(aValue)+len(bValue))
+		merged = append(merged, aValue...)
+		for _, v := range bValue {
+			if !lo.ContainsBy(aValue, func(item interface{}) bool { return reflect.DeepEqual(item, v) }) {
+				merged = append(merged, v)
+			}
+		}
+		return merged, nil
+	}
+	if !reflect.DeepEqual(a, b) {
+		return nil, fmt.Errorf("%s is set to different values", path)
+	}
+	return a, nil
+}
+
 func namespacedSecretToConfiguration(
 	s store.Storer,
 	reference configurationv1.NamespacedSecretValueFromSource) (
@@ -630,3 +630,64 @@ func TestPrettyPrintServiceList(t *testing.T) {
 		})
 	}
 }
+
+func TestMergePluginConfigs(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		a, b     kong.Configuration
+		expected kong.Configuration
+		err      string
+	}{
+		{
+			name:     "empty configurations",
+			expected: kong.Configuration{},
+		},
+		{
+			name: "lists are concatenated without duplicates",
+			a:    kong.Configuration{"access": []interface{}{"a()", "b()"}},
+			b:    kong.Configuration{"access": []string{"b()", "c()"}},
+			expected: kong.Configuration{
+				"access": []interface{}{"a()", "b()", "c()"},
+			},
+		},
+		{
+			name: "objects are merged",
+			a:    kong.Configuration{"add": map[string]interface{}{"headers": []interface{}{"x-a:a"}}, "http_method": "GET"},
+			b:    kong.Configuration{"add": map[string][]string{"querystring": {"a:b"}}, "remove": map[string][]string{"headers": {"x-b"}}},
+			expected: kong.Configuration{
+				"add":         map[string]interface{}{"headers": []interface{}{"x-a:a"}, "querystring": []interface{}{"a:b"}},
+				"remove":      map[string]interface{}{"headers": []interface{}{"x-b"}},
+				"http_method": "GET",
+			},
+		},
+		{
+			name:     "equal scalars are kept",
+			a:        kong.Configuration{"http_method": "GET"},
+			b:        kong.Configuration{"http_method": "GET"},
+			expected: kong.Configuration{"http_method": "GET"},
+		},
+		{
+			name: "different scalars can't be merged",
+			a:    kong.Configuration{"add": map[string]interface{}{"body": "a"}},
+			b:    kong.Configuration{"add": map[string]interface{}{"body": "b"}},
+			err:  "config.add.body is set to different values",
+		},
+		{
+			name: "values of different types can't be merged",
+			a:    kong.Configuration{"add": map[string]interface{}{"headers": "x-a:a"}},
+			b:    kong.Configuration{"add": map[string][]string{"headers": {"x-b:b"}}},
+			err:  "config.add.headers is set to different values",
+		},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			merged, err := mergePluginConfigs(tc.a, tc.b)
+			if tc.err != "" {
+				require.EqualError(t, err, tc.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, merged)
+		})
+	}
+}
@@ -0,0 +1,438 @@
+package parser
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/samber/lo"
+	"golang.org/x/exp/maps"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/annotations"
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/dataplane/failures"
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/dataplane/kongstate"
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/store"
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/util"
+	configurationv1 "github.com/kong/kubernetes-ingress-controller/v2/pkg/apis/configuration/v1"
+)
+
+// -----------------------------------------------------------------------------
+// Parser - Kubernetes Object Explanations
+// -----------------------------------------------------------------------------
+
+// explainedEntityKind is the kind of the Kong entities an explanation lists.
+type explainedEntityKind int
+
+const (
+	explainedService explainedEntityKind = iota
+	explainedRoute
+	explainedUpstream
+	explainedTarget
+	explainedPlugin
+	explainedCertificate
+)
+
+// explainedObject identifies a Kubernetes object which Kong entities were generated for.
+type explainedObject struct {
+	kind      string
+	namespace string
+	name      string
+}
+
+func (o explainedObject) String() string {
+	return o.kind + "/" + o.namespace + "/" + o.name
+}
+
+// explainedObjectFor returns the explainedObject identifying the provided object. Objects
+// without a kind can't be looked up, so they're not explained.
+func explainedObjectFor(obj client.Object) (explainedObject, bool) {
+	if obj == nil || obj.GetObjectKind().GroupVersionKind().Kind == "" {
+		return explainedObject{}, false
+	}
+	return explainedObject{
+		kind:      obj.GetObjectKind().GroupVersionKind().Kind,
+		namespace: obj.GetNamespace(),
+		name:      obj.GetName(),
+	}, true
+}
+
+// explainedObjectForInfo returns the explainedObject identifying the object the provided info describes.
+func explainedObjectForInfo(info util.K8sObjectInfo) (explainedObject, bool) {
+	if info.GroupVersionKind.Kind == "" {
+		return explainedObject{}, false
+	}
+	return explainedObject{
+		kind:      info.GroupVersionKind.Kind,
+		namespace: info.Namespace,
+		name:      info.Name,
+	}, true
+}
+
+// kubernetesObjectExplainer indexes the Kong entities of a KongState by the Kubernetes objects they were
+// generated for.
+type kubernetesObjectExplainer struct {
+	storer       store.Storer
+	explanations util.KubernetesObjectExplanations
+}
+
+// explainKongState returns the explanations of all the Kubernetes objects which Kong entities in the provided
+// state were generated for or which translation failed. certs are the certificates the state's certificates
+// were merged from, which (unlike the merged ones) know the objects they were generated for.
+func explainKongState(
+	s store.Storer,
+	state *kongstate.KongState,
+	certs []certWrapper,
+	translationFailures []failures.ResourceFailure,
+) util.KubernetesObjectExplanations {
+	e := &kubernetesObjectExplainer{
+		storer:       s,
+		explanations: util.KubernetesObjectExplanations{},
+	}
+
+	// routes and services are indexed by their names, which plugins refer to them with.
+	routeOrigins := make(map[string]util.K8sObjectInfo)
+	services := make(map[string]*kongstate.Service)
+	for i := range state.Services {
+		service := &state.Services[i]
+		services[*service.Name] = service
+		e.explainService(service)
+		for j := range service.Routes {
+			route := &service.Routes[j]
+			routeOrigins[*route.Name] = route.Ingress
+			e.explainRoute(route)
+		}
+	}
+	for i := range state.Upstreams {
+		e.explainUpstream(&state.Upstreams[i])
+	}
+	consumerOrigins := make(map[string]client.Object)
+	for i := range state.Consumers {
+		if consumer := &state.Consumers[i]; consumer.Username != nil {
+			consumerOrigins[*consumer.Username] = &consumer.K8sKongConsumer
+		}
+	}
+	for _, plugin := range state.Plugins {
+		e.explainPlugin(plugin, services, routeOrigins, consumerOrigins)
+	}
+	e.explainCertificates(state.Certificates, certs)
+
+	for _, failure := range translationFailures {
+		for _, obj := range failure.CausingObjects() {
+			if o, ok := explainedObjectFor(obj); ok {
+				explanation := e.explanation(o)
+				explanation.TranslationFailures = append(explanation.TranslationFailures, failure.Message())
+			}
+		}
+	}
+
+	for _, explanation := range e.explanations {
+		for _, entities := range [][]util.ExplainedKongEntity{
+			explanation.Services, explanation.Routes, explanation.Upstreams,
+			explanation.Targets, explanation.Plugins, explanation.Certificates,
+		} {
+			sort.Slice(entities, func(i, j int) bool { return entities[i].Name < entities[j].Name })
+		}
+	}
+	return e.explanations
+}
+
+func (e *kubernetesObjectExplainer) explainService(service *kongstate.Service) {
+	var influences []util.KongEntityInfluence
+	k8sServices := sortedK8sServices(service.K8sServices)
+	for _, svc := range k8sServices {
+		if o, ok := explainedObjectFor(svc); ok {
+			influences = append(influences, annotationInfluences(o, svc.Annotations, func(key string) bool {
+				return key != annotations.PluginsKey
+			})...)
+		}
+	}
+	if kongIngress, err := kongstate.KongIngressForServices(e.storer, service.K8sServices); err == nil && kongIngress != nil {
+		influences = append(influences, kongIngressInfluences(kongIngress, "proxy", kongIngress.Proxy)...)
+	}
+
+	entity := util.ExplainedKongEntity{
+		Name:       *service.Name,
+		Entity:     service.Service,
+		Influences: influences,
+	}
+	// services shared by multiple objects have only one of them as their parent, so
+	// they're explained for the objects of all their routes.
+	e.add(service.Parent, explainedService, entity)
+	for _, route := range service.Routes {
+		if o, ok := explainedObjectForInfo(route.Ingress); ok {
+			e.addTo(o, explainedService, entity)
+		}
+	}
+	for _, svc := range k8sServices {
+		e.add(svc, explainedService, entity)
+	}
+}
+
+func (e *kubernetesObjectExplainer) explainRoute(route *kongstate.Route) {
+	o, ok := explainedObjectForInfo(route.Ingress)
+	if !ok {
+		return
+	}
+	influences := annotationInfluences(o, route.Ingress.Annotations, func(key string) bool {
+		return key != annotations.PluginsKey
+	})
+	if kongIngress, err := kongstate.KongIngressForObject(e.storer, route.Ingress); err == nil && kongIngress != nil {
+		influences = append(influences, kongIngressInfluences(kongIngress, "route", kongIngress.Route)...)
+	}
+
+	e.addTo(o, explainedRoute, util.ExplainedKongEntity{
+		Name:       *route.Name,
+		Entity:     route.Route,
+		Influences: influences,
+	})
+}
+
+func (e *kubernetesObjectExplainer) explainUpstream(upstream *kongstate.Upstream) {
+	var influences []util.KongEntityInfluence
+	k8sServices := sortedK8sServices(upstream.Service.K8sServices)
+	for _, svc := range k8sServices {
+		if o, ok := explainedObjectFor(svc); ok {
+			influences = append(influences, annotationInfluences(o, svc.Annotations, func(key string) bool {
+				return key == annotations.HostHeaderKey
+			})...)
+		}
+	}
+	if kongIngress, err := kongstate.KongIngressForServices(e.storer, upstream.Service.K8sServices); err == nil && kongIngress != nil {
+		influences = append(influences, kongIngressInfluences(kongIngress, "upstream", kongIngress.Upstream)...)
+	}
+
+	var origins []explainedObject
+	if o, ok := explainedObjectFor(upstream.Service.Parent); ok {
+		origins = append(origins, o)
+	}
+	for _, route := range upstream.Service.Routes {
+		if o, ok := explainedObjectForInfo(route.Ingress); ok {
+			origins = append(origins, o)
+		}
+	}
+	for _, svc := range k8sServices {
+		if o, ok := explainedObjectFor(svc); ok {
+			origins = append(origins, o)
+		}
+	}
+	upstreamEntity := util.ExplainedKongEntity{
+		Name:       *upstream.Name,
+		Entity:     upstream.Upstream,
+		Influences: influences,
+	}
+	for _, o := range origins {
+		e.addTo(o, explainedUpstream, upstreamEntity)
+		for _, target := range upstream.Targets {
+			e.addTo(o, explainedTarget, util.ExplainedKongEntity{
+				Name:   fmt.Sprintf("%s (upstream %s)", *target.Target.Target, *upstream.Name),
+				Entity: target.Target,
+			})
+		}
+	}
+}
+
+func (e *kubernetesObjectExplainer) explainPlugin(
+	plugin kongstate.Plugin,
+	services map[string]*kongstate.Service,
+	routeOrigins map[string]util.K8sObjectInfo,
+	consumerOrigins map[string]client.Object,
+) {
+	parent, ok := explainedObjectFor(plugin.K8sParent)
+	if !ok {
+		return
+	}
+
+	// the plugin is generated for the objects which refer to its KongPlugin (or KongClusterPlugin)
+	// with an annotation, so it's explained for them too.
+	type referrer struct {
+		object      explainedObject
+		annotations map[string]string
+	}
+	var (
+		referrers   []referrer
+		attachments []string
+	)
+	if plugin.Service != nil && plugin.Service.ID != nil {
+		attachments = append(attachments, "service "+*plugin.Service.ID)
+		if service, ok := services[*plugin.Service.ID]; ok {
+			for _, svc := range sortedK8sServices(service.K8sServices) {
+				if !lo.Contains(annotations.ExtractKongPluginsFromAnnotations(svc.Annotations), parent.name) {
+					continue
+				}
+				if o, ok := explainedObjectFor(svc); ok {
+					referrers = append(referrers, referrer{object: o, annotations: svc.Annotations})
+				}
+			}
+		}
+	}
+	if plugin.Route != nil && plugin.Route.ID != nil {
+		attachments = append(attachments, "route "+*plugin.Route.ID)
+		if info, ok := routeOrigins[*plugin.Route.ID]; ok {
+			if o, ok := explainedObjectForInfo(info); ok {
+				referrers = append(referrers, referrer{object: o, annotations: info.Annotations})
+			}
+		}
+	}
+	if plugin.Consumer != nil && plugin.Consumer.ID != nil {
+		attachments = append(attachments, "consumer "+*plugin.Consumer.ID)
+		if consumer, ok := consumerOrigins[*plugin.Consumer.ID]; ok {
+			if o, ok := explainedObjectFor(consumer); ok {
+				referrers = append(referrers, referrer{object: o, annotations: consumer.GetAnnotations()})
+			}
+		}
+	}
+
+	name := parent.String()
+	if plugin.Name != nil {
+		name = *plugin.Name + " (" + parent.String() + ")"
+	}
+	if len(attachments) > 0 {
+		name += " for " + strings.Join(attachments, ", ")
+	}
+	var influences []util.KongEntityInfluence
+	for _, r := range referrers {
+		influences = append(influences, annotationInfluences(r.object, r.annotations, func(key string) bool {
+			return key == annotations.PluginsKey
+		})...)
+	}
+	entity := util.ExplainedKongEntity{
+		Name:       name,
+		Entity:     plugin.Plugin,
+		Influences: influences,
+	}
+
+	e.addTo(parent, explainedPlugin, entity)
+	for _, r := range referrers {
+		e.addTo(r.object, explainedPlugin, entity)
+	}
+}
+
+func (e *kubernetesObjectExplainer) explainCertificates(certificates []kongstate.Certificate, certs []certWrapper) {
+	// certificates generated from multiple Secrets containing identical certificates are merged, so
+	// the merged certificates are looked up by their contents.
+	merged := make(map[string]kongstate.Certificate, len(certificates))
+	for _, cert := range certificates {
+		if cert.Cert != nil && cert.Key != nil {
+			merged[*cert.Cert+*cert.Key] = cert
+		}
+	}
+	for _, cw := range certs {
+		cert, ok := merged[cw.identifier]
+		if !ok {
+			continue
+		}
+		entity := util.ExplainedKongEntity{
+			Name:   *cert.ID,
+			Entity: cert.SanitizedCopy().Certificate,
+		}
+		for _, parent := range cw.parents {
+			e.add(parent, explainedCertificate, entity)
+		}
+	}
+}
+
+// explanation returns the explanation of the provided object, creating it if it doesn't exist yet.
+func (e *kubernetesObjectExplainer) explanation(o explainedObject) *util.KubernetesObjectExplanation {
+	key := util.KubernetesObjectExplanationKey(o.kind, o.namespace, o.name)
+	explanation, ok := e.explanations[key]
+	if !ok {
+		explanation = &util.KubernetesObjectExplanation{
+			Kind:      o.kind,
+			Namespace: o.namespace,
+			Name:      o.name,
+		}
+		e.explanations[key] = explanation
+	}
+	return explanation
+}
+
+// add adds the entity to the explanation of the provided object.
+func (e *kubernetesObjectExplainer) add(obj client.Object, kind explainedEntityKind, entity util.ExplainedKongEntity) {
+	if o, ok := explainedObjectFor(obj); ok {
+		e.addTo(o, kind, entity)
+	}
+}
+
+// addTo adds the entity to the explanation of the provided object, unless an entity of the same kind and name
+// has already been added to it.
+func (e *kubernetesObjectExplainer) addTo(o explainedObject, kind explainedEntityKind, entity util.ExplainedKongEntity) {
+	explanation := e.explanation(o)
+	var entities *[]util.ExplainedKongEntity
+	switch kind {
+	case explainedService:
+		entities = &explanation.Services
+	case explainedRoute:
+		entities = &explanation.Routes
+	case explainedUpstream:
+		entities = &explanation.Upstreams
+	case explainedTarget:
+		entities = &explanation.Targets
+	case explainedPlugin:
+		entities = &explanation.Plugins
+	case explainedCertificate:
+		entities = &explanation.Certificates
+	}
+	if lo.ContainsBy(*entities, func(existing util.ExplainedKongEntity) bool { return existing.Name == entity.Name }) {
+		return
+	}
+	*entities = append(*entities, entity)
+}
+
+// annotationInfluences returns the influences of the Kong annotations of the provided object which keys
+// (without the konghq.com prefix) satisfy the provided predicate.
+func annotationInfluences(o explainedObject, anns map[string]string, include func(key string) bool) []util.KongEntityInfluence {
+	var influences []util.KongEntityInfluence
+	keys := maps.Keys(anns)
+	sort.Strings(keys)
+	for _, key := range keys {
+		if !strings.HasPrefix(key, annotations.AnnotationPrefix+"/") {
+			continue
+		}
+		if !include(strings.TrimPrefix(key, annotations.AnnotationPrefix)) {
+			continue
+		}
+		influences = append(influences, util.KongEntityInfluence{
+			Object:  o.String(),
+			Setting: key,
+			Value:   anns[key],
+		})
+	}
+	return influences
+}
+
+// kongIngressInfluences returns the influences of the fields set in the provided section of a KongIngress.
+func kongIngressInfluences(kongIngress *configurationv1.KongIngress, section string, value interface{}) []util.KongEntityInfluence {
+	raw, err := json.Marshal(value)
+	if err != nil {
+		return nil
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &fields); err != nil {
+		return nil
+	}
+
+	o := explainedObject{kind: "KongIngress", namespace: kongIngress.Namespace, name: kongIngress.Name}
+	var influences []util.KongEntityInfluence
+	keys := maps.Keys(fields)
+	sort.Strings(keys)
+	for _, key := range keys {
+		influences = append(influences, util.KongEntityInfluence{
+			Object:  o.String(),
+			Setting: section + "." + key,
+			Value:   string(fields[key]),
+		})
+	}
+	return influences
+}
+
+// sortedK8sServices returns the provided Kubernetes Services sorted by their keys.
+func sortedK8sServices(services map[string]*corev1.Service) []*corev1.Service {
+	keys := maps.Keys(services)
+	sort.Strings(keys)
+	return lo.Map(keys, func(key string, _ int) *corev1.Service {
+		return services[key]
+	})
+}
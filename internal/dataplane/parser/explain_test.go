@@ -0,0 +1,156 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/kong/go-kong/kong"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	netv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/annotations"
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/store"
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/util"
+	configurationv1 "github.com/kong/kubernetes-ingress-controller/v2/pkg/apis/configuration/v1"
+)
+
+func TestExplainKubernetesObjects(t *testing.T) {
+	ingressTypeMeta := metav1.TypeMeta{Kind: "Ingress", APIVersion: netv1.SchemeGroupVersion.String()}
+	newIngress := func(name, secretName string, anns map[string]string) *netv1.Ingress {
+		anns[annotations.IngressClassKey] = annotations.DefaultIngressClass
+		return &netv1.Ingress{
+			TypeMeta: ingressTypeMeta,
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        name,
+				Namespace:   "default",
+				Annotations: anns,
+			},
+			Spec: netv1.IngressSpec{
+				TLS: []netv1.IngressTLS{{Hosts: []string{name + ".example"}, SecretName: secretName}},
+				Rules: []netv1.IngressRule{{
+					Host: name + ".example",
+					IngressRuleValue: netv1.IngressRuleValue{HTTP: &netv1.HTTPIngressRuleValue{
+						Paths: []netv1.HTTPIngressPath{{
+							Path:     "/",
+							PathType: lo.ToPtr(netv1.PathTypePrefix),
+							Backend: netv1.IngressBackend{Service: &netv1.IngressServiceBackend{
+								Name: "httpbin",
+								Port: netv1.ServiceBackendPort{Number: 80},
+							}},
+						}},
+					}},
+				}},
+			},
+		}
+	}
+
+	s, err := store.NewFakeStore(store.FakeObjects{
+		IngressesV1: []*netv1.Ingress{
+			newIngress("foo", "foo-cert", map[string]string{
+				annotations.AnnotationPrefix + annotations.PluginsKey:       "rate-limit",
+				annotations.AnnotationPrefix + annotations.StripPathKey:     "true",
+				annotations.AnnotationPrefix + annotations.ConfigurationKey: "methods",
+			}),
+			newIngress("bar", "missing-cert", map[string]string{}),
+		},
+		Services: []*corev1.Service{{
+			TypeMeta: metav1.TypeMeta{Kind: "Service", APIVersion: corev1.SchemeGroupVersion.String()},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "httpbin",
+				Namespace: "default",
+				Annotations: map[string]string{
+					annotations.AnnotationPrefix + annotations.ProtocolKey:   "https",
+					annotations.AnnotationPrefix + annotations.HostHeaderKey: "httpbin.org",
+				},
+			},
+			Spec: corev1.ServiceSpec{Ports: []corev1.ServicePort{{Port: 80}}},
+		}},
+		Secrets: []*corev1.Secret{{
+			TypeMeta: metav1.TypeMeta{Kind: "Secret", APIVersion: corev1.SchemeGroupVersion.String()},
+			ObjectMeta: metav1.ObjectMeta{
+				UID:       "7428fb98-180b-4702-a91f-61351a33c6e4",
+				Name:      "foo-cert",
+				Namespace: "default",
+			},
+			Data: map[string][]byte{
+				"tls.crt": []byte(tlsPairs[0].Cert),
+				"tls.key": []byte(tlsPairs[0].Key),
+			},
+		}},
+		KongPlugins: []*configurationv1.KongPlugin{{
+			TypeMeta:   metav1.TypeMeta{Kind: "KongPlugin", APIVersion: configurationv1.SchemeGroupVersion.String()},
+			ObjectMeta: metav1.ObjectMeta{Name: "rate-limit", Namespace: "default"},
+			PluginName: "rate-limiting",
+		}},
+		KongIngresses: []*configurationv1.KongIngress{{
+			ObjectMeta: metav1.ObjectMeta{Name: "methods", Namespace: "default"},
+			Route:      &configurationv1.KongIngressRoute{Methods: []*string{kong.String("GET")}},
+		}},
+	})
+	require.NoError(t, err)
+
+	p := mustNewParser(t, s)
+	p.EnableKubernetesObjectExplanations()
+	_, translationFailures := p.Build()
+	require.Len(t, translationFailures, 1)
+	explanations := p.GenerateKubernetesObjectExplanations()
+	assert.Nil(t, p.GenerateKubernetesObjectExplanations(), "explanations should be consumed")
+
+	t.Log("verifying that the entities generated for an Ingress are explained along with what influenced them")
+	foo, ok := explanations.Get("ingress", "default", "foo")
+	require.True(t, ok)
+	require.Len(t, foo.Services, 1)
+	assert.Equal(t, "default.httpbin.pnum-80", foo.Services[0].Name)
+	require.Len(t, foo.Routes, 1)
+	assert.Equal(t, []util.KongEntityInfluence{
+		{Object: "Ingress/default/foo", Setting: "konghq.com/override", Value: "methods"},
+		{Object: "Ingress/default/foo", Setting: "konghq.com/strip-path", Value: "true"},
+		{Object: "KongIngress/default/methods", Setting: "route.methods", Value: `["GET"]`},
+	}, foo.Routes[0].Influences)
+	require.Len(t, foo.Upstreams, 1)
+	assert.Equal(t, []util.KongEntityInfluence{
+		{Object: "Service/default/httpbin", Setting: "konghq.com/host-header", Value: "httpbin.org"},
+	}, foo.Upstreams[0].Influences)
+	require.Len(t, foo.Plugins, 1)
+	assert.Equal(t, []util.KongEntityInfluence{
+		{Object: "Ingress/default/foo", Setting: "konghq.com/plugins", Value: "rate-limit"},
+	}, foo.Plugins[0].Influences)
+	require.Len(t, foo.Certificates, 1)
+	assert.Equal(t, "7428fb98-180b-4702-a91f-61351a33c6e4", foo.Certificates[0].Name)
+	certificate, ok := foo.Certificates[0].Entity.(kong.Certificate)
+	require.True(t, ok)
+	assert.NotEqual(t, tlsPairs[0].Key, *certificate.Key, "certificate keys should be redacted")
+	assert.Empty(t, foo.TranslationFailures)
+
+	t.Log("verifying that the entities generated for a Service are explained")
+	service, ok := explanations.Get("Service", "default", "httpbin")
+	require.True(t, ok)
+	require.Len(t, service.Services, 1)
+	assert.Equal(t, []util.KongEntityInfluence{
+		{Object: "Service/default/httpbin", Setting: "konghq.com/host-header", Value: "httpbin.org"},
+		{Object: "Service/default/httpbin", Setting: "konghq.com/protocol", Value: "https"},
+	}, service.Services[0].Influences)
+	assert.Len(t, service.Upstreams, 1)
+	assert.Empty(t, service.Routes)
+
+	t.Log("verifying that the entities generated for a KongPlugin and a Secret are explained")
+	plugin, ok := explanations.Get("KongPlugin", "default", "rate-limit")
+	require.True(t, ok)
+	require.Len(t, plugin.Plugins, 1)
+	assert.Contains(t, plugin.Plugins[0].Name, "rate-limiting")
+	secret, ok := explanations.Get("Secret", "default", "foo-cert")
+	require.True(t, ok)
+	assert.Len(t, secret.Certificates, 1)
+
+	t.Log("verifying that translation failures are explained")
+	bar, ok := explanations.Get("Ingress", "default", "bar")
+	require.True(t, ok)
+	assert.Len(t, bar.Routes, 1)
+	assert.Equal(t, []string{"failed to fetch the secret (default/missing-cert)"}, bar.TranslationFailures)
+
+	_, ok = explanations.Get("Ingress", "default", "baz")
+	assert.False(t, ok)
+}
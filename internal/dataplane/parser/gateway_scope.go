@@ -0,0 +1,228 @@
+package parser
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/samber/lo"
+	netv1 "k8s.io/api/networking/v1"
+	netv1beta1 "k8s.io/api/networking/v1beta1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	knative "knative.dev/networking/pkg/apis/networking/v1alpha1"
+	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+	gatewayv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/store"
+	configurationv1 "github.com/kong/kubernetes-ingress-controller/v2/pkg/apis/configuration/v1"
+	configurationv1beta1 "github.com/kong/kubernetes-ingress-controller/v2/pkg/apis/configuration/v1beta1"
+)
+
+// -----------------------------------------------------------------------------
+// Parser - Gateway Scope
+// -----------------------------------------------------------------------------
+
+// GatewayScope selects the Kubernetes objects translated by a parser when some Gateways are served by
+// dedicated Kong instances (managed Gateways) rather than the Kong instances shared by all the other
+// objects.
+type GatewayScope struct {
+	// Gateway is the managed Gateway the configuration is built for: only the Gateway API routes attached
+	// to it and its certificates are translated. When it's nil, the configuration is built for the shared
+	// Kong instances and excludes the ManagedGateways and the routes only attached to them.
+	Gateway *types.NamespacedName
+
+	// ManagedGateways are the Gateways served by dedicated Kong instances.
+	ManagedGateways sets.Set[types.NamespacedName]
+}
+
+// String describes the scope, so that translations made in different scopes can be told apart.
+func (s *GatewayScope) String() string {
+	if s == nil {
+		return "all"
+	}
+	if s.Gateway != nil {
+		return s.Gateway.String()
+	}
+	managed := lo.Map(s.ManagedGateways.UnsortedList(), func(nn types.NamespacedName, _ int) string {
+		return nn.String()
+	})
+	sort.Strings(managed)
+	return fmt.Sprintf("all-except=%s", strings.Join(managed, ","))
+}
+
+// EnableGatewayScope restricts the objects translated by the parser to the provided scope.
+func (p *Parser) EnableGatewayScope(scope GatewayScope) {
+	p.gatewayScope = &scope
+	p.storer = gatewayScopedStorer{Storer: p.storer, scope: scope}
+}
+
+// gatewayScopedStorer is a store.Storer which only lists the objects belonging to a GatewayScope.
+type gatewayScopedStorer struct {
+	store.Storer
+	scope GatewayScope
+}
+
+// includesGateway returns true if the Gateway belongs to the scope.
+func (s gatewayScopedStorer) includesGateway(gateway types.NamespacedName) bool {
+	if s.scope.Gateway != nil {
+		return gateway == *s.scope.Gateway
+	}
+	return !s.scope.ManagedGateways.Has(gateway)
+}
+
+// includesParentRefs returns true if a route with the provided parentRefs belongs to the scope, that is
+// when it's attached to the managed Gateway of the scope or, for the shared scope, when any of its
+// parents isn't a managed Gateway.
+func (s gatewayScopedStorer) includesParentRefs(routeNamespace string, parentRefs []gatewayv1beta1.ParentReference) bool {
+	for _, parentRef := range parentRefs {
+		isGateway := (parentRef.Group == nil || *parentRef.Group == gatewayv1beta1.GroupName) &&
+			(parentRef.Kind == nil || *parentRef.Kind == KindGateway)
+		if !isGateway {
+			if s.scope.Gateway == nil {
+				return true
+			}
+			continue
+		}
+		namespace := routeNamespace
+		if parentRef.Namespace != nil {
+			namespace = string(*parentRef.Namespace)
+		}
+		if s.includesGateway(types.NamespacedName{Namespace: namespace, Name: string(parentRef.Name)}) {
+			return true
+		}
+	}
+	return false
+}
+
+func (s gatewayScopedStorer) ListGateways() ([]*gatewayv1beta1.Gateway, error) {
+	gateways, err := s.Storer.ListGateways()
+	if err != nil {
+		return nil, err
+	}
+	var scoped []*gatewayv1beta1.Gateway
+	for _, gateway := range gateways {
+		if s.includesGateway(types.NamespacedName{Namespace: gateway.Namespace, Name: gateway.Name}) {
+			scoped = append(scoped, gateway)
+		}
+	}
+	return scoped, nil
+}
+
+func (s gatewayScopedStorer) ListHTTPRoutes() ([]*gatewayv1beta1.HTTPRoute, error) {
+	routes, err := s.Storer.ListHTTPRoutes()
+	return filterScopedRoutes(s, routes, err, func(r *gatewayv1beta1.HTTPRoute) []gatewayv1beta1.ParentReference {
+		return r.Spec.ParentRefs
+	})
+}
+
+func (s gatewayScopedStorer) ListUDPRoutes() ([]*gatewayv1alpha2.UDPRoute, error) {
+	routes, err := s.Storer.ListUDPRoutes()
+	return filterScopedRoutes(s, routes, err, func(r *gatewayv1alpha2.UDPRoute) []gatewayv1beta1.ParentReference {
+		return v1alpha2ParentRefsToV1beta1(r.Spec.ParentRefs)
+	})
+}
+
+func (s gatewayScopedStorer) ListTCPRoutes() ([]*gatewayv1alpha2.TCPRoute, error) {
+	routes, err := s.Storer.ListTCPRoutes()
+	return filterScopedRoutes(s, routes, err, func(r *gatewayv1alpha2.TCPRoute) []gatewayv1beta1.ParentReference {
+		return v1alpha2ParentRefsToV1beta1(r.Spec.ParentRefs)
+	})
+}
+
+func (s gatewayScopedStorer) ListTLSRoutes() ([]*gatewayv1alpha2.TLSRoute, error) {
+	routes, err := s.Storer.ListTLSRoutes()
+	return filterScopedRoutes(s, routes, err, func(r *gatewayv1alpha2.TLSRoute) []gatewayv1beta1.ParentReference {
+		return v1alpha2ParentRefsToV1beta1(r.Spec.ParentRefs)
+	})
+}
+
+// The configuration of a managed Gateway only includes the Gateway API routes attached to it, so the
+// objects translated independently of Gateways aren't listed for it.
+
+func (s gatewayScopedStorer) ListIngressesV1beta1() []*netv1beta1.Ingress {
+	if s.scope.Gateway != nil {
+		return nil
+	}
+	return s.Storer.ListIngressesV1beta1()
+}
+
+func (s gatewayScopedStorer) ListIngressesV1() []*netv1.Ingress {
+	if s.scope.Gateway != nil {
+		return nil
+	}
+	return s.Storer.ListIngressesV1()
+}
+
+func (s gatewayScopedStorer) ListTCPIngresses() ([]*configurationv1beta1.TCPIngress, error) {
+	if s.scope.Gateway != nil {
+		return nil, nil
+	}
+	return s.Storer.ListTCPIngresses()
+}
+
+func (s gatewayScopedStorer) ListUDPIngresses() ([]*configurationv1beta1.UDPIngress, error) {
+	if s.scope.Gateway != nil {
+		return nil, nil
+	}
+	return s.Storer.ListUDPIngresses()
+}
+
+func (s gatewayScopedStorer) ListKnativeIngresses() ([]*knative.Ingress, error) {
+	if s.scope.Gateway != nil {
+		return nil, nil
+	}
+	return s.Storer.ListKnativeIngresses()
+}
+
+func (s gatewayScopedStorer) ListGlobalKongPlugins() ([]*configurationv1.KongPlugin, error) {
+	if s.scope.Gateway != nil {
+		return nil, nil
+	}
+	return s.Storer.ListGlobalKongPlugins()
+}
+
+func (s gatewayScopedStorer) ListGlobalKongClusterPlugins() ([]*configurationv1.KongClusterPlugin, error) {
+	if s.scope.Gateway != nil {
+		return nil, nil
+	}
+	return s.Storer.ListGlobalKongClusterPlugins()
+}
+
+// filterScopedRoutes returns the routes belonging to the scope of the storer.
+func filterScopedRoutes[T interface{ GetNamespace() string }](
+	s gatewayScopedStorer, routes []T, err error, parentRefs func(T) []gatewayv1beta1.ParentReference,
+) ([]T, error) {
+	if err != nil {
+		return nil, err
+	}
+	var scoped []T
+	for _, route := range routes {
+		if s.includesParentRefs(route.GetNamespace(), parentRefs(route)) {
+			scoped = append(scoped, route)
+		}
+	}
+	return scoped, nil
+}
+
+// v1alpha2ParentRefsToV1beta1 converts the parentRefs of v1alpha2 routes to their v1beta1 equivalent.
+func v1alpha2ParentRefsToV1beta1(parentRefs []gatewayv1alpha2.ParentReference) []gatewayv1beta1.ParentReference {
+	converted := make([]gatewayv1beta1.ParentReference, 0, len(parentRefs))
+	for _, parentRef := range parentRefs {
+		ref := gatewayv1beta1.ParentReference{Name: gatewayv1beta1.ObjectName(parentRef.Name)}
+		if parentRef.Group != nil {
+			group := gatewayv1beta1.Group(*parentRef.Group)
+			ref.Group = &group
+		}
+		if parentRef.Kind != nil {
+			kind := gatewayv1beta1.Kind(*parentRef.Kind)
+			ref.Kind = &kind
+		}
+		if parentRef.Namespace != nil {
+			namespace := gatewayv1beta1.Namespace(*parentRef.Namespace)
+			ref.Namespace = &namespace
+		}
+		converted = append(converted, ref)
+	}
+	return converted
+}
@@ -0,0 +1,140 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	netv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+	gatewayv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/annotations"
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/store"
+)
+
+func TestGatewayScopedStorer(t *testing.T) {
+	otherNamespace := gatewayv1beta1.Namespace("other")
+	serviceKind := gatewayv1beta1.Kind("Service")
+	coreGroup := gatewayv1beta1.Group("")
+	httpRoute := func(name string, parentRefs ...gatewayv1beta1.ParentReference) *gatewayv1beta1.HTTPRoute {
+		return &gatewayv1beta1.HTTPRoute{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+			Spec: gatewayv1beta1.HTTPRouteSpec{
+				CommonRouteSpec: gatewayv1beta1.CommonRouteSpec{ParentRefs: parentRefs},
+			},
+		}
+	}
+	fakeStore, err := store.NewFakeStore(store.FakeObjects{
+		Gateways: []*gatewayv1beta1.Gateway{
+			{ObjectMeta: metav1.ObjectMeta{Name: "shared", Namespace: "default"}},
+			{ObjectMeta: metav1.ObjectMeta{Name: "managed", Namespace: "default"}},
+			{ObjectMeta: metav1.ObjectMeta{Name: "managed", Namespace: "other"}},
+		},
+		HTTPRoutes: []*gatewayv1beta1.HTTPRoute{
+			httpRoute("to-shared", gatewayv1beta1.ParentReference{Name: "shared"}),
+			httpRoute("to-managed", gatewayv1beta1.ParentReference{Name: "managed"}),
+			httpRoute("to-other-managed", gatewayv1beta1.ParentReference{Name: "managed", Namespace: &otherNamespace}),
+			httpRoute("to-both",
+				gatewayv1beta1.ParentReference{Name: "shared"},
+				gatewayv1beta1.ParentReference{Name: "managed"},
+			),
+			httpRoute("to-service", gatewayv1beta1.ParentReference{Name: "svc", Kind: &serviceKind, Group: &coreGroup}),
+		},
+		TCPRoutes: []*gatewayv1alpha2.TCPRoute{
+			{
+				ObjectMeta: metav1.ObjectMeta{Name: "tcp-to-managed", Namespace: "default"},
+				Spec: gatewayv1alpha2.TCPRouteSpec{
+					CommonRouteSpec: gatewayv1alpha2.CommonRouteSpec{
+						ParentRefs: []gatewayv1alpha2.ParentReference{{Name: "managed"}},
+					},
+				},
+			},
+		},
+		IngressesV1: []*netv1.Ingress{
+			{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "ingress",
+					Namespace:   "default",
+					Annotations: map[string]string{annotations.IngressClassKey: annotations.DefaultIngressClass},
+				},
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	managed := types.NamespacedName{Namespace: "default", Name: "managed"}
+	otherManaged := types.NamespacedName{Namespace: "other", Name: "managed"}
+
+	testCases := []struct {
+		name               string
+		scope              GatewayScope
+		expectedGateways   []string
+		expectedHTTPRoutes []string
+		expectedTCPRoutes  []string
+		expectedIngresses  int
+	}{
+		{
+			name:               "shared scope excludes the managed gateways and the routes attached only to them",
+			scope:              GatewayScope{ManagedGateways: sets.New(managed, otherManaged)},
+			expectedGateways:   []string{"default/shared"},
+			expectedHTTPRoutes: []string{"to-both", "to-service", "to-shared"},
+			expectedIngresses:  1,
+		},
+		{
+			name:               "managed gateway scope includes only the routes attached to it",
+			scope:              GatewayScope{Gateway: &managed, ManagedGateways: sets.New(managed, otherManaged)},
+			expectedGateways:   []string{"default/managed"},
+			expectedHTTPRoutes: []string{"to-both", "to-managed"},
+			expectedTCPRoutes:  []string{"tcp-to-managed"},
+		},
+		{
+			name:               "parentRefs default to the namespace of the route",
+			scope:              GatewayScope{Gateway: &otherManaged, ManagedGateways: sets.New(managed, otherManaged)},
+			expectedGateways:   []string{"other/managed"},
+			expectedHTTPRoutes: []string{"to-other-managed"},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			s := gatewayScopedStorer{Storer: fakeStore, scope: tc.scope}
+
+			gateways, err := s.ListGateways()
+			require.NoError(t, err)
+			assert.ElementsMatch(t, tc.expectedGateways, lo.Map(gateways, func(g *gatewayv1beta1.Gateway, _ int) string {
+				return g.Namespace + "/" + g.Name
+			}))
+
+			httpRoutes, err := s.ListHTTPRoutes()
+			require.NoError(t, err)
+			assert.ElementsMatch(t, tc.expectedHTTPRoutes, lo.Map(httpRoutes, func(r *gatewayv1beta1.HTTPRoute, _ int) string {
+				return r.Name
+			}))
+
+			tcpRoutes, err := s.ListTCPRoutes()
+			require.NoError(t, err)
+			assert.ElementsMatch(t, tc.expectedTCPRoutes, lo.Map(tcpRoutes, func(r *gatewayv1alpha2.TCPRoute, _ int) string {
+				return r.Name
+			}))
+
+			assert.Len(t, s.ListIngressesV1(), tc.expectedIngresses)
+		})
+	}
+}
+
+func TestGatewayScopeString(t *testing.T) {
+	managed := types.NamespacedName{Namespace: "default", Name: "managed"}
+	otherManaged := types.NamespacedName{Namespace: "other", Name: "managed"}
+
+	var noScope *GatewayScope
+	assert.Equal(t, "all", noScope.String())
+	assert.Equal(t, "default/managed", (&GatewayScope{Gateway: &managed}).String())
+	assert.Equal(t, "all-except=default/managed,other/managed",
+		(&GatewayScope{ManagedGateways: sets.New(otherManaged, managed)}).String())
+}
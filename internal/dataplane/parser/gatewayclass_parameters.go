@@ -0,0 +1,140 @@
+package parser
+
+import (
+	"fmt"
+
+	"github.com/kong/go-kong/kong"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+	gatewayv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/dataplane/kongstate"
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/dataplane/parser/translators"
+	configurationv1alpha1 "github.com/kong/kubernetes-ingress-controller/v2/pkg/apis/configuration/v1alpha1"
+)
+
+// -----------------------------------------------------------------------------
+// Parser - GatewayClassParameters
+// -----------------------------------------------------------------------------
+
+// fillGatewayClassDefaults applies the defaults configured by the GatewayClassParameters of GatewayClasses to
+// the services generated for the Gateway API routes attached to the Gateways of those classes. The upstream
+// timeouts and retries are applied before KongIngresses and annotations override them, and the default
+// plugins are attached to the services so that the plugins attached to the routes take precedence over them.
+// It returns the names of the services which must use the service-upstream mode.
+func (p *Parser) fillGatewayClassDefaults(services map[string]kongstate.Service) sets.Set[string] {
+	serviceUpstreams := sets.New[string]()
+	routeParams := make(map[client.Object]*configurationv1alpha1.GatewayClassParametersSpec)
+	for key, service := range services {
+		if service.Parent == nil {
+			continue
+		}
+		params, ok := routeParams[service.Parent]
+		if !ok {
+			params = p.gatewayClassParametersForRoute(service.Parent)
+			routeParams[service.Parent] = params
+		}
+		if params == nil {
+			continue
+		}
+
+		if params.ServiceUpstream {
+			serviceUpstreams.Insert(*service.Name)
+		}
+		if upstream := params.Upstream; upstream != nil {
+			if upstream.ConnectTimeout != nil {
+				service.ConnectTimeout = kong.Int(*upstream.ConnectTimeout)
+			}
+			if upstream.ReadTimeout != nil {
+				service.ReadTimeout = kong.Int(*upstream.ReadTimeout)
+			}
+			if upstream.WriteTimeout != nil {
+				service.WriteTimeout = kong.Int(*upstream.WriteTimeout)
+			}
+			if upstream.Retries != nil {
+				service.Retries = kong.Int(*upstream.Retries)
+			}
+		}
+		for _, name := range params.Plugins {
+			plugin, err := kongstate.PluginForKind(p.storer, "KongClusterPlugin", "", name)
+			if err != nil {
+				p.registerTranslationFailure(fmt.Sprintf("default KongClusterPlugin %s of the GatewayClass can't be applied: %s", name, err),
+					service.Parent)
+				continue
+			}
+			// the plugins generated for the service itself take precedence.
+			if hasServicePlugin(service, *plugin.Name) {
+				continue
+			}
+			service.Plugins = append(service.Plugins, plugin.Plugin)
+		}
+		if _, isHTTPRoute := service.Parent.(*gatewayv1beta1.HTTPRoute); isHTTPRoute &&
+			params.EnableLegacyRegexDetection && p.flagEnabledRegexPathPrefix {
+			for i := range service.Routes {
+				for j, path := range service.Routes[i].Paths {
+					// the paths which already are regular expressions are left unchanged.
+					service.Routes[i].Paths[j] = kong.String(maybePrependRegexPrefix(*path, translators.KongPathRegexPrefix, true))
+				}
+			}
+		}
+		services[key] = service
+	}
+	return serviceUpstreams
+}
+
+// hasServicePlugin returns true if the service has a plugin of the provided type.
+func hasServicePlugin(service kongstate.Service, name string) bool {
+	for _, plugin := range service.Plugins {
+		if plugin.Name != nil && *plugin.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// gatewayClassParametersForRoute returns the GatewayClassParameters of the class of the first Gateway the
+// provided Gateway API route is attached to. It returns nil for the objects which aren't Gateway API routes,
+// the routes which aren't attached to any known Gateway, and when the parameters can't be resolved.
+func (p *Parser) gatewayClassParametersForRoute(route client.Object) *configurationv1alpha1.GatewayClassParametersSpec {
+	var parentRefs []gatewayv1beta1.ParentReference
+	switch r := route.(type) {
+	case *gatewayv1beta1.HTTPRoute:
+		parentRefs = r.Spec.ParentRefs
+	case *gatewayv1alpha2.TCPRoute:
+		parentRefs = v1alpha2ParentRefsToV1beta1(r.Spec.ParentRefs)
+	case *gatewayv1alpha2.UDPRoute:
+		parentRefs = v1alpha2ParentRefsToV1beta1(r.Spec.ParentRefs)
+	case *gatewayv1alpha2.TLSRoute:
+		parentRefs = v1alpha2ParentRefsToV1beta1(r.Spec.ParentRefs)
+	default:
+		return nil
+	}
+
+	for _, parentRef := range parentRefs {
+		if (parentRef.Group != nil && *parentRef.Group != gatewayv1beta1.GroupName) ||
+			(parentRef.Kind != nil && *parentRef.Kind != KindGateway) {
+			continue
+		}
+		namespace := route.GetNamespace()
+		if parentRef.Namespace != nil {
+			namespace = string(*parentRef.Namespace)
+		}
+		gateway, err := p.storer.GetGateway(namespace, string(parentRef.Name))
+		if err != nil {
+			continue
+		}
+		gatewayClass, err := p.storer.GetGatewayClass(string(gateway.Spec.GatewayClassName))
+		if err != nil {
+			continue
+		}
+		params, err := p.storer.GetGatewayClassParametersV1Alpha1(gatewayClass)
+		if err != nil {
+			// the GatewayClass isn't accepted because of its parameters, which is reported in its status.
+			p.logger.WithError(err).Errorf("could not resolve the parameters of GatewayClass %s, using defaults", gatewayClass.Name)
+			return nil
+		}
+		return &params.Spec
+	}
+	return nil
+}
@@ -0,0 +1,167 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/kong/go-kong/kong"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	gatewayv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/annotations"
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/dataplane/kongstate"
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/store"
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/util/builder"
+	configurationv1 "github.com/kong/kubernetes-ingress-controller/v2/pkg/apis/configuration/v1"
+	configurationv1alpha1 "github.com/kong/kubernetes-ingress-controller/v2/pkg/apis/configuration/v1alpha1"
+)
+
+func TestFillGatewayClassDefaults(t *testing.T) {
+	gateway := func(name, className string) *gatewayv1beta1.Gateway {
+		return &gatewayv1beta1.Gateway{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: corev1.NamespaceDefault},
+			Spec: gatewayv1beta1.GatewaySpec{
+				GatewayClassName: gatewayv1beta1.ObjectName(className),
+				Listeners: []gatewayv1beta1.Listener{
+					{Name: "http", Protocol: gatewayv1beta1.HTTPProtocolType, Port: 80},
+				},
+			},
+		}
+	}
+	gatewayClass := func(name string, ref *gatewayv1beta1.ParametersReference) *gatewayv1beta1.GatewayClass {
+		return &gatewayv1beta1.GatewayClass{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec:       gatewayv1beta1.GatewayClassSpec{ParametersRef: ref},
+		}
+	}
+	httpRoute := func(name, gatewayName string) *gatewayv1beta1.HTTPRoute {
+		httproute := &gatewayv1beta1.HTTPRoute{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: corev1.NamespaceDefault},
+			Spec: gatewayv1beta1.HTTPRouteSpec{
+				CommonRouteSpec: commonRouteSpecMock(gatewayName),
+				Rules: []gatewayv1beta1.HTTPRouteRule{{
+					Matches: []gatewayv1beta1.HTTPRouteMatch{
+						builder.NewHTTPRouteMatch().WithPathPrefix("/v[0-9]+/api").Build(),
+						builder.NewHTTPRouteMatch().WithPathPrefix("/plain").Build(),
+					},
+					BackendRefs: []gatewayv1beta1.HTTPBackendRef{
+						builder.NewHTTPBackendRef("backend").WithPort(80).Build(),
+					},
+				}},
+			},
+		}
+		httproute.SetGroupVersionKind(httprouteGVK)
+		return httproute
+	}
+
+	fakestore, err := store.NewFakeStore(store.FakeObjects{
+		Gateways: []*gatewayv1beta1.Gateway{
+			gateway("with-params", "with-params"),
+			gateway("without-params", "without-params"),
+			gateway("broken-params", "broken-params"),
+		},
+		GatewayClasses: []*gatewayv1beta1.GatewayClass{
+			gatewayClass("with-params", &gatewayv1beta1.ParametersReference{
+				Group: gatewayv1beta1.Group(configurationv1alpha1.GroupVersion.Group),
+				Kind:  configurationv1alpha1.GatewayClassParametersKind,
+				Name:  "params",
+			}),
+			gatewayClass("without-params", nil),
+			gatewayClass("broken-params", &gatewayv1beta1.ParametersReference{
+				Group: gatewayv1beta1.Group(configurationv1alpha1.GroupVersion.Group),
+				Kind:  configurationv1alpha1.GatewayClassParametersKind,
+				Name:  "missing",
+			}),
+		},
+		GatewayClassParametersV1alpha1: []*configurationv1alpha1.GatewayClassParameters{{
+			ObjectMeta: metav1.ObjectMeta{Name: "params"},
+			Spec: configurationv1alpha1.GatewayClassParametersSpec{
+				ServiceUpstream:            true,
+				EnableLegacyRegexDetection: true,
+				Upstream: &configurationv1alpha1.GatewayClassUpstreamDefaults{
+					ConnectTimeout: lo.ToPtr(5000),
+					Retries:        lo.ToPtr(1),
+				},
+				Plugins: []string{"rate-limit", "missing"},
+			},
+		}},
+		HTTPRoutes: []*gatewayv1beta1.HTTPRoute{
+			httpRoute("with-params", "with-params"),
+			httpRoute("without-params", "without-params"),
+			httpRoute("broken-params", "broken-params"),
+		},
+		KongClusterPlugins: []*configurationv1.KongClusterPlugin{{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "rate-limit",
+				Annotations: map[string]string{annotations.IngressClassKey: annotations.DefaultIngressClass},
+			},
+			PluginName: "rate-limiting",
+		}},
+		Services: []*corev1.Service{{
+			ObjectMeta: metav1.ObjectMeta{Name: "backend", Namespace: corev1.NamespaceDefault},
+			Spec: corev1.ServiceSpec{
+				Ports: []corev1.ServicePort{{Name: "http", Protocol: corev1.ProtocolTCP, Port: 80}},
+			},
+		}},
+		Endpoints: []*corev1.Endpoints{{
+			ObjectMeta: metav1.ObjectMeta{Name: "backend", Namespace: corev1.NamespaceDefault},
+			Subsets: []corev1.EndpointSubset{{
+				Addresses: []corev1.EndpointAddress{{IP: "10.0.0.1"}},
+				Ports:     []corev1.EndpointPort{{Name: "http", Protocol: corev1.ProtocolTCP, Port: 80}},
+			}},
+		}},
+	})
+	require.NoError(t, err)
+
+	p := mustNewParser(t, fakestore)
+	p.EnableRegexPathPrefix()
+	state, translationFailures := p.Build()
+
+	services := lo.SliceToMap(state.Services, func(s kongstate.Service) (string, kongstate.Service) {
+		return *s.Name, s
+	})
+	upstreamTargets := lo.SliceToMap(state.Upstreams, func(u kongstate.Upstream) (string, []string) {
+		return *u.Name, lo.Map(u.Targets, func(t kongstate.Target, _ int) string { return *t.Target.Target })
+	})
+	routePaths := func(service kongstate.Service) []string {
+		var paths []string
+		for _, route := range service.Routes {
+			paths = append(paths, lo.Map(route.Paths, func(p *string, _ int) string { return *p })...)
+		}
+		return paths
+	}
+
+	t.Run("the defaults of the GatewayClassParameters are applied", func(t *testing.T) {
+		service, ok := services["httproute.default.with-params.0"]
+		require.True(t, ok)
+		assert.Equal(t, kong.Int(5000), service.ConnectTimeout)
+		assert.Equal(t, kong.Int(DefaultServiceTimeout), service.ReadTimeout)
+		assert.Equal(t, kong.Int(1), service.Retries)
+		require.Len(t, service.Plugins, 1)
+		assert.Equal(t, "rate-limiting", *service.Plugins[0].Name)
+		assert.ElementsMatch(t, []string{"~/v[0-9]+/api", "/plain"}, routePaths(service))
+		assert.Equal(t, []string{"backend.default.svc:80"}, upstreamTargets["httproute.default.with-params.0"])
+	})
+
+	t.Run("missing default plugins are reported", func(t *testing.T) {
+		require.Len(t, translationFailures, 1)
+		assert.Contains(t, translationFailures[0].Message(), "default KongClusterPlugin missing of the GatewayClass can't be applied")
+		assert.Equal(t, "with-params", translationFailures[0].CausingObjects()[0].GetName())
+	})
+
+	for _, name := range []string{"without-params", "broken-params"} {
+		name := name
+		t.Run("the routes of GatewayClasses "+name+" are left unchanged", func(t *testing.T) {
+			service, ok := services["httproute.default."+name+".0"]
+			require.True(t, ok)
+			assert.Equal(t, kong.Int(DefaultServiceTimeout), service.ConnectTimeout)
+			assert.Equal(t, kong.Int(DefaultRetries), service.Retries)
+			assert.Empty(t, service.Plugins)
+			assert.ElementsMatch(t, []string{"/v[0-9]+/api", "/plain"}, routePaths(service))
+			assert.Equal(t, []string{"10.0.0.1:80"}, upstreamTargets["httproute.default."+name+".0"])
+		})
+	}
+}
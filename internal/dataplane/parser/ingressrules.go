@@ -30,6 +30,20 @@ func newIngressRules() ingressRules {
 	}
 }
 
+// deepCopy returns a copy of the ingress rules which services can be modified without affecting
+// the original ones. SNIs are shared with the copy, as they're only read when merged.
+func (ir ingressRules) deepCopy() ingressRules {
+	out := ingressRules{
+		SecretNameToSNIs:      ir.SecretNameToSNIs,
+		ServiceNameToServices: make(map[string]kongstate.Service, len(ir.ServiceNameToServices)),
+	}
+	for name, service := range ir.ServiceNameToServices {
+		service := service
+		out.ServiceNameToServices[name] = *service.DeepCopy()
+	}
+	return out
+}
+
 func mergeIngressRules(objs ...ingressRules) ingressRules {
 	result := newIngressRules()
 
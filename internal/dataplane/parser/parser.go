@@ -3,17 +3,20 @@ package parser
 import (
 	"bytes"
 	"crypto/tls"
+	"crypto/x509"
 	"fmt"
 	"reflect"
 	"sort"
 	"strings"
 
+	"github.com/google/uuid"
 	"github.com/kong/go-kong/kong"
 	"github.com/sirupsen/logrus"
 	corev1 "k8s.io/api/core/v1"
 	netv1 "k8s.io/api/networking/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/sets"
 	knative "knative.dev/networking/pkg/apis/networking/v1alpha1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
@@ -24,6 +27,7 @@ import (
 	"github.com/kong/kubernetes-ingress-controller/v2/internal/dataplane/kongstate"
 	"github.com/kong/kubernetes-ingress-controller/v2/internal/store"
 	"github.com/kong/kubernetes-ingress-controller/v2/internal/util"
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/versions"
 	configurationv1alpha1 "github.com/kong/kubernetes-ingress-controller/v2/pkg/apis/configuration/v1alpha1"
 	configurationv1beta1 "github.com/kong/kubernetes-ingress-controller/v2/pkg/apis/configuration/v1beta1"
 )
@@ -44,15 +48,25 @@ const (
 // equivalent Kong objects and configurations, producing a complete
 // state configuration for the Kong Admin API.
 type Parser struct {
-	logger                      logrus.FieldLogger
-	storer                      store.Storer
-	configuredKubernetesObjects []client.Object
+	logger                       logrus.FieldLogger
+	storer                       store.Storer
+	configuredKubernetesObjects  []client.Object
+	kubernetesObjectExplanations util.KubernetesObjectExplanations
 
 	featureEnabledReportConfiguredKubernetesObjects bool
+	featureEnabledExplainKubernetesObjects          bool
 	featureEnabledCombinedServiceRoutes             bool
+	featureEnabledExpressionRoutes                  bool
 
-	flagEnabledRegexPathPrefix bool
-	failuresCollector          *failures.ResourceFailuresCollector
+	flagEnabledRegexPathPrefix      bool
+	flagEnabledDualCertificates     bool
+	flagEnabledQueryParamMatches    bool
+	flagEnabledPreFunctionFilters   bool
+	flagEnabledRequestMirrorFilters bool
+	failuresCollector               *failures.ResourceFailuresCollector
+
+	gatewayScope     *GatewayScope
+	translationCache *TranslationCache
 }
 
 // NewParser produces a new Parser object provided a logging mechanism
@@ -94,6 +108,9 @@ func (p *Parser) Build() (*kongstate.KongState, []failures.ResourceFailure) {
 		p.ingressRulesFromTLSRoutes(),
 	)
 
+	// apply the defaults of the GatewayClasses to the services of the Gateway API routes
+	serviceUpstreams := p.fillGatewayClassDefaults(ingressRules.ServiceNameToServices)
+
 	// populate any Kubernetes Service objects relevant objects and get the
 	// services to be skipped because of annotations inconsistency
 	servicesToBeSkipped := ingressRules.populateServices(p.logger, p.storer, p.failuresCollector)
@@ -109,7 +126,7 @@ func (p *Parser) Build() (*kongstate.KongState, []failures.ResourceFailure) {
 	}
 
 	// generate Upstreams and Targets from service defs
-	result.Upstreams = p.getUpstreams(ingressRules.ServiceNameToServices)
+	result.Upstreams = p.getUpstreams(ingressRules.ServiceNameToServices, serviceUpstreams)
 
 	// merge KongIngress with Routes, Services and Upstream
 	result.FillOverrides(p.logger, p.storer)
@@ -118,7 +135,7 @@ func (p *Parser) Build() (*kongstate.KongState, []failures.ResourceFailure) {
 	result.FillConsumersAndCredentials(p.logger, p.storer)
 
 	// process annotation plugins
-	result.FillPlugins(p.logger, p.storer)
+	result.FillPlugins(p.logger, p.storer, p.failuresCollector)
 
 	// generate Certificates and SNIs
 	ingressCerts := p.getCerts(ingressRules.SecretNameToSNIs)
@@ -129,7 +146,17 @@ func (p *Parser) Build() (*kongstate.KongState, []failures.ResourceFailure) {
 	// populate CA certificates in Kong
 	result.CACertificates = p.getCACerts()
 
-	return &result, p.popTranslationFailures()
+	// translations of the objects which are gone are not needed anymore
+	if p.translationCache != nil {
+		p.translationCache.prune()
+	}
+
+	translationFailures := p.popTranslationFailures()
+	if p.featureEnabledExplainKubernetesObjects {
+		certs := append(append([]certWrapper{}, ingressCerts...), gatewayCerts...)
+		p.kubernetesObjectExplanations = explainKongState(p.storer, &result, certs, translationFailures)
+	}
+	return &result, translationFailures
 }
 
 // -----------------------------------------------------------------------------
@@ -163,6 +190,23 @@ func (p *Parser) GenerateKubernetesObjectReport() []client.Object {
 	return report
 }
 
+// EnableKubernetesObjectExplanations turns on object explanations for this parser:
+// each subsequent call to Build() will index the Kong entities it generated, along
+// with the translation failures, by the Kubernetes objects they originate from.
+// The index can be retrieved by calling GenerateKubernetesObjectExplanations().
+func (p *Parser) EnableKubernetesObjectExplanations() {
+	p.featureEnabledExplainKubernetesObjects = true
+}
+
+// GenerateKubernetesObjectExplanations provides the explanations of the Kubernetes
+// objects built by the last Build() call. The explanations are consumed: the
+// parser won't provide them again until another build is run.
+func (p *Parser) GenerateKubernetesObjectExplanations() util.KubernetesObjectExplanations {
+	explanations := p.kubernetesObjectExplanations
+	p.kubernetesObjectExplanations = nil
+	return explanations
+}
+
 // -----------------------------------------------------------------------------
 // Parser - Public Methods - Other Optional Features
 // -----------------------------------------------------------------------------
@@ -175,6 +219,13 @@ func (p *Parser) EnableCombinedServiceRoutes() {
 	p.featureEnabledCombinedServiceRoutes = true
 }
 
+// EnableExpressionRoutes makes the parser translate HTTPRoutes into Kong routes matching requests
+// with expressions, instead of the traditional route fields. It must only be enabled when Kong runs
+// the expressions router, which also makes matching query parameters possible.
+func (p *Parser) EnableExpressionRoutes() {
+	p.featureEnabledExpressionRoutes = true
+}
+
 // EnableRegexPathPrefix enables adding the Kong 3.x+ regex path prefix on regex paths generated by the controller
 // (to satisfy the Ingress Prefix and Exact path types) or indicated by a resource (e.g. when an HTTPRoute uses a
 // RegularExpression Match). It does _not_ enable heuristic regex path detection for Ingress ImplementationSpecific
@@ -183,6 +234,39 @@ func (p *Parser) EnableRegexPathPrefix() {
 	p.flagEnabledRegexPathPrefix = true
 }
 
+// EnableDualCertificates enables translating Gateway listeners referencing two certificates with different
+// key types into Kong certificates with an alternative certificate (cert_alt and key_alt), which requires
+// Kong 2.3 or later.
+func (p *Parser) EnableDualCertificates() {
+	p.flagEnabledDualCertificates = true
+}
+
+// EnableQueryParamMatches enables translating the query param matches of HTTPRoutes into expression routes
+// matching the http.queries field, which requires Kong 3.4 or later. Query param matches are only translated
+// when the expression routes are enabled as well.
+func (p *Parser) EnableQueryParamMatches() {
+	p.flagEnabledQueryParamMatches = true
+}
+
+// EnablePreFunctionFilters enables translating the HTTPRoute filters which Kong has no plugin for
+// (RequestRedirect and URLRewrite) into pre-function plugins, which requires Kong to run untrusted
+// Lua code (its untrusted_lua setting not being off).
+func (p *Parser) EnablePreFunctionFilters() {
+	p.flagEnabledPreFunctionFilters = true
+}
+
+// EnableRequestMirrorFilters enables translating HTTPRoute RequestMirror filters into pre-function plugins,
+// which requires the untrusted Lua code run by Kong to be allowed to require resty.http and access ngx.timer.
+func (p *Parser) EnableRequestMirrorFilters() {
+	p.flagEnabledRequestMirrorFilters = true
+}
+
+// EnableTranslationCache makes the parser reuse the translations of the objects which have not changed
+// since they were stored in the provided cache, instead of translating them again on every build.
+func (p *Parser) EnableTranslationCache(cache *TranslationCache) {
+	p.translationCache = cache
+}
+
 // -----------------------------------------------------------------------------
 // Parser - Private Methods
 // -----------------------------------------------------------------------------
@@ -267,7 +351,7 @@ func findPort(svc *corev1.Service, wantPort kongstate.PortDef) (*corev1.ServiceP
 	return nil, fmt.Errorf("no suitable port found")
 }
 
-func (p *Parser) getUpstreams(serviceMap map[string]kongstate.Service) []kongstate.Upstream {
+func (p *Parser) getUpstreams(serviceMap map[string]kongstate.Service, serviceUpstreams sets.Set[string]) []kongstate.Upstream {
 	upstreamDedup := make(map[string]struct{}, len(serviceMap))
 	var empty struct{}
 	upstreams := make([]kongstate.Upstream, 0, len(serviceMap))
@@ -302,7 +386,7 @@ func (p *Parser) getUpstreams(serviceMap map[string]kongstate.Service) []kongsta
 				}
 
 				// get the new targets for this backend service
-				newTargets := getServiceEndpoints(p.logger, p.storer, k8sService, port)
+				newTargets := getServiceEndpoints(p.logger, p.storer, k8sService, port, serviceUpstreams.Has(*service.Name))
 
 				if len(newTargets) == 0 {
 					p.logger.WithField("service_name", *service.Name).Infof("no targets could be found for kubernetes service %s/%s", k8sService.Namespace, k8sService.Name)
@@ -385,6 +469,9 @@ type certWrapper struct {
 	cert              kong.Certificate
 	snis              []string
 	CreationTimestamp metav1.Time
+
+	// parents are the objects the certificate was generated for, including the Secret it comes from.
+	parents []client.Object
 }
 
 func (p *Parser) getGatewayCerts() []certWrapper {
@@ -424,61 +511,104 @@ func (p *Parser) getGatewayCerts() []certWrapper {
 			if !ready {
 				continue
 			}
-			if listener.TLS != nil {
-				if len(listener.TLS.CertificateRefs) > 0 {
-					if len(listener.TLS.CertificateRefs) > 1 {
-						// TODO support cert_alt and key_alt if there are 2 SecretObjectReferences
-						// https://github.com/Kong/kubernetes-ingress-controller/issues/2604
-						p.registerTranslationFailure("listener '%s' has more than one certificateRef, it's not supported", gateway)
-						continue
-					}
+			if listener.TLS != nil && len(listener.TLS.CertificateRefs) > 0 {
+				if cert, ok := p.getGatewayListenerCert(gateway, listener); ok {
+					certs = append(certs, cert)
+				}
+			}
+		}
+	}
+	return certs
+}
 
-					// determine the Secret Namespace
-					ref := listener.TLS.CertificateRefs[0]
-					namespace := gateway.Namespace
-					if ref.Namespace != nil {
-						namespace = string(*ref.Namespace)
-					}
+// getGatewayListenerCert returns the certificate served by a TLS Gateway listener. A listener may reference
+// two certificates with different key types, e.g. RSA and ECDSA, which are translated into a single Kong
+// certificate serving the second one as the alternative certificate (cert_alt and key_alt).
+func (p *Parser) getGatewayListenerCert(gateway *gatewayv1beta1.Gateway, listener gatewayv1beta1.Listener) (certWrapper, bool) {
+	refs := listener.TLS.CertificateRefs
+	switch {
+	case len(refs) > 2:
+		p.registerTranslationFailure(fmt.Sprintf("listener '%s' has more than two certificateRefs, it's not supported", listener.Name), gateway)
+		return certWrapper{}, false
+	case len(refs) == 2 && !p.flagEnabledDualCertificates:
+		p.registerTranslationFailure(fmt.Sprintf("listener '%s' has two certificateRefs, which requires Kong %s or later",
+			listener.Name, versions.DualCertificateVersionCutoff), gateway)
+		return certWrapper{}, false
+	}
 
-					// retrieve the Secret and extract the PEM strings
-					secret, err := s.GetSecret(namespace, string(ref.Name))
-					if err != nil {
-						log.WithFields(logrus.Fields{
-							"gateway":          gateway.Name,
-							"listener":         listener.Name,
-							"secret_name":      string(ref.Name),
-							"secret_namespace": namespace,
-						}).WithError(err).Error("failed to fetch secret")
-						continue
-					}
-					cert, key, err := getCertFromSecret(secret)
-					if err != nil {
-						p.registerTranslationFailure("failed to construct certificate from secret", secret, gateway)
-						continue
-					}
+	type keyPair struct {
+		cert      string
+		key       string
+		algorithm x509.PublicKeyAlgorithm
+		secret    *corev1.Secret
+	}
+	pairs := make([]keyPair, 0, len(refs))
+	for _, ref := range refs {
+		// determine the Secret Namespace
+		namespace := gateway.Namespace
+		if ref.Namespace != nil {
+			namespace = string(*ref.Namespace)
+		}
 
-					// determine the SNI
-					hostname := "*"
-					if listener.Hostname != nil {
-						hostname = string(*listener.Hostname)
-					}
+		// retrieve the Secret and extract the PEM strings
+		secret, err := p.storer.GetSecret(namespace, string(ref.Name))
+		if err != nil {
+			p.logger.WithFields(logrus.Fields{
+				"gateway":          gateway.Name,
+				"listener":         listener.Name,
+				"secret_name":      string(ref.Name),
+				"secret_namespace": namespace,
+			}).WithError(err).Error("failed to fetch secret")
+			return certWrapper{}, false
+		}
+		cert, key, err := getCertFromSecret(secret)
+		if err != nil {
+			p.registerTranslationFailure("failed to construct certificate from secret", secret, gateway)
+			return certWrapper{}, false
+		}
+		algorithm, err := util.GetCertificateKeyAlgorithm([]byte(cert))
+		if err != nil {
+			p.registerTranslationFailure(fmt.Sprintf("failed to determine the key type of the certificate: %s", err), secret, gateway)
+			return certWrapper{}, false
+		}
+		pairs = append(pairs, keyPair{cert: cert, key: key, algorithm: algorithm, secret: secret})
+	}
 
-					// create a Kong certificate, wrap it in metadata, and add it to the certs slice
-					certs = append(certs, certWrapper{
-						identifier: cert + key,
-						cert: kong.Certificate{
-							ID:   kong.String(string(secret.UID)),
-							Cert: kong.String(cert),
-							Key:  kong.String(key),
-						},
-						CreationTimestamp: secret.CreationTimestamp,
-						snis:              []string{hostname},
-					})
-				}
-			}
+	// determine the SNI
+	hostname := "*"
+	if listener.Hostname != nil {
+		hostname = string(*listener.Hostname)
+	}
+
+	// create a Kong certificate and wrap it in metadata
+	primary := pairs[0]
+	cert := certWrapper{
+		identifier: primary.cert + primary.key,
+		cert: kong.Certificate{
+			ID:   kong.String(string(primary.secret.UID)),
+			Cert: kong.String(primary.cert),
+			Key:  kong.String(primary.key),
+		},
+		CreationTimestamp: primary.secret.CreationTimestamp,
+		snis:              []string{hostname},
+		parents:           []client.Object{gateway, primary.secret},
+	}
+	if len(pairs) == 2 {
+		alt := pairs[1]
+		if alt.algorithm == primary.algorithm {
+			p.registerTranslationFailure(fmt.Sprintf("the certificates of listener '%s' must have different key types, both are %s",
+				listener.Name, primary.algorithm), gateway, primary.secret, alt.secret)
+			return certWrapper{}, false
 		}
+		// the primary Secret may also be served alone by other listeners, so the dual certificate
+		// needs its own ID, derived from both Secrets to remain stable across translations.
+		cert.identifier += alt.cert + alt.key
+		cert.cert.ID = kong.String(uuid.NewSHA1(uuid.NameSpaceOID, []byte(string(primary.secret.UID)+"/"+string(alt.secret.UID))).String())
+		cert.cert.CertAlt = kong.String(alt.cert)
+		cert.cert.KeyAlt = kong.String(alt.key)
+		cert.parents = append(cert.parents, alt.secret)
 	}
-	return certs
+	return cert, true
 }
 
 func (p *Parser) getCerts(secretsToSNIs SecretNameToSNIs) []certWrapper {
@@ -506,6 +636,7 @@ func (p *Parser) getCerts(secretsToSNIs SecretNameToSNIs) []certWrapper {
 			},
 			CreationTimestamp: secret.CreationTimestamp,
 			snis:              SNIs.Hosts(),
+			parents:           append(SNIs.Parents(), secret),
 		})
 	}
 
@@ -572,6 +703,7 @@ func getServiceEndpoints(
 	s store.Storer,
 	svc *corev1.Service,
 	servicePort *corev1.ServicePort,
+	serviceUpstream bool,
 ) []kongstate.Target {
 	log = log.WithFields(logrus.Fields{
 		"service_name":      svc.Name,
@@ -584,13 +716,13 @@ func getServiceEndpoints(
 	// for TCP as this is the default protocol for service ports.
 	protocols := listProtocols(svc)
 
-	// Check if the service is an upstream service through Ingress Class parameters.
-	var isSvcUpstream bool
+	// Check if the service is an upstream service through GatewayClass or Ingress Class parameters.
+	isSvcUpstream := serviceUpstream
 	ingressClassParameters, err := getIngressClassParametersOrDefault(s)
 	if err != nil {
 		log.Debugf("error getting an IngressClassParameters: %v", err)
 	} else {
-		isSvcUpstream = ingressClassParameters.ServiceUpstream
+		isSvcUpstream = isSvcUpstream || ingressClassParameters.ServiceUpstream
 	}
 
 	// check all protocols for associated endpoints
@@ -1,6 +1,7 @@
 package parser
 
 import (
+	"crypto/x509"
 	"fmt"
 	"reflect"
 	"sort"
@@ -9,6 +10,7 @@ import (
 	"time"
 
 	"github.com/kong/go-kong/kong"
+	"github.com/samber/lo"
 	"github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -20,11 +22,13 @@ import (
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	knative "knative.dev/networking/pkg/apis/networking/v1alpha1"
+	gatewayv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
 
 	"github.com/kong/kubernetes-ingress-controller/v2/internal/annotations"
 	"github.com/kong/kubernetes-ingress-controller/v2/internal/dataplane/kongstate"
 	"github.com/kong/kubernetes-ingress-controller/v2/internal/store"
 	"github.com/kong/kubernetes-ingress-controller/v2/internal/util"
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/util/test/certificate"
 	configurationv1 "github.com/kong/kubernetes-ingress-controller/v2/pkg/apis/configuration/v1"
 )
 
@@ -4840,6 +4844,129 @@ func TestCertificate(t *testing.T) {
 	})
 }
 
+func TestGetGatewayCerts_DualCertificates(t *testing.T) {
+	newSecret := func(name string, algorithm x509.PublicKeyAlgorithm) *corev1.Secret {
+		cert, key := certificate.SelfSigned(t, "example.com", algorithm)
+		return &corev1.Secret{
+			TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "Secret"},
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "default",
+				Name:      name,
+				UID:       types.UID(name + "-uid"),
+			},
+			Data: map[string][]byte{
+				corev1.TLSCertKey:       cert,
+				corev1.TLSPrivateKeyKey: key,
+			},
+		}
+	}
+	rsaSecret := newSecret("rsa", x509.RSA)
+	anotherRSASecret := newSecret("another-rsa", x509.RSA)
+	ecdsaSecret := newSecret("ecdsa", x509.ECDSA)
+
+	newGateway := func(secrets ...string) *gatewayv1beta1.Gateway {
+		listener := gatewayv1beta1.Listener{
+			Name:     "https",
+			Port:     443,
+			Protocol: gatewayv1beta1.HTTPSProtocolType,
+			Hostname: lo.ToPtr(gatewayv1beta1.Hostname("example.com")),
+			TLS:      &gatewayv1beta1.GatewayTLSConfig{},
+		}
+		for _, secret := range secrets {
+			listener.TLS.CertificateRefs = append(listener.TLS.CertificateRefs, gatewayv1beta1.SecretObjectReference{
+				Name: gatewayv1beta1.ObjectName(secret),
+			})
+		}
+		return &gatewayv1beta1.Gateway{
+			TypeMeta:   metav1.TypeMeta{APIVersion: gatewayv1beta1.GroupVersion.String(), Kind: "Gateway"},
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "gateway"},
+			Spec:       gatewayv1beta1.GatewaySpec{Listeners: []gatewayv1beta1.Listener{listener}},
+			Status: gatewayv1beta1.GatewayStatus{
+				Listeners: []gatewayv1beta1.ListenerStatus{{
+					Name: "https",
+					Conditions: []metav1.Condition{{
+						Type:   string(gatewayv1beta1.ListenerConditionReady),
+						Status: metav1.ConditionTrue,
+						Reason: string(gatewayv1beta1.ListenerReasonReady),
+					}},
+				}},
+			},
+		}
+	}
+
+	for _, tc := range []struct {
+		name                   string
+		gateway                *gatewayv1beta1.Gateway
+		dualCertificates       bool
+		expectedCertAlt        bool
+		expectedTranslationErr bool
+	}{
+		{
+			name:             "RSA and ECDSA certificates are served as alternatives of each other",
+			gateway:          newGateway("rsa", "ecdsa"),
+			dualCertificates: true,
+			expectedCertAlt:  true,
+		},
+		{
+			name:                   "certificates with the same key type are rejected",
+			gateway:                newGateway("rsa", "another-rsa"),
+			dualCertificates:       true,
+			expectedTranslationErr: true,
+		},
+		{
+			name:                   "more than two certificates are rejected",
+			gateway:                newGateway("rsa", "ecdsa", "another-rsa"),
+			dualCertificates:       true,
+			expectedTranslationErr: true,
+		},
+		{
+			name:                   "two certificates are rejected when Kong doesn't support alternative certificates",
+			gateway:                newGateway("rsa", "ecdsa"),
+			expectedTranslationErr: true,
+		},
+		{
+			name:    "a single certificate doesn't require alternative certificates support",
+			gateway: newGateway("ecdsa"),
+		},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			fakestore, err := store.NewFakeStore(store.FakeObjects{
+				Gateways: []*gatewayv1beta1.Gateway{tc.gateway},
+				Secrets:  []*corev1.Secret{rsaSecret, anotherRSASecret, ecdsaSecret},
+			})
+			require.NoError(t, err)
+			p := mustNewParser(t, fakestore)
+			if tc.dualCertificates {
+				p.EnableDualCertificates()
+			}
+
+			certs := p.getGatewayCerts()
+			if tc.expectedTranslationErr {
+				require.Empty(t, certs)
+				require.Len(t, p.popTranslationFailures(), 1)
+				return
+			}
+			require.Empty(t, p.popTranslationFailures())
+			require.Len(t, certs, 1)
+			cert := certs[0].cert
+			assert.Equal(t, []string{"example.com"}, certs[0].snis)
+			if !tc.expectedCertAlt {
+				assert.Nil(t, cert.CertAlt)
+				assert.Nil(t, cert.KeyAlt)
+				return
+			}
+
+			assert.Equal(t, strings.TrimSpace(string(rsaSecret.Data[corev1.TLSCertKey])), *cert.Cert)
+			assert.Equal(t, strings.TrimSpace(string(ecdsaSecret.Data[corev1.TLSCertKey])), *cert.CertAlt)
+			assert.Equal(t, strings.TrimSpace(string(ecdsaSecret.Data[corev1.TLSPrivateKeyKey])), *cert.KeyAlt)
+			assert.NotEqual(t, string(rsaSecret.UID), *cert.ID, "the dual certificate must not reuse the ID of its primary Secret")
+			assert.Equal(t, *cert.ID, *p.getGatewayCerts()[0].cert.ID, "the ID of the dual certificate must be stable")
+			assert.Len(t, certs[0].parents, 3)
+		})
+	}
+}
+
 func mustNewParser(t *testing.T, storer store.Storer) *Parser {
 	p, err := NewParser(logrus.New(), storer)
 	require.NoError(t, err)
@@ -5,6 +5,6 @@ import "errors"
 var (
 	errRouteValidationNoRules                          = errors.New("no rules provided")
 	errRouteValidationMissingBackendRefs               = errors.New("missing backendRef in rule")
-	errRouteValidationQueryParamMatchesUnsupported     = errors.New("query param matches are not yet supported")
+	errRouteValidationQueryParamMatchesUnsupported     = errors.New("query param matches are only supported with the expressions router of Kong")
 	errRouteValidationNoMatchRulesOrHostnamesSpecified = errors.New("no match rules or hostnames specified")
 )
@@ -0,0 +1,242 @@
+package parser
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/kong/go-kong/kong"
+	gatewayv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/dataplane/kongstate"
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/util"
+)
+
+// -----------------------------------------------------------------------------
+// Translate HTTPRoute - Expression Routes
+// -----------------------------------------------------------------------------
+
+// Kong routes generated for the expressions router match requests with a single expression
+// instead of the traditional route fields (hosts, paths, methods and headers). Expressions
+// are able to match request properties which the traditional fields can't, such as query
+// parameters. See https://docs.konghq.com/gateway/latest/reference/expressions-language/.
+
+// generateKongExpressionRouteFromHTTPRouteMatches converts HTTPRouteMatches to a Kong Route object
+// matching requests with an expression. Like generateKongRouteFromHTTPRouteMatches, it assumes that
+// the HTTPRouteMatches share the query params, headers and methods.
+func generateKongExpressionRouteFromHTTPRouteMatches(
+	routeName string,
+	matches []gatewayv1beta1.HTTPRouteMatch,
+	ingressObjectInfo util.K8sObjectInfo,
+	hostnames []*string,
+	plugins []kong.Plugin,
+) (kongstate.Route, error) {
+	// as with the traditional routes, an HTTPRoute without matches routes all the traffic
+	// for its hostnames, so it needs to have some.
+	if len(matches) == 0 && len(hostnames) == 0 {
+		return kongstate.Route{}, errRouteValidationNoMatchRulesOrHostnamesSpecified
+	}
+
+	predicates := make([]string, 0, 5)
+	if hostsPredicate := hostnamesExpression(hostnames); hostsPredicate != "" {
+		predicates = append(predicates, hostsPredicate)
+	}
+
+	if len(matches) > 0 {
+		matchPredicates, err := httpRouteMatchesExpressions(matches)
+		if err != nil {
+			return kongstate.Route{}, err
+		}
+		predicates = append(predicates, matchPredicates...)
+	}
+
+	// the expression of a route matching everything must still have a predicate.
+	if len(predicates) == 0 {
+		predicates = append(predicates, `http.path ^= "/"`)
+	}
+
+	r := kongstate.Route{
+		Ingress: ingressObjectInfo,
+		Route: kong.Route{
+			Name:         kong.String(routeName),
+			Protocols:    kong.StringSlice("http", "https"),
+			PreserveHost: kong.Bool(true),
+			Expression:   kong.String(expressionAnd(predicates...)),
+		},
+	}
+
+	// stripPath needs to be disabled by default to be conformant with the Gateway API
+	if len(matches) > 0 {
+		r.StripPath = kong.Bool(false)
+	}
+
+	// attach the plugins to be applied to the given route
+	if len(plugins) != 0 {
+		r.Plugins = append(r.Plugins, plugins...)
+	}
+
+	return r, nil
+}
+
+// httpRouteMatchesExpressions returns the predicates matching requests which match any of
+// the provided HTTPRouteMatches, which share the query params, headers and methods.
+func httpRouteMatchesExpressions(matches []gatewayv1beta1.HTTPRouteMatch) ([]string, error) {
+	var predicates []string
+
+	// requests match any of the paths, unless one of the matches doesn't restrict the path.
+	pathPredicates := make([]string, 0, len(matches))
+	for _, match := range matches {
+		if match.Path == nil {
+			pathPredicates = nil
+			break
+		}
+		pathPredicate, err := httpRoutePathExpression(*match.Path)
+		if err != nil {
+			return nil, err
+		}
+		pathPredicates = append(pathPredicates, pathPredicate)
+	}
+	if len(pathPredicates) > 0 {
+		predicates = append(predicates, expressionOr(pathPredicates...))
+	}
+
+	match := matches[0]
+	if match.Method != nil {
+		predicates = append(predicates, fmt.Sprintf("http.method == %s", expressionString(string(*match.Method))))
+	}
+
+	// According to the spec of HTTPHeaderMatch, only the first of the entries with equivalent
+	// (case-insensitive) header names must be considered for a match.
+	seenHeaders := make(map[string]struct{}, len(match.Headers))
+	for _, header := range match.Headers {
+		name := strings.ToLower(string(header.Name))
+		if _, ok := seenHeaders[name]; ok {
+			continue
+		}
+		seenHeaders[name] = struct{}{}
+
+		field := "http.headers." + strings.ReplaceAll(name, "-", "_")
+		switch {
+		case header.Type == nil || *header.Type == gatewayv1beta1.HeaderMatchExact:
+			predicates = append(predicates, fmt.Sprintf("%s == %s", field, expressionString(header.Value)))
+		case *header.Type == gatewayv1beta1.HeaderMatchRegularExpression:
+			predicates = append(predicates, fmt.Sprintf("%s ~ %s", field, expressionRegex(header.Value)))
+		default:
+			return nil, fmt.Errorf("unknown/unsupported header match type: %s", string(*header.Type))
+		}
+	}
+
+	// According to the spec of HTTPQueryParamMatch, only the first of the entries with
+	// equivalent query param names must be considered for a match.
+	seenQueryParams := make(map[string]struct{}, len(match.QueryParams))
+	for _, queryParam := range match.QueryParams {
+		if _, ok := seenQueryParams[queryParam.Name]; ok {
+			continue
+		}
+		seenQueryParams[queryParam.Name] = struct{}{}
+
+		field := "http.queries." + queryParam.Name
+		switch {
+		case queryParam.Type == nil || *queryParam.Type == gatewayv1beta1.QueryParamMatchExact:
+			predicates = append(predicates, fmt.Sprintf("%s == %s", field, expressionString(queryParam.Value)))
+		case *queryParam.Type == gatewayv1beta1.QueryParamMatchRegularExpression:
+			predicates = append(predicates, fmt.Sprintf("%s ~ %s", field, expressionRegex(queryParam.Value)))
+		default:
+			return nil, fmt.Errorf("unknown/unsupported query param match type: %s", string(*queryParam.Type))
+		}
+	}
+
+	return predicates, nil
+}
+
+// httpRoutePathExpression returns the predicate matching the paths of requests against an HTTPPathMatch.
+func httpRoutePathExpression(path gatewayv1beta1.HTTPPathMatch) (string, error) {
+	value := "/"
+	if path.Value != nil {
+		value = *path.Value
+	}
+
+	if path.Type == nil {
+		return pathPrefixExpression(value), nil
+	}
+	switch *path.Type {
+	case gatewayv1beta1.PathMatchExact:
+		return fmt.Sprintf("http.path == %s", expressionString(value)), nil
+	case gatewayv1beta1.PathMatchPathPrefix:
+		return pathPrefixExpression(value), nil
+	case gatewayv1beta1.PathMatchRegularExpression:
+		return fmt.Sprintf("http.path ~ %s", expressionRegex(value)), nil
+	}
+	return "", fmt.Errorf("unknown/unsupported path match type: %s", string(*path.Type))
+}
+
+// pathPrefixExpression returns the predicate of a PathPrefix match. Prefixes match
+// whole path elements: /foo matches /foo and /foo/bar, but not /foobar.
+func pathPrefixExpression(prefix string) string {
+	if strings.HasSuffix(prefix, "/") {
+		return fmt.Sprintf("http.path ^= %s", expressionString(prefix))
+	}
+	return expressionOr(
+		fmt.Sprintf("http.path == %s", expressionString(prefix)),
+		fmt.Sprintf("http.path ^= %s", expressionString(prefix+"/")),
+	)
+}
+
+// hostnamesExpression returns the predicate matching requests for any of the provided
+// hostnames, or an empty string when there are none.
+func hostnamesExpression(hostnames []*string) string {
+	predicates := make([]string, 0, len(hostnames))
+	for _, hostname := range hostnames {
+		if strings.HasPrefix(*hostname, "*") {
+			predicates = append(predicates, fmt.Sprintf("http.host =^ %s", expressionString(strings.TrimPrefix(*hostname, "*"))))
+			continue
+		}
+		predicates = append(predicates, fmt.Sprintf("http.host == %s", expressionString(*hostname)))
+	}
+	return expressionOr(predicates...)
+}
+
+// expressionAnd returns the expression matching when all the provided predicates match.
+func expressionAnd(predicates ...string) string {
+	return joinExpressions(predicates, " && ")
+}
+
+// expressionOr returns the expression matching when any of the provided predicates matches.
+func expressionOr(predicates ...string) string {
+	return joinExpressions(predicates, " || ")
+}
+
+func joinExpressions(predicates []string, operator string) string {
+	switch len(predicates) {
+	case 0:
+		return ""
+	case 1:
+		return predicates[0]
+	}
+	grouped := make([]string, 0, len(predicates))
+	for _, predicate := range predicates {
+		grouped = append(grouped, "("+predicate+")")
+	}
+	return strings.Join(grouped, operator)
+}
+
+// expressionString returns the expression string literal of the provided value.
+func expressionString(value string) string {
+	return `"` + expressionStringReplacer.Replace(value) + `"`
+}
+
+var expressionStringReplacer = strings.NewReplacer(
+	`\`, `\\`,
+	`"`, `\"`,
+	"\n", `\n`,
+	"\r", `\r`,
+	"\t", `\t`,
+)
+
+// expressionRegex returns the expression literal of the provided regular expression. Raw
+// string literals are used where possible, sparing the escaping of the regex's backslashes.
+func expressionRegex(regex string) string {
+	if strings.Contains(regex, `"#`) {
+		return expressionString(regex)
+	}
+	return `r#"` + regex + `"#`
+}
@@ -0,0 +1,162 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/kong/go-kong/kong"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	gatewayv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/store"
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/util"
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/util/builder"
+)
+
+func TestGenerateKongExpressionRouteFromHTTPRouteMatches(t *testing.T) {
+	for _, tc := range []struct {
+		name               string
+		matches            []gatewayv1beta1.HTTPRouteMatch
+		hostnames          []*string
+		expectedExpression string
+		expectedErr        error
+	}{
+		{
+			name: "prefix and exact query param",
+			matches: []gatewayv1beta1.HTTPRouteMatch{
+				builder.NewHTTPRouteMatch().WithPathPrefix("/api").WithQueryParam("version", "v1").Build(),
+			},
+			hostnames: []*string{kong.String("example.com"), kong.String("*.example.net")},
+			expectedExpression: `((http.host == "example.com") || (http.host =^ ".example.net")) && ` +
+				`((http.path == "/api") || (http.path ^= "/api/")) && (http.queries.version == "v1")`,
+		},
+		{
+			name: "exact path, method, headers and regex query param",
+			matches: []gatewayv1beta1.HTTPRouteMatch{
+				builder.NewHTTPRouteMatch().
+					WithPathExact("/v1/users").
+					WithMethod(gatewayv1beta1.HTTPMethodGet).
+					WithHeader("X-Env", "prod").
+					WithHeader("x-env", "dev").
+					WithQueryParamRegex("version", `^v[12]$`).
+					Build(),
+			},
+			expectedExpression: `(http.path == "/v1/users") && (http.method == "GET") && ` +
+				`(http.headers.x_env == "prod") && (http.queries.version ~ r#"^v[12]$"#)`,
+		},
+		{
+			name: "multiple paths sharing a query param",
+			matches: []gatewayv1beta1.HTTPRouteMatch{
+				builder.NewHTTPRouteMatch().WithPathPrefix("/v1/").WithQueryParam("q", `a"b\c`).Build(),
+				builder.NewHTTPRouteMatch().WithPathRegex(`/v[0-9]+/items`).WithQueryParam("q", `a"b\c`).Build(),
+			},
+			expectedExpression: `((http.path ^= "/v1/") || (http.path ~ r#"/v[0-9]+/items"#)) && (http.queries.q == "a\"b\\c")`,
+		},
+		{
+			name:               "hostnames only",
+			hostnames:          []*string{kong.String("example.com")},
+			expectedExpression: `http.host == "example.com"`,
+		},
+		{
+			name:        "no hostnames nor matches",
+			expectedErr: errRouteValidationNoMatchRulesOrHostnamesSpecified,
+		},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			route, err := generateKongExpressionRouteFromHTTPRouteMatches("route", tc.matches, util.K8sObjectInfo{}, tc.hostnames, nil)
+			if tc.expectedErr != nil {
+				require.ErrorIs(t, err, tc.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedExpression, *route.Expression)
+			assert.Empty(t, route.Paths, "traditional fields must not be set on expression routes")
+			assert.Empty(t, route.Hosts, "traditional fields must not be set on expression routes")
+		})
+	}
+}
+
+func TestIngressRulesFromHTTPRoutes_ExpressionRoutes(t *testing.T) {
+	fakestore, err := store.NewFakeStore(store.FakeObjects{})
+	require.NoError(t, err)
+
+	httproute := &gatewayv1beta1.HTTPRoute{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "versioned-httproute",
+			Namespace: corev1.NamespaceDefault,
+		},
+		Spec: gatewayv1beta1.HTTPRouteSpec{
+			CommonRouteSpec: commonRouteSpecMock("fake-gateway"),
+			Rules: []gatewayv1beta1.HTTPRouteRule{
+				{
+					Matches: []gatewayv1beta1.HTTPRouteMatch{
+						builder.NewHTTPRouteMatch().WithPathPrefix("/api").WithQueryParam("version", "v2").Build(),
+					},
+					BackendRefs: []gatewayv1beta1.HTTPBackendRef{
+						builder.NewHTTPBackendRef("api-v2").WithPort(80).Build(),
+					},
+				},
+				{
+					Matches: []gatewayv1beta1.HTTPRouteMatch{
+						builder.NewHTTPRouteMatch().WithPathPrefix("/api").Build(),
+					},
+					BackendRefs: []gatewayv1beta1.HTTPBackendRef{
+						builder.NewHTTPBackendRef("api-v1").WithPort(80).Build(),
+					},
+				},
+			},
+		},
+	}
+	httproute.SetGroupVersionKind(httprouteGVK)
+
+	t.Run("query param matches can't be translated without the expressions router", func(t *testing.T) {
+		p := mustNewParser(t, fakestore)
+		rules := newIngressRules()
+		require.ErrorIs(t, p.ingressRulesFromHTTPRoute(&rules, httproute), errRouteValidationQueryParamMatchesUnsupported)
+	})
+
+	t.Run("query param matches can't be translated when Kong doesn't support them", func(t *testing.T) {
+		p := mustNewParser(t, fakestore)
+		p.EnableExpressionRoutes()
+		rules := newIngressRules()
+		require.ErrorContains(t, p.ingressRulesFromHTTPRoute(&rules, httproute), "queryparam matching is only supported")
+	})
+
+	t.Run("query params which can't be used in expressions aren't translated", func(t *testing.T) {
+		p := mustNewParser(t, fakestore)
+		p.EnableExpressionRoutes()
+		p.EnableQueryParamMatches()
+		invalid := httproute.DeepCopy()
+		invalid.Spec.Rules[0].Matches[0].QueryParams[0].Name = "api-version"
+		rules := newIngressRules()
+		require.ErrorContains(t, p.ingressRulesFromHTTPRoute(&rules, invalid), `queryparam "api-version" can't be matched`)
+		require.Empty(t, rules.ServiceNameToServices)
+	})
+
+	for _, combined := range []bool{false, true} {
+		p := mustNewParser(t, fakestore)
+		p.EnableExpressionRoutes()
+		p.EnableQueryParamMatches()
+		if combined {
+			p.EnableCombinedServiceRoutes()
+		}
+
+		rules := newIngressRules()
+		require.NoError(t, p.ingressRulesFromHTTPRoute(&rules, httproute))
+		require.Len(t, rules.ServiceNameToServices, 2)
+
+		v2Routes := rules.ServiceNameToServices["httproute.default.versioned-httproute.0"].Routes
+		require.Len(t, v2Routes, 1)
+		assert.Equal(t, `((http.path == "/api") || (http.path ^= "/api/")) && (http.queries.version == "v2")`, *v2Routes[0].Expression)
+
+		v1Routes := rules.ServiceNameToServices["httproute.default.versioned-httproute.1"].Routes
+		require.Len(t, v1Routes, 1)
+		assert.Equal(t, `(http.path == "/api") || (http.path ^= "/api/")`, *v1Routes[0].Expression)
+
+		assert.Greater(t, *v2Routes[0].Priority, *v1Routes[0].Priority,
+			"the route matching the query param must take precedence over the one which doesn't")
+	}
+}
@@ -1,14 +1,23 @@
 package parser
 
 import (
+	"errors"
 	"fmt"
+	"net/http"
+	"sort"
+	"strings"
 
 	"github.com/kong/go-kong/kong"
+	"github.com/samber/lo"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
 	gatewayv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
 
 	"github.com/kong/kubernetes-ingress-controller/v2/internal/dataplane/kongstate"
 	"github.com/kong/kubernetes-ingress-controller/v2/internal/dataplane/parser/translators"
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/store"
 	"github.com/kong/kubernetes-ingress-controller/v2/internal/util"
+	gatewayvalidation "github.com/kong/kubernetes-ingress-controller/v2/internal/validation/gateway"
 )
 
 // -----------------------------------------------------------------------------
@@ -26,10 +35,37 @@ func (p *Parser) ingressRulesFromHTTPRoutes() ingressRules {
 		return result
 	}
 
+	// the listeners the HTTPRoutes are attached to depend on the Gateways in the scope of the parser.
+	settings := fmt.Sprintf("combined=%t regex=%t expressions=%t queries=%t prefunctions=%t mirrors=%t gateways=%s",
+		p.featureEnabledCombinedServiceRoutes, p.flagEnabledRegexPathPrefix, p.featureEnabledExpressionRoutes,
+		p.flagEnabledQueryParamMatches, p.flagEnabledPreFunctionFilters, p.flagEnabledRequestMirrorFilters, p.gatewayScope)
+	creationRanks := httpRoutesCreationRanks(httpRouteList)
+	if len(httpRouteList) > 1<<httpRouteCreationPriorityBits {
+		p.logger.Warnf("the precedence of the routes of the HTTPRoutes past the %d oldest matching the same requests is undefined",
+			1<<httpRouteCreationPriorityBits)
+	}
 	for _, httproute := range httpRouteList {
-		if err := p.ingressRulesFromHTTPRoute(&result, httproute); err != nil {
-			p.registerTranslationFailure(fmt.Sprintf("HTTPRoute can't be routed: %s", err), httproute)
-		} else {
+		httproute := httproute
+		if len(httproute.Spec.Rules) > 1<<httpRouteRulePriorityBits {
+			p.logger.WithField("httproute", httproute.Namespace+"/"+httproute.Name).
+				Warnf("the precedence of the routes of the rules past the %d first matching the same requests is undefined",
+					1<<httpRouteRulePriorityBits)
+		}
+		translation := p.translateObject(httproute, settings, func() objectTranslation {
+			return p.httpRouteTranslation(httproute)
+		})
+
+		result.SecretNameToSNIs.merge(translation.rules.SecretNameToSNIs)
+		for serviceName, service := range translation.rules.ServiceNameToServices {
+			// the priorities of the routes depend on the creation of the other HTTPRoutes, so they
+			// can't be cached along with the translation.
+			setHTTPRouteCreationPriority(service.Routes, creationRanks[httproute.Namespace+"/"+httproute.Name], len(httpRouteList))
+			result.ServiceNameToServices[serviceName] = service
+		}
+		for _, reason := range translation.failureReasons {
+			p.registerTranslationFailure(reason, httproute)
+		}
+		if translation.parsed {
 			// at this point the object has been configured and can be
 			// reported as successfully parsed.
 			p.ReportKubernetesObjectUpdate(httproute)
@@ -39,10 +75,35 @@ func (p *Parser) ingressRulesFromHTTPRoutes() ingressRules {
 	return result
 }
 
+// httpRouteTranslation translates the provided HTTPRoute into Kong services and routes.
+// The services generated before a translation error are kept in the translation.
+func (p *Parser) httpRouteTranslation(httproute *gatewayv1beta1.HTTPRoute) objectTranslation {
+	result := newIngressRules()
+	if err := p.ingressRulesFromHTTPRoute(&result, httproute); err != nil {
+		return objectTranslation{
+			rules:          result,
+			failureReasons: []string{fmt.Sprintf("HTTPRoute can't be routed: %s", err)},
+		}
+	}
+	return objectTranslation{rules: result, parsed: true}
+}
+
 func (p *Parser) ingressRulesFromHTTPRoute(result *ingressRules, httproute *gatewayv1beta1.HTTPRoute) error {
 	if err := validateHTTPRoute(httproute); err != nil {
 		return fmt.Errorf("validation failed : %w", err)
 	}
+	if err := p.validateHTTPRoutePreFunctionFilters(httproute); err != nil {
+		return err
+	}
+	if err := p.validateHTTPRouteRequestMirrorRefs(httproute); err != nil {
+		return err
+	}
+	// without the expressions router, the query param matches are rejected when generating the routes.
+	if p.featureEnabledExpressionRoutes {
+		if err := gatewayvalidation.ValidateHTTPRouteQueryParamMatches(httproute, p.flagEnabledQueryParamMatches); err != nil {
+			return err
+		}
+	}
 
 	if p.featureEnabledCombinedServiceRoutes {
 		return p.ingressRulesFromHTTPRouteWithCombinedServiceRoutes(httproute, result)
@@ -67,12 +128,79 @@ func validateHTTPRoute(httproute *gatewayv1beta1.HTTPRoute) error {
 			return errRouteValidationMissingBackendRefs
 		}
 	}
+	return gatewayvalidation.ValidateHTTPRouteFilters(httproute)
+}
+
+// validateHTTPRoutePreFunctionFilters verifies that Kong can run the Lua code of the pre-function plugins
+// the filters of an HTTPRoute are translated into, according to its untrusted_lua settings.
+func (p *Parser) validateHTTPRoutePreFunctionFilters(httproute *gatewayv1beta1.HTTPRoute) error {
+	for ruleNumber, rule := range httproute.Spec.Rules {
+		for _, filter := range rule.Filters {
+			switch filter.Type {
+			case gatewayv1beta1.HTTPRouteFilterRequestRedirect, gatewayv1beta1.HTTPRouteFilterURLRewrite:
+				if !p.flagEnabledPreFunctionFilters {
+					return fmt.Errorf("rule %d: %s filters require Kong's untrusted_lua setting not to be off", ruleNumber, filter.Type)
+				}
+			case gatewayv1beta1.HTTPRouteFilterRequestMirror:
+				if !p.flagEnabledRequestMirrorFilters {
+					return fmt.Errorf("rule %d: %s filters require Kong's untrusted_lua setting to be on, or its sandbox "+
+						"to allow requiring resty.http (untrusted_lua_sandbox_requires) and accessing ngx.timer "+
+						"(untrusted_lua_sandbox_environment)", ruleNumber, filter.Type)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// validateHTTPRouteRequestMirrorRefs verifies that the Services referenced by the RequestMirror
+// filters of an HTTPRoute in other namespaces are permitted by ReferenceGrants.
+func (p *Parser) validateHTTPRouteRequestMirrorRefs(httproute *gatewayv1beta1.HTTPRoute) error {
+	var allowed map[gatewayv1beta1.Namespace][]gatewayv1alpha2.ReferenceGrantTo
+	for ruleNumber, rule := range httproute.Spec.Rules {
+		for _, filter := range rule.Filters {
+			if filter.Type != gatewayv1beta1.HTTPRouteFilterRequestMirror || filter.RequestMirror == nil {
+				continue
+			}
+			ref := gatewayv1beta1.BackendRef{BackendObjectReference: filter.RequestMirror.BackendRef}
+			if ref.Namespace == nil || string(*ref.Namespace) == httproute.Namespace {
+				continue
+			}
+			if ref.Group == nil {
+				ref.Group = lo.ToPtr(gatewayv1beta1.Group(""))
+			}
+			if ref.Kind == nil {
+				ref.Kind = lo.ToPtr(gatewayv1beta1.Kind("Service"))
+			}
+
+			if allowed == nil {
+				grants, err := p.storer.ListReferenceGrants()
+				if err != nil {
+					return fmt.Errorf("could not retrieve ReferenceGrants: %w", err)
+				}
+				allowed = getPermittedForReferenceGrantFrom(gatewayv1alpha2.ReferenceGrantFrom{
+					Group:     gatewayv1alpha2.Group(httproute.GetObjectKind().GroupVersionKind().Group),
+					Kind:      gatewayv1alpha2.Kind(httproute.GetObjectKind().GroupVersionKind().Kind),
+					Namespace: gatewayv1alpha2.Namespace(httproute.Namespace),
+				}, grants)
+			}
+			if !newRefChecker(ref).IsRefAllowedByGrant(allowed) {
+				return fmt.Errorf("rule %d: RequestMirror filter backendRef %s/%s is not permitted by any ReferenceGrant",
+					ruleNumber, *ref.Namespace, ref.Name)
+			}
+		}
+	}
 	return nil
 }
 
 // ingressRulesFromHTTPRouteWithCombinedServiceRoutes generates a set of proto-Kong routes (ingress rules) from an HTTPRoute.
 // If multiple rules in the HTTPRoute use the same Service, it combines them into a single Kong route.
 func (p *Parser) ingressRulesFromHTTPRouteWithCombinedServiceRoutes(httproute *gatewayv1beta1.HTTPRoute, result *ingressRules) error {
+	protocols, err := p.httpRouteListenerProtocols(httproute)
+	if err != nil {
+		return err
+	}
+
 	for _, kongServiceTranslation := range translators.TranslateHTTPRoute(httproute) {
 		// HTTPRoute uses a wrapper HTTPBackendRef to add optional filters to its BackendRefs
 		backendRefs := httpBackendRefsToBackendRefs(kongServiceTranslation.BackendRefs)
@@ -87,10 +215,18 @@ func (p *Parser) ingressRulesFromHTTPRouteWithCombinedServiceRoutes(httproute *g
 
 		// generate the routes for the service and attach them to the service
 		for _, kongRouteTranslation := range kongServiceTranslation.KongRoutes {
-			route, err := generateKongRouteFromTranslation(httproute, kongRouteTranslation, p.flagEnabledRegexPathPrefix)
+			route, err := generateKongRouteFromTranslation(httproute, kongRouteTranslation, p.flagEnabledRegexPathPrefix, p.featureEnabledExpressionRoutes)
+			if err != nil {
+				return err
+			}
+			plugins, err := p.httpRouteExtensionRefPlugins(httproute, kongRouteTranslation.Filters)
 			if err != nil {
 				return err
 			}
+			if err := attachHTTPRouteExtensionRefPlugins(&route, plugins); err != nil {
+				return err
+			}
+			route.Protocols = protocols
 			service.Routes = append(service.Routes, route)
 		}
 
@@ -105,14 +241,29 @@ func (p *Parser) ingressRulesFromHTTPRouteWithCombinedServiceRoutes(httproute *g
 // It generates a separate route for each rule.
 // It is planned for deprecation in favor of ingressRulesFromHTTPRouteWithCombinedServiceRoutes.
 func (p *Parser) ingressRulesFromHTTPRouteLegacyFallback(httproute *gatewayv1beta1.HTTPRoute, result *ingressRules) error {
+	protocols, err := p.httpRouteListenerProtocols(httproute)
+	if err != nil {
+		return err
+	}
+
 	// each rule may represent a different set of backend services that will be accepting
 	// traffic, so we make separate routes and Kong services for every present rule.
 	for ruleNumber, rule := range httproute.Spec.Rules {
 		// determine the routes needed to route traffic to services for this rule
-		routes, err := generateKongRoutesFromHTTPRouteRule(httproute, ruleNumber, rule, p.flagEnabledRegexPathPrefix)
+		routes, err := generateKongRoutesFromHTTPRouteRule(httproute, ruleNumber, rule, p.flagEnabledRegexPathPrefix, p.featureEnabledExpressionRoutes)
+		if err != nil {
+			return err
+		}
+		plugins, err := p.httpRouteExtensionRefPlugins(httproute, rule.Filters)
 		if err != nil {
 			return err
 		}
+		for i := range routes {
+			if err := attachHTTPRouteExtensionRefPlugins(&routes[i], plugins); err != nil {
+				return err
+			}
+			routes[i].Protocols = protocols
+		}
 
 		// HTTPRoute uses a wrapper HTTPBackendRef to add optional filters to its BackendRefs
 		backendRefs := httpBackendRefsToBackendRefs(rule.BackendRefs)
@@ -130,10 +281,120 @@ func (p *Parser) ingressRulesFromHTTPRouteLegacyFallback(httproute *gatewayv1bet
 	return nil
 }
 
+// httpRouteExtensionRefPlugins returns the plugins configured by the KongPlugins and KongClusterPlugins
+// referenced by the provided ExtensionRef filters of an HTTPRoute rule.
+func (p *Parser) httpRouteExtensionRefPlugins(httproute *gatewayv1beta1.HTTPRoute, filters []gatewayv1beta1.HTTPRouteFilter) ([]kong.Plugin, error) {
+	var plugins []kong.Plugin
+	for _, filter := range filters {
+		if filter.Type != gatewayv1beta1.HTTPRouteFilterExtensionRef || filter.ExtensionRef == nil {
+			continue
+		}
+		ref := filter.ExtensionRef
+		plugin, err := kongstate.PluginForKind(p.storer, string(ref.Kind), httproute.Namespace, string(ref.Name))
+		if err != nil {
+			return nil, fmt.Errorf("ExtensionRef filter %s %s can't be resolved: %w", ref.Kind, ref.Name, err)
+		}
+		plugins = append(plugins, plugin.Plugin)
+	}
+	return plugins, nil
+}
+
+// httpRouteListenerProtocols returns the protocols of the Kong routes generated from an HTTPRoute: the
+// protocols of the Gateway listeners selected by the SectionNames and Ports of its ParentRefs, so that
+// the routes attached to HTTPS listeners only aren't reachable through the HTTP ones and vice versa.
+// Kong routes can't match the ports requests are received on, so the routes attached to a listener
+// match the requests received by all the listeners of the same protocol. Listeners reported as not
+// ready, e.g. because they conflict with the listeners of another Gateway, are ignored.
+func (p *Parser) httpRouteListenerProtocols(httproute *gatewayv1beta1.HTTPRoute) ([]*string, error) {
+	var matchesHTTP, matchesHTTPS, matchesNotReady bool
+	for _, parentRef := range httproute.Spec.ParentRefs {
+		if parentRef.Group != nil && string(*parentRef.Group) != gatewayv1beta1.GroupName {
+			continue
+		}
+		if parentRef.Kind != nil && *parentRef.Kind != KindGateway {
+			continue
+		}
+
+		gatewayNamespace := httproute.Namespace
+		if parentRef.Namespace != nil {
+			gatewayNamespace = string(*parentRef.Namespace)
+		}
+		gateway, err := p.storer.GetGateway(gatewayNamespace, string(parentRef.Name))
+		if err != nil {
+			if errors.As(err, &store.ErrNotFound{}) {
+				// the listeners of the Gateway aren't known, the protocols are determined by the other ParentRefs.
+				continue
+			}
+			return nil, err
+		}
+
+		for _, listener := range gateway.Spec.Listeners {
+			if parentRef.SectionName != nil && *parentRef.SectionName != "" && *parentRef.SectionName != listener.Name {
+				continue
+			}
+			if parentRef.Port != nil && *parentRef.Port != listener.Port {
+				continue
+			}
+			if isGatewayListenerNotReady(gateway, listener.Name) {
+				matchesNotReady = true
+				continue
+			}
+			switch listener.Protocol {
+			case gatewayv1beta1.HTTPProtocolType:
+				matchesHTTP = true
+			case gatewayv1beta1.HTTPSProtocolType:
+				matchesHTTPS = true
+			}
+		}
+	}
+
+	switch {
+	case matchesNotReady && !matchesHTTP && !matchesHTTPS:
+		return nil, fmt.Errorf("all the Gateway listeners the HTTPRoute is attached to aren't ready")
+	case matchesHTTP && !matchesHTTPS:
+		return kong.StringSlice("http"), nil
+	case matchesHTTPS && !matchesHTTP:
+		return kong.StringSlice("https"), nil
+	default:
+		return kong.StringSlice("http", "https"), nil
+	}
+}
+
 // -----------------------------------------------------------------------------
 // Translate HTTPRoute - Utils
 // -----------------------------------------------------------------------------
 
+// isGatewayListenerNotReady returns true if the status of the Gateway reports the listener as not ready.
+// Listeners without a status yet are considered ready, as the Gateway may not have been reconciled yet.
+func isGatewayListenerNotReady(gateway *gatewayv1beta1.Gateway, name gatewayv1beta1.SectionName) bool {
+	for _, status := range gateway.Status.Listeners {
+		if status.Name != name {
+			continue
+		}
+		for _, condition := range status.Conditions {
+			if condition.Type == string(gatewayv1beta1.ListenerConditionReady) {
+				return condition.Status == metav1.ConditionFalse
+			}
+		}
+	}
+	return false
+}
+
+// attachHTTPRouteExtensionRefPlugins attaches the plugins referenced by ExtensionRef filters to a route.
+// Kong accepts a single plugin of each type per route, so they can't have the type of the plugins
+// generated from the other filters, nor of each other.
+func attachHTTPRouteExtensionRefPlugins(route *kongstate.Route, plugins []kong.Plugin) error {
+	for _, plugin := range plugins {
+		for _, attached := range route.Plugins {
+			if *attached.Name == *plugin.Name {
+				return fmt.Errorf("route %s can't have multiple %s plugins", *route.Name, *plugin.Name)
+			}
+		}
+		route.Plugins = append(route.Plugins, *plugin.DeepCopy())
+	}
+	return nil
+}
+
 // getHTTPRouteHostnamesAsSliceOfStringPointers translates the hostnames defined
 // in an HTTPRoute specification into a []*string slice, which is the type required
 // by kong.Route{}.
@@ -157,6 +418,7 @@ func generateKongRoutesFromHTTPRouteRule(
 	ruleNumber int,
 	rule gatewayv1beta1.HTTPRouteRule,
 	addRegexPrefix bool,
+	expressionRoutes bool,
 ) ([]kongstate.Route, error) {
 	// gather the k8s object information and hostnames from the httproute
 	objectInfo := util.FromK8sObject(httproute)
@@ -168,11 +430,12 @@ func generateKongRoutesFromHTTPRouteRule(
 	// all matches to determine all the routes that will be needed for the services.
 	var routes []kongstate.Route
 
-	// generate kong plugins from rule.filters
-	plugins := generatePluginsFromHTTPRouteFilters(rule.Filters)
-
 	if len(rule.Matches) > 0 {
 		for matchNumber := range rule.Matches {
+			// generate kong plugins from rule.filters, for the requests matching this match only
+			matches := rule.Matches[matchNumber : matchNumber+1]
+			plugins := generatePluginsFromHTTPRouteFilters(rule.Filters, matches, httproute.Namespace)
+
 			// determine the name of the route, identify it as a route that belongs
 			// to a Kubernetes HTTPRoute object.
 			routeName := fmt.Sprintf(
@@ -185,25 +448,29 @@ func generateKongRoutesFromHTTPRouteRule(
 
 			r, err := generateKongRouteFromHTTPRouteMatches(
 				routeName,
-				rule.Matches[matchNumber:matchNumber+1],
+				matches,
 				objectInfo,
 				hostnames,
 				plugins,
 				addRegexPrefix,
+				expressionRoutes,
 			)
 			if err != nil {
 				return nil, err
 			}
+			setHTTPRouteRulePriority(&r, hostnames, matches, ruleNumber, len(httproute.Spec.Rules), expressionRoutes)
 
 			// add the route to the list of routes for the service(s)
 			routes = append(routes, r)
 		}
 	} else {
 		routeName := fmt.Sprintf("httproute.%s.%s.0.0", httproute.Namespace, httproute.Name)
-		r, err := generateKongRouteFromHTTPRouteMatches(routeName, rule.Matches, objectInfo, hostnames, plugins, addRegexPrefix)
+		plugins := generatePluginsFromHTTPRouteFilters(rule.Filters, rule.Matches, httproute.Namespace)
+		r, err := generateKongRouteFromHTTPRouteMatches(routeName, rule.Matches, objectInfo, hostnames, plugins, addRegexPrefix, expressionRoutes)
 		if err != nil {
 			return nil, err
 		}
+		setHTTPRouteRulePriority(&r, hostnames, rule.Matches, ruleNumber, len(httproute.Spec.Rules), expressionRoutes)
 
 		// add the route to the list of routes for the service(s)
 		routes = append(routes, r)
@@ -216,6 +483,7 @@ func generateKongRouteFromTranslation(
 	httproute *gatewayv1beta1.HTTPRoute,
 	translation translators.KongRouteTranslation,
 	addRegexPrefix bool,
+	expressionRoutes bool,
 ) (kongstate.Route, error) {
 	// gather the k8s object information and hostnames from the httproute
 	objectInfo := util.FromK8sObject(httproute)
@@ -224,20 +492,28 @@ func generateKongRouteFromTranslation(
 	hostnames := getHTTPRouteHostnamesAsSliceOfStringPointers(httproute)
 
 	// generate kong plugins from rule.filters
-	plugins := generatePluginsFromHTTPRouteFilters(translation.Filters)
+	plugins := generatePluginsFromHTTPRouteFilters(translation.Filters, translation.Matches, httproute.Namespace)
 
-	return generateKongRouteFromHTTPRouteMatches(
+	r, err := generateKongRouteFromHTTPRouteMatches(
 		translation.Name,
 		translation.Matches,
 		objectInfo,
 		hostnames,
 		plugins,
 		addRegexPrefix,
+		expressionRoutes,
 	)
+	if err != nil {
+		return kongstate.Route{}, err
+	}
+	setHTTPRouteRulePriority(&r, hostnames, translation.Matches, translation.RuleNumber, len(httproute.Spec.Rules), expressionRoutes)
+	return r, nil
 }
 
 // generateKongRouteFromHTTPRouteMatches converts an HTTPRouteMatches to a Kong Route object.
 // This function assumes that the HTTPRouteMatches share the query params, headers and methods.
+// When expressionRoutes is set, the route matches requests with an expression instead of the
+// traditional route fields.
 func generateKongRouteFromHTTPRouteMatches(
 	routeName string,
 	matches []gatewayv1beta1.HTTPRouteMatch,
@@ -245,7 +521,12 @@ func generateKongRouteFromHTTPRouteMatches(
 	hostnames []*string,
 	plugins []kong.Plugin,
 	addRegexPrefix bool,
+	expressionRoutes bool,
 ) (kongstate.Route, error) {
+	if expressionRoutes {
+		return generateKongExpressionRouteFromHTTPRouteMatches(routeName, matches, ingressObjectInfo, hostnames, plugins)
+	}
+
 	if len(matches) == 0 {
 		// it's acceptable for an HTTPRoute to have no matches in the rulesets,
 		// but only backends as long as there are hostnames. In this case, we
@@ -275,7 +556,7 @@ func generateKongRouteFromHTTPRouteMatches(
 		return r, nil
 	}
 
-	// TODO: implement query param matches (https://github.com/Kong/kubernetes-ingress-controller/issues/2778)
+	// the traditional router can't match query params, they can only be matched with expressions.
 	if len(matches[0].QueryParams) > 0 {
 		return kongstate.Route{}, errRouteValidationQueryParamMatchesUnsupported
 	}
@@ -372,20 +653,68 @@ func generateKongstateRoute(routeName string, ingressObjectInfo util.K8sObjectIn
 }
 
 // generatePluginsFromHTTPRouteFilters  converts HTTPRouteFilter into Kong filters.
-func generatePluginsFromHTTPRouteFilters(filters []gatewayv1beta1.HTTPRouteFilter) []kong.Plugin {
+// The filters must have been validated with gatewayvalidation.ValidateHTTPRouteFilters, the ones
+// which are not supported are skipped. The matches are the ones of the route the filters apply to,
+// and the namespace the one of the HTTPRoute, the default namespace of the referenced backends.
+func generatePluginsFromHTTPRouteFilters(
+	filters []gatewayv1beta1.HTTPRouteFilter,
+	matches []gatewayv1beta1.HTTPRouteMatch,
+	namespace string,
+) []kong.Plugin {
 	kongPlugins := make([]kong.Plugin, 0)
 	if len(filters) == 0 {
 		return kongPlugins
 	}
 
 	for _, filter := range filters {
-		if filter.Type == gatewayv1beta1.HTTPRouteFilterRequestHeaderModifier {
+		switch filter.Type {
+		case gatewayv1beta1.HTTPRouteFilterRequestHeaderModifier:
 			kongPlugins = append(kongPlugins, generateRequestHeaderModifierKongPlugin(filter.RequestHeaderModifier))
+		case gatewayv1beta1.HTTPRouteFilterRequestRedirect:
+			kongPlugins = append(kongPlugins, generateRequestRedirectKongPlugin(filter.RequestRedirect, matches))
+		case gatewayv1beta1.HTTPRouteFilterURLRewrite:
+			kongPlugins = append(kongPlugins, generateURLRewriteKongPlugin(filter.URLRewrite, matches))
+		case gatewayv1beta1.HTTPRouteFilterRequestMirror:
+			// requests are mirrored as they're received, before the other filters apply.
+			kongPlugins = append([]kong.Plugin{generateRequestMirrorKongPlugin(filter.RequestMirror, namespace)}, kongPlugins...)
+		}
+	}
+
+	return mergePreFunctionKongPlugins(kongPlugins)
+}
+
+// mergePreFunctionKongPlugins merges the pre-function plugins generated from different filters
+// into a single one running their code in order, as Kong accepts a single plugin of each type
+// per route. The code of each filter runs in its own block, scoping its local variables.
+func mergePreFunctionKongPlugins(plugins []kong.Plugin) []kong.Plugin {
+	var (
+		merged       []kong.Plugin
+		preFunctions []string
+	)
+	for _, plugin := range plugins {
+		if *plugin.Name != "pre-function" {
+			merged = append(merged, plugin)
+			continue
 		}
-		// TODO: https://github.com/Kong/kubernetes-ingress-controller/issues/2793
+		preFunctions = append(preFunctions, plugin.Config["access"].([]string)...)
 	}
 
-	return kongPlugins
+	if len(preFunctions) < 2 {
+		return plugins
+	}
+
+	var code strings.Builder
+	for _, preFunction := range preFunctions {
+		code.WriteString("do\n")
+		code.WriteString(preFunction)
+		code.WriteString("end\n")
+	}
+	return append(merged, kong.Plugin{
+		Name: kong.String("pre-function"),
+		Config: kong.Configuration{
+			"access": []string{code.String()},
+		},
+	})
 }
 
 // generateRequestHeaderModifierKongPlugin converts a gatewayv1beta1.HTTPRequestHeaderFilter into a
@@ -430,6 +759,190 @@ func generateRequestHeaderModifierKongPlugin(modifier *gatewayv1beta1.HTTPReques
 	return plugin
 }
 
+// generateRequestRedirectKongPlugin converts a gatewayv1beta1.HTTPRequestRedirectFilter into a
+// kong.Plugin of type pre-function, responding to the requests with a redirect instead of
+// proxying them. The matches are used to determine the prefixes replaced by ReplacePrefixMatch.
+func generateRequestRedirectKongPlugin(
+	redirect *gatewayv1beta1.HTTPRequestRedirectFilter,
+	matches []gatewayv1beta1.HTTPRouteMatch,
+) kong.Plugin {
+	var code strings.Builder
+	code.WriteString("local scheme = kong.request.get_forwarded_scheme()\n")
+	code.WriteString("local host = kong.request.get_forwarded_host()\n")
+	code.WriteString("local port = kong.request.get_forwarded_port()\n")
+	code.WriteString("local path = kong.request.get_path()\n")
+
+	if redirect.Scheme != nil {
+		fmt.Fprintf(&code, "scheme = %s\n", luaString(*redirect.Scheme))
+		// when the scheme changes, the port defaults to the well-known port of the new scheme.
+		if redirect.Port == nil {
+			code.WriteString("port = nil\n")
+		}
+	}
+	if redirect.Hostname != nil {
+		fmt.Fprintf(&code, "host = %s\n", luaString(string(*redirect.Hostname)))
+	}
+	if redirect.Port != nil {
+		fmt.Fprintf(&code, "port = %d\n", *redirect.Port)
+	}
+	if redirect.Path != nil {
+		writeHTTPPathModifierLua(&code, *redirect.Path, matches)
+	}
+
+	statusCode := http.StatusFound
+	if redirect.StatusCode != nil {
+		statusCode = *redirect.StatusCode
+	}
+	code.WriteString(`local location = scheme .. "://" .. host
+if port and not ((scheme == "http" and port == 80) or (scheme == "https" and port == 443)) then
+  location = location .. ":" .. port
+end
+location = location .. path
+local query = kong.request.get_raw_query()
+if query ~= "" then
+  location = location .. "?" .. query
+end
+`)
+	fmt.Fprintf(&code, "return kong.response.exit(%d, nil, { [\"Location\"] = location })\n", statusCode)
+
+	return kong.Plugin{
+		Name: kong.String("pre-function"),
+		Config: kong.Configuration{
+			"access": []string{code.String()},
+		},
+	}
+}
+
+// generateRequestMirrorKongPlugin converts a gatewayv1beta1.HTTPRequestMirrorFilter into a kong.Plugin
+// of type pre-function, sending copies of the requests to the referenced Service in the background.
+// The responses of the mirrored requests are ignored. The Service is addressed by its cluster DNS name,
+// the namespace being the default one of the reference.
+func generateRequestMirrorKongPlugin(mirror *gatewayv1beta1.HTTPRequestMirrorFilter, namespace string) kong.Plugin {
+	if mirror.BackendRef.Namespace != nil {
+		namespace = string(*mirror.BackendRef.Namespace)
+	}
+	url := fmt.Sprintf("http://%s.%s.svc:%d", mirror.BackendRef.Name, namespace, *mirror.BackendRef.Port)
+
+	var code strings.Builder
+	code.WriteString(`local http = require("resty.http")
+local method = kong.request.get_method()
+local path = kong.request.get_path_with_query()
+local headers = kong.request.get_headers()
+headers["content-length"] = nil
+headers["transfer-encoding"] = nil
+local body = kong.request.get_raw_body()
+ngx.timer.at(0, function(premature)
+  if premature then
+    return
+  end
+  local httpc = http.new()
+`)
+	fmt.Fprintf(&code, "  local _, err = httpc:request_uri(%s .. path, { method = method, headers = headers, body = body })\n", luaString(url))
+	code.WriteString(`  if err then
+    kong.log.err("failed to mirror request: ", err)
+  end
+end)
+`)
+
+	return kong.Plugin{
+		Name: kong.String("pre-function"),
+		Config: kong.Configuration{
+			"access": []string{code.String()},
+		},
+	}
+}
+
+// generateURLRewriteKongPlugin converts a gatewayv1beta1.HTTPURLRewriteFilter into a kong.Plugin
+// of type pre-function, rewriting the host and path of the requests proxied to the upstream.
+// The matches are used to determine the prefixes replaced by ReplacePrefixMatch.
+func generateURLRewriteKongPlugin(
+	rewrite *gatewayv1beta1.HTTPURLRewriteFilter,
+	matches []gatewayv1beta1.HTTPRouteMatch,
+) kong.Plugin {
+	var code strings.Builder
+	if rewrite.Hostname != nil {
+		// setting the Host header sets the host of the upstream requests too.
+		fmt.Fprintf(&code, "kong.service.request.set_header(\"Host\", %s)\n", luaString(string(*rewrite.Hostname)))
+	}
+	if rewrite.Path != nil {
+		code.WriteString("local path = kong.request.get_path()\n")
+		writeHTTPPathModifierLua(&code, *rewrite.Path, matches)
+		code.WriteString("kong.service.request.set_path(path)\n")
+	}
+
+	return kong.Plugin{
+		Name: kong.String("pre-function"),
+		Config: kong.Configuration{
+			"access": []string{code.String()},
+		},
+	}
+}
+
+// writeHTTPPathModifierLua writes the Lua code modifying the path variable as the provided modifier does.
+func writeHTTPPathModifierLua(code *strings.Builder, modifier gatewayv1beta1.HTTPPathModifier, matches []gatewayv1beta1.HTTPRouteMatch) {
+	switch modifier.Type {
+	case gatewayv1beta1.FullPathHTTPPathModifier:
+		fmt.Fprintf(code, "path = %s\n", luaString(*modifier.ReplaceFullPath))
+	case gatewayv1beta1.PrefixMatchHTTPPathModifier:
+		writeReplacePrefixMatchLua(code, *modifier.ReplacePrefixMatch, matches)
+	}
+}
+
+// writeReplacePrefixMatchLua writes the Lua code replacing the prefix of the request path
+// matched by any of the provided matches with the replacement, the longest prefix first.
+// Prefixes match whole path elements, so they're compared without their trailing slashes.
+func writeReplacePrefixMatchLua(code *strings.Builder, replacement string, matches []gatewayv1beta1.HTTPRouteMatch) {
+	prefixes := make([]string, 0, len(matches))
+	for _, match := range matches {
+		prefix := "/"
+		if match.Path != nil && match.Path.Value != nil {
+			prefix = *match.Path.Value
+		}
+		prefixes = append(prefixes, strings.TrimRight(prefix, "/"))
+	}
+	if len(prefixes) == 0 {
+		prefixes = append(prefixes, "")
+	}
+	prefixes = lo.Uniq(prefixes)
+	sort.SliceStable(prefixes, func(i, j int) bool {
+		return len(prefixes[i]) > len(prefixes[j])
+	})
+
+	luaPrefixes := make([]string, 0, len(prefixes))
+	for _, prefix := range prefixes {
+		luaPrefixes = append(luaPrefixes, luaString(prefix))
+	}
+	fmt.Fprintf(code, `for _, prefix in ipairs({ %s }) do
+  if path == prefix or path:sub(1, #prefix + 1) == prefix .. "/" then
+    path = %s .. path:sub(#prefix + 1)
+    break
+  end
+end
+if path == "" then
+  path = "/"
+end
+`, strings.Join(luaPrefixes, ", "), luaString(strings.TrimRight(replacement, "/")))
+}
+
+// luaString returns the Lua string literal of the provided value.
+func luaString(value string) string {
+	var b strings.Builder
+	b.WriteByte('"')
+	for i := 0; i < len(value); i++ {
+		switch c := value[i]; {
+		case c == '"' || c == '\\':
+			b.WriteByte('\\')
+			b.WriteByte(c)
+		case c < ' ' || c == 0x7f:
+			fmt.Fprintf(&b, "\\%03d", c)
+		default:
+			b.WriteByte(c)
+		}
+	}
+	b.WriteByte('"')
+	return b.String()
+}
+
 func kongHeaderFormatter(header gatewayv1beta1.HTTPHeader) string {
 	return fmt.Sprintf("%s:%s", header.Name, header.Value)
 }
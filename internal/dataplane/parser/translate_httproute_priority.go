@@ -0,0 +1,164 @@
+package parser
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/kong/go-kong/kong"
+	"github.com/samber/lo"
+	gatewayv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/dataplane/kongstate"
+)
+
+// -----------------------------------------------------------------------------
+// Translate HTTPRoute - Route Priorities
+// -----------------------------------------------------------------------------
+
+// The Gateway API defines the precedence of the rules of the HTTPRoutes attached to a Gateway
+// matching the same requests. The Kong routes generated from HTTPRoutes have priorities reproducing
+// it: the regex_priority of the routes for the traditional router, and the priority of the routes for
+// the expressions router. The priorities are composed of the following bit fields, from the most
+// significant to the least significant, fitting in the 46 bits of the route priorities of Kong:
+//
+//   - hostname: 1 bit set for precise hostnames and 8 bits for the length of the longest hostname
+//   - path: 1 bit set for exact paths and 10 bits for the length of the longest path
+//   - method: 1 bit set when the method is matched
+//   - headers: 5 bits for the number of header matches
+//   - query params: 5 bits for the number of query param matches
+//   - creation: 10 bits, higher for the oldest HTTPRoutes, ties being broken by namespace and name
+//   - rule: 5 bits, higher for the rules appearing first in their HTTPRoute
+//
+// The creation field depends on the other HTTPRoutes, so it's only set once all the HTTPRoutes are
+// translated, by setHTTPRouteCreationPriority.
+//
+// The creation and rule fields saturate: all the HTTPRoutes past the 1024th oldest share the lowest
+// creation priority, and all the rules past the 32nd of an HTTPRoute share the lowest rule priority,
+// so the precedence between their routes matching the same requests isn't defined.
+
+const (
+	httpRouteMatchesPriorityBits  = 22
+	httpRouteRulePriorityBits     = 5
+	httpRouteCreationPriorityBits = 10
+)
+
+// setHTTPRouteRulePriority sets the priority of a Kong route generated from the provided hostnames
+// and matches of the rule with the provided number of an HTTPRoute having ruleCount rules.
+func setHTTPRouteRulePriority(
+	route *kongstate.Route,
+	hostnames []*string,
+	matches []gatewayv1beta1.HTTPRouteMatch,
+	ruleNumber, ruleCount int,
+	expressionRoutes bool,
+) {
+	priority := httpRouteHostnamesPriority(hostnames)<<httpRouteMatchesPriorityBits | httpRouteMatchesPriority(matches)
+	priority <<= httpRouteCreationPriorityBits + httpRouteRulePriorityBits
+	priority |= reversedRankPriority(ruleNumber, ruleCount, httpRouteRulePriorityBits)
+
+	if expressionRoutes {
+		route.Priority = kong.Int(priority)
+		return
+	}
+	route.RegexPriority = kong.Int(priority)
+}
+
+// setHTTPRouteCreationPriority adds the creation field to the priorities of the Kong routes
+// generated from an HTTPRoute, the creationRank-th oldest of count HTTPRoutes.
+func setHTTPRouteCreationPriority(routes []kongstate.Route, creationRank, count int) {
+	priority := reversedRankPriority(creationRank, count, httpRouteCreationPriorityBits) << httpRouteRulePriorityBits
+	for i := range routes {
+		if routes[i].Priority != nil {
+			routes[i].Priority = kong.Int(*routes[i].Priority | priority)
+		}
+		if routes[i].RegexPriority != nil {
+			routes[i].RegexPriority = kong.Int(*routes[i].RegexPriority | priority)
+		}
+	}
+}
+
+// httpRoutesCreationRanks returns the ranks of the provided HTTPRoutes, indexed by namespace and
+// name, ordered by creation timestamp and then alphabetically by namespace and name.
+func httpRoutesCreationRanks(httproutes []*gatewayv1beta1.HTTPRoute) map[string]int {
+	sorted := make([]*gatewayv1beta1.HTTPRoute, len(httproutes))
+	copy(sorted, httproutes)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if !sorted[i].CreationTimestamp.Equal(&sorted[j].CreationTimestamp) {
+			return sorted[i].CreationTimestamp.Before(&sorted[j].CreationTimestamp)
+		}
+		return sorted[i].Namespace+"/"+sorted[i].Name < sorted[j].Namespace+"/"+sorted[j].Name
+	})
+
+	ranks := make(map[string]int, len(sorted))
+	for rank, httproute := range sorted {
+		ranks[httproute.Namespace+"/"+httproute.Name] = rank
+	}
+	return ranks
+}
+
+// reversedRankPriority returns the priority field of the provided bits of the rank-th of count
+// items, the lowest ranks having the highest priorities. The priorities of the items ranked
+// after the field's capacity are 0.
+func reversedRankPriority(rank, count, bits int) int {
+	priority := lo.Min([]int{count, 1 << bits}) - 1 - rank
+	if priority < 0 {
+		return 0
+	}
+	return priority
+}
+
+// httpRouteHostnamesPriority returns the hostname field of the priority of a route matching the
+// provided hostnames, set from the most precise of them.
+func httpRouteHostnamesPriority(hostnames []*string) int {
+	const maxHostnameLength = 1<<8 - 1
+
+	var priority int
+	for _, hostname := range hostnames {
+		hostnamePriority := lo.Min([]int{len(*hostname), maxHostnameLength})
+		if !strings.HasPrefix(*hostname, "*") {
+			hostnamePriority |= 1 << 8
+		}
+		priority = lo.Max([]int{priority, hostnamePriority})
+	}
+	return priority
+}
+
+// httpRouteMatchesPriority returns the fields of the priority of a route generated from the provided
+// HTTPRouteMatches, which share the query params, headers and methods: exact paths take precedence
+// over prefixes, longer prefixes over shorter ones, then matches with a method, and finally those
+// with the most header and query param matches.
+func httpRouteMatchesPriority(matches []gatewayv1beta1.HTTPRouteMatch) int {
+	const (
+		maxCount      = 1<<5 - 1
+		maxPathLength = 1<<10 - 1
+	)
+	if len(matches) == 0 {
+		return 0
+	}
+
+	var pathPriority int
+	for _, match := range matches {
+		if match.Path == nil || match.Path.Value == nil {
+			continue
+		}
+		priority := lo.Min([]int{len(*match.Path.Value), maxPathLength})
+		if match.Path.Type != nil && *match.Path.Type == gatewayv1beta1.PathMatchExact {
+			priority |= 1 << 10
+		}
+		pathPriority = lo.Max([]int{pathPriority, priority})
+	}
+
+	match := matches[0]
+	priority := pathPriority << 11
+	if match.Method != nil {
+		priority |= 1 << 10
+	}
+	headers := lo.UniqBy(match.Headers, func(h gatewayv1beta1.HTTPHeaderMatch) string {
+		return strings.ToLower(string(h.Name))
+	})
+	queryParams := lo.UniqBy(match.QueryParams, func(q gatewayv1beta1.HTTPQueryParamMatch) string {
+		return q.Name
+	})
+	priority |= lo.Min([]int{len(headers), maxCount}) << 5
+	priority |= lo.Min([]int{len(queryParams), maxCount})
+	return priority
+}
@@ -0,0 +1,231 @@
+package parser
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kong/go-kong/kong"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	gatewayv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/dataplane/kongstate"
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/store"
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/util/builder"
+)
+
+func TestHTTPRouteMatchesPriority(t *testing.T) {
+	for _, tc := range []struct {
+		name   string
+		higher []gatewayv1beta1.HTTPRouteMatch
+		lower  []gatewayv1beta1.HTTPRouteMatch
+	}{
+		{
+			name:   "exact paths take precedence over prefixes",
+			higher: []gatewayv1beta1.HTTPRouteMatch{builder.NewHTTPRouteMatch().WithPathExact("/foo").Build()},
+			lower:  []gatewayv1beta1.HTTPRouteMatch{builder.NewHTTPRouteMatch().WithPathPrefix("/foo/bar/baz").Build()},
+		},
+		{
+			name:   "longer prefixes take precedence over shorter ones",
+			higher: []gatewayv1beta1.HTTPRouteMatch{builder.NewHTTPRouteMatch().WithPathPrefix("/foo/bar").Build()},
+			lower:  []gatewayv1beta1.HTTPRouteMatch{builder.NewHTTPRouteMatch().WithPathPrefix("/foo").WithMethod(gatewayv1beta1.HTTPMethodGet).Build()},
+		},
+		{
+			name: "the most precise path of the matches is considered",
+			higher: []gatewayv1beta1.HTTPRouteMatch{
+				builder.NewHTTPRouteMatch().WithPathPrefix("/foo").Build(),
+				builder.NewHTTPRouteMatch().WithPathPrefix("/foo/bar").Build(),
+			},
+			lower: []gatewayv1beta1.HTTPRouteMatch{builder.NewHTTPRouteMatch().WithPathPrefix("/foo/ba").Build()},
+		},
+		{
+			name:   "method matches take precedence over header matches",
+			higher: []gatewayv1beta1.HTTPRouteMatch{builder.NewHTTPRouteMatch().WithPathPrefix("/foo").WithMethod(gatewayv1beta1.HTTPMethodGet).Build()},
+			lower:  []gatewayv1beta1.HTTPRouteMatch{builder.NewHTTPRouteMatch().WithPathPrefix("/foo").WithHeader("x-foo", "bar").Build()},
+		},
+		{
+			name:   "more header matches take precedence over query param matches",
+			higher: []gatewayv1beta1.HTTPRouteMatch{builder.NewHTTPRouteMatch().WithHeader("x-foo", "bar").WithHeader("x-bar", "baz").Build()},
+			lower:  []gatewayv1beta1.HTTPRouteMatch{builder.NewHTTPRouteMatch().WithHeader("x-foo", "bar").WithQueryParam("foo", "bar").Build()},
+		},
+		{
+			name:   "header matches with equivalent names count once",
+			higher: []gatewayv1beta1.HTTPRouteMatch{builder.NewHTTPRouteMatch().WithHeader("x-foo", "bar").WithQueryParam("foo", "bar").Build()},
+			lower:  []gatewayv1beta1.HTTPRouteMatch{builder.NewHTTPRouteMatch().WithHeader("x-foo", "bar").WithHeader("X-Foo", "baz").Build()},
+		},
+		{
+			name:   "query param matches take precedence over no matches",
+			higher: []gatewayv1beta1.HTTPRouteMatch{builder.NewHTTPRouteMatch().WithQueryParam("foo", "bar").Build()},
+			lower:  []gatewayv1beta1.HTTPRouteMatch{builder.NewHTTPRouteMatch().Build()},
+		},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Greater(t, httpRouteMatchesPriority(tc.higher), httpRouteMatchesPriority(tc.lower))
+		})
+	}
+}
+
+func TestHTTPRouteHostnamesPriority(t *testing.T) {
+	assert.Greater(t,
+		httpRouteHostnamesPriority(kong.StringSlice("foo.com")),
+		httpRouteHostnamesPriority(kong.StringSlice("*.foo.bar.com")),
+		"precise hostnames must take precedence over wildcard hostnames",
+	)
+	assert.Greater(t,
+		httpRouteHostnamesPriority(kong.StringSlice("*.foo.bar.com")),
+		httpRouteHostnamesPriority(kong.StringSlice("*.bar.com")),
+		"longer wildcard hostnames must take precedence over shorter ones",
+	)
+	assert.Greater(t,
+		httpRouteHostnamesPriority(kong.StringSlice("*.bar.com")),
+		httpRouteHostnamesPriority(nil),
+		"routes with hostnames must take precedence over routes without",
+	)
+	assert.Equal(t,
+		httpRouteHostnamesPriority(kong.StringSlice("*.foo.bar.com", "foo.com")),
+		httpRouteHostnamesPriority(kong.StringSlice("foo.com")),
+		"the most precise of the hostnames must be considered",
+	)
+}
+
+func TestHTTPRoutesCreationRanks(t *testing.T) {
+	now := time.Now()
+	newHTTPRoute := func(namespace, name string, created time.Time) *gatewayv1beta1.HTTPRoute {
+		return &gatewayv1beta1.HTTPRoute{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              name,
+				Namespace:         namespace,
+				CreationTimestamp: metav1.NewTime(created),
+			},
+		}
+	}
+
+	ranks := httpRoutesCreationRanks([]*gatewayv1beta1.HTTPRoute{
+		newHTTPRoute("default", "newest", now),
+		newHTTPRoute("default", "b", now.Add(-time.Hour)),
+		newHTTPRoute("default", "a", now.Add(-time.Hour)),
+		newHTTPRoute("another", "c", now.Add(-time.Hour)),
+		newHTTPRoute("default", "oldest", now.Add(-2*time.Hour)),
+	})
+	assert.Equal(t, map[string]int{
+		"default/oldest": 0,
+		"another/c":      1,
+		"default/a":      2,
+		"default/b":      3,
+		"default/newest": 4,
+	}, ranks)
+
+	assert.Equal(t, 4, reversedRankPriority(0, 5, httpRouteCreationPriorityBits))
+	assert.Equal(t, 0, reversedRankPriority(4, 5, httpRouteCreationPriorityBits))
+	assert.Equal(t, 0, reversedRankPriority(40, 50, 5), "items ranked after the capacity of the field must have the lowest priority")
+}
+
+func TestIngressRulesFromHTTPRoutes_Priorities(t *testing.T) {
+	now := time.Now()
+	newHTTPRoute := func(name string, created time.Time, rules ...gatewayv1beta1.HTTPRouteRule) *gatewayv1beta1.HTTPRoute {
+		httproute := &gatewayv1beta1.HTTPRoute{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              name,
+				Namespace:         corev1.NamespaceDefault,
+				CreationTimestamp: metav1.NewTime(created),
+			},
+			Spec: gatewayv1beta1.HTTPRouteSpec{
+				CommonRouteSpec: commonRouteSpecMock("fake-gateway"),
+				Rules:           rules,
+			},
+		}
+		httproute.SetGroupVersionKind(httprouteGVK)
+		return httproute
+	}
+	newRule := func(backend string, match gatewayv1beta1.HTTPRouteMatch) gatewayv1beta1.HTTPRouteRule {
+		return gatewayv1beta1.HTTPRouteRule{
+			Matches: []gatewayv1beta1.HTTPRouteMatch{match},
+			BackendRefs: []gatewayv1beta1.HTTPBackendRef{
+				builder.NewHTTPBackendRef(backend).WithPort(80).Build(),
+			},
+		}
+	}
+
+	fakestore, err := store.NewFakeStore(store.FakeObjects{
+		HTTPRoutes: []*gatewayv1beta1.HTTPRoute{
+			newHTTPRoute("newer", now,
+				newRule("first", builder.NewHTTPRouteMatch().WithPathPrefix("/api").Build()),
+				newRule("second", builder.NewHTTPRouteMatch().WithPathPrefix("/api").Build()),
+			),
+			newHTTPRoute("older", now.Add(-time.Hour),
+				newRule("older", builder.NewHTTPRouteMatch().WithPathPrefix("/api").Build()),
+				newRule("exact", builder.NewHTTPRouteMatch().WithPathExact("/api").Build()),
+				newRule("older-last", builder.NewHTTPRouteMatch().WithPathPrefix("/api").Build()),
+			),
+		},
+	})
+	require.NoError(t, err)
+
+	for _, expressionRoutes := range []bool{false, true} {
+		p := mustNewParser(t, fakestore)
+		if expressionRoutes {
+			p.EnableExpressionRoutes()
+		}
+		rules := p.ingressRulesFromHTTPRoutes()
+
+		priority := func(serviceName string) int {
+			routes := rules.ServiceNameToServices[serviceName].Routes
+			require.Len(t, routes, 1)
+			route := routes[0].Route
+			if expressionRoutes {
+				require.Nil(t, route.RegexPriority)
+				return *route.Priority
+			}
+			require.Nil(t, route.Priority)
+			return *route.RegexPriority
+		}
+
+		var (
+			exact       = priority("httproute.default.older.1")
+			olderPrefix = priority("httproute.default.older.0")
+			olderLast   = priority("httproute.default.older.2")
+			first       = priority("httproute.default.newer.0")
+			second      = priority("httproute.default.newer.1")
+		)
+		assert.Greater(t, exact, olderPrefix, "exact path matches must take precedence over prefixes")
+		assert.Greater(t, olderPrefix, first, "the rules of the oldest HTTPRoute must take precedence")
+		assert.Greater(t, olderLast, first, "the rules of the oldest HTTPRoute must take precedence whatever their position")
+		assert.Greater(t, olderPrefix, olderLast, "the rules appearing first in an HTTPRoute must take precedence")
+		assert.Greater(t, first, second, "the rules appearing first in an HTTPRoute must take precedence")
+	}
+
+	t.Log("verifying that the priorities of the routes aren't cumulated by the translation cache")
+	p := mustNewParser(t, fakestore)
+	p.EnableTranslationCache(NewTranslationCache())
+	first := p.ingressRulesFromHTTPRoutes()
+	second := p.ingressRulesFromHTTPRoutes()
+	assert.Equal(t, first.ServiceNameToServices, second.ServiceNameToServices)
+}
+
+func TestHTTPRoutePrioritiesSaturation(t *testing.T) {
+	matches := []gatewayv1beta1.HTTPRouteMatch{builder.NewHTTPRouteMatch().WithPathPrefix("/api").Build()}
+	priority := func(ruleNumber, ruleCount, creationRank, count int) int {
+		route := kongstate.Route{}
+		setHTTPRouteRulePriority(&route, nil, matches, ruleNumber, ruleCount, true)
+		routes := []kongstate.Route{route}
+		setHTTPRouteCreationPriority(routes, creationRank, count)
+		return *routes[0].Priority
+	}
+
+	t.Log("verifying that the rules past the capacity of the rule field share the lowest rule priority")
+	assert.Greater(t, priority(30, 40, 0, 1), priority(31, 40, 0, 1))
+	assert.Equal(t, priority(31, 40, 0, 1), priority(32, 40, 0, 1))
+	assert.Equal(t, priority(31, 40, 0, 1), priority(39, 40, 0, 1))
+
+	t.Log("verifying that the HTTPRoutes past the capacity of the creation field share the lowest creation priority")
+	assert.Greater(t, priority(0, 1, 1022, 1030), priority(0, 1, 1023, 1030))
+	assert.Equal(t, priority(0, 1, 1023, 1030), priority(0, 1, 1024, 1030))
+	assert.Equal(t, priority(0, 1, 1023, 1030), priority(0, 1, 1029, 1030))
+
+	t.Log("verifying that the saturated fields don't overflow into the more significant fields")
+	assert.Equal(t, httpRouteMatchesPriority(matches)<<(httpRouteCreationPriorityBits+httpRouteRulePriorityBits), priority(39, 40, 1029, 1030))
+	assert.Equal(t, httpRouteMatchesPriority(matches)<<(httpRouteCreationPriorityBits+httpRouteRulePriorityBits)|(1<<15-1), priority(0, 40, 0, 1030))
+}
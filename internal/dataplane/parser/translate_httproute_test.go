@@ -1,6 +1,7 @@
 package parser
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/kong/go-kong/kong"
@@ -10,12 +11,15 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
 	gatewayv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
 
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/annotations"
 	"github.com/kong/kubernetes-ingress-controller/v2/internal/dataplane/kongstate"
 	"github.com/kong/kubernetes-ingress-controller/v2/internal/store"
 	"github.com/kong/kubernetes-ingress-controller/v2/internal/util"
 	"github.com/kong/kubernetes-ingress-controller/v2/internal/util/builder"
+	configurationv1 "github.com/kong/kubernetes-ingress-controller/v2/pkg/apis/configuration/v1"
 )
 
 // httprouteGVK is the GVK for HTTPRoutes, needed in unit tests because
@@ -86,8 +90,9 @@ func getIngressRulesFromHTTPRoutesCommonTestCases() []testCaseIngressRulesFromHT
 							Namespace: "default",
 							Routes: []kongstate.Route{{ // only 1 route should be created
 								Route: kong.Route{
-									Name:         kong.String("httproute.default.basic-httproute.0.0"),
-									PreserveHost: kong.Bool(true),
+									Name:          kong.String("httproute.default.basic-httproute.0.0"),
+									RegexPriority: kong.Int((1<<8 | 14) << 37),
+									PreserveHost:  kong.Bool(true),
 									Protocols: []*string{
 										kong.String("http"),
 										kong.String("https"),
@@ -172,7 +177,8 @@ func getIngressRulesFromHTTPRoutesCommonTestCases() []testCaseIngressRulesFromHT
 							Namespace: "default",
 							Routes: []kongstate.Route{{ // only 1 route should be created
 								Route: kong.Route{
-									Name: kong.String("httproute.default.basic-httproute.0.0"),
+									Name:          kong.String("httproute.default.basic-httproute.0.0"),
+									RegexPriority: kong.Int(8 << 26),
 									Paths: []*string{
 										kong.String("/httpbin"),
 									},
@@ -213,7 +219,7 @@ func getIngressRulesFromHTTPRoutesCommonTestCases() []testCaseIngressRulesFromHT
 			},
 		},
 		{
-			msg: "an HTTPRoute with queryParam matches is not yet supported",
+			msg: "an HTTPRoute with queryParam matches is not supported by the traditional router",
 			routes: []*gatewayv1beta1.HTTPRoute{{
 				ObjectMeta: metav1.ObjectMeta{
 					Name:      "basic-httproute",
@@ -280,7 +286,8 @@ func getIngressRulesFromHTTPRoutesCommonTestCases() []testCaseIngressRulesFromHT
 							Namespace: "default",
 							Routes: []kongstate.Route{{ // only 1 route should be created
 								Route: kong.Route{
-									Name: kong.String("httproute.default.basic-httproute.0.0"),
+									Name:          kong.String("httproute.default.basic-httproute.0.0"),
+									RegexPriority: kong.Int(9 << 26),
 									Paths: []*string{
 										kong.String("/httpbin$"),
 									},
@@ -340,7 +347,8 @@ func getIngressRulesFromHTTPRoutesCommonTestCases() []testCaseIngressRulesFromHT
 							Namespace: "default",
 							Routes: []kongstate.Route{{ // only 1 route should be created
 								Route: kong.Route{
-									Name: kong.String("httproute.default.basic-httproute.0.0"),
+									Name:          kong.String("httproute.default.basic-httproute.0.0"),
+									RegexPriority: kong.Int((1<<10 | 8) << 26),
 									Paths: []*string{
 										kong.String("/httpbin$"),
 									},
@@ -412,7 +420,8 @@ func getIngressRulesFromHTTPRoutesCombinedRoutesTestCases() []testCaseIngressRul
 								// only 1 route with two paths should be created
 								{
 									Route: kong.Route{
-										Name: kong.String("httproute.default.basic-httproute.0.0"),
+										Name:          kong.String("httproute.default.basic-httproute.0.0"),
+										RegexPriority: kong.Int(10<<26 | 1),
 										Paths: []*string{
 											kong.String("/httpbin-1"),
 											kong.String("/httpbin-2"),
@@ -485,7 +494,8 @@ func getIngressRulesFromHTTPRoutesCombinedRoutesTestCases() []testCaseIngressRul
 							Namespace: "default",
 							Routes: []kongstate.Route{{ // only 1 route should be created for this service
 								Route: kong.Route{
-									Name: kong.String("httproute.default.basic-httproute.0.0"),
+									Name:          kong.String("httproute.default.basic-httproute.0.0"),
+									RegexPriority: kong.Int(10<<26 | 1),
 									Paths: []*string{
 										kong.String("/httpbin-1"),
 									},
@@ -517,7 +527,8 @@ func getIngressRulesFromHTTPRoutesCombinedRoutesTestCases() []testCaseIngressRul
 							Namespace: "default",
 							Routes: []kongstate.Route{{
 								Route: kong.Route{
-									Name: kong.String("httproute.default.basic-httproute.1.0"),
+									Name:          kong.String("httproute.default.basic-httproute.1.0"),
+									RegexPriority: kong.Int(10 << 26),
 									Paths: []*string{
 										kong.String("/httpbin-2"),
 									},
@@ -601,7 +612,8 @@ func getIngressRulesFromHTTPRoutesCombinedRoutesTestCases() []testCaseIngressRul
 							Routes: []kongstate.Route{
 								{
 									Route: kong.Route{
-										Name: kong.String("httproute.default.basic-httproute.0.0"),
+										Name:          kong.String("httproute.default.basic-httproute.0.0"),
+										RegexPriority: kong.Int(10<<26 | 2),
 										Paths: []*string{
 											kong.String("/httpbin-1"),
 											kong.String("/httpbin-2"),
@@ -637,7 +649,8 @@ func getIngressRulesFromHTTPRoutesCombinedRoutesTestCases() []testCaseIngressRul
 							Routes: []kongstate.Route{
 								{
 									Route: kong.Route{
-										Name: kong.String("httproute.default.basic-httproute.2.0"),
+										Name:          kong.String("httproute.default.basic-httproute.2.0"),
+										RegexPriority: kong.Int(10 << 26),
 										Paths: []*string{
 											kong.String("/httpbin-2"),
 										},
@@ -731,7 +744,8 @@ func getIngressRulesFromHTTPRoutesCombinedRoutesTestCases() []testCaseIngressRul
 								// two route  should be created, as the filters are different
 								{
 									Route: kong.Route{
-										Name: kong.String("httproute.default.basic-httproute.0.0"),
+										Name:          kong.String("httproute.default.basic-httproute.0.0"),
+										RegexPriority: kong.Int(7<<26 | 1),
 										Paths: []*string{
 											kong.String("/path-0"),
 										},
@@ -756,7 +770,8 @@ func getIngressRulesFromHTTPRoutesCombinedRoutesTestCases() []testCaseIngressRul
 								},
 								{
 									Route: kong.Route{
-										Name: kong.String("httproute.default.basic-httproute.1.0"),
+										Name:          kong.String("httproute.default.basic-httproute.1.0"),
+										RegexPriority: kong.Int(7 << 26),
 										Paths: []*string{
 											kong.String("/path-1"),
 										},
@@ -850,7 +865,8 @@ func getIngressRulesFromHTTPRoutesCombinedRoutesTestCases() []testCaseIngressRul
 								// First two matches consolidated into a single route
 								{
 									Route: kong.Route{
-										Name: kong.String("httproute.default.basic-httproute.0.0"),
+										Name:          kong.String("httproute.default.basic-httproute.0.0"),
+										RegexPriority: kong.Int(7 << 26),
 										Paths: []*string{
 											kong.String("/path-0"),
 											kong.String("/path-1"),
@@ -867,7 +883,8 @@ func getIngressRulesFromHTTPRoutesCombinedRoutesTestCases() []testCaseIngressRul
 								// Second two matches consolidated into a single route
 								{
 									Route: kong.Route{
-										Name: kong.String("httproute.default.basic-httproute.0.2"),
+										Name:          kong.String("httproute.default.basic-httproute.0.2"),
+										RegexPriority: kong.Int((7<<11 | 1<<10) << 15),
 										Paths: []*string{
 											kong.String("/path-2"),
 											kong.String("/path-3"),
@@ -885,7 +902,8 @@ func getIngressRulesFromHTTPRoutesCombinedRoutesTestCases() []testCaseIngressRul
 								// Third two matches consolidated into a single route
 								{
 									Route: kong.Route{
-										Name: kong.String("httproute.default.basic-httproute.0.4"),
+										Name:          kong.String("httproute.default.basic-httproute.0.4"),
+										RegexPriority: kong.Int((7<<11 | 2<<5) << 15),
 										Paths: []*string{
 											kong.String("/path-4"),
 											kong.String("/path-5"),
@@ -1002,7 +1020,8 @@ func getIngressRulesFromHTTPRoutesCombinedRoutesTestCases() []testCaseIngressRul
 								// First two matches from rule one and rule two consolidated into a single route
 								{
 									Route: kong.Route{
-										Name: kong.String("httproute.default.basic-httproute.0.0"),
+										Name:          kong.String("httproute.default.basic-httproute.0.0"),
+										RegexPriority: kong.Int(7<<26 | 2),
 										Paths: []*string{
 											kong.String("/path-0"),
 											kong.String("/path-1"),
@@ -1021,7 +1040,8 @@ func getIngressRulesFromHTTPRoutesCombinedRoutesTestCases() []testCaseIngressRul
 								// Second two matches consolidated into a single route
 								{
 									Route: kong.Route{
-										Name: kong.String("httproute.default.basic-httproute.0.2"),
+										Name:          kong.String("httproute.default.basic-httproute.0.2"),
+										RegexPriority: kong.Int((7<<11|1<<10)<<15 | 2),
 										Paths: []*string{
 											kong.String("/path-2"),
 											kong.String("/path-3"),
@@ -1040,7 +1060,8 @@ func getIngressRulesFromHTTPRoutesCombinedRoutesTestCases() []testCaseIngressRul
 								// Matches from rule 3, that has different filter, are not consolidated
 								{
 									Route: kong.Route{
-										Name: kong.String("httproute.default.basic-httproute.2.0"),
+										Name:          kong.String("httproute.default.basic-httproute.2.0"),
+										RegexPriority: kong.Int(7 << 26),
 										Paths: []*string{
 											kong.String("/path-6"),
 											kong.String("/path-7"),
@@ -1253,7 +1274,8 @@ func TestIngressRulesFromHTTPRoutes_RegexPrefix(t *testing.T) {
 							Namespace: "default",
 							Routes: []kongstate.Route{{ // only 1 route should be created
 								Route: kong.Route{
-									Name: kong.String("httproute.default.basic-httproute.0.0"),
+									Name:          kong.String("httproute.default.basic-httproute.0.0"),
+									RegexPriority: kong.Int(9 << 26),
 									Paths: []*string{
 										kong.String("~/httpbin$"),
 									},
@@ -1333,3 +1355,684 @@ func commonRouteSpecMock(parentReferentName string) gatewayv1beta1.CommonRouteSp
 		}},
 	}
 }
+
+func TestGenerateRequestRedirectKongPlugin(t *testing.T) {
+	t.Run("redirect to https on a canonical host", func(t *testing.T) {
+		hostname := gatewayv1beta1.PreciseHostname("www.example.com")
+		plugin := generateRequestRedirectKongPlugin(&gatewayv1beta1.HTTPRequestRedirectFilter{
+			Scheme:     kong.String("https"),
+			Hostname:   &hostname,
+			StatusCode: lo.ToPtr(301),
+		}, nil)
+
+		assert.Equal(t, "pre-function", *plugin.Name)
+		assert.Equal(t, kong.Configuration{
+			"access": []string{`local scheme = kong.request.get_forwarded_scheme()
+local host = kong.request.get_forwarded_host()
+local port = kong.request.get_forwarded_port()
+local path = kong.request.get_path()
+scheme = "https"
+port = nil
+host = "www.example.com"
+local location = scheme .. "://" .. host
+if port and not ((scheme == "http" and port == 80) or (scheme == "https" and port == 443)) then
+  location = location .. ":" .. port
+end
+location = location .. path
+local query = kong.request.get_raw_query()
+if query ~= "" then
+  location = location .. "?" .. query
+end
+return kong.response.exit(301, nil, { ["Location"] = location })
+`},
+		}, plugin.Config)
+	})
+
+	t.Run("redirect replacing the full path on another port", func(t *testing.T) {
+		port := gatewayv1beta1.PortNumber(8443)
+		plugin := generateRequestRedirectKongPlugin(&gatewayv1beta1.HTTPRequestRedirectFilter{
+			Port: &port,
+			Path: &gatewayv1beta1.HTTPPathModifier{
+				Type:            gatewayv1beta1.FullPathHTTPPathModifier,
+				ReplaceFullPath: kong.String(`/new"path`),
+			},
+		}, nil)
+
+		code := plugin.Config["access"].([]string)[0]
+		assert.Contains(t, code, "port = 8443\n")
+		assert.NotContains(t, code, "port = nil\n")
+		assert.Contains(t, code, `path = "/new\"path"`+"\n")
+		assert.Contains(t, code, "return kong.response.exit(302, ", "redirects must default to 302")
+	})
+
+	t.Run("redirect replacing the prefix match", func(t *testing.T) {
+		plugin := generateRequestRedirectKongPlugin(&gatewayv1beta1.HTTPRequestRedirectFilter{
+			Path: &gatewayv1beta1.HTTPPathModifier{
+				Type:               gatewayv1beta1.PrefixMatchHTTPPathModifier,
+				ReplacePrefixMatch: kong.String("/v2/"),
+			},
+		}, []gatewayv1beta1.HTTPRouteMatch{
+			builder.NewHTTPRouteMatch().WithPathPrefix("/api/").Build(),
+			builder.NewHTTPRouteMatch().WithPathPrefix("/api/v1").Build(),
+			builder.NewHTTPRouteMatch().WithPathPrefix("/").Build(),
+		})
+
+		code := plugin.Config["access"].([]string)[0]
+		assert.Contains(t, code, `for _, prefix in ipairs({ "/api/v1", "/api", "" }) do
+  if path == prefix or path:sub(1, #prefix + 1) == prefix .. "/" then
+    path = "/v2" .. path:sub(#prefix + 1)
+    break
+  end
+end
+`, "prefixes must be replaced longest first, without their trailing slashes")
+	})
+}
+
+func TestGenerateURLRewriteKongPlugin(t *testing.T) {
+	t.Run("rewrite of the hostname and the full path", func(t *testing.T) {
+		hostname := gatewayv1beta1.PreciseHostname("backend.internal")
+		plugin := generateURLRewriteKongPlugin(&gatewayv1beta1.HTTPURLRewriteFilter{
+			Hostname: &hostname,
+			Path: &gatewayv1beta1.HTTPPathModifier{
+				Type:            gatewayv1beta1.FullPathHTTPPathModifier,
+				ReplaceFullPath: kong.String("/status"),
+			},
+		}, nil)
+
+		assert.Equal(t, "pre-function", *plugin.Name)
+		assert.Equal(t, kong.Configuration{
+			"access": []string{`kong.service.request.set_header("Host", "backend.internal")
+local path = kong.request.get_path()
+path = "/status"
+kong.service.request.set_path(path)
+`},
+		}, plugin.Config)
+	})
+
+	t.Run("rewrite replacing the prefix match", func(t *testing.T) {
+		plugin := generateURLRewriteKongPlugin(&gatewayv1beta1.HTTPURLRewriteFilter{
+			Path: &gatewayv1beta1.HTTPPathModifier{
+				Type:               gatewayv1beta1.PrefixMatchHTTPPathModifier,
+				ReplacePrefixMatch: kong.String("/"),
+			},
+		}, []gatewayv1beta1.HTTPRouteMatch{
+			builder.NewHTTPRouteMatch().WithPathPrefix("/legacy").Build(),
+		})
+
+		code := plugin.Config["access"].([]string)[0]
+		assert.NotContains(t, code, "Host", "the hostname must not be rewritten")
+		assert.Contains(t, code, `for _, prefix in ipairs({ "/legacy" }) do`)
+		assert.Contains(t, code, "end\nkong.service.request.set_path(path)\n", "the path must be set once replaced")
+	})
+}
+
+func TestGenerateRequestMirrorKongPlugin(t *testing.T) {
+	plugin := generateRequestMirrorKongPlugin(&gatewayv1beta1.HTTPRequestMirrorFilter{
+		BackendRef: gatewayv1beta1.BackendObjectReference{
+			Name: "canary",
+			Port: lo.ToPtr(gatewayv1beta1.PortNumber(8080)),
+		},
+	}, "default")
+
+	assert.Equal(t, "pre-function", *plugin.Name)
+	assert.Equal(t, kong.Configuration{
+		"access": []string{`local http = require("resty.http")
+local method = kong.request.get_method()
+local path = kong.request.get_path_with_query()
+local headers = kong.request.get_headers()
+headers["content-length"] = nil
+headers["transfer-encoding"] = nil
+local body = kong.request.get_raw_body()
+ngx.timer.at(0, function(premature)
+  if premature then
+    return
+  end
+  local httpc = http.new()
+  local _, err = httpc:request_uri("http://canary.default.svc:8080" .. path, { method = method, headers = headers, body = body })
+  if err then
+    kong.log.err("failed to mirror request: ", err)
+  end
+end)
+`},
+	}, plugin.Config)
+
+	t.Log("verifying that the namespace of the reference takes precedence")
+	plugin = generateRequestMirrorKongPlugin(&gatewayv1beta1.HTTPRequestMirrorFilter{
+		BackendRef: gatewayv1beta1.BackendObjectReference{
+			Name:      "canary",
+			Namespace: lo.ToPtr(gatewayv1beta1.Namespace("staging")),
+			Port:      lo.ToPtr(gatewayv1beta1.PortNumber(80)),
+		},
+	}, "default")
+	assert.Contains(t, plugin.Config["access"].([]string)[0], `"http://canary.staging.svc:80" .. path`)
+}
+
+func TestGeneratePluginsFromHTTPRouteFilters_PreFunctions(t *testing.T) {
+	plugins := generatePluginsFromHTTPRouteFilters([]gatewayv1beta1.HTTPRouteFilter{
+		{
+			Type: gatewayv1beta1.HTTPRouteFilterURLRewrite,
+			URLRewrite: &gatewayv1beta1.HTTPURLRewriteFilter{
+				Path: &gatewayv1beta1.HTTPPathModifier{
+					Type:            gatewayv1beta1.FullPathHTTPPathModifier,
+					ReplaceFullPath: kong.String("/status"),
+				},
+			},
+		},
+		{
+			Type: gatewayv1beta1.HTTPRouteFilterRequestHeaderModifier,
+			RequestHeaderModifier: &gatewayv1beta1.HTTPRequestHeaderFilter{
+				Remove: []string{"X-Debug"},
+			},
+		},
+		{
+			Type: gatewayv1beta1.HTTPRouteFilterRequestMirror,
+			RequestMirror: &gatewayv1beta1.HTTPRequestMirrorFilter{
+				BackendRef: gatewayv1beta1.BackendObjectReference{
+					Name: "canary",
+					Port: lo.ToPtr(gatewayv1beta1.PortNumber(80)),
+				},
+			},
+		},
+	}, nil, "default")
+
+	require.Len(t, plugins, 2, "the pre-function plugins must be merged, as routes accept one plugin of each type")
+	assert.Equal(t, "request-transformer", *plugins[0].Name)
+	assert.Equal(t, "pre-function", *plugins[1].Name)
+
+	mirror := generateRequestMirrorKongPlugin(&gatewayv1beta1.HTTPRequestMirrorFilter{
+		BackendRef: gatewayv1beta1.BackendObjectReference{
+			Name: "canary",
+			Port: lo.ToPtr(gatewayv1beta1.PortNumber(80)),
+		},
+	}, "default").Config["access"].([]string)[0]
+	rewrite := "local path = kong.request.get_path()\npath = \"/status\"\nkong.service.request.set_path(path)\n"
+	assert.Equal(t, []string{"do\n" + mirror + "end\ndo\n" + rewrite + "end\n"}, plugins[1].Config["access"],
+		"requests must be mirrored before the other filters apply")
+}
+
+func TestIngressRulesFromHTTPRoutes_RequestMirrorReferenceGrants(t *testing.T) {
+	httproute := &gatewayv1beta1.HTTPRoute{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "mirroring-httproute",
+			Namespace: corev1.NamespaceDefault,
+		},
+		Spec: gatewayv1beta1.HTTPRouteSpec{
+			CommonRouteSpec: commonRouteSpecMock("fake-gateway"),
+			Rules: []gatewayv1beta1.HTTPRouteRule{
+				{
+					Matches: []gatewayv1beta1.HTTPRouteMatch{
+						builder.NewHTTPRouteMatch().WithPathPrefix("/").Build(),
+					},
+					Filters: []gatewayv1beta1.HTTPRouteFilter{{
+						Type: gatewayv1beta1.HTTPRouteFilterRequestMirror,
+						RequestMirror: &gatewayv1beta1.HTTPRequestMirrorFilter{
+							BackendRef: gatewayv1beta1.BackendObjectReference{
+								Name:      "canary",
+								Namespace: lo.ToPtr(gatewayv1beta1.Namespace("staging")),
+								Port:      lo.ToPtr(gatewayv1beta1.PortNumber(80)),
+							},
+						},
+					}},
+					BackendRefs: []gatewayv1beta1.HTTPBackendRef{
+						builder.NewHTTPBackendRef("backend").WithPort(80).Build(),
+					},
+				},
+			},
+		},
+	}
+	httproute.SetGroupVersionKind(httprouteGVK)
+
+	t.Run("mirrors to other namespaces require a ReferenceGrant", func(t *testing.T) {
+		fakestore, err := store.NewFakeStore(store.FakeObjects{})
+		require.NoError(t, err)
+		p := mustNewParser(t, fakestore)
+		p.EnableRequestMirrorFilters()
+		rules := newIngressRules()
+		assert.EqualError(t, p.ingressRulesFromHTTPRoute(&rules, httproute),
+			"rule 0: RequestMirror filter backendRef staging/canary is not permitted by any ReferenceGrant")
+	})
+
+	t.Run("mirrors to other namespaces permitted by a ReferenceGrant", func(t *testing.T) {
+		fakestore, err := store.NewFakeStore(store.FakeObjects{
+			ReferenceGrants: []*gatewayv1alpha2.ReferenceGrant{{
+				ObjectMeta: metav1.ObjectMeta{Name: "mirrors", Namespace: "staging"},
+				Spec: gatewayv1alpha2.ReferenceGrantSpec{
+					From: []gatewayv1alpha2.ReferenceGrantFrom{{
+						Group:     gatewayv1alpha2.Group("gateway.networking.k8s.io"),
+						Kind:      gatewayv1alpha2.Kind("HTTPRoute"),
+						Namespace: gatewayv1alpha2.Namespace(corev1.NamespaceDefault),
+					}},
+					To: []gatewayv1alpha2.ReferenceGrantTo{{
+						Kind: gatewayv1alpha2.Kind("Service"),
+					}},
+				},
+			}},
+		})
+		require.NoError(t, err)
+		p := mustNewParser(t, fakestore)
+		p.EnableRequestMirrorFilters()
+		rules := newIngressRules()
+		require.NoError(t, p.ingressRulesFromHTTPRoute(&rules, httproute))
+
+		routes := rules.ServiceNameToServices["httproute.default.mirroring-httproute.0"].Routes
+		require.Len(t, routes, 1)
+		require.Len(t, routes[0].Plugins, 1)
+		assert.Contains(t, routes[0].Plugins[0].Config["access"].([]string)[0], `"http://canary.staging.svc:80" .. path`)
+	})
+}
+
+func TestIngressRulesFromHTTPRoutes_PreFunctionFilters(t *testing.T) {
+	fakestore, err := store.NewFakeStore(store.FakeObjects{})
+	require.NoError(t, err)
+
+	newHTTPRoute := func(filter gatewayv1beta1.HTTPRouteFilter) *gatewayv1beta1.HTTPRoute {
+		httproute := &gatewayv1beta1.HTTPRoute{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "filtered-httproute",
+				Namespace: corev1.NamespaceDefault,
+			},
+			Spec: gatewayv1beta1.HTTPRouteSpec{
+				CommonRouteSpec: commonRouteSpecMock("fake-gateway"),
+				Rules: []gatewayv1beta1.HTTPRouteRule{{
+					Matches: []gatewayv1beta1.HTTPRouteMatch{
+						builder.NewHTTPRouteMatch().WithPathPrefix("/").Build(),
+					},
+					Filters: []gatewayv1beta1.HTTPRouteFilter{filter},
+					BackendRefs: []gatewayv1beta1.HTTPBackendRef{
+						builder.NewHTTPBackendRef("backend").WithPort(80).Build(),
+					},
+				}},
+			},
+		}
+		httproute.SetGroupVersionKind(httprouteGVK)
+		return httproute
+	}
+
+	for _, tc := range []struct {
+		name           string
+		filter         gatewayv1beta1.HTTPRouteFilter
+		enable         func(p *Parser)
+		expectedErrMsg string
+	}{
+		{
+			name: "redirect without pre-function filters",
+			filter: gatewayv1beta1.HTTPRouteFilter{
+				Type:            gatewayv1beta1.HTTPRouteFilterRequestRedirect,
+				RequestRedirect: &gatewayv1beta1.HTTPRequestRedirectFilter{Scheme: kong.String("https")},
+			},
+			enable:         func(p *Parser) {},
+			expectedErrMsg: "rule 0: RequestRedirect filters require Kong's untrusted_lua setting not to be off",
+		},
+		{
+			name: "redirect with pre-function filters",
+			filter: gatewayv1beta1.HTTPRouteFilter{
+				Type:            gatewayv1beta1.HTTPRouteFilterRequestRedirect,
+				RequestRedirect: &gatewayv1beta1.HTTPRequestRedirectFilter{Scheme: kong.String("https")},
+			},
+			enable: (*Parser).EnablePreFunctionFilters,
+		},
+		{
+			name: "rewrite without pre-function filters",
+			filter: gatewayv1beta1.HTTPRouteFilter{
+				Type:       gatewayv1beta1.HTTPRouteFilterURLRewrite,
+				URLRewrite: &gatewayv1beta1.HTTPURLRewriteFilter{Hostname: lo.ToPtr(gatewayv1beta1.PreciseHostname("example.com"))},
+			},
+			enable:         func(p *Parser) {},
+			expectedErrMsg: "rule 0: URLRewrite filters require Kong's untrusted_lua setting not to be off",
+		},
+		{
+			name: "mirror with pre-function filters only",
+			filter: gatewayv1beta1.HTTPRouteFilter{
+				Type: gatewayv1beta1.HTTPRouteFilterRequestMirror,
+				RequestMirror: &gatewayv1beta1.HTTPRequestMirrorFilter{
+					BackendRef: gatewayv1beta1.BackendObjectReference{
+						Name: "canary",
+						Port: lo.ToPtr(gatewayv1beta1.PortNumber(80)),
+					},
+				},
+			},
+			enable: (*Parser).EnablePreFunctionFilters,
+			expectedErrMsg: "rule 0: RequestMirror filters require Kong's untrusted_lua setting to be on, or its sandbox " +
+				"to allow requiring resty.http (untrusted_lua_sandbox_requires) and accessing ngx.timer (untrusted_lua_sandbox_environment)",
+		},
+		{
+			name: "mirror with request mirror filters",
+			filter: gatewayv1beta1.HTTPRouteFilter{
+				Type: gatewayv1beta1.HTTPRouteFilterRequestMirror,
+				RequestMirror: &gatewayv1beta1.HTTPRequestMirrorFilter{
+					BackendRef: gatewayv1beta1.BackendObjectReference{
+						Name: "canary",
+						Port: lo.ToPtr(gatewayv1beta1.PortNumber(80)),
+					},
+				},
+			},
+			enable: (*Parser).EnableRequestMirrorFilters,
+		},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			p := mustNewParser(t, fakestore)
+			tc.enable(p)
+			rules := newIngressRules()
+			err := p.ingressRulesFromHTTPRoute(&rules, newHTTPRoute(tc.filter))
+			if tc.expectedErrMsg != "" {
+				require.EqualError(t, err, tc.expectedErrMsg)
+				require.Empty(t, rules.ServiceNameToServices)
+				return
+			}
+			require.NoError(t, err)
+
+			routes := rules.ServiceNameToServices["httproute.default.filtered-httproute.0"].Routes
+			require.Len(t, routes, 1)
+			require.Len(t, routes[0].Plugins, 1)
+			assert.Equal(t, "pre-function", *routes[0].Plugins[0].Name)
+		})
+	}
+}
+
+func TestIngressRulesFromHTTPRoutes_ExtensionRef(t *testing.T) {
+	extensionRef := func(kind, name string) gatewayv1beta1.HTTPRouteFilter {
+		return gatewayv1beta1.HTTPRouteFilter{
+			Type: gatewayv1beta1.HTTPRouteFilterExtensionRef,
+			ExtensionRef: &gatewayv1beta1.LocalObjectReference{
+				Group: "configuration.konghq.com",
+				Kind:  gatewayv1beta1.Kind(kind),
+				Name:  gatewayv1beta1.ObjectName(name),
+			},
+		}
+	}
+	newHTTPRoute := func(filters ...gatewayv1beta1.HTTPRouteFilter) *gatewayv1beta1.HTTPRoute {
+		httproute := &gatewayv1beta1.HTTPRoute{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "extended-httproute",
+				Namespace: corev1.NamespaceDefault,
+			},
+			Spec: gatewayv1beta1.HTTPRouteSpec{
+				CommonRouteSpec: commonRouteSpecMock("fake-gateway"),
+				Rules: []gatewayv1beta1.HTTPRouteRule{
+					{
+						Matches: []gatewayv1beta1.HTTPRouteMatch{
+							builder.NewHTTPRouteMatch().WithPathPrefix("/admin").Build(),
+						},
+						Filters: filters,
+						BackendRefs: []gatewayv1beta1.HTTPBackendRef{
+							builder.NewHTTPBackendRef("admin").WithPort(80).Build(),
+						},
+					},
+					{
+						Matches: []gatewayv1beta1.HTTPRouteMatch{
+							builder.NewHTTPRouteMatch().WithPathPrefix("/").Build(),
+						},
+						BackendRefs: []gatewayv1beta1.HTTPBackendRef{
+							builder.NewHTTPBackendRef("public").WithPort(80).Build(),
+						},
+					},
+				},
+			},
+		}
+		httproute.SetGroupVersionKind(httprouteGVK)
+		return httproute
+	}
+
+	fakestore, err := store.NewFakeStore(store.FakeObjects{
+		KongPlugins: []*configurationv1.KongPlugin{{
+			ObjectMeta: metav1.ObjectMeta{Name: "auth", Namespace: corev1.NamespaceDefault},
+			PluginName: "key-auth",
+		}},
+		KongClusterPlugins: []*configurationv1.KongClusterPlugin{{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "rate-limit",
+				Annotations: map[string]string{annotations.IngressClassKey: annotations.DefaultIngressClass},
+			},
+			PluginName: "rate-limiting",
+		}},
+	})
+	require.NoError(t, err)
+
+	for _, combined := range []bool{false, true} {
+		p := mustNewParser(t, fakestore)
+		if combined {
+			p.EnableCombinedServiceRoutes()
+		}
+
+		rules := newIngressRules()
+		require.NoError(t, p.ingressRulesFromHTTPRoute(&rules,
+			newHTTPRoute(extensionRef("KongPlugin", "auth"), extensionRef("KongClusterPlugin", "rate-limit"))))
+
+		adminRoutes := rules.ServiceNameToServices["httproute.default.extended-httproute.0"].Routes
+		require.Len(t, adminRoutes, 1)
+		require.Len(t, adminRoutes[0].Plugins, 2)
+		assert.Equal(t, "key-auth", *adminRoutes[0].Plugins[0].Name)
+		assert.Equal(t, "rate-limiting", *adminRoutes[0].Plugins[1].Name)
+
+		publicRoutes := rules.ServiceNameToServices["httproute.default.extended-httproute.1"].Routes
+		require.Len(t, publicRoutes, 1)
+		assert.Empty(t, publicRoutes[0].Plugins, "plugins must only be attached to the routes of the rule referencing them")
+	}
+
+	t.Run("missing plugins", func(t *testing.T) {
+		p := mustNewParser(t, fakestore)
+		rules := newIngressRules()
+		err := p.ingressRulesFromHTTPRoute(&rules, newHTTPRoute(extensionRef("KongClusterPlugin", "auth")))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "ExtensionRef filter KongClusterPlugin auth can't be resolved")
+	})
+
+	t.Run("multiple plugins of the same type", func(t *testing.T) {
+		p := mustNewParser(t, fakestore)
+		rules := newIngressRules()
+		assert.EqualError(t, p.ingressRulesFromHTTPRoute(&rules, newHTTPRoute(
+			extensionRef("KongPlugin", "auth"),
+			extensionRef("KongPlugin", "auth"),
+		)), "route httproute.default.extended-httproute.0.0 can't have multiple key-auth plugins")
+	})
+}
+
+func TestIngressRulesFromHTTPRoutes_URLRewrite(t *testing.T) {
+	fakestore, err := store.NewFakeStore(store.FakeObjects{})
+	require.NoError(t, err)
+
+	rewrite := gatewayv1beta1.HTTPRouteFilter{
+		Type: gatewayv1beta1.HTTPRouteFilterURLRewrite,
+		URLRewrite: &gatewayv1beta1.HTTPURLRewriteFilter{
+			Path: &gatewayv1beta1.HTTPPathModifier{
+				Type:               gatewayv1beta1.PrefixMatchHTTPPathModifier,
+				ReplacePrefixMatch: kong.String("/"),
+			},
+		},
+	}
+	httproute := &gatewayv1beta1.HTTPRoute{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "rewriting-httproute",
+			Namespace: corev1.NamespaceDefault,
+		},
+		Spec: gatewayv1beta1.HTTPRouteSpec{
+			CommonRouteSpec: commonRouteSpecMock("fake-gateway"),
+			Rules: []gatewayv1beta1.HTTPRouteRule{
+				{
+					Matches: []gatewayv1beta1.HTTPRouteMatch{
+						builder.NewHTTPRouteMatch().WithPathPrefix("/v1").Build(),
+						builder.NewHTTPRouteMatch().WithPathPrefix("/v1/legacy").Build(),
+					},
+					Filters: []gatewayv1beta1.HTTPRouteFilter{rewrite},
+					BackendRefs: []gatewayv1beta1.HTTPBackendRef{
+						builder.NewHTTPBackendRef("backend").WithPort(80).Build(),
+					},
+				},
+			},
+		},
+	}
+	httproute.SetGroupVersionKind(httprouteGVK)
+
+	rewrittenPrefixes := func(route kongstate.Route) string {
+		require.Len(t, route.Plugins, 1)
+		code := route.Plugins[0].Config["access"].([]string)[0]
+		for _, line := range strings.Split(code, "\n") {
+			if strings.HasPrefix(line, "for _, prefix in ipairs(") {
+				return line
+			}
+		}
+		return ""
+	}
+
+	t.Run("each route only rewrites the prefix it matches", func(t *testing.T) {
+		p := mustNewParser(t, fakestore)
+		p.EnablePreFunctionFilters()
+		rules := newIngressRules()
+		require.NoError(t, p.ingressRulesFromHTTPRoute(&rules, httproute))
+
+		routes := rules.ServiceNameToServices["httproute.default.rewriting-httproute.0"].Routes
+		require.Len(t, routes, 2)
+		assert.Equal(t, `for _, prefix in ipairs({ "/v1" }) do`, rewrittenPrefixes(routes[0]))
+		assert.Equal(t, `for _, prefix in ipairs({ "/v1/legacy" }) do`, rewrittenPrefixes(routes[1]))
+	})
+
+	t.Run("combined routes rewrite the longest prefix they match", func(t *testing.T) {
+		p := mustNewParser(t, fakestore)
+		p.EnablePreFunctionFilters()
+		p.EnableCombinedServiceRoutes()
+		rules := newIngressRules()
+		require.NoError(t, p.ingressRulesFromHTTPRoute(&rules, httproute))
+
+		routes := rules.ServiceNameToServices["httproute.default.rewriting-httproute.0"].Routes
+		require.Len(t, routes, 1)
+		assert.Equal(t, `for _, prefix in ipairs({ "/v1/legacy", "/v1" }) do`, rewrittenPrefixes(routes[0]))
+	})
+}
+
+func TestIngressRulesFromHTTPRoutes_ListenerProtocols(t *testing.T) {
+	gateway := &gatewayv1beta1.Gateway{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "gateway",
+			Namespace: corev1.NamespaceDefault,
+		},
+		Spec: gatewayv1beta1.GatewaySpec{
+			Listeners: []gatewayv1beta1.Listener{
+				builder.NewListener("http").WithPort(80).HTTP().Build(),
+				builder.NewListener("https").WithPort(443).HTTPS().Build(),
+			},
+		},
+	}
+	conflictedGateway := gateway.DeepCopy()
+	conflictedGateway.Name = "conflicted-gateway"
+	conflictedGateway.Status.Listeners = []gatewayv1beta1.ListenerStatus{
+		{
+			Name: "http",
+			Conditions: []metav1.Condition{{
+				Type:   string(gatewayv1beta1.ListenerConditionReady),
+				Status: metav1.ConditionFalse,
+				Reason: string(gatewayv1beta1.ListenerReasonPending),
+			}},
+		},
+		{
+			Name: "https",
+			Conditions: []metav1.Condition{{
+				Type:   string(gatewayv1beta1.ListenerConditionReady),
+				Status: metav1.ConditionTrue,
+				Reason: string(gatewayv1beta1.ListenerReasonReady),
+			}},
+		},
+	}
+	fakestore, err := store.NewFakeStore(store.FakeObjects{
+		Gateways: []*gatewayv1beta1.Gateway{gateway, conflictedGateway},
+	})
+	require.NoError(t, err)
+
+	for _, tc := range []struct {
+		name              string
+		parentRefs        []gatewayv1beta1.ParentReference
+		expectedProtocols []*string
+		expectedErr       bool
+	}{
+		{
+			name:              "routes attached to all the listeners match both protocols",
+			parentRefs:        []gatewayv1beta1.ParentReference{{Name: "gateway"}},
+			expectedProtocols: kong.StringSlice("http", "https"),
+		},
+		{
+			name: "routes attached to the HTTPS listener by port only match HTTPS",
+			parentRefs: []gatewayv1beta1.ParentReference{{
+				Name: "gateway",
+				Port: lo.ToPtr(gatewayv1beta1.PortNumber(443)),
+			}},
+			expectedProtocols: kong.StringSlice("https"),
+		},
+		{
+			name: "routes attached to the HTTP listener by name only match HTTP",
+			parentRefs: []gatewayv1beta1.ParentReference{{
+				Name:        "gateway",
+				SectionName: lo.ToPtr(gatewayv1beta1.SectionName("http")),
+			}},
+			expectedProtocols: kong.StringSlice("http"),
+		},
+		{
+			name:              "routes attached to unknown gateways aren't restricted",
+			parentRefs:        []gatewayv1beta1.ParentReference{{Name: "fake-gateway"}},
+			expectedProtocols: kong.StringSlice("http", "https"),
+		},
+		{
+			name: "unknown gateways don't lift the restrictions of the other ParentRefs",
+			parentRefs: []gatewayv1beta1.ParentReference{
+				{Name: "fake-gateway"},
+				{Name: "gateway", SectionName: lo.ToPtr(gatewayv1beta1.SectionName("https"))},
+			},
+			expectedProtocols: kong.StringSlice("https"),
+		},
+		{
+			name:              "listeners which aren't ready are ignored",
+			parentRefs:        []gatewayv1beta1.ParentReference{{Name: "conflicted-gateway"}},
+			expectedProtocols: kong.StringSlice("https"),
+		},
+		{
+			name: "routes only attached to listeners which aren't ready aren't translated",
+			parentRefs: []gatewayv1beta1.ParentReference{{
+				Name:        "conflicted-gateway",
+				SectionName: lo.ToPtr(gatewayv1beta1.SectionName("http")),
+			}},
+			expectedErr: true,
+		},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			httproute := &gatewayv1beta1.HTTPRoute{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "basic-httproute",
+					Namespace: corev1.NamespaceDefault,
+				},
+				Spec: gatewayv1beta1.HTTPRouteSpec{
+					CommonRouteSpec: gatewayv1beta1.CommonRouteSpec{
+						ParentRefs: tc.parentRefs,
+					},
+					Rules: []gatewayv1beta1.HTTPRouteRule{{
+						Matches: []gatewayv1beta1.HTTPRouteMatch{
+							builder.NewHTTPRouteMatch().WithPathPrefix("/httpbin").Build(),
+						},
+						BackendRefs: []gatewayv1beta1.HTTPBackendRef{
+							builder.NewHTTPBackendRef("fake-service").WithPort(80).Build(),
+						},
+					}},
+				},
+			}
+			httproute.SetGroupVersionKind(httprouteGVK)
+
+			for _, combined := range []bool{false, true} {
+				p := mustNewParser(t, fakestore)
+				if combined {
+					p.EnableCombinedServiceRoutes()
+				}
+				rules := newIngressRules()
+				err := p.ingressRulesFromHTTPRoute(&rules, httproute)
+				if tc.expectedErr {
+					require.Error(t, err)
+					require.Empty(t, rules.ServiceNameToServices)
+					continue
+				}
+				require.NoError(t, err)
+
+				routes := rules.ServiceNameToServices["httproute.default.basic-httproute.0"].Routes
+				require.Len(t, routes, 1)
+				assert.Equal(t, tc.expectedProtocols, routes[0].Protocols)
+			}
+		})
+	}
+}
@@ -14,6 +14,7 @@ import (
 	"github.com/kong/kubernetes-ingress-controller/v2/internal/dataplane/parser/translators"
 	"github.com/kong/kubernetes-ingress-controller/v2/internal/store"
 	"github.com/kong/kubernetes-ingress-controller/v2/internal/util"
+	configurationv1alpha1 "github.com/kong/kubernetes-ingress-controller/v2/pkg/apis/configuration/v1alpha1"
 )
 
 func serviceBackendPortToStr(port netv1.ServiceBackendPort) string {
@@ -200,108 +201,30 @@ func (p *Parser) ingressRulesFromIngressV1() ingressRules {
 			&ingressList[j].CreationTimestamp)
 	})
 
+	settings := fmt.Sprintf("combined=%t regex=%t legacy=%t",
+		p.featureEnabledCombinedServiceRoutes, p.flagEnabledRegexPathPrefix, icp.EnableLegacyRegexDetection)
 	for _, ingress := range ingressList {
-		regexPrefix := translators.ControllerPathRegexPrefix
-		if prefix, ok := ingress.ObjectMeta.Annotations[annotations.AnnotationPrefix+annotations.RegexPrefixKey]; ok {
-			regexPrefix = prefix
-		}
-		ingressSpec := ingress.Spec
-
-		if ingressSpec.DefaultBackend != nil {
+		ingress := ingress
+		if ingress.Spec.DefaultBackend != nil {
 			allDefaultBackends = append(allDefaultBackends, *ingress)
 		}
 
-		result.SecretNameToSNIs.addFromIngressV1TLS(ingressSpec.TLS, ingress)
-
-		var objectSuccessfullyParsed bool
-
-		if p.featureEnabledCombinedServiceRoutes {
-			for _, kongStateService := range translators.TranslateIngress(ingress, p.flagEnabledRegexPathPrefix) {
-				for _, route := range kongStateService.Routes {
-					for i, path := range route.Paths {
-						newPath := maybePrependRegexPrefix(*path, regexPrefix, icp.EnableLegacyRegexDetection && p.flagEnabledRegexPathPrefix)
-						route.Paths[i] = &newPath
-					}
-				}
-				result.ServiceNameToServices[*kongStateService.Service.Name] = *kongStateService
-				objectSuccessfullyParsed = true
-			}
-		} else {
-			for i, rule := range ingressSpec.Rules {
-				if rule.HTTP == nil {
-					continue
-				}
-				for j, rulePath := range rule.HTTP.Paths {
-					pathTypeImplementationSpecific := netv1.PathTypeImplementationSpecific
-					if rulePath.PathType == nil {
-						rulePath.PathType = &pathTypeImplementationSpecific
-					}
-
-					paths := translators.PathsFromIngressPaths(rulePath, p.flagEnabledRegexPathPrefix)
-					if paths == nil {
-						// registering a failure, but technically it should never happen thanks to Kubernetes API validations
-						p.registerTranslationFailure(
-							fmt.Sprintf("could not translate Ingress Path %s to Kong paths", rulePath.Path), ingress,
-						)
-						continue
-					}
-
-					for i, path := range paths {
-						newPath := maybePrependRegexPrefix(*path, regexPrefix, icp.EnableLegacyRegexDetection && p.flagEnabledRegexPathPrefix)
-						paths[i] = &newPath
-					}
-
-					r := kongstate.Route{
-						Ingress: util.FromK8sObject(ingress),
-						Route: kong.Route{
-							Name:              kong.String(fmt.Sprintf("%s.%s.%d%d", ingress.Namespace, ingress.Name, i, j)),
-							Paths:             paths,
-							StripPath:         kong.Bool(false),
-							PreserveHost:      kong.Bool(true),
-							Protocols:         kong.StringSlice("http", "https"),
-							RegexPriority:     kong.Int(priorityForPath[*rulePath.PathType]),
-							RequestBuffering:  kong.Bool(true),
-							ResponseBuffering: kong.Bool(true),
-						},
-					}
-					if rule.Host != "" {
-						r.Hosts = kong.StringSlice(rule.Host)
-					}
+		translation := p.translateObject(ingress, settings, func() objectTranslation {
+			return p.ingressV1Translation(ingress, icp)
+		})
 
-					port := translators.PortDefFromServiceBackendPort(&rulePath.Backend.Service.Port)
-					serviceName := fmt.Sprintf("%s.%s.%s", ingress.Namespace, rulePath.Backend.Service.Name,
-						serviceBackendPortToStr(rulePath.Backend.Service.Port))
-					service, ok := result.ServiceNameToServices[serviceName]
-					if !ok {
-						service = kongstate.Service{
-							Service: kong.Service{
-								Name: kong.String(serviceName),
-								Host: kong.String(fmt.Sprintf("%s.%s.%s.svc", rulePath.Backend.Service.Name, ingress.Namespace,
-									port.CanonicalString())),
-								Port:           kong.Int(DefaultHTTPPort),
-								Protocol:       kong.String("http"),
-								Path:           kong.String("/"),
-								ConnectTimeout: kong.Int(DefaultServiceTimeout),
-								ReadTimeout:    kong.Int(DefaultServiceTimeout),
-								WriteTimeout:   kong.Int(DefaultServiceTimeout),
-								Retries:        kong.Int(DefaultRetries),
-							},
-							Namespace: ingress.Namespace,
-							Backends: []kongstate.ServiceBackend{{
-								Name:    rulePath.Backend.Service.Name,
-								PortDef: port,
-							}},
-							Parent: ingress,
-						}
-					}
-					service.Routes = append(service.Routes, r)
-					result.ServiceNameToServices[serviceName] = service
-					objectSuccessfullyParsed = true
-				}
+		result.SecretNameToSNIs.merge(translation.rules.SecretNameToSNIs)
+		for serviceName, service := range translation.rules.ServiceNameToServices {
+			if existing, ok := result.ServiceNameToServices[serviceName]; ok && !p.featureEnabledCombinedServiceRoutes {
+				existing.Routes = append(existing.Routes, service.Routes...)
+				service = existing
 			}
+			result.ServiceNameToServices[serviceName] = service
 		}
-
-		if objectSuccessfullyParsed {
+		for _, reason := range translation.failureReasons {
+			p.registerTranslationFailure(reason, ingress)
+		}
+		if translation.parsed {
 			p.ReportKubernetesObjectUpdate(ingress)
 		}
 	}
@@ -358,3 +281,112 @@ func (p *Parser) ingressRulesFromIngressV1() ingressRules {
 
 	return result
 }
+
+// ingressV1Translation translates the rules of the provided Ingress into Kong services and routes.
+// Default backends are not translated, as only the one of the oldest Ingress is used.
+func (p *Parser) ingressV1Translation(ingress *netv1.Ingress, icp configurationv1alpha1.IngressClassParametersSpec) objectTranslation {
+	result := newIngressRules()
+	var failureReasons []string
+
+	regexPrefix := translators.ControllerPathRegexPrefix
+	if prefix, ok := ingress.ObjectMeta.Annotations[annotations.AnnotationPrefix+annotations.RegexPrefixKey]; ok {
+		regexPrefix = prefix
+	}
+	ingressSpec := ingress.Spec
+
+	result.SecretNameToSNIs.addFromIngressV1TLS(ingressSpec.TLS, ingress)
+
+	var objectSuccessfullyParsed bool
+
+	if p.featureEnabledCombinedServiceRoutes {
+		for _, kongStateService := range translators.TranslateIngress(ingress, p.flagEnabledRegexPathPrefix) {
+			for _, route := range kongStateService.Routes {
+				for i, path := range route.Paths {
+					newPath := maybePrependRegexPrefix(*path, regexPrefix, icp.EnableLegacyRegexDetection && p.flagEnabledRegexPathPrefix)
+					route.Paths[i] = &newPath
+				}
+			}
+			result.ServiceNameToServices[*kongStateService.Service.Name] = *kongStateService
+			objectSuccessfullyParsed = true
+		}
+	} else {
+		for i, rule := range ingressSpec.Rules {
+			if rule.HTTP == nil {
+				continue
+			}
+			for j, rulePath := range rule.HTTP.Paths {
+				pathTypeImplementationSpecific := netv1.PathTypeImplementationSpecific
+				if rulePath.PathType == nil {
+					rulePath.PathType = &pathTypeImplementationSpecific
+				}
+
+				paths := translators.PathsFromIngressPaths(rulePath, p.flagEnabledRegexPathPrefix)
+				if paths == nil {
+					// registering a failure, but technically it should never happen thanks to Kubernetes API validations
+					failureReasons = append(failureReasons,
+						fmt.Sprintf("could not translate Ingress Path %s to Kong paths", rulePath.Path),
+					)
+					continue
+				}
+
+				for i, path := range paths {
+					newPath := maybePrependRegexPrefix(*path, regexPrefix, icp.EnableLegacyRegexDetection && p.flagEnabledRegexPathPrefix)
+					paths[i] = &newPath
+				}
+
+				r := kongstate.Route{
+					Ingress: util.FromK8sObject(ingress),
+					Route: kong.Route{
+						Name:              kong.String(fmt.Sprintf("%s.%s.%d%d", ingress.Namespace, ingress.Name, i, j)),
+						Paths:             paths,
+						StripPath:         kong.Bool(false),
+						PreserveHost:      kong.Bool(true),
+						Protocols:         kong.StringSlice("http", "https"),
+						RegexPriority:     kong.Int(priorityForPath[*rulePath.PathType]),
+						RequestBuffering:  kong.Bool(true),
+						ResponseBuffering: kong.Bool(true),
+					},
+				}
+				if rule.Host != "" {
+					r.Hosts = kong.StringSlice(rule.Host)
+				}
+
+				port := translators.PortDefFromServiceBackendPort(&rulePath.Backend.Service.Port)
+				serviceName := fmt.Sprintf("%s.%s.%s", ingress.Namespace, rulePath.Backend.Service.Name,
+					serviceBackendPortToStr(rulePath.Backend.Service.Port))
+				service, ok := result.ServiceNameToServices[serviceName]
+				if !ok {
+					service = kongstate.Service{
+						Service: kong.Service{
+							Name: kong.String(serviceName),
+							Host: kong.String(fmt.Sprintf("%s.%s.%s.svc", rulePath.Backend.Service.Name, ingress.Namespace,
+								port.CanonicalString())),
+							Port:           kong.Int(DefaultHTTPPort),
+							Protocol:       kong.String("http"),
+							Path:           kong.String("/"),
+							ConnectTimeout: kong.Int(DefaultServiceTimeout),
+							ReadTimeout:    kong.Int(DefaultServiceTimeout),
+							WriteTimeout:   kong.Int(DefaultServiceTimeout),
+							Retries:        kong.Int(DefaultRetries),
+						},
+						Namespace: ingress.Namespace,
+						Backends: []kongstate.ServiceBackend{{
+							Name:    rulePath.Backend.Service.Name,
+							PortDef: port,
+						}},
+						Parent: ingress,
+					}
+				}
+				service.Routes = append(service.Routes, r)
+				result.ServiceNameToServices[serviceName] = service
+				objectSuccessfullyParsed = true
+			}
+		}
+	}
+
+	return objectTranslation{
+		rules:          result,
+		failureReasons: failureReasons,
+		parsed:         objectSuccessfullyParsed,
+	}
+}
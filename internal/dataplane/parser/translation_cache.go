@@ -0,0 +1,184 @@
+package parser
+
+import (
+	"fmt"
+	"sync"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+	gatewayv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/store"
+	configurationv1 "github.com/kong/kubernetes-ingress-controller/v2/pkg/apis/configuration/v1"
+)
+
+// -----------------------------------------------------------------------------
+// Parser - Translation Cache - Public Types
+// -----------------------------------------------------------------------------
+
+// TranslationCache keeps the results of translating individual Kubernetes objects into Kong
+// services and routes across builds, so that objects which have not changed since the previous
+// build don't have to be translated again. Objects are identified by their UID and resourceVersion,
+// so objects lacking those (e.g. the ones which were not retrieved from the Kubernetes API) are never
+// cached. The parts of the configuration depending on other objects (e.g. upstream targets depending
+// on Endpoints, or certificates depending on Secrets) are generated on every build.
+type TranslationCache struct {
+	lock    sync.Mutex
+	entries map[string]translationCacheEntry
+
+	// used are the keys of the entries which were used since the cache was last pruned.
+	used map[string]struct{}
+}
+
+// NewTranslationCache provides a new, empty TranslationCache.
+func NewTranslationCache() *TranslationCache {
+	return &TranslationCache{
+		entries: make(map[string]translationCacheEntry),
+		used:    make(map[string]struct{}),
+	}
+}
+
+// Invalidate drops the translations of the provided changed objects, along with the translations
+// depending on the kinds of the changed objects.
+func (c *TranslationCache) Invalidate(changes store.Changes) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	// backends of HTTPRoutes are allowed by ReferenceGrants, their protocols depend on the
+	// listeners of their Gateways, and the plugins of their ExtensionRef filters are
+	// configured by KongPlugins, KongClusterPlugins and Secrets.
+	referenceGrantsChanged := changes.HasType(&gatewayv1alpha2.ReferenceGrant{})
+	gatewaysChanged := changes.HasType(&gatewayv1beta1.Gateway{})
+	pluginsChanged := changes.HasType(&configurationv1.KongPlugin{}) ||
+		changes.HasType(&configurationv1.KongClusterPlugin{}) ||
+		changes.HasType(&corev1.Secret{})
+	for key, entry := range c.entries {
+		httproute, isHTTPRoute := entry.object.(*gatewayv1beta1.HTTPRoute)
+		if changes.Has(entry.object) ||
+			(isHTTPRoute && (referenceGrantsChanged || gatewaysChanged)) ||
+			(isHTTPRoute && pluginsChanged && hasHTTPRouteExtensionRefFilters(httproute)) {
+			delete(c.entries, key)
+		}
+	}
+}
+
+func hasHTTPRouteExtensionRefFilters(httproute *gatewayv1beta1.HTTPRoute) bool {
+	for _, rule := range httproute.Spec.Rules {
+		for _, filter := range rule.Filters {
+			if filter.Type == gatewayv1beta1.HTTPRouteFilterExtensionRef {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Len returns the number of cached translations.
+func (c *TranslationCache) Len() int {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return len(c.entries)
+}
+
+// -----------------------------------------------------------------------------
+// Parser - Translation Cache - Private Types and Methods
+// -----------------------------------------------------------------------------
+
+// objectTranslation is the result of translating a single Kubernetes object.
+type objectTranslation struct {
+	// rules are the ingress rules generated for the object.
+	rules ingressRules
+	// failureReasons are the reasons of the translation failures of the object.
+	failureReasons []string
+	// parsed indicates whether the object was successfully parsed.
+	parsed bool
+}
+
+// deepCopy returns a copy of the translation which can be modified without affecting the original.
+func (t objectTranslation) deepCopy() objectTranslation {
+	return objectTranslation{
+		rules:          t.rules.deepCopy(),
+		failureReasons: append([]string{}, t.failureReasons...),
+		parsed:         t.parsed,
+	}
+}
+
+type translationCacheEntry struct {
+	object          client.Object
+	uid             string
+	resourceVersion string
+	// settings describe the parser settings the object was translated with.
+	settings    string
+	translation objectTranslation
+}
+
+// get returns the cached translation of the provided object, if the object has not changed and
+// was translated with the same settings.
+func (c *TranslationCache) get(obj client.Object, settings string) (objectTranslation, bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	key := translationCacheKey(obj)
+	entry, ok := c.entries[key]
+	if !ok ||
+		entry.uid != string(obj.GetUID()) ||
+		entry.resourceVersion != obj.GetResourceVersion() ||
+		entry.settings != settings {
+		return objectTranslation{}, false
+	}
+	c.used[key] = struct{}{}
+	return entry.translation.deepCopy(), true
+}
+
+// set caches the translation of the provided object, if it can be identified.
+func (c *TranslationCache) set(obj client.Object, settings string, translation objectTranslation) {
+	if obj.GetUID() == "" || obj.GetResourceVersion() == "" {
+		return
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	key := translationCacheKey(obj)
+	c.entries[key] = translationCacheEntry{
+		object:          obj,
+		uid:             string(obj.GetUID()),
+		resourceVersion: obj.GetResourceVersion(),
+		settings:        settings,
+		translation:     translation.deepCopy(),
+	}
+	c.used[key] = struct{}{}
+}
+
+// prune drops the translations which were not used since the cache was last pruned, as
+// the objects they were generated for are gone.
+func (c *TranslationCache) prune() {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	for key := range c.entries {
+		if _, ok := c.used[key]; !ok {
+			delete(c.entries, key)
+		}
+	}
+	c.used = make(map[string]struct{})
+}
+
+func translationCacheKey(obj client.Object) string {
+	return fmt.Sprintf("%T/%s/%s", obj, obj.GetNamespace(), obj.GetName())
+}
+
+// translateObject returns the translation of the provided object, reusing the cached one if the
+// parser has a translation cache and the object has not changed since it was cached.
+func (p *Parser) translateObject(obj client.Object, settings string, translate func() objectTranslation) objectTranslation {
+	if p.translationCache == nil {
+		return translate()
+	}
+	if translation, ok := p.translationCache.get(obj, settings); ok {
+		return translation
+	}
+	translation := translate()
+	p.translationCache.set(obj, settings, translation)
+	return translation
+}
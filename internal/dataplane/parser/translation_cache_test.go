@@ -0,0 +1,136 @@
+package parser
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	netv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+	gatewayv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/annotations"
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/dataplane/kongstate"
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/store"
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/util/builder"
+)
+
+func TestTranslationCache(t *testing.T) {
+	newIngress := func(resourceVersion, path string) *netv1.Ingress {
+		return &netv1.Ingress{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            "foo",
+				Namespace:       "default",
+				UID:             types.UID("ingress-uid"),
+				ResourceVersion: resourceVersion,
+				Annotations:     map[string]string{annotations.IngressClassKey: annotations.DefaultIngressClass},
+			},
+			Spec: netv1.IngressSpec{
+				Rules: []netv1.IngressRule{{
+					IngressRuleValue: netv1.IngressRuleValue{HTTP: &netv1.HTTPIngressRuleValue{
+						Paths: []netv1.HTTPIngressPath{{
+							Path:     path,
+							PathType: lo.ToPtr(netv1.PathTypeImplementationSpecific),
+							Backend: netv1.IngressBackend{Service: &netv1.IngressServiceBackend{
+								Name: "foo-svc",
+								Port: netv1.ServiceBackendPort{Number: 80},
+							}},
+						}},
+					}},
+				}},
+			},
+		}
+	}
+	httpRoute := &gatewayv1beta1.HTTPRoute{
+		TypeMeta: metav1.TypeMeta{Kind: httprouteGVK.Kind, APIVersion: httprouteGVK.GroupVersion().String()},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            "bar",
+			Namespace:       "default",
+			UID:             types.UID("httproute-uid"),
+			ResourceVersion: "1",
+		},
+		Spec: gatewayv1beta1.HTTPRouteSpec{
+			Rules: []gatewayv1beta1.HTTPRouteRule{{
+				BackendRefs: []gatewayv1beta1.HTTPBackendRef{
+					builder.NewHTTPBackendRef("foo-svc").WithPort(80).Build(),
+				},
+			}},
+		},
+	}
+	service := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: "foo-svc", Namespace: "default"},
+	}
+
+	cache := NewTranslationCache()
+	build := func(ingresses ...*netv1.Ingress) *kongstate.KongState {
+		s, err := store.NewFakeStore(store.FakeObjects{
+			IngressesV1: ingresses,
+			HTTPRoutes:  []*gatewayv1beta1.HTTPRoute{httpRoute},
+			Services:    []*corev1.Service{service},
+		})
+		require.NoError(t, err)
+		p := mustNewParser(t, s)
+		p.EnableTranslationCache(cache)
+		state, _ := p.Build()
+		return state
+	}
+	ingressPaths := func(state *kongstate.KongState) []string {
+		var paths []string
+		for _, s := range state.Services {
+			for _, r := range s.Routes {
+				if strings.HasPrefix(*r.Name, "default.foo.") {
+					for _, path := range r.Paths {
+						paths = append(paths, *path)
+					}
+				}
+			}
+		}
+		return paths
+	}
+
+	t.Log("verifying that translations of the objects are cached")
+	assert.Equal(t, []string{"/foo"}, ingressPaths(build(newIngress("1", "/foo"))))
+	assert.Equal(t, 2, cache.Len())
+
+	t.Log("verifying that translations are reused for objects with the same resourceVersion")
+	assert.Equal(t, []string{"/foo"}, ingressPaths(build(newIngress("1", "/bar"))))
+
+	t.Log("verifying that objects with a different resourceVersion are translated again")
+	assert.Equal(t, []string{"/bar"}, ingressPaths(build(newIngress("2", "/bar"))))
+	assert.Equal(t, 2, cache.Len())
+
+	t.Log("verifying that translations of the changed objects are invalidated")
+	cacheStores := store.NewCacheStores()
+	require.NoError(t, cacheStores.Add(newIngress("2", "/bar")))
+	cache.Invalidate(cacheStores.TakeChanges())
+	assert.Equal(t, 1, cache.Len())
+
+	t.Log("verifying that translations of HTTPRoutes are invalidated when ReferenceGrants change")
+	build(newIngress("2", "/bar"))
+	require.Equal(t, 2, cache.Len())
+	require.NoError(t, cacheStores.Add(&gatewayv1alpha2.ReferenceGrant{
+		ObjectMeta: metav1.ObjectMeta{Name: "grant", Namespace: "default"},
+	}))
+	cache.Invalidate(cacheStores.TakeChanges())
+	assert.Equal(t, 1, cache.Len())
+
+	t.Log("verifying that translations of HTTPRoutes are invalidated when Gateways change")
+	build(newIngress("2", "/bar"))
+	require.Equal(t, 2, cache.Len())
+	require.NoError(t, cacheStores.Add(&gatewayv1beta1.Gateway{
+		ObjectMeta: metav1.ObjectMeta{Name: "gateway", Namespace: "default"},
+	}))
+	cache.Invalidate(cacheStores.TakeChanges())
+	assert.Equal(t, 1, cache.Len())
+
+	t.Log("verifying that translations of the objects which are gone are dropped")
+	build(newIngress("2", "/bar"))
+	require.Equal(t, 2, cache.Len())
+	build()
+	assert.Equal(t, 1, cache.Len())
+}
@@ -24,6 +24,8 @@ type KongRouteTranslation struct {
 	Name    string
 	Matches []gatewayv1beta1.HTTPRouteMatch
 	Filters []gatewayv1beta1.HTTPRouteFilter
+	// RuleNumber is the number of the first rule the matches come from.
+	RuleNumber int
 }
 
 // TranslateHTTPRoute translates a list of HTTPRoutes into a list of HTTPRouteTranslationMeta
@@ -147,9 +149,10 @@ func (i *httpRouteTranslationIndex) translateToKongRoutes(rulesMeta []httpRouteR
 		kongRouteName := i.translateToKongRouteName(matchGroup)
 
 		kongRoutes = append(kongRoutes, KongRouteTranslation{
-			Name:    kongRouteName,
-			Matches: matchGroup.httpRouteMatches(),
-			Filters: filters,
+			Name:       kongRouteName,
+			Matches:    matchGroup.httpRouteMatches(),
+			Filters:    filters,
+			RuleNumber: matchGroup[0].RuleNumber,
 		})
 	}
 
@@ -185,9 +185,11 @@ func (m *ingressTranslationMeta) translateIntoKongRoutes() *kongstate.Route {
 	routeName := fmt.Sprintf("%s.%s.%s.%s.%s", m.parentIngress.GetNamespace(), m.parentIngress.GetName(), m.serviceName, ingressHost, m.servicePort.CanonicalString())
 	route := &kongstate.Route{
 		Ingress: util.K8sObjectInfo{
-			Namespace:   m.parentIngress.GetNamespace(),
-			Name:        m.parentIngress.GetName(),
-			Annotations: m.parentIngress.GetAnnotations(),
+			Namespace:        m.parentIngress.GetNamespace(),
+			Name:             m.parentIngress.GetName(),
+			UID:              m.parentIngress.GetUID(),
+			Annotations:      m.parentIngress.GetAnnotations(),
+			GroupVersionKind: m.parentIngress.GetObjectKind().GroupVersionKind(),
 		},
 		Route: kong.Route{
 			Name:              kong.String(routeName),
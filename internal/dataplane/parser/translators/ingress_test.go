@@ -1200,8 +1200,9 @@ func TestTranslateIngress(t *testing.T) {
 				},
 				Routes: []kongstate.Route{{
 					Ingress: util.K8sObjectInfo{
-						Name:      "test-ingress",
-						Namespace: corev1.NamespaceDefault,
+						Name:             "test-ingress",
+						Namespace:        corev1.NamespaceDefault,
+						GroupVersionKind: netv1.SchemeGroupVersion.WithKind("Ingress"),
 					},
 					Route: kong.Route{
 						Name:              kong.String("default.test-ingress.test-service.konghq.com.http"),
@@ -0,0 +1,180 @@
+package sendconfig
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/kong/go-kong/kong"
+	"github.com/sirupsen/logrus"
+
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/dataplane/failures"
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/util"
+)
+
+// -----------------------------------------------------------------------------
+// Sendconfig - Config Errors - Public Types
+// -----------------------------------------------------------------------------
+
+// UpdateError is returned when Kong rejects a configuration and reports which of its
+// entities caused the rejection. Those entities are mapped back to the Kubernetes objects
+// they were generated from, so that the objects can be provided with feedback.
+type UpdateError struct {
+	resourceFailures []failures.ResourceFailure
+	err              error
+}
+
+// ResourceFailures returns failures of the Kubernetes objects that caused the update to be rejected.
+func (e UpdateError) ResourceFailures() []failures.ResourceFailure {
+	return e.resourceFailures
+}
+
+func (e UpdateError) Error() string {
+	return e.err.Error()
+}
+
+func (e UpdateError) Unwrap() error {
+	return e.err
+}
+
+// -----------------------------------------------------------------------------
+// Sendconfig - Config Errors - Private Types
+// -----------------------------------------------------------------------------
+
+// configErrorResponse is the body Kong responds with when a declarative configuration sent
+// to its /config endpoint with flatten_errors enabled is invalid.
+type configErrorResponse struct {
+	Code            int                `json:"code"`
+	Name            string             `json:"name"`
+	Message         string             `json:"message"`
+	FlattenedErrors []flatEntityErrors `json:"flattened_errors"`
+}
+
+// flatEntityErrors describes all the problems Kong found with a single entity.
+type flatEntityErrors struct {
+	Name   string           `json:"entity_name"`
+	ID     string           `json:"entity_id"`
+	Type   string           `json:"entity_type"`
+	Tags   []string         `json:"entity_tags"`
+	Errors []flatFieldError `json:"errors"`
+}
+
+// flatFieldError describes a single problem with an entity. Field is empty for problems
+// concerning the entity as a whole.
+type flatFieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+	Type    string `json:"type"`
+}
+
+// -----------------------------------------------------------------------------
+// Sendconfig - Config Errors - Private Functions
+// -----------------------------------------------------------------------------
+
+// reloadDeclarativeConfig sends config to Kong's /config endpoint. Kong is asked to report
+// validation problems per entity, and whenever it does an UpdateError with the resulting
+// resource failures is returned.
+func reloadDeclarativeConfig(
+	ctx context.Context,
+	log logrus.FieldLogger,
+	client *kong.Client,
+	config []byte,
+) error {
+	type sendConfigParams struct {
+		CheckHash     int `url:"check_hash"`
+		FlattenErrors int `url:"flatten_errors"`
+	}
+	req, err := client.NewRequest(http.MethodPost, "/config", sendConfigParams{CheckHash: 1, FlattenErrors: 1}, bytes.NewReader(config))
+	if err != nil {
+		return fmt.Errorf("creating new HTTP request for /config: %w", err)
+	}
+
+	resp, err := client.DoRAW(ctx, req)
+	if err != nil {
+		return fmt.Errorf("failed posting new config to /config: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 200 && resp.StatusCode < 400 {
+		return nil
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf(
+			"failed posting new config to /config: got status code %d (and failed to read the response body): %w",
+			resp.StatusCode, err,
+		)
+	}
+	postErr := fmt.Errorf("failed posting new config to /config: got status code %d, body: %s", resp.StatusCode, body)
+	if resp.StatusCode != http.StatusBadRequest {
+		return postErr
+	}
+
+	resourceFailures, err := resourceFailuresFromConfigError(log, body)
+	if err != nil {
+		log.WithError(err).Debug("could not parse Kong's configuration error response")
+		return postErr
+	}
+	if len(resourceFailures) == 0 {
+		return postErr
+	}
+
+	return UpdateError{
+		resourceFailures: resourceFailures,
+		err:              postErr,
+	}
+}
+
+// resourceFailuresFromConfigError parses the flattened errors from Kong's /config error response
+// and maps every reported problem to the Kubernetes object identified by the entity's tags.
+// Entities which can't be traced back to a Kubernetes object are skipped.
+func resourceFailuresFromConfigError(log logrus.FieldLogger, body []byte) ([]failures.ResourceFailure, error) {
+	var configErr configErrorResponse
+	if err := json.Unmarshal(body, &configErr); err != nil {
+		return nil, fmt.Errorf("could not unmarshal config error response: %w", err)
+	}
+
+	var resourceFailures []failures.ResourceFailure
+	for _, entityErrors := range configErr.FlattenedErrors {
+		obj, ok := util.ObjectFromTags(entityErrors.Tags)
+		if !ok {
+			log.WithFields(logrus.Fields{
+				"entity_type": entityErrors.Type,
+				"entity_name": entityErrors.Name,
+			}).Debug("rejected Kong entity could not be traced back to a Kubernetes object")
+			continue
+		}
+
+		for _, problem := range entityErrors.Errors {
+			message := fmt.Sprintf("invalid %s: %s", entityErrors.description(), problem.Message)
+			if problem.Field != "" {
+				message = fmt.Sprintf("invalid %s: %s: %s", entityErrors.description(), problem.Field, problem.Message)
+			}
+
+			resourceFailure, err := failures.NewResourceFailure(message, obj)
+			if err != nil {
+				log.WithError(err).WithField("resource_failure_reason", message).Debug("failed to create resource failure")
+				continue
+			}
+			resourceFailures = append(resourceFailures, resourceFailure)
+		}
+	}
+
+	return resourceFailures, nil
+}
+
+// description returns a short description of the entity that can be used to refer to it in messages.
+func (e flatEntityErrors) description() string {
+	switch {
+	case e.Name != "":
+		return fmt.Sprintf("%s %q", e.Type, e.Name)
+	case e.ID != "":
+		return fmt.Sprintf("%s %q", e.Type, e.ID)
+	default:
+		return e.Type
+	}
+}
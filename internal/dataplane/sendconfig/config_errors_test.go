@@ -0,0 +1,128 @@
+package sendconfig
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/kong/go-kong/kong"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const flattenedErrorsResponse = `{
+	"code": 14,
+	"name": "invalid declarative configuration",
+	"message": "declarative config is invalid: {}",
+	"flattened_errors": [
+		{
+			"entity_type": "plugin",
+			"entity_name": "rate-limiting",
+			"entity_id": "5bc6d8a4-3a6f-4bd2-8b2c-4c6d9b2e6b3e",
+			"entity_tags": [
+				"k8s-name:rate-limit",
+				"k8s-namespace:tenant-a",
+				"k8s-kind:KongPlugin",
+				"k8s-uid:2a1f3e7e-3b7e-4d1b-9b1f-5c2b9f7f7f7f",
+				"k8s-group:configuration.konghq.com",
+				"k8s-version:v1",
+				"managed-by-ingress-controller"
+			],
+			"errors": [
+				{"field": "config.policy", "message": "expected one of: local, cluster, redis", "type": "field"},
+				{"message": "at least one of these fields must be non-empty: 'config.second', 'config.minute'", "type": "entity"}
+			]
+		},
+		{
+			"entity_type": "service",
+			"entity_name": "not-managed",
+			"entity_tags": ["managed-by-someone-else"],
+			"errors": [
+				{"field": "host", "message": "invalid value", "type": "field"}
+			]
+		}
+	]
+}`
+
+func TestReloadDeclarativeConfig(t *testing.T) {
+	testCases := []struct {
+		name             string
+		status           int
+		body             string
+		wantErr          bool
+		wantFailures     []string
+		wantFailedObject string
+	}{
+		{
+			name:   "config accepted",
+			status: http.StatusCreated,
+			body:   `{}`,
+		},
+		{
+			name:    "config rejected without flattened errors",
+			status:  http.StatusBadRequest,
+			body:    `{"code": 14, "name": "invalid declarative configuration", "fields": {"services": [{"host": "invalid value"}]}}`,
+			wantErr: true,
+		},
+		{
+			name:    "server error",
+			status:  http.StatusInternalServerError,
+			body:    `{"message": "An unexpected error occurred"}`,
+			wantErr: true,
+		},
+		{
+			name:    "config rejected with flattened errors",
+			status:  http.StatusBadRequest,
+			body:    flattenedErrorsResponse,
+			wantErr: true,
+			wantFailures: []string{
+				`invalid plugin "rate-limiting": config.policy: expected one of: local, cluster, redis`,
+				`invalid plugin "rate-limiting": at least one of these fields must be non-empty: 'config.second', 'config.minute'`,
+			},
+			wantFailedObject: "tenant-a/rate-limit",
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "/config", r.URL.Path)
+				assert.Equal(t, "1", r.URL.Query().Get("flatten_errors"))
+				assert.Equal(t, "1", r.URL.Query().Get("check_hash"))
+				w.WriteHeader(tc.status)
+				_, _ = w.Write([]byte(tc.body))
+			}))
+			defer server.Close()
+
+			client, err := kong.NewClient(kong.String(server.URL), server.Client())
+			require.NoError(t, err)
+
+			err = reloadDeclarativeConfig(context.Background(), logrus.New(), client, []byte(`{"_format_version": "3.0"}`))
+			if !tc.wantErr {
+				require.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+
+			var updateErr UpdateError
+			if len(tc.wantFailures) == 0 {
+				require.False(t, errors.As(err, &updateErr))
+				return
+			}
+			require.True(t, errors.As(err, &updateErr))
+			resourceFailures := updateErr.ResourceFailures()
+			require.Len(t, resourceFailures, len(tc.wantFailures))
+			for i, resourceFailure := range resourceFailures {
+				assert.Equal(t, tc.wantFailures[i], resourceFailure.Message())
+				require.Len(t, resourceFailure.CausingObjects(), 1)
+				obj := resourceFailure.CausingObjects()[0]
+				assert.Equal(t, tc.wantFailedObject, obj.GetNamespace()+"/"+obj.GetName())
+				assert.Equal(t, "KongPlugin", obj.GetObjectKind().GroupVersionKind().Kind)
+			}
+		})
+	}
+}
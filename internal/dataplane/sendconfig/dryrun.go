@@ -0,0 +1,146 @@
+package sendconfig
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/blang/semver/v4"
+	"github.com/kong/deck/crud"
+	"github.com/kong/deck/diff"
+	"github.com/kong/deck/dump"
+	"github.com/kong/deck/file"
+	"github.com/kong/deck/state"
+	deckutils "github.com/kong/deck/utils"
+	"github.com/sirupsen/logrus"
+
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/adminapi"
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/dataplane/deckgen"
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/util"
+)
+
+// DryRunResult describes what applying a configuration to a Kong instance would do.
+type DryRunResult struct {
+	// ConfigSHA is the checksum of the configuration.
+	ConfigSHA []byte
+	// Payload is the declarative configuration which would be sent to Kong in DB-less mode.
+	Payload []byte
+	// LiveConfigHash is the hash of the configuration Kong is currently serving, as reported by Kong.
+	LiveConfigHash string
+	// Changes are the changes the configuration would make to the entities Kong is currently serving.
+	Changes []util.KongEntityChange
+}
+
+// PerformDryRun computes what writing `targetContent` to Kong Admin API would do, without writing it:
+// the declarative configuration which would be sent (DB-less mode), and the entities which would be
+// created, updated and deleted compared to the configuration Kong is currently serving.
+func PerformDryRun(ctx context.Context,
+	log logrus.FieldLogger,
+	client *adminapi.Client,
+	version semver.Version,
+	concurrency int,
+	inMemory bool,
+	skipCACertificates bool,
+	targetContent *file.Content,
+	selectorTags []string,
+) (DryRunResult, error) {
+	var (
+		result DryRunResult
+		err    error
+	)
+	result.ConfigSHA, err = deckgen.GenerateSHA(targetContent)
+	if err != nil {
+		return result, err
+	}
+
+	status, err := client.Status(ctx)
+	if err != nil {
+		return result, fmt.Errorf("failed getting status of %s: %w", client.BaseRootURL(), err)
+	}
+	result.LiveConfigHash = status.ConfigurationHash
+
+	dumpConfig := dump.Config{SelectorTags: selectorTags, SkipCACerts: skipCACertificates}
+	result.Changes, err = diffWithCurrentState(ctx, targetContent, client, dumpConfig, version, concurrency)
+	if err != nil {
+		return result, err
+	}
+
+	// the declarative configuration is generated last, as generating it alters the content.
+	if inMemory {
+		result.Payload, err = declarativeConfig(targetContent)
+		if err != nil {
+			return result, err
+		}
+	}
+
+	log.WithField("kong_url", client.BaseRootURL()).
+		Debugf("computed %d changes the configuration would make to Kong", len(result.Changes))
+	return result, nil
+}
+
+// diffWithCurrentState returns the changes of the entities which writing `targetContent` to Kong would make.
+func diffWithCurrentState(
+	ctx context.Context,
+	targetContent *file.Content,
+	client *adminapi.Client,
+	dumpConfig dump.Config,
+	version semver.Version,
+	concurrency int,
+) ([]util.KongEntityChange, error) {
+	cs, err := currentState(ctx, client.Client, dumpConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed getting current state for %s: %w", client.BaseRootURL(), err)
+	}
+
+	ts, err := targetState(ctx, targetContent, cs, version, client.Client, dumpConfig)
+	if err != nil {
+		return nil, deckConfigConflictError{err}
+	}
+
+	syncer, err := diff.NewSyncer(diff.SyncerOpts{
+		CurrentState:    cs,
+		TargetState:     ts,
+		KongClient:      client.Client,
+		SilenceWarnings: true,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("creating a new syncer for %s: %w", client.BaseRootURL(), err)
+	}
+
+	var (
+		lock    sync.Mutex
+		changes []util.KongEntityChange
+	)
+	errs := syncer.Run(ctx, concurrency, func(e crud.Event) (crud.Arg, error) {
+		entity := ""
+		if c, ok := e.Obj.(state.ConsoleString); ok {
+			entity = c.Console()
+		}
+		lock.Lock()
+		changes = append(changes, util.KongEntityChange{
+			Operation: strings.ToLower(e.Op.String()),
+			Kind:      string(e.Kind),
+			Entity:    entity,
+		})
+		lock.Unlock()
+		// nothing is sent to Kong, the entity is returned as if it was.
+		return e.Obj, nil
+	})
+	if errs != nil {
+		return nil, deckutils.ErrArray{Errors: errs}
+	}
+
+	// changes are computed concurrently, so they're sorted to be reported in a stable order.
+	sort.Slice(changes, func(i, j int) bool {
+		if changes[i].Kind != changes[j].Kind {
+			return changes[i].Kind < changes[j].Kind
+		}
+		if changes[i].Entity != changes[j].Entity {
+			return changes[i].Entity < changes[j].Entity
+		}
+		return changes[i].Operation < changes[j].Operation
+	})
+	return changes, nil
+}
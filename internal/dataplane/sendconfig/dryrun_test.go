@@ -0,0 +1,105 @@
+package sendconfig
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/blang/semver/v4"
+	"github.com/kong/deck/file"
+	"github.com/kong/go-kong/kong"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/adminapi"
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/util"
+)
+
+// fakeLiveKong is a minimal Kong Admin API serving a single service, which records any request
+// that would change its configuration.
+type fakeLiveKong struct {
+	lock   sync.Mutex
+	writes []string
+}
+
+func (k *fakeLiveKong) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	k.lock.Lock()
+	defer k.lock.Unlock()
+
+	switch {
+	case r.Method != http.MethodGet:
+		k.writes = append(k.writes, r.Method+" "+r.URL.Path)
+		w.WriteHeader(http.StatusInternalServerError)
+	case r.URL.Path == "/status":
+		_, _ = w.Write([]byte(`{"configuration_hash": "8f2c5d0a3c1b4e7f9a6d2b1c0e3f4a5b"}`))
+	case strings.HasPrefix(r.URL.Path, "/schemas/"):
+		w.WriteHeader(http.StatusNotFound)
+	case r.URL.Path == "/services":
+		_, _ = w.Write([]byte(`{"data": [{
+			"id": "0a1b2c3d-0000-4000-8000-000000000000",
+			"name": "stale",
+			"host": "stale.default.80.svc",
+			"port": 80,
+			"protocol": "http"
+		}], "next": null}`))
+	default:
+		_, _ = w.Write([]byte(`{"data": [], "next": null}`))
+	}
+}
+
+func TestPerformDryRun(t *testing.T) {
+	fakeKong := &fakeLiveKong{}
+	server := httptest.NewServer(fakeKong)
+	defer server.Close()
+
+	kongClient, err := kong.NewClient(kong.String(server.URL), server.Client())
+	require.NoError(t, err)
+
+	for _, inMemory := range []bool{true, false} {
+		targetContent := &file.Content{
+			FormatVersion: "3.0",
+			Services: []file.FService{{
+				Service: kong.Service{
+					Name: kong.String("httpbin"),
+					Host: kong.String("httpbin.default.80.svc"),
+				},
+				Routes: []*file.FRoute{{
+					Route: kong.Route{
+						Name:  kong.String("httpbin"),
+						Paths: kong.StringSlice("/httpbin"),
+					},
+				}},
+			}},
+		}
+
+		result, err := PerformDryRun(
+			context.Background(),
+			logrus.New(),
+			adminapi.NewClient(kongClient),
+			semver.MustParse("3.1.0"),
+			1,
+			inMemory,
+			false,
+			targetContent,
+			nil,
+		)
+		require.NoError(t, err)
+		assert.NotEmpty(t, result.ConfigSHA)
+		assert.Equal(t, "8f2c5d0a3c1b4e7f9a6d2b1c0e3f4a5b", result.LiveConfigHash)
+		require.Len(t, result.Changes, 3)
+		assert.Equal(t, util.KongEntityChange{Operation: "create", Kind: "route", Entity: "httpbin"}, result.Changes[0])
+		assert.Equal(t, util.KongEntityChange{Operation: "create", Kind: "service", Entity: "httpbin"}, result.Changes[1])
+		assert.Equal(t, util.KongEntityChange{Operation: "delete", Kind: "service", Entity: "stale"}, result.Changes[2])
+		if inMemory {
+			assert.Contains(t, string(result.Payload), `"name":"httpbin"`)
+		} else {
+			assert.Empty(t, result.Payload)
+		}
+	}
+
+	assert.Empty(t, fakeKong.writes, "nothing should be sent to Kong")
+}
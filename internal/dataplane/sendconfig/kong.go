@@ -1,8 +1,11 @@
 package sendconfig
 
 import (
+	"bytes"
 	"context"
+	"encoding/hex"
 	"fmt"
+	"time"
 
 	"github.com/blang/semver/v4"
 	"github.com/go-logr/logr"
@@ -85,6 +88,17 @@ type ClientWithPluginStore struct {
 	*util.PluginSchemaStore
 	// lastConfigSHA is a checksum of the last successful update to the data-plane
 	lastConfigSHA []byte
+
+	// lastSuccess is the time of the last successful update to the data-plane.
+	lastSuccess time.Time
+	// lastError is the error the last update to the data-plane failed with, if it failed.
+	lastError error
+	// lastFailedConfigSHA is a checksum of the configuration the last update to the data-plane failed with.
+	lastFailedConfigSHA []byte
+	// consecutiveFailures is the number of updates to the data-plane which failed in a row.
+	consecutiveFailures int
+	// backoffUntil is the time until which the configuration the last update failed with is not sent again.
+	backoffUntil time.Time
 }
 
 func (c *ClientWithPluginStore) SetLastConfigSHA(s []byte) {
@@ -94,3 +108,57 @@ func (c *ClientWithPluginStore) SetLastConfigSHA(s []byte) {
 func (c *ClientWithPluginStore) LastConfigSHA() []byte {
 	return c.lastConfigSHA
 }
+
+// RecordUpdateSuccess records that the configuration with the provided checksum was successfully applied
+// to the data-plane, resetting the state of any previous failures.
+func (c *ClientWithPluginStore) RecordUpdateSuccess(configSHA []byte, now time.Time) {
+	c.lastConfigSHA = configSHA
+	c.lastSuccess = now
+	c.lastError = nil
+	c.lastFailedConfigSHA = nil
+	c.consecutiveFailures = 0
+	c.backoffUntil = time.Time{}
+}
+
+// RecordUpdateFailure records that the configuration with the provided checksum failed to be applied to
+// the data-plane with the provided error. The same configuration is not supposed to be sent again before
+// the provided backoff elapses.
+func (c *ClientWithPluginStore) RecordUpdateFailure(configSHA []byte, err error, now time.Time, backoff time.Duration) {
+	c.lastError = err
+	c.lastFailedConfigSHA = configSHA
+	c.consecutiveFailures++
+	c.backoffUntil = now.Add(backoff)
+}
+
+// ConsecutiveFailures returns the number of updates to the data-plane which failed in a row.
+func (c *ClientWithPluginStore) ConsecutiveFailures() int {
+	return c.consecutiveFailures
+}
+
+// IsBackingOff returns true if the configuration with the provided checksum is the one the last update
+// failed with and it should not be sent again yet. Any other configuration can be sent right away, as
+// the failure might have been caused by the configuration itself rather than the data-plane.
+func (c *ClientWithPluginStore) IsBackingOff(configSHA []byte, now time.Time) bool {
+	return c.consecutiveFailures > 0 &&
+		now.Before(c.backoffUntil) &&
+		bytes.Equal(configSHA, c.lastFailedConfigSHA)
+}
+
+// Status returns the state of the updates to the data-plane.
+func (c *ClientWithPluginStore) Status() util.KongInstanceStatus {
+	status := util.KongInstanceStatus{
+		URL:                 c.BaseRootURL(),
+		LastConfigSHA:       hex.EncodeToString(c.lastConfigSHA),
+		ConsecutiveFailures: c.consecutiveFailures,
+	}
+	if !c.lastSuccess.IsZero() {
+		status.LastSuccess = lo.ToPtr(c.lastSuccess)
+	}
+	if c.lastError != nil {
+		status.LastError = c.lastError.Error()
+	}
+	if !c.backoffUntil.IsZero() {
+		status.BackoffUntil = lo.ToPtr(c.backoffUntil)
+	}
+	return status
+}
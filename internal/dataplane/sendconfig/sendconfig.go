@@ -21,10 +21,12 @@ import (
 	"github.com/kong/go-kong/kong"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/attribute"
 
 	"github.com/kong/kubernetes-ingress-controller/v2/internal/adminapi"
 	"github.com/kong/kubernetes-ingress-controller/v2/internal/dataplane/deckgen"
 	"github.com/kong/kubernetes-ingress-controller/v2/internal/metrics"
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/tracing"
 )
 
 const initialHash = "00000000000000000000000000000000"
@@ -47,10 +49,18 @@ func PerformUpdate(ctx context.Context,
 	oldSHA []byte,
 	promMetrics *metrics.CtrlFuncMetrics,
 ) ([]byte, error) {
+	ctx, span := tracing.Start(ctx, "sendconfig.PerformUpdate",
+		tracing.KongURLKey.String(client.BaseRootURL()),
+		attribute.Bool("kong.in_memory", inMemory),
+	)
+	defer span.End()
+
 	newSHA, err := deckgen.GenerateSHA(targetContent)
 	if err != nil {
+		tracing.RecordError(span, err)
 		return oldSHA, err
 	}
+	span.SetAttributes(tracing.ConfigSHAKey.String(hex.EncodeToString(newSHA)))
 
 	// disable optimization if reverse sync is enabled
 	if !reverseSync {
@@ -60,21 +70,15 @@ func PerformUpdate(ctx context.Context,
 				log.Debugf("sha %s has been reported", hex.EncodeToString(newSHA))
 			}
 
-			// we assume ready as not all Kong versions provide their configuration hash,
-			// and their readiness state is always unknown
-			ready := true
-
 			status, err := client.Status(ctx)
 			if err != nil {
+				tracing.RecordError(span, err)
 				return nil, err
 			}
 
-			if status.ConfigurationHash == initialHash {
-				ready = false
-			}
-
-			if ready {
+			if isConfigured(status.ConfigurationHash) {
 				log.Debug("no configuration change, skipping sync to kong")
+				span.SetAttributes(attribute.Bool("kong.config.unchanged", true))
 				return oldSHA, nil
 			}
 			log.Debugf("starting to send configuration (hash: %s)", status.ConfigurationHash)
@@ -103,6 +107,8 @@ func PerformUpdate(ctx context.Context,
 			metrics.SuccessKey:  metrics.SuccessFalse,
 			metrics.ProtocolKey: metricsProtocol,
 		}).Observe(float64(timeEnd.Sub(timeStart).Milliseconds()))
+		span.SetAttributes(tracing.FailureReasonKey.String(pushFailureReason(err)))
+		tracing.RecordError(span, err)
 		return nil, err
 	}
 
@@ -119,6 +125,22 @@ func PerformUpdate(ctx context.Context,
 	return newSHA, nil
 }
 
+// HasConfiguration reports whether Kong has been configured since it started. Kong versions which
+// don't provide their configuration hash are assumed to be configured, as their state is unknown.
+func HasConfiguration(ctx context.Context, client *adminapi.Client) (bool, error) {
+	status, err := client.Status(ctx)
+	if err != nil {
+		return false, err
+	}
+	return isConfigured(status.ConfigurationHash), nil
+}
+
+// isConfigured reports whether the configuration hash reported by Kong differs from the hash of
+// the empty configuration Kong starts with.
+func isConfigured(configurationHash string) bool {
+	return configurationHash != initialHash
+}
+
 // -----------------------------------------------------------------------------
 // Sendconfig - Private Functions
 // -----------------------------------------------------------------------------
@@ -129,23 +151,29 @@ func onUpdateInMemoryMode(
 	state *file.Content,
 	client *kong.Client,
 ) error {
-	// Kong will error out if this is set
-	state.Info = nil
-	// Kong errors out if `null`s are present in `config` of plugins
-	deckgen.CleanUpNullsInPluginConfigs(state)
-
-	config, err := json.Marshal(state)
+	config, err := declarativeConfig(state)
 	if err != nil {
-		return fmt.Errorf("constructing kong configuration: %w", err)
+		return err
 	}
 
 	log.WithField("kong_url", client.BaseRootURL()).
 		Debug("sending configuration to Kong Admin API")
-	if err = client.Configs.ReloadDeclarativeRawConfig(ctx, bytes.NewReader(config), true); err != nil {
-		return err
-	}
+	return reloadDeclarativeConfig(ctx, log, client, config)
+}
 
-	return nil
+// declarativeConfig returns the declarative configuration sent to Kong in DB-less mode for the provided content.
+// The content isn't modified, so it can be sent to several Kong instances concurrently.
+func declarativeConfig(state *file.Content) ([]byte, error) {
+	// Kong errors out if `null`s are present in `config` of plugins
+	content := deckgen.WithoutNullsInPluginConfigs(state)
+	// Kong will error out if this is set
+	content.Info = nil
+
+	config, err := json.Marshal(content)
+	if err != nil {
+		return nil, fmt.Errorf("constructing kong configuration: %w", err)
+	}
+	return config, nil
 }
 
 func onUpdateDBMode(
@@ -1,12 +1,14 @@
 package sendconfig
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"net"
 	"net/http"
 	"testing"
 
+	"github.com/kong/deck/file"
 	deckutils "github.com/kong/deck/utils"
 	"github.com/kong/go-kong/kong"
 	"github.com/stretchr/testify/assert"
@@ -103,3 +105,35 @@ func TestPushFailureReason(t *testing.T) {
 		})
 	}
 }
+
+func TestDeclarativeConfigDoesNotModifyContent(t *testing.T) {
+	plugin := func() *file.FPlugin {
+		return &file.FPlugin{Plugin: kong.Plugin{
+			Name:   kong.String("response-transformer"),
+			Config: kong.Configuration{"add": map[string]interface{}{"headers": []string{"x-a:b"}}, "remove": nil},
+		}}
+	}
+	content := &file.Content{
+		FormatVersion: "3.0",
+		Info:          &file.Info{SelectorTags: []string{"managed-by-ingress-controller"}},
+		Services: []file.FService{{
+			Service: kong.Service{Name: kong.String("svc")},
+			Routes:  []*file.FRoute{{Route: kong.Route{Name: kong.String("route")}, Plugins: []*file.FPlugin{plugin()}}},
+			Plugins: []*file.FPlugin{plugin()},
+		}},
+		Consumers: []file.FConsumer{{Consumer: kong.Consumer{Username: kong.String("consumer")}, Plugins: []*file.FPlugin{plugin()}}},
+		Plugins:   []file.FPlugin{*plugin()},
+	}
+	before, err := json.Marshal(content)
+	require.NoError(t, err)
+
+	config, err := declarativeConfig(content)
+	require.NoError(t, err)
+	assert.NotContains(t, string(config), "managed-by-ingress-controller", "info must be left out")
+	assert.NotContains(t, string(config), `"remove"`, "null plugin config keys must be left out")
+	assert.Contains(t, string(config), "x-a:b")
+
+	after, err := json.Marshal(content)
+	require.NoError(t, err)
+	assert.JSONEq(t, string(before), string(after), "the content must not be modified")
+}
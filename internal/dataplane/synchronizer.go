@@ -29,6 +29,16 @@ const (
 	DefaultSyncSeconds float32 = 3.0
 
 	DefaultInitWaitPeriod = 5 * time.Second
+
+	// DefaultSyncMinInterval is the default minimum time between updates to the
+	// DataplaneClient in the event-driven mode. It's also the time the configuration
+	// has to stay unchanged for before it's applied.
+	DefaultSyncMinInterval = time.Second
+
+	// DefaultSyncMaxLatency is the default maximum time between a change to the
+	// configuration and an update to the DataplaneClient in the event-driven mode,
+	// unless the DataplaneClient is backing off after failed updates.
+	DefaultSyncMaxLatency = 5 * time.Second
 )
 
 // -----------------------------------------------------------------------------
@@ -51,6 +61,13 @@ type Synchronizer struct {
 	isServerRunning bool
 	initWaitPeriod  time.Duration
 
+	// event-driven mode configuration, in which updates are triggered by changes
+	// to the configuration and the stagger is the period of full resyncs.
+	eventDriven     bool
+	minSyncInterval time.Duration
+	maxSyncLatency  time.Duration
+	changes         <-chan struct{}
+
 	lock sync.RWMutex
 }
 
@@ -70,6 +87,19 @@ func WithInitWaitPeriod(period time.Duration) SynchronizerOption {
 	}
 }
 
+// WithEventDrivenSync returns a SynchronizerOption which makes the synchronizer update the data-plane
+// when the configuration changes, rather than only at regular intervals. An update happens once the
+// configuration has not changed for minInterval, but no later than maxLatency after the first change.
+// Updates are at least minInterval apart, and are backed off exponentially after failures. The stagger
+// period is still used to perform full resyncs. The client has to implement ChangeNotifier.
+func WithEventDrivenSync(minInterval, maxLatency time.Duration) SynchronizerOption {
+	return func(s *Synchronizer) {
+		s.eventDriven = true
+		s.minSyncInterval = minInterval
+		s.maxSyncLatency = maxLatency
+	}
+}
+
 // NewSynchronizer will provide a new Synchronizer object with a specified
 // stagger time for data-plane updates to occur. Note that this starts some
 // background goroutines and the caller is resonsible for marking the provided
@@ -90,6 +120,14 @@ func NewSynchronizer(logger logrus.FieldLogger, client Client, opts ...Synchroni
 
 	synchronizer.dbMode = client.DBMode()
 
+	if synchronizer.eventDriven {
+		notifier, ok := client.(ChangeNotifier)
+		if !ok {
+			return nil, fmt.Errorf("event-driven sync is not supported by %T dataplane client", client)
+		}
+		synchronizer.changes = notifier.Changes()
+	}
+
 	return synchronizer, nil
 }
 
@@ -119,7 +157,11 @@ func (p *Synchronizer) Start(ctx context.Context) error {
 	}
 
 	p.syncTicker = time.NewTicker(p.stagger)
-	go p.startUpdateServer(ctx)
+	if p.eventDriven {
+		go p.startEventDrivenUpdateServer(ctx)
+	} else {
+		go p.startUpdateServer(ctx)
+	}
 	p.isServerRunning = true
 
 	return nil
@@ -168,17 +210,7 @@ func (p *Synchronizer) startUpdateServer(ctx context.Context) {
 	for {
 		select {
 		case <-ctx.Done():
-			p.logger.Info("context done: shutting down the proxy update server")
-			if err := ctx.Err(); err != nil && !errors.Is(err, context.Canceled) {
-				p.logger.Error(err, "context completed with error")
-			}
-			p.syncTicker.Stop()
-
-			p.lock.Lock()
-			defer p.lock.Unlock()
-			p.isServerRunning = false
-			p.configApplied = false
-
+			p.stopUpdateServer(ctx)
 			return
 
 		case <-p.syncTicker.C:
@@ -191,6 +223,20 @@ func (p *Synchronizer) startUpdateServer(ctx context.Context) {
 	}
 }
 
+// stopUpdateServer cleans up after the update server when the provided context is done.
+func (p *Synchronizer) stopUpdateServer(ctx context.Context) {
+	p.logger.Info("context done: shutting down the proxy update server")
+	if err := ctx.Err(); err != nil && !errors.Is(err, context.Canceled) {
+		p.logger.Error(err, "context completed with error")
+	}
+	p.syncTicker.Stop()
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	p.isServerRunning = false
+	p.configApplied = false
+}
+
 // -----------------------------------------------------------------------------
 // Synchronizer - Private Methods - Helper
 // -----------------------------------------------------------------------------
@@ -0,0 +1,154 @@
+package dataplane
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// syncMaxBackoff is the maximum time updates are backed off for after consecutive failures
+// in the event-driven mode.
+const syncMaxBackoff = time.Minute
+
+// -----------------------------------------------------------------------------
+// Synchronizer - Private Methods - Event-Driven Server
+// -----------------------------------------------------------------------------
+
+// startEventDrivenUpdateServer runs a server in a background goroutine that is responsible for
+// updating the kong proxy backend whenever the configuration changes, as well as at regular
+// intervals to resync it.
+func (p *Synchronizer) startEventDrivenUpdateServer(ctx context.Context) {
+	var (
+		initialConfig sync.Once
+		timer         = time.NewTimer(0)
+		d             = &syncDebouncer{
+			minInterval: p.minSyncInterval,
+			maxLatency:  p.maxSyncLatency,
+		}
+	)
+	defer timer.Stop()
+
+	// the initial update is performed right away, as with the regular intervals mode.
+	d.resync(time.Now())
+	for {
+		select {
+		case <-ctx.Done():
+			p.stopUpdateServer(ctx)
+			return
+
+		case <-p.changes:
+			d.changed(time.Now())
+
+		case <-p.syncTicker.C:
+			d.resync(time.Now())
+
+		case <-timer.C:
+			now := time.Now()
+			if next, ok := d.next(); !ok || now.Before(next) {
+				break
+			}
+			err := p.dataplaneClient.Update(ctx)
+			d.updated(time.Now(), err)
+			if err != nil {
+				p.logger.Error(err, "could not update kong admin", "retry_in", time.Until(d.notBefore).String())
+			} else {
+				initialConfig.Do(p.markConfigApplied)
+			}
+		}
+
+		if next, ok := d.next(); ok {
+			resetTimer(timer, time.Until(next))
+		}
+	}
+}
+
+// resetTimer resets the provided timer to fire after the provided duration, draining its
+// channel if it has fired and the value has not been received yet.
+func resetTimer(timer *time.Timer, d time.Duration) {
+	if !timer.Stop() {
+		select {
+		case <-timer.C:
+		default:
+		}
+	}
+	timer.Reset(d)
+}
+
+// -----------------------------------------------------------------------------
+// Synchronizer - Private Types - Debouncer
+// -----------------------------------------------------------------------------
+
+// syncDebouncer decides when the data-plane should be updated in the event-driven mode.
+type syncDebouncer struct {
+	minInterval time.Duration
+	maxLatency  time.Duration
+
+	// pending indicates that there are changes which have not been applied yet.
+	pending bool
+	// pendingSince is the time of the first change which has not been applied yet.
+	pendingSince time.Time
+	// lastChange is the time of the most recent change which has not been applied yet.
+	lastChange time.Time
+	// notBefore is the earliest time the next update can happen at.
+	notBefore time.Time
+	// failures is the number of updates which failed in a row.
+	failures int
+}
+
+// changed records a change to the configuration.
+func (d *syncDebouncer) changed(now time.Time) {
+	if !d.pending {
+		d.pending = true
+		d.pendingSince = now
+	}
+	d.lastChange = now
+}
+
+// resync requests an update which doesn't wait for the configuration to stop changing.
+func (d *syncDebouncer) resync(now time.Time) {
+	if !d.pending {
+		d.pending = true
+		d.pendingSince = now
+		d.lastChange = now.Add(-d.minInterval)
+	}
+}
+
+// updated records the result of an update. Failed updates are retried with an exponential backoff.
+func (d *syncDebouncer) updated(now time.Time, err error) {
+	if err == nil {
+		d.pending = false
+		d.failures = 0
+		d.notBefore = now.Add(d.minInterval)
+		return
+	}
+
+	d.failures++
+	backoff := d.minInterval
+	for i := 1; i < d.failures && backoff < syncMaxBackoff; i++ {
+		backoff *= 2
+	}
+	if backoff > syncMaxBackoff {
+		backoff = syncMaxBackoff
+	}
+	d.notBefore = now.Add(backoff)
+
+	// the retry doesn't have to wait for the configuration to stop changing.
+	d.pending = true
+	d.pendingSince = now
+	d.lastChange = now.Add(-d.minInterval)
+}
+
+// next returns the time the next update should happen at, if there's any pending.
+func (d *syncDebouncer) next() (time.Time, bool) {
+	if !d.pending {
+		return time.Time{}, false
+	}
+	next := d.lastChange.Add(d.minInterval)
+	if deadline := d.pendingSince.Add(d.maxLatency); deadline.Before(next) {
+		next = deadline
+	}
+	if next.Before(d.notBefore) {
+		next = d.notBefore
+	}
+	return next, true
+}
@@ -0,0 +1,135 @@
+package dataplane
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSyncDebouncer(t *testing.T) {
+	start := time.Now()
+	at := func(d time.Duration) time.Time { return start.Add(d) }
+	d := &syncDebouncer{minInterval: time.Second, maxLatency: 5 * time.Second}
+
+	t.Log("verifying that nothing is scheduled without changes")
+	_, ok := d.next()
+	assert.False(t, ok)
+
+	t.Log("verifying that an update waits for the configuration to stop changing")
+	d.changed(at(0))
+	d.changed(at(500 * time.Millisecond))
+	next, ok := d.next()
+	require.True(t, ok)
+	assert.Equal(t, at(1500*time.Millisecond), next)
+
+	t.Log("verifying that an update doesn't wait longer than the maximum latency")
+	for i := time.Duration(1); i <= 10; i++ {
+		d.changed(at(i * 500 * time.Millisecond))
+	}
+	next, _ = d.next()
+	assert.Equal(t, at(5*time.Second), next)
+
+	t.Log("verifying that updates are at least the minimum interval apart")
+	d.updated(at(5*time.Second), nil)
+	_, ok = d.next()
+	assert.False(t, ok)
+	d.resync(at(5100 * time.Millisecond))
+	next, _ = d.next()
+	assert.Equal(t, at(6*time.Second), next)
+
+	t.Log("verifying that failed updates are backed off exponentially")
+	d.updated(at(6*time.Second), errors.New("failed"))
+	next, _ = d.next()
+	assert.Equal(t, at(7*time.Second), next)
+	d.updated(at(7*time.Second), errors.New("failed"))
+	next, _ = d.next()
+	assert.Equal(t, at(9*time.Second), next)
+	d.updated(at(9*time.Second), errors.New("failed"))
+	d.changed(at(9 * time.Second))
+	next, _ = d.next()
+	assert.Equal(t, at(13*time.Second), next, "changes should not override the backoff")
+
+	t.Log("verifying that the backoff is reset after a successful update")
+	d.updated(at(13*time.Second), nil)
+	d.changed(at(20 * time.Second))
+	next, _ = d.next()
+	assert.Equal(t, at(21*time.Second), next)
+}
+
+func TestSynchronizerEventDriven(t *testing.T) {
+	tick := time.Millisecond * 10
+
+	t.Log("verifying that event-driven sync requires a client which notifies about changes")
+	_, err := NewSynchronizer(logrus.New(), &fakeDataplaneClient{dbmode: "off"}, WithEventDrivenSync(tick, 5*tick))
+	require.Error(t, err)
+
+	c := &fakeNotifyingDataplaneClient{
+		fakeDataplaneClient: fakeDataplaneClient{dbmode: "off"},
+		changes:             make(chan struct{}, 1),
+	}
+	sync, err := NewSynchronizer(logrus.New(), c,
+		WithStagger(time.Hour), WithInitWaitPeriod(tick), WithEventDrivenSync(tick, 5*tick),
+	)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	require.NoError(t, sync.Start(ctx))
+
+	t.Log("verifying that the initial update is performed right away")
+	assert.Eventually(t, sync.IsReady, time.Second, tick)
+	assert.Equal(t, 1, c.totalUpdates())
+
+	t.Log("verifying that a change triggers an update")
+	c.changes <- struct{}{}
+	assert.Eventually(t, func() bool { return c.totalUpdates() == 2 }, time.Second, tick)
+
+	t.Log("verifying that nothing is updated without changes")
+	time.Sleep(10 * tick)
+	assert.Equal(t, 2, c.totalUpdates())
+
+	t.Log("verifying that failed updates are retried")
+	c.setFailing(true)
+	c.changes <- struct{}{}
+	assert.Eventually(t, func() bool { return c.totalUpdates() >= 4 }, time.Second, tick)
+	c.setFailing(false)
+
+	cancel()
+	assert.Eventually(t, func() bool { return !sync.IsRunning() }, time.Second, tick)
+}
+
+// fakeNotifyingDataplaneClient fakes the dataplane.Client and dataplane.ChangeNotifier interfaces
+// so that we can unit test the event-driven dataplane.Synchronizer.
+type fakeNotifyingDataplaneClient struct {
+	fakeDataplaneClient
+	changes chan struct{}
+
+	failingLock sync.Mutex
+	failing     bool
+}
+
+func (c *fakeNotifyingDataplaneClient) Changes() <-chan struct{} {
+	return c.changes
+}
+
+func (c *fakeNotifyingDataplaneClient) Update(ctx context.Context) error {
+	_ = c.fakeDataplaneClient.Update(ctx)
+	c.failingLock.Lock()
+	defer c.failingLock.Unlock()
+	if c.failing {
+		return errors.New("failed to update")
+	}
+	return nil
+}
+
+func (c *fakeNotifyingDataplaneClient) setFailing(failing bool) {
+	c.failingLock.Lock()
+	defer c.failingLock.Unlock()
+	c.failing = failing
+}
@@ -0,0 +1,165 @@
+package diagnostics
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+
+	"github.com/kong/deck/file"
+)
+
+const (
+	// EntityAdded indicates that an entity is present only in the newer configuration.
+	EntityAdded = "added"
+	// EntityRemoved indicates that an entity is present only in the older configuration.
+	EntityRemoved = "removed"
+	// EntityModified indicates that an entity is present in both configurations, but its fields differ.
+	EntityModified = "modified"
+
+	// rootEntity identifies the top-level fields of a configuration (e.g. its format version).
+	rootEntity = "config"
+)
+
+// ConfigDiff is a structured diff between two configurations pushed to Kong.
+type ConfigDiff struct {
+	// From describes the older configuration.
+	From ConfigHistoryEntry `json:"from"`
+	// To describes the newer configuration.
+	To ConfigHistoryEntry `json:"to"`
+	// Changes are the entities which differ between the configurations.
+	Changes []EntityDiff `json:"changes"`
+}
+
+// EntityDiff describes how a Kong entity differs between two configurations.
+type EntityDiff struct {
+	// Entity identifies the entity by its path in the configuration, e.g. "services/default.httpbin.80/routes/default.httpbin.00".
+	Entity string `json:"entity"`
+	// Change is one of EntityAdded, EntityRemoved or EntityModified.
+	Change string `json:"change"`
+	// Fields are the fields of a modified entity which differ, along with their values in both configurations.
+	Fields map[string]FieldDiff `json:"fields,omitempty"`
+}
+
+// FieldDiff holds the values of a field in two configurations.
+type FieldDiff struct {
+	From interface{} `json:"from,omitempty"`
+	To   interface{} `json:"to,omitempty"`
+}
+
+// diffConfigs returns a structured diff between the configurations of the provided entries.
+func diffConfigs(from, to ConfigHistoryEntry) (ConfigDiff, error) {
+	fromEntities, err := flattenConfig(from.Config)
+	if err != nil {
+		return ConfigDiff{}, fmt.Errorf("failed to process configuration %d: %w", from.ID, err)
+	}
+	toEntities, err := flattenConfig(to.Config)
+	if err != nil {
+		return ConfigDiff{}, fmt.Errorf("failed to process configuration %d: %w", to.ID, err)
+	}
+
+	from.Config, to.Config = nil, nil
+	diff := ConfigDiff{From: from, To: to, Changes: []EntityDiff{}}
+	for _, entity := range sortedKeys(fromEntities, toEntities) {
+		fromFields, inFrom := fromEntities[entity]
+		toFields, inTo := toEntities[entity]
+		switch {
+		case !inFrom:
+			diff.Changes = append(diff.Changes, EntityDiff{Entity: entity, Change: EntityAdded})
+		case !inTo:
+			diff.Changes = append(diff.Changes, EntityDiff{Entity: entity, Change: EntityRemoved})
+		default:
+			fields := make(map[string]FieldDiff)
+			for _, field := range sortedKeys(fromFields, toFields) {
+				if !reflect.DeepEqual(fromFields[field], toFields[field]) {
+					fields[field] = FieldDiff{From: fromFields[field], To: toFields[field]}
+				}
+			}
+			if len(fields) > 0 {
+				diff.Changes = append(diff.Changes, EntityDiff{Entity: entity, Change: EntityModified, Fields: fields})
+			}
+		}
+	}
+	return diff, nil
+}
+
+// flattenConfig returns the fields of all the entities of the provided configuration, by their paths. Nested
+// entities (e.g. routes of a service) are not included in the fields of their parents, but on their own.
+func flattenConfig(config *file.Content) (map[string]map[string]interface{}, error) {
+	entities := make(map[string]map[string]interface{})
+	if config == nil {
+		return entities, nil
+	}
+
+	raw, err := json.Marshal(config)
+	if err != nil {
+		return nil, err
+	}
+	var root map[string]interface{}
+	if err := json.Unmarshal(raw, &root); err != nil {
+		return nil, err
+	}
+	flattenEntity("", root, entities)
+	if fields := entities[""]; len(fields) > 0 {
+		entities[rootEntity] = fields
+	}
+	delete(entities, "")
+	return entities, nil
+}
+
+func flattenEntity(path string, fields map[string]interface{}, entities map[string]map[string]interface{}) {
+	for field, value := range fields {
+		nested, ok := value.([]interface{})
+		if !ok || len(nested) == 0 || !allObjects(nested) {
+			continue
+		}
+		delete(fields, field)
+		for i, n := range nested {
+			nestedPath := field + "/" + entityName(n.(map[string]interface{}), i)
+			if path != "" {
+				nestedPath = path + "/" + nestedPath
+			}
+			// entities which can't be told apart by their names are told apart by their positions.
+			if _, ok := entities[nestedPath]; ok {
+				nestedPath += "#" + strconv.Itoa(i)
+			}
+			flattenEntity(nestedPath, n.(map[string]interface{}), entities)
+		}
+	}
+	entities[path] = fields
+}
+
+// entityName returns a human-readable identifier of an entity, unique among its siblings in most cases.
+func entityName(fields map[string]interface{}, index int) string {
+	for _, field := range []string{"name", "username", "id", "target", "group", "key", "client_id", "custom_id"} {
+		if name, ok := fields[field].(string); ok && name != "" {
+			return name
+		}
+	}
+	return strconv.Itoa(index)
+}
+
+func allObjects(values []interface{}) bool {
+	for _, v := range values {
+		if _, ok := v.(map[string]interface{}); !ok {
+			return false
+		}
+	}
+	return true
+}
+
+func sortedKeys[T any](maps ...map[string]T) []string {
+	seen := make(map[string]struct{})
+	var keys []string
+	for _, m := range maps {
+		for k := range m {
+			if _, ok := seen[k]; !ok {
+				seen[k] = struct{}{}
+				keys = append(keys, k)
+			}
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
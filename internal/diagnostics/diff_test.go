@@ -0,0 +1,67 @@
+package diagnostics
+
+import (
+	"testing"
+
+	"github.com/kong/deck/file"
+	"github.com/kong/go-kong/kong"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiffConfigs(t *testing.T) {
+	from := ConfigHistoryEntry{
+		ID: 1,
+		Config: &file.Content{
+			FormatVersion: "3.0",
+			Services: []file.FService{
+				{
+					Service: kong.Service{Name: kong.String("httpbin"), Retries: kong.Int(5)},
+					Routes: []*file.FRoute{
+						{Route: kong.Route{Name: kong.String("httpbin.0"), Paths: kong.StringSlice("/httpbin")}},
+						{Route: kong.Route{Name: kong.String("httpbin.1"), Paths: kong.StringSlice("/other")}},
+					},
+				},
+				{Service: kong.Service{Name: kong.String("removed")}},
+			},
+		},
+	}
+	to := ConfigHistoryEntry{
+		ID:     2,
+		Failed: true,
+		Config: &file.Content{
+			FormatVersion: "3.0",
+			Services: []file.FService{
+				{
+					Service: kong.Service{Name: kong.String("httpbin"), Retries: kong.Int(3)},
+					Routes: []*file.FRoute{
+						{Route: kong.Route{Name: kong.String("httpbin.0"), Paths: kong.StringSlice("/httpbin")}},
+						{Route: kong.Route{Name: kong.String("httpbin.1"), Paths: kong.StringSlice("/changed")}},
+					},
+				},
+				{Service: kong.Service{Name: kong.String("added")}},
+			},
+		},
+	}
+
+	diff, err := diffConfigs(from, to)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), diff.From.ID)
+	assert.Equal(t, uint64(2), diff.To.ID)
+	assert.Nil(t, diff.From.Config)
+	assert.Nil(t, diff.To.Config)
+	assert.Equal(t, []EntityDiff{
+		{Entity: "services/added", Change: EntityAdded},
+		{
+			Entity: "services/httpbin",
+			Change: EntityModified,
+			Fields: map[string]FieldDiff{"retries": {From: float64(5), To: float64(3)}},
+		},
+		{
+			Entity: "services/httpbin/routes/httpbin.1",
+			Change: EntityModified,
+			Fields: map[string]FieldDiff{"paths": {From: []interface{}{"/other"}, To: []interface{}{"/changed"}}},
+		},
+		{Entity: "services/removed", Change: EntityRemoved},
+	}, diff.Changes)
+}
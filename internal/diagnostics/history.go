@@ -0,0 +1,130 @@
+package diagnostics
+
+import (
+	"sync"
+	"time"
+
+	"github.com/kong/deck/file"
+
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/util"
+)
+
+// DefaultConfigHistorySize is the default number of the configurations pushed to Kong which are retained.
+const DefaultConfigHistorySize = 16
+
+// ConfigHistoryEntry describes a configuration pushed to a Kong instance.
+type ConfigHistoryEntry struct {
+	// ID identifies the entry. IDs are assigned in the order the configurations were pushed.
+	ID uint64 `json:"id"`
+	// Time is the time the configuration was pushed at.
+	Time time.Time `json:"time"`
+	// URL is the base URL of the Kong Admin API the configuration was pushed to.
+	URL string `json:"url,omitempty"`
+	// SHA is the checksum of the configuration.
+	SHA string `json:"sha,omitempty"`
+	// Failed indicates whether the configuration failed to apply.
+	Failed bool `json:"failed"`
+	// Error is the error the configuration failed to apply with.
+	Error string `json:"error,omitempty"`
+	// Config is the configuration, omitted when listing the entries.
+	Config *file.Content `json:"config,omitempty"`
+}
+
+// configHistory is a bounded ring buffer of the configurations pushed to Kong.
+type configHistory struct {
+	lock    sync.RWMutex
+	entries []ConfigHistoryEntry
+	// next is the position in entries the next entry is stored at.
+	next int
+	// lastID is the ID of the most recently stored entry.
+	lastID uint64
+}
+
+func newConfigHistory(size int) *configHistory {
+	if size < 1 {
+		size = DefaultConfigHistorySize
+	}
+	return &configHistory{entries: make([]ConfigHistoryEntry, 0, size)}
+}
+
+// add stores the provided config dump, dropping the oldest one if the history is full.
+func (h *configHistory) add(dump util.ConfigDump) ConfigHistoryEntry {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	h.lastID++
+	config := dump.Config
+	entry := ConfigHistoryEntry{
+		ID:     h.lastID,
+		Time:   dump.Time,
+		URL:    dump.URL,
+		SHA:    dump.SHA,
+		Failed: dump.Failed,
+		Error:  dump.Error,
+		Config: &config,
+	}
+	if entry.Time.IsZero() {
+		entry.Time = time.Now()
+	}
+
+	if len(h.entries) < cap(h.entries) {
+		h.entries = append(h.entries, entry)
+	} else {
+		h.entries[h.next] = entry
+	}
+	h.next = (h.next + 1) % cap(h.entries)
+	return entry
+}
+
+// list returns the retained entries without their configurations, from the oldest to the newest.
+func (h *configHistory) list() []ConfigHistoryEntry {
+	h.lock.RLock()
+	defer h.lock.RUnlock()
+
+	list := make([]ConfigHistoryEntry, 0, len(h.entries))
+	for _, entry := range h.ordered() {
+		entry.Config = nil
+		list = append(list, entry)
+	}
+	return list
+}
+
+// get returns the entry with the provided ID, if it's retained.
+func (h *configHistory) get(id uint64) (ConfigHistoryEntry, bool) {
+	h.lock.RLock()
+	defer h.lock.RUnlock()
+
+	for _, entry := range h.entries {
+		if entry.ID == id {
+			return entry, true
+		}
+	}
+	return ConfigHistoryEntry{}, false
+}
+
+// lastFailedAndSuccessful returns the most recent entry with a failed configuration, along with the most
+// recent entry with a successful configuration pushed before it, if they're retained.
+func (h *configHistory) lastFailedAndSuccessful() (failed ConfigHistoryEntry, successful ConfigHistoryEntry, ok bool) {
+	h.lock.RLock()
+	defer h.lock.RUnlock()
+
+	var foundFailed bool
+	ordered := h.ordered()
+	for i := len(ordered) - 1; i >= 0; i-- {
+		switch {
+		case !foundFailed && ordered[i].Failed:
+			failed, foundFailed = ordered[i], true
+		case foundFailed && !ordered[i].Failed:
+			return failed, ordered[i], true
+		}
+	}
+	return ConfigHistoryEntry{}, ConfigHistoryEntry{}, false
+}
+
+// ordered returns the retained entries from the oldest to the newest. It must be called with the lock held.
+func (h *configHistory) ordered() []ConfigHistoryEntry {
+	if len(h.entries) < cap(h.entries) {
+		return append([]ConfigHistoryEntry{}, h.entries...)
+	}
+	return append(append([]ConfigHistoryEntry{}, h.entries[h.next:]...), h.entries[:h.next]...)
+}
@@ -0,0 +1,55 @@
+package diagnostics
+
+import (
+	"testing"
+
+	"github.com/kong/deck/file"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/util"
+)
+
+func TestConfigHistory(t *testing.T) {
+	h := newConfigHistory(3)
+
+	t.Log("verifying that no failed configuration is found in an empty history")
+	_, _, ok := h.lastFailedAndSuccessful()
+	assert.False(t, ok)
+
+	for _, dump := range []util.ConfigDump{
+		{SHA: "1"},
+		{SHA: "2", Failed: true},
+		{SHA: "3"},
+		{SHA: "4", Failed: true, Error: "rejected"},
+	} {
+		dump.Config = file.Content{FormatVersion: dump.SHA}
+		h.add(dump)
+	}
+
+	t.Log("verifying that only the most recent configurations are retained, listed from the oldest")
+	list := h.list()
+	require.Len(t, list, 3)
+	for i, sha := range []string{"2", "3", "4"} {
+		assert.Equal(t, uint64(i+2), list[i].ID)
+		assert.Equal(t, sha, list[i].SHA)
+		assert.Nil(t, list[i].Config, "configurations should not be listed")
+		assert.False(t, list[i].Time.IsZero())
+	}
+
+	t.Log("verifying that retained configurations can be fetched by their IDs")
+	_, ok = h.get(1)
+	assert.False(t, ok)
+	entry, ok := h.get(4)
+	require.True(t, ok)
+	assert.True(t, entry.Failed)
+	assert.Equal(t, "rejected", entry.Error)
+	require.NotNil(t, entry.Config)
+	assert.Equal(t, "4", entry.Config.FormatVersion)
+
+	t.Log("verifying that the last failed configuration is paired with the last successful one preceding it")
+	failed, successful, ok := h.lastFailedAndSuccessful()
+	require.True(t, ok)
+	assert.Equal(t, uint64(4), failed.ID)
+	assert.Equal(t, uint64(3), successful.ID)
+}
@@ -7,6 +7,8 @@ import (
 	"fmt"
 	"net/http"
 	"net/http/pprof"
+	"strconv"
+	"strings"
 	"sync"
 	"time"
 
@@ -22,11 +24,20 @@ type Server struct {
 	ProfilingEnabled bool
 	ConfigDumps      util.ConfigDumpDiagnostic
 	ConfigLock       *sync.RWMutex
+
+	// ConfigHistorySize is the number of the configurations pushed to Kong which are retained.
+	// DefaultConfigHistorySize is used when it's not set.
+	ConfigHistorySize int
+
+	history *configHistory
 }
 
 var (
 	successfulConfigDump file.Content
 	failedConfigDump     file.Content
+	instancesStatus      = []util.KongInstanceStatus{}
+	dryRuns              = []util.KongInstanceDryRun{}
+	explanations         = util.KubernetesObjectExplanations{}
 )
 
 const (
@@ -35,6 +46,8 @@ const (
 
 // Listen starts up the HTTP server and blocks until ctx expires.
 func (s *Server) Listen(ctx context.Context, port int) error {
+	s.history = newConfigHistory(s.ConfigHistorySize)
+
 	mux := http.NewServeMux()
 	if s.ConfigDumps != (util.ConfigDumpDiagnostic{}) {
 		s.installDumpHandlers(mux)
@@ -78,6 +91,7 @@ func (s *Server) receiveConfig(ctx context.Context) {
 	for {
 		select {
 		case dump := <-s.ConfigDumps.Configs:
+			s.history.add(dump)
 			s.ConfigLock.Lock()
 			if dump.Failed {
 				failedConfigDump = dump.Config
@@ -85,6 +99,18 @@ func (s *Server) receiveConfig(ctx context.Context) {
 				successfulConfigDump = dump.Config
 			}
 			s.ConfigLock.Unlock()
+		case statuses := <-s.ConfigDumps.InstancesStatus:
+			s.ConfigLock.Lock()
+			instancesStatus = statuses
+			s.ConfigLock.Unlock()
+		case reports := <-s.ConfigDumps.DryRuns:
+			s.ConfigLock.Lock()
+			dryRuns = reports
+			s.ConfigLock.Unlock()
+		case e := <-s.ConfigDumps.Explanations:
+			s.ConfigLock.Lock()
+			explanations = e
+			s.ConfigLock.Unlock()
 		case <-ctx.Done():
 			if err := ctx.Err(); err != nil && !errors.Is(err, context.Canceled) {
 				s.Logger.Error(err, "shutting down diagnostic config collection: context completed with error")
@@ -115,6 +141,12 @@ func installProfilingHandlers(mux *http.ServeMux) {
 func (s *Server) installDumpHandlers(mux *http.ServeMux) {
 	mux.HandleFunc("/debug/config/successful", s.lastConfig(&successfulConfigDump))
 	mux.HandleFunc("/debug/config/failed", s.lastConfig(&failedConfigDump))
+	mux.HandleFunc("/debug/config/instances", s.instancesStatus)
+	mux.HandleFunc("/debug/config/dry-run", s.dryRuns)
+	mux.HandleFunc("/debug/config/history", s.configHistory)
+	mux.HandleFunc("/debug/config/history/", s.configHistoryEntry)
+	mux.HandleFunc("/debug/config/diff", s.configDiff)
+	mux.HandleFunc("/debug/config/explain", s.explain)
 }
 
 // redirectTo redirects request to a certain destination.
@@ -134,3 +166,112 @@ func (s *Server) lastConfig(config *file.Content) func(rw http.ResponseWriter, r
 		s.ConfigLock.RUnlock()
 	}
 }
+
+// instancesStatus responds with the state of the configuration updates of each of the Kong instances.
+func (s *Server) instancesStatus(rw http.ResponseWriter, _ *http.Request) {
+	rw.Header().Set("Content-Type", "application/json")
+	s.ConfigLock.RLock()
+	if err := json.NewEncoder(rw).Encode(instancesStatus); err != nil {
+		rw.WriteHeader(http.StatusInternalServerError)
+	}
+	s.ConfigLock.RUnlock()
+}
+
+// dryRuns responds with the changes the last configuration would make to each of the Kong instances
+// when the controller runs in dry-run mode.
+func (s *Server) dryRuns(rw http.ResponseWriter, _ *http.Request) {
+	rw.Header().Set("Content-Type", "application/json")
+	s.ConfigLock.RLock()
+	if err := json.NewEncoder(rw).Encode(dryRuns); err != nil {
+		rw.WriteHeader(http.StatusInternalServerError)
+	}
+	s.ConfigLock.RUnlock()
+}
+
+// configHistory responds with the list of the retained configurations pushed to Kong, without the configurations.
+func (s *Server) configHistory(rw http.ResponseWriter, _ *http.Request) {
+	writeJSON(rw, s.history.list())
+}
+
+// configHistoryEntry responds with the retained configuration pushed to Kong with the ID given in the path.
+func (s *Server) configHistoryEntry(rw http.ResponseWriter, req *http.Request) {
+	id, err := strconv.ParseUint(strings.TrimPrefix(req.URL.Path, "/debug/config/history/"), 10, 64)
+	if err != nil {
+		http.Error(rw, "invalid configuration ID", http.StatusBadRequest)
+		return
+	}
+	entry, ok := s.history.get(id)
+	if !ok {
+		http.Error(rw, fmt.Sprintf("configuration %d not found", id), http.StatusNotFound)
+		return
+	}
+	writeJSON(rw, entry)
+}
+
+// configDiff responds with a structured diff between the retained configurations with the IDs given in
+// the "from" and "to" query parameters or, if they're not given, between the most recent failed configuration
+// and the last successful one pushed before it.
+func (s *Server) configDiff(rw http.ResponseWriter, req *http.Request) {
+	var from, to ConfigHistoryEntry
+	query := req.URL.Query()
+	if !query.Has("from") && !query.Has("to") {
+		var ok bool
+		to, from, ok = s.history.lastFailedAndSuccessful()
+		if !ok {
+			http.Error(rw, "no failed configuration preceded by a successful one found", http.StatusNotFound)
+			return
+		}
+	} else {
+		for _, p := range []struct {
+			name  string
+			entry *ConfigHistoryEntry
+		}{{"from", &from}, {"to", &to}} {
+			param, entry := p.name, p.entry
+			id, err := strconv.ParseUint(query.Get(param), 10, 64)
+			if err != nil {
+				http.Error(rw, fmt.Sprintf("invalid %q configuration ID", param), http.StatusBadRequest)
+				return
+			}
+			var ok bool
+			if *entry, ok = s.history.get(id); !ok {
+				http.Error(rw, fmt.Sprintf("configuration %d not found", id), http.StatusNotFound)
+				return
+			}
+		}
+	}
+
+	diff, err := diffConfigs(from, to)
+	if err != nil {
+		http.Error(rw, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	writeJSON(rw, diff)
+}
+
+// explain responds with the Kong entities generated by the last translation for the Kubernetes object of the
+// kind, namespace and name given in the query parameters, along with the failures its translation resulted in.
+func (s *Server) explain(rw http.ResponseWriter, req *http.Request) {
+	query := req.URL.Query()
+	kind, namespace, name := query.Get("kind"), query.Get("namespace"), query.Get("name")
+	if kind == "" || name == "" {
+		http.Error(rw, "kind and name query parameters are required", http.StatusBadRequest)
+		return
+	}
+
+	s.ConfigLock.RLock()
+	explanation, ok := explanations.Get(kind, namespace, name)
+	s.ConfigLock.RUnlock()
+	if !ok {
+		http.Error(rw, fmt.Sprintf("no Kong entities nor translation failures found for %s %s/%s", kind, namespace, name),
+			http.StatusNotFound)
+		return
+	}
+	writeJSON(rw, explanation)
+}
+
+func writeJSON(rw http.ResponseWriter, v interface{}) {
+	rw.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(rw).Encode(v); err != nil {
+		rw.WriteHeader(http.StatusInternalServerError)
+	}
+}
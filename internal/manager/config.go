@@ -16,6 +16,8 @@ import (
 	"github.com/kong/kubernetes-ingress-controller/v2/internal/annotations"
 	"github.com/kong/kubernetes-ingress-controller/v2/internal/controllers/gateway"
 	"github.com/kong/kubernetes-ingress-controller/v2/internal/dataplane"
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/diagnostics"
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/tracing"
 )
 
 // -----------------------------------------------------------------------------
@@ -44,14 +46,21 @@ type Config struct {
 	CacheSyncTimeout                  time.Duration
 
 	// Kong Proxy configurations
-	APIServerHost       string
-	APIServerQPS        int
-	APIServerBurst      int
-	MetricsAddr         string
-	ProbeAddr           string
-	KongAdminURL        []string
-	ProxySyncSeconds    float32
-	ProxyTimeoutSeconds float32
+	APIServerHost         string
+	APIServerQPS          int
+	APIServerBurst        int
+	MetricsAddr           string
+	ProbeAddr             string
+	KongAdminURL          []string
+	KongAdminSvc          types.NamespacedName
+	KongAdminSvcPortNames []string
+	ProxySyncSeconds      float32
+	ProxyTimeoutSeconds   float32
+	ProxyReadinessQuorum  float64
+	ProxyEventDrivenSync  bool
+	ProxySyncMinInterval  time.Duration
+	ProxySyncMaxLatency   time.Duration
+	DryRun                bool
 
 	// Kubernetes configurations
 	KubeconfigPath           string
@@ -62,6 +71,8 @@ type Config struct {
 	FilterTags               []string
 	WatchNamespaces          []string
 	GatewayAPIControllerName string
+	ManagedGatewayClass      string
+	ManagedGatewayKongImage  string
 
 	// Ingress status
 	PublishServiceUDP       types.NamespacedName
@@ -89,9 +100,14 @@ type Config struct {
 	AdmissionServer admission.ServerConfig
 
 	// Diagnostics and performance
-	EnableProfiling     bool
-	EnableConfigDumps   bool
-	DumpSensitiveConfig bool
+	EnableProfiling       bool
+	EnableConfigDumps     bool
+	DumpSensitiveConfig   bool
+	ConfigDumpHistorySize int
+
+	// Tracing
+	TracingOTLPEndpoint    string
+	TracingOTLPServiceName string
 
 	// Feature Gates
 	FeatureGates map[string]bool
@@ -151,15 +167,43 @@ func (c *Config) FlagSet() *pflag.FlagSet {
 	flagSet.StringSliceVar(&c.KongAdminURL, "kong-admin-url", []string{"http://localhost:8001"},
 		`Kong Admin URL(s) to connect to in the format "protocol://address:port". `+
 			`More than 1 URL can be provided, in such case the flag should be used multiple times or a corresponding env variable should use comma delimited addresses.`)
+	flagSet.Var(NewValidatedValue(&c.KongAdminSvc, namespacedNameFromFlagValue), "kong-admin-svc",
+		`Kong Admin API Service in "namespace/name" format, whose ready endpoints are discovered and configured instead of the ones provided with --kong-admin-url.`)
+	flagSet.StringSliceVar(&c.KongAdminSvcPortNames, "kong-admin-svc-port-names", adminapi.DefaultAdminAPIServicePortNames,
+		"Names of the ports of the --kong-admin-svc Service that expose Kong Admin API. Ports with names ending with \"tls\" are expected to serve HTTPS.")
 	flagSet.Float32Var(&c.ProxySyncSeconds, "proxy-sync-seconds", dataplane.DefaultSyncSeconds,
 		"Define the rate (in seconds) in which configuration updates will be applied to the Kong Admin API.",
 	)
 	flagSet.Float32Var(&c.ProxyTimeoutSeconds, "proxy-timeout-seconds", dataplane.DefaultTimeoutSeconds,
 		"Sets the timeout (in seconds) for all requests to Kong's Admin API.",
 	)
+	flagSet.BoolVar(&c.ProxyEventDrivenSync, "proxy-event-driven-sync", false,
+		"Apply configuration updates to the Kong Admin API when Kubernetes objects change instead of at regular intervals. "+
+			"Updates are debounced (see --proxy-sync-min-interval and --proxy-sync-max-latency) and backed off exponentially after failures, "+
+			"while --proxy-sync-seconds sets the rate of full resyncs.",
+	)
+	flagSet.DurationVar(&c.ProxySyncMinInterval, "proxy-sync-min-interval", dataplane.DefaultSyncMinInterval,
+		"With --proxy-event-driven-sync, the minimum time between configuration updates, which is also the time Kubernetes objects have to stay unchanged for before an update.",
+	)
+	flagSet.DurationVar(&c.ProxySyncMaxLatency, "proxy-sync-max-latency", dataplane.DefaultSyncMaxLatency,
+		"With --proxy-event-driven-sync, the maximum time between a change to Kubernetes objects and a configuration update, unless updates are backed off after failures.",
+	)
+	flagSet.Float64Var(&c.ProxyReadinessQuorum, "proxy-readiness-quorum", dataplane.DefaultReadinessQuorum,
+		"Fraction (between 0 and 1) of the Kong instances that must have configuration applied for an update to be considered successful and for the controller to become ready. "+
+			"Kong instances that fail are retried with a backoff on their own. At least one instance is always required.",
+	)
+	flagSet.BoolVar(&c.DryRun, "dry-run", false,
+		"Translate Kubernetes objects and compute the changes the resulting configuration would make to each Kong instance without ever applying it. "+
+			fmt.Sprintf("The changes are exposed as metrics and, with --dump-config, via web interface host:%v/debug/config/dry-run. ", DiagnosticsPort)+
+			"No Kubernetes object is written (status updates, managed Gateway provisioning and events are discarded) and leader election is disabled.",
+	)
 
 	// Kubernetes configurations
 	flagSet.Var(NewValidatedValueWithDefault(&c.GatewayAPIControllerName, gatewayAPIControllerNameFromFlagValue, string(gateway.ControllerName)), "gateway-api-controller-name", "The controller name to match on Gateway API resources.")
+	flagSet.StringVar(&c.ManagedGatewayClass, "gateway-managed-class", "",
+		`Name of the GatewayClass which Gateways are each provisioned their own Kong Deployment and Services, and only have their routes pushed to it. Leave empty to disable managed Gateways.`)
+	flagSet.StringVar(&c.ManagedGatewayKongImage, "gateway-managed-kong-image", gateway.DefaultManagedGatewayKongImage,
+		`Kong image of the Deployments provisioned for managed Gateways. It should match the version of the Kong instances configured with --kong-admin-url.`)
 	flagSet.StringVar(&c.KubeconfigPath, "kubeconfig", "", "Path to the kubeconfig file.")
 	flagSet.StringVar(&c.IngressClassName, "ingress-class", annotations.DefaultIngressClass, `Name of the ingress class to route through this controller.`)
 	flagSet.StringVar(&c.LeaderElectionID, "election-id", "5b374a9e.konghq.com", `Election id to use for status update.`)
@@ -216,6 +260,15 @@ func (c *Config) FlagSet() *pflag.FlagSet {
 	flagSet.BoolVar(&c.EnableProfiling, "profiling", false, fmt.Sprintf("Enable profiling via web interface host:%v/debug/pprof/", DiagnosticsPort))
 	flagSet.BoolVar(&c.EnableConfigDumps, "dump-config", false, fmt.Sprintf("Enable config dumps via web interface host:%v/debug/config", DiagnosticsPort))
 	flagSet.BoolVar(&c.DumpSensitiveConfig, "dump-sensitive-config", false, "Include credentials and TLS secrets in configs exposed with --dump-config")
+	flagSet.IntVar(&c.ConfigDumpHistorySize, "dump-config-history-size", diagnostics.DefaultConfigHistorySize,
+		fmt.Sprintf("Number of the last configs pushed to Kong that are retained and exposed with --dump-config via web interface host:%v/debug/config/history", DiagnosticsPort))
+
+	// Tracing
+	flagSet.StringVar(&c.TracingOTLPEndpoint, "tracing-otlp-endpoint", "",
+		"URL of the OpenTelemetry collector endpoint (e.g. http://otel-collector:4318/v1/traces) which traces of reconciling objects, "+
+			"translating them and pushing configuration to Kong are exported to with OTLP/HTTP. Tracing is disabled when not set.")
+	flagSet.StringVar(&c.TracingOTLPServiceName, "tracing-otlp-service-name", tracing.DefaultOTLPServiceName,
+		"Service name the traces exported with --tracing-otlp-endpoint are reported under.")
 
 	// Feature Gates (see FEATURE_GATES.md)
 	flagSet.Var(cliflag.NewMapStringBool(&c.FeatureGates), "feature-gates", "A set of key=value pairs that describe feature gates for alpha/beta/experimental features. "+
@@ -3,6 +3,7 @@ package manager
 import (
 	"errors"
 	"fmt"
+	"net/url"
 	"regexp"
 	"strings"
 
@@ -43,6 +44,15 @@ func (c *Config) Validate() error {
 	if err := c.validateKongAdminAPI(); err != nil {
 		return fmt.Errorf("invalid kong admin api configuration: %w", err)
 	}
+	if err := c.validateProxySync(); err != nil {
+		return fmt.Errorf("invalid proxy sync configuration: %w", err)
+	}
+	if err := c.validateDiagnostics(); err != nil {
+		return fmt.Errorf("invalid diagnostics configuration: %w", err)
+	}
+	if err := c.validateTracing(); err != nil {
+		return fmt.Errorf("invalid tracing configuration: %w", err)
+	}
 
 	return nil
 }
@@ -72,6 +82,54 @@ func (c *Config) validateKongAdminAPI() error {
 	if err := validateClientTLS(c.KongAdminAPIConfig.TLSClient); err != nil {
 		return fmt.Errorf("TLS client config invalid: %w", err)
 	}
+	if c.KongAdminSvc.Name != "" {
+		if c.flagSet != nil && c.flagSet.Changed("kong-admin-url") {
+			return errors.New("--kong-admin-url and --kong-admin-svc can't be used together")
+		}
+		if len(c.KongAdminSvcPortNames) == 0 {
+			return errors.New("no port names provided for --kong-admin-svc")
+		}
+	}
+	if c.ProxyReadinessQuorum < 0 || c.ProxyReadinessQuorum > 1 {
+		return fmt.Errorf("--proxy-readiness-quorum must be between 0 and 1, got %v", c.ProxyReadinessQuorum)
+	}
+	return nil
+}
+
+func (c *Config) validateProxySync() error {
+	if !c.ProxyEventDrivenSync {
+		return nil
+	}
+	if c.ProxySyncMinInterval <= 0 {
+		return errors.New("--proxy-sync-min-interval must be positive")
+	}
+	if c.ProxySyncMaxLatency < c.ProxySyncMinInterval {
+		return errors.New("--proxy-sync-max-latency can't be lower than --proxy-sync-min-interval")
+	}
+	return nil
+}
+
+func (c *Config) validateDiagnostics() error {
+	if c.EnableConfigDumps && c.ConfigDumpHistorySize < 1 {
+		return errors.New("--dump-config-history-size must be at least 1")
+	}
+	return nil
+}
+
+func (c *Config) validateTracing() error {
+	if c.TracingOTLPEndpoint == "" {
+		return nil
+	}
+	u, err := url.Parse(c.TracingOTLPEndpoint)
+	if err != nil {
+		return fmt.Errorf("--tracing-otlp-endpoint is not a valid URL: %w", err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return errors.New("--tracing-otlp-endpoint must be an http or https URL")
+	}
+	if c.TracingOTLPServiceName == "" {
+		return errors.New("--tracing-otlp-service-name must not be empty")
+	}
 	return nil
 }
 
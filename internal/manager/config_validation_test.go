@@ -54,6 +54,19 @@ func TestConfigValidatedVars(t *testing.T) {
 				ExpectedErrorContains: "the expected format is namespace/name",
 			},
 		},
+		"--kong-admin-svc": {
+			{
+				Input: "kong/kong-admin",
+				ExtractValueFn: func(c manager.Config) any {
+					return c.KongAdminSvc
+				},
+				ExpectedValue: types.NamespacedName{Namespace: "kong", Name: "kong-admin"},
+			},
+			{
+				Input:                 "kong-admin",
+				ExpectedErrorContains: "the expected format is namespace/name",
+			},
+		},
 	}
 
 	for flag, flagTestCases := range testCasesGroupedByFlag {
@@ -205,5 +218,99 @@ func TestConfigValidate(t *testing.T) {
 			c.KongAdminAPIConfig.TLSClient.KeyFile = "non-empty-path"
 			require.NoError(t, c.Validate())
 		})
+
+		t.Run("service discovery is allowed", func(t *testing.T) {
+			var c manager.Config
+			require.NoError(t, c.FlagSet().Parse([]string{"--kong-admin-svc", "kong/kong-admin"}))
+			require.NoError(t, c.Validate())
+		})
+
+		t.Run("service discovery together with admin urls is rejected", func(t *testing.T) {
+			var c manager.Config
+			require.NoError(t, c.FlagSet().Parse([]string{
+				"--kong-admin-svc", "kong/kong-admin",
+				"--kong-admin-url", "http://localhost:8001",
+			}))
+			require.ErrorContains(t, c.Validate(), "--kong-admin-url and --kong-admin-svc can't be used together")
+		})
+
+		t.Run("service discovery without port names is rejected", func(t *testing.T) {
+			c := manager.Config{
+				KongAdminSvc: types.NamespacedName{Namespace: "kong", Name: "kong-admin"},
+			}
+			require.ErrorContains(t, c.Validate(), "no port names provided for --kong-admin-svc")
+		})
+
+		t.Run("readiness quorum out of range is rejected", func(t *testing.T) {
+			var c manager.Config
+			require.NoError(t, c.FlagSet().Parse([]string{"--proxy-readiness-quorum", "1.5"}))
+			require.ErrorContains(t, c.Validate(), "--proxy-readiness-quorum must be between 0 and 1")
+		})
+
+		t.Run("readiness quorum of a majority is accepted", func(t *testing.T) {
+			var c manager.Config
+			require.NoError(t, c.FlagSet().Parse([]string{"--proxy-readiness-quorum", "0.5"}))
+			require.NoError(t, c.Validate())
+		})
+	})
+
+	t.Run("proxy sync", func(t *testing.T) {
+		t.Run("event-driven sync with defaults is accepted", func(t *testing.T) {
+			var c manager.Config
+			require.NoError(t, c.FlagSet().Parse([]string{"--proxy-event-driven-sync"}))
+			require.NoError(t, c.Validate())
+		})
+
+		t.Run("max latency lower than min interval is rejected", func(t *testing.T) {
+			var c manager.Config
+			require.NoError(t, c.FlagSet().Parse([]string{
+				"--proxy-event-driven-sync",
+				"--proxy-sync-min-interval", "2s",
+				"--proxy-sync-max-latency", "1s",
+			}))
+			require.ErrorContains(t, c.Validate(), "--proxy-sync-max-latency can't be lower than --proxy-sync-min-interval")
+		})
+
+		t.Run("non-positive min interval is rejected", func(t *testing.T) {
+			var c manager.Config
+			require.NoError(t, c.FlagSet().Parse([]string{"--proxy-event-driven-sync", "--proxy-sync-min-interval", "0s"}))
+			require.ErrorContains(t, c.Validate(), "--proxy-sync-min-interval must be positive")
+		})
+	})
+
+	t.Run("diagnostics", func(t *testing.T) {
+		t.Run("config dumps with default history size are accepted", func(t *testing.T) {
+			var c manager.Config
+			require.NoError(t, c.FlagSet().Parse([]string{"--dump-config"}))
+			require.NoError(t, c.Validate())
+		})
+
+		t.Run("empty config dump history is rejected", func(t *testing.T) {
+			var c manager.Config
+			require.NoError(t, c.FlagSet().Parse([]string{"--dump-config", "--dump-config-history-size", "0"}))
+			require.ErrorContains(t, c.Validate(), "--dump-config-history-size must be at least 1")
+		})
+	})
+
+	t.Run("tracing", func(t *testing.T) {
+		t.Run("OTLP HTTP endpoint is accepted", func(t *testing.T) {
+			var c manager.Config
+			require.NoError(t, c.FlagSet().Parse([]string{"--tracing-otlp-endpoint", "http://otel-collector:4318/v1/traces"}))
+			require.NoError(t, c.Validate())
+		})
+
+		t.Run("OTLP endpoint which is not an HTTP URL is rejected", func(t *testing.T) {
+			var c manager.Config
+			require.NoError(t, c.FlagSet().Parse([]string{"--tracing-otlp-endpoint", "otel-collector:4317"}))
+			require.ErrorContains(t, c.Validate(), "--tracing-otlp-endpoint must be an http or https URL")
+		})
+
+		t.Run("empty service name is rejected", func(t *testing.T) {
+			var c manager.Config
+			require.NoError(t, c.FlagSet().Parse([]string{
+				"--tracing-otlp-endpoint", "https://otel-collector:4318/v1/traces", "--tracing-otlp-service-name", "",
+			}))
+			require.ErrorContains(t, c.Validate(), "--tracing-otlp-service-name must not be empty")
+		})
 	})
 }
@@ -2,9 +2,11 @@ package manager
 
 import (
 	"fmt"
+	"os"
 	"reflect"
 
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/sets"
 	knativev1alpha1 "knative.dev/networking/pkg/apis/networking/v1alpha1"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
@@ -81,9 +83,39 @@ func setupControllers(
 		restMapper,
 	)
 
+	// GatewayClassParameters are only referenced by the GatewayClasses of the Gateway API controllers.
+	gatewayClassParametersEnabled := featureGates[gatewayFeature] && ShouldEnableCRDController(
+		schema.GroupVersionResource{
+			Group:    konghqcomv1alpha1.GroupVersion.Group,
+			Version:  konghqcomv1alpha1.GroupVersion.Version,
+			Resource: "gatewayclassparameterses",
+		},
+		restMapper,
+	)
+
 	referenceIndexers := ctrlref.NewCacheIndexers()
 
+	kongAdminAPIClientFactory, err := adminAPIClientFactory(c)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Kong Admin API client factory: %w", err)
+	}
+
 	controllers := []ControllerDef{
+		// ---------------------------------------------------------------------------
+		// Kong Admin API Discovery Controllers
+		// ---------------------------------------------------------------------------
+		{
+			Enabled: c.KongAdminSvc.Name != "",
+			Controller: &configuration.KongAdminAPIServiceReconciler{
+				Client:           mgr.GetClient(),
+				Log:              ctrl.Log.WithName("controllers").WithName("KongAdminAPIService"),
+				CacheSyncTimeout: c.CacheSyncTimeout,
+				ServiceNN:        c.KongAdminSvc,
+				PortNames:        sets.New(c.KongAdminSvcPortNames...),
+				ClientFactory:    kongAdminAPIClientFactory,
+				ClientsManager:   dataplaneClient,
+			},
+		},
 		// ---------------------------------------------------------------------------
 		// Core API Controllers
 		// ---------------------------------------------------------------------------
@@ -258,6 +290,16 @@ func setupControllers(
 				CacheSyncTimeout: c.CacheSyncTimeout,
 			},
 		},
+		{
+			Enabled: gatewayClassParametersEnabled,
+			Controller: &configuration.KongV1Alpha1GatewayClassParametersReconciler{
+				Client:           mgr.GetClient(),
+				Log:              ctrl.Log.WithName("controllers").WithName("GatewayClassParameters"),
+				Scheme:           mgr.GetScheme(),
+				DataplaneClient:  dataplaneClient,
+				CacheSyncTimeout: c.CacheSyncTimeout,
+			},
+		},
 		{
 			Enabled: c.KongPluginEnabled && ShouldEnableCRDController(
 				schema.GroupVersionResource{
@@ -345,6 +387,34 @@ func setupControllers(
 			},
 		},
 		// ---------------------------------------------------------------------------
+		// Policy Attachment Controllers
+		// ---------------------------------------------------------------------------
+		{
+			Enabled: c.KongPluginEnabled && ShouldEnableCRDController(
+				schema.GroupVersionResource{
+					Group:    konghqcomv1.GroupVersion.Group,
+					Version:  konghqcomv1.GroupVersion.Version,
+					Resource: "kongplugins",
+				},
+				restMapper,
+			),
+			Controller: &gateway.KongPluginPolicyReconciler{
+				Client:           mgr.GetClient(),
+				Log:              ctrl.Log.WithName("controllers").WithName("KongPluginPolicy"),
+				Scheme:           mgr.GetScheme(),
+				CacheSyncTimeout: c.CacheSyncTimeout,
+				// the policies targeting Services are supported regardless of the Gateway API support.
+				EnableGatewayAPI: featureGates[gatewayFeature] && ShouldEnableCRDController(
+					schema.GroupVersionResource{
+						Group:    gatewayv1beta1.GroupVersion.Group,
+						Version:  gatewayv1beta1.GroupVersion.Version,
+						Resource: "httproutes",
+					},
+					restMapper,
+				),
+			},
+		},
+		// ---------------------------------------------------------------------------
 		// Gateway API Controllers - Beta APIs
 		// ---------------------------------------------------------------------------
 		{
@@ -357,15 +427,20 @@ func setupControllers(
 				restMapper,
 			),
 			Controller: &gateway.GatewayReconciler{
-				Client:               mgr.GetClient(),
-				Log:                  ctrl.Log.WithName("controllers").WithName(gatewayFeature),
-				Scheme:               mgr.GetScheme(),
-				DataplaneClient:      dataplaneClient,
-				PublishService:       c.PublishService.String(),
-				WatchNamespaces:      c.WatchNamespaces,
-				EnableReferenceGrant: referenceGrantsEnabled,
-				CacheSyncTimeout:     c.CacheSyncTimeout,
-				ReferenceIndexers:    referenceIndexers,
+				Client:                       mgr.GetClient(),
+				Log:                          ctrl.Log.WithName("controllers").WithName(gatewayFeature),
+				Scheme:                       mgr.GetScheme(),
+				DataplaneClient:              dataplaneClient,
+				PublishService:               c.PublishService.String(),
+				WatchNamespaces:              c.WatchNamespaces,
+				EnableReferenceGrant:         referenceGrantsEnabled,
+				EnableGatewayClassParameters: gatewayClassParametersEnabled,
+				CacheSyncTimeout:             c.CacheSyncTimeout,
+				ReferenceIndexers:            referenceIndexers,
+				ManagedGatewayClass:          c.ManagedGatewayClass,
+				ManagedGatewayKongImage:      c.ManagedGatewayKongImage,
+				// the controller's manifests expose its namespace to its pods as POD_NAMESPACE.
+				ControllerNamespace: os.Getenv("POD_NAMESPACE"),
 			},
 		},
 		{
@@ -0,0 +1,116 @@
+package manager
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/cluster"
+
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/util"
+)
+
+// -----------------------------------------------------------------------------
+// Controller Manager - Dry Run
+// -----------------------------------------------------------------------------
+
+// newDryRunClientFunc builds the clients of the controller manager in dry-run mode: the controllers
+// read Kubernetes objects as usual, but their writes (including status updates, the provisioning of
+// managed Gateways and the policies attached to KongPlugins) never reach the API server.
+func newDryRunClientFunc(logger logr.Logger) cluster.NewClientFunc {
+	return func(cache cache.Cache, config *rest.Config, options client.Options, uncachedObjects ...client.Object) (client.Client, error) {
+		cl, err := cluster.DefaultNewClient(cache, config, options, uncachedObjects...)
+		if err != nil {
+			return nil, err
+		}
+		return &dryRunClient{Client: cl, logger: logger}, nil
+	}
+}
+
+// dryRunClient is a Kubernetes client which discards writes.
+type dryRunClient struct {
+	client.Client
+	logger logr.Logger
+}
+
+func (c *dryRunClient) Create(_ context.Context, obj client.Object, _ ...client.CreateOption) error {
+	c.discard("create", obj)
+	return nil
+}
+
+func (c *dryRunClient) Update(_ context.Context, obj client.Object, _ ...client.UpdateOption) error {
+	c.discard("update", obj)
+	return nil
+}
+
+func (c *dryRunClient) Patch(_ context.Context, obj client.Object, _ client.Patch, _ ...client.PatchOption) error {
+	c.discard("patch", obj)
+	return nil
+}
+
+func (c *dryRunClient) Delete(_ context.Context, obj client.Object, _ ...client.DeleteOption) error {
+	c.discard("delete", obj)
+	return nil
+}
+
+func (c *dryRunClient) DeleteAllOf(_ context.Context, obj client.Object, _ ...client.DeleteAllOfOption) error {
+	c.discard("delete all of", obj)
+	return nil
+}
+
+func (c *dryRunClient) Status() client.SubResourceWriter {
+	return c.SubResource("status")
+}
+
+func (c *dryRunClient) SubResource(subResource string) client.SubResourceClient {
+	return &dryRunSubResourceClient{
+		SubResourceClient: c.Client.SubResource(subResource),
+		client:            c,
+		subResource:       subResource,
+	}
+}
+
+func (c *dryRunClient) discard(verb string, obj client.Object) {
+	c.logger.V(util.DebugLevel).Info("dry-run mode enabled, discarding write",
+		"verb", verb, "kind", obj.GetObjectKind().GroupVersionKind().Kind,
+		"namespace", obj.GetNamespace(), "name", obj.GetName())
+}
+
+// dryRunSubResourceClient is a client of a Kubernetes subresource which discards writes.
+type dryRunSubResourceClient struct {
+	client.SubResourceClient
+	client      *dryRunClient
+	subResource string
+}
+
+func (c *dryRunSubResourceClient) Create(_ context.Context, obj client.Object, _ client.Object, _ ...client.SubResourceCreateOption) error {
+	c.client.discard("create "+c.subResource, obj)
+	return nil
+}
+
+func (c *dryRunSubResourceClient) Update(_ context.Context, obj client.Object, _ ...client.SubResourceUpdateOption) error {
+	c.client.discard("update "+c.subResource, obj)
+	return nil
+}
+
+func (c *dryRunSubResourceClient) Patch(_ context.Context, obj client.Object, _ client.Patch, _ ...client.SubResourcePatchOption) error {
+	c.client.discard("patch "+c.subResource, obj)
+	return nil
+}
+
+// dryRunEventRecorder is an event recorder which discards the events, as recording them writes
+// Event objects.
+type dryRunEventRecorder struct{}
+
+var _ record.EventRecorder = dryRunEventRecorder{}
+
+func (dryRunEventRecorder) Event(runtime.Object, string, string, string) {}
+
+func (dryRunEventRecorder) Eventf(runtime.Object, string, string, string, ...interface{}) {}
+
+func (dryRunEventRecorder) AnnotatedEventf(runtime.Object, map[string]string, string, string, string, ...interface{}) {
+}
@@ -0,0 +1,90 @@
+package manager
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/cluster"
+	gatewayv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+)
+
+func TestDryRunControllerOptions(t *testing.T) {
+	var (
+		lock     sync.Mutex
+		requests []string
+	)
+	apiServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		lock.Lock()
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		lock.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"apiVersion":"v1","kind":"Service","metadata":{"namespace":"default","name":"kong"}}`))
+	}))
+	defer apiServer.Close()
+	recordedRequests := func() []string {
+		lock.Lock()
+		defer lock.Unlock()
+		recorded := requests
+		requests = nil
+		return recorded
+	}
+
+	c := &Config{DryRun: true}
+	opts, err := setupControllerOptions(logr.Discard(), c, "off")
+	require.NoError(t, err)
+	assert.False(t, opts.LeaderElection, "leader election must be disabled in dry-run mode")
+	c.Konnect.ConfigSynchronizationEnabled = true
+	assert.False(t, leaderElectionEnabled(logr.Discard(), c, "postgres"), "leader election must be disabled in dry-run mode")
+
+	mapper := meta.NewDefaultRESTMapper(nil)
+	mapper.Add(corev1.SchemeGroupVersion.WithKind("Service"), meta.RESTScopeNamespace)
+	mapper.Add(appsv1.SchemeGroupVersion.WithKind("Deployment"), meta.RESTScopeNamespace)
+	mapper.AddSpecific(gatewayv1beta1.SchemeGroupVersion.WithKind("Gateway"),
+		gatewayv1beta1.SchemeGroupVersion.WithResource("gateways"),
+		gatewayv1beta1.SchemeGroupVersion.WithResource("gateway"),
+		meta.RESTScopeNamespace,
+	)
+	cl, err := opts.NewClient(nil, &rest.Config{Host: apiServer.URL}, client.Options{Scheme: opts.Scheme, Mapper: mapper}, &corev1.Service{})
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	t.Log("verifying that reads reach the API server")
+	service := &corev1.Service{}
+	require.NoError(t, cl.Get(ctx, client.ObjectKey{Namespace: "default", Name: "kong"}, service))
+	assert.Equal(t, "kong", service.Name)
+	assert.Equal(t, []string{"GET /api/v1/namespaces/default/services/kong"}, recordedRequests())
+
+	t.Log("verifying that no write reaches the API server")
+	gateway := &gatewayv1beta1.Gateway{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "kong"}}
+	deployment := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "kong"}}
+	require.NoError(t, cl.Create(ctx, deployment))
+	require.NoError(t, cl.Update(ctx, gateway))
+	require.NoError(t, cl.Patch(ctx, gateway, client.MergeFrom(gateway.DeepCopy())))
+	require.NoError(t, cl.Delete(ctx, deployment))
+	require.NoError(t, cl.DeleteAllOf(ctx, &appsv1.Deployment{}, client.InNamespace("default")))
+	require.NoError(t, cl.Status().Update(ctx, gateway))
+	require.NoError(t, cl.Status().Patch(ctx, gateway, client.MergeFrom(gateway.DeepCopy())))
+	require.NoError(t, cl.SubResource("status").Update(ctx, gateway))
+	assert.Empty(t, recordedRequests())
+
+	t.Log("verifying that the default client, writing to the API server, is used outside of dry-run mode")
+	opts, err = setupControllerOptions(logr.Discard(), &Config{}, "off")
+	require.NoError(t, err)
+	assert.Nil(t, opts.NewClient)
+	cl, err = cluster.DefaultNewClient(nil, &rest.Config{Host: apiServer.URL}, client.Options{Scheme: opts.Scheme, Mapper: mapper})
+	require.NoError(t, err)
+	require.NoError(t, cl.Status().Update(ctx, gateway))
+	assert.Equal(t, []string{"PUT /apis/gateway.networking.k8s.io/v1beta1/namespaces/default/gateways/kong/status"}, recordedRequests())
+}
@@ -26,6 +26,11 @@ const (
 	// objects like Ingress instead of creating a route per path.
 	combinedRoutesFeature = "CombinedRoutes"
 
+	// incrementalTranslationFeature is the name of the feature-gate for reusing the
+	// translations of the objects which have not changed since the previous sync
+	// instead of translating all the objects on every sync.
+	incrementalTranslationFeature = "IncrementalTranslation"
+
 	// featureGatesDocsURL provides a link to the documentation for feature gates in the KIC repository.
 	featureGatesDocsURL = "https://github.com/Kong/kubernetes-ingress-controller/blob/main/FEATURE_GATES.md"
 )
@@ -55,9 +60,10 @@ func setupFeatureGates(setupLog logr.Logger, featureGates map[string]bool) (map[
 // NOTE: if you're adding a new feature gate, it needs to be added here.
 func getFeatureGatesDefaults() map[string]bool {
 	return map[string]bool{
-		knativeFeature:        false,
-		gatewayFeature:        true,
-		gatewayAlphaFeature:   false,
-		combinedRoutesFeature: true,
+		knativeFeature:                false,
+		gatewayFeature:                true,
+		gatewayAlphaFeature:           false,
+		combinedRoutesFeature:         true,
+		incrementalTranslationFeature: false,
 	}
 }
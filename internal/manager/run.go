@@ -12,6 +12,7 @@ import (
 	"github.com/sirupsen/logrus"
 	"k8s.io/apimachinery/pkg/runtime"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
 	knativev1alpha1 "knative.dev/networking/pkg/apis/networking/v1alpha1"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
@@ -24,6 +25,7 @@ import (
 	"github.com/kong/kubernetes-ingress-controller/v2/internal/manager/metadata"
 	mgrutils "github.com/kong/kubernetes-ingress-controller/v2/internal/manager/utils"
 	"github.com/kong/kubernetes-ingress-controller/v2/internal/manager/utils/kongconfig"
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/tracing"
 	"github.com/kong/kubernetes-ingress-controller/v2/internal/util"
 	"github.com/kong/kubernetes-ingress-controller/v2/internal/util/kubernetes/object/status"
 	"github.com/kong/kubernetes-ingress-controller/v2/internal/versions"
@@ -48,6 +50,21 @@ func Run(ctx context.Context, c *Config, diagnostic util.ConfigDumpDiagnostic, d
 		return fmt.Errorf("failed to configure feature gates: %w", err)
 	}
 
+	if c.TracingOTLPEndpoint != "" {
+		setupLog.Info("exporting traces", "endpoint", c.TracingOTLPEndpoint)
+		shutdownTracing, err := tracing.Setup(ctx, ctrl.Log.WithName("tracing"), c.TracingOTLPEndpoint, c.TracingOTLPServiceName)
+		if err != nil {
+			return fmt.Errorf("failed to set up tracing: %w", err)
+		}
+		defer func() {
+			shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			if err := shutdownTracing(shutdownCtx); err != nil { //nolint:contextcheck
+				setupLog.Error(err, "failed to export the remaining traces")
+			}
+		}()
+	}
+
 	setupLog.Info("getting the kubernetes client configuration")
 	kubeconfig, err := c.GetKubeconfig()
 	if err != nil {
@@ -57,7 +74,7 @@ func Run(ctx context.Context, c *Config, diagnostic util.ConfigDumpDiagnostic, d
 	if c.KongAdminToken != "" {
 		c.KongAdminAPIConfig.Headers = append(c.KongAdminAPIConfig.Headers, "kong-admin-token:"+c.KongAdminToken)
 	}
-	kongClients, err := getKongClients(ctx, c)
+	kongClients, err := getKongClients(ctx, c, kubeconfig, setupLog)
 	if err != nil {
 		return fmt.Errorf("unable to build kong api client(s): %w", err)
 	}
@@ -71,6 +88,7 @@ func Run(ctx context.Context, c *Config, diagnostic util.ConfigDumpDiagnostic, d
 	if err != nil {
 		return fmt.Errorf("could not validate Kong admin root(s) configuration: %w", err)
 	}
+	routerFlavor := kongconfig.RouterFlavorFromRoot(kongRoots[0])
 	semV := semver.Version{Major: v.Major(), Minor: v.Minor(), Patch: v.Patch()}
 	versions.SetKongVersion(semV)
 	kongConfig := sendconfig.New(ctx, setupLog, kongClients, semV, dbMode, c.Concurrency, c.FilterTags)
@@ -85,13 +103,11 @@ func Run(ctx context.Context, c *Config, diagnostic util.ConfigDumpDiagnostic, d
 		return fmt.Errorf("unable to start controller manager: %w", err)
 	}
 
-	setupLog.Info("Starting Admission Server")
-	if err := setupAdmissionServer(ctx, c, mgr.GetClient(), deprecatedLogger); err != nil {
-		return err
-	}
-
 	setupLog.Info("Initializing Dataplane Client")
-	eventRecorder := mgr.GetEventRecorderFor(KongClientEventRecorderComponentName)
+	var eventRecorder record.EventRecorder = dryRunEventRecorder{}
+	if !c.DryRun {
+		eventRecorder = mgr.GetEventRecorderFor(KongClientEventRecorderComponentName)
+	}
 	dataplaneClient, err := dataplane.NewKongClient(
 		deprecatedLogger,
 		time.Duration(c.ProxyTimeoutSeconds*float32(time.Second)),
@@ -107,17 +123,54 @@ func Run(ctx context.Context, c *Config, diagnostic util.ConfigDumpDiagnostic, d
 		return fmt.Errorf("failed to initialize kong data-plane client: %w", err)
 	}
 
+	setupLog.Info("Starting Admission Server")
+	if err := setupAdmissionServer(ctx, c, mgr.GetClient(), dataplaneClient, routerFlavor, deprecatedLogger); err != nil {
+		return err
+	}
+
 	setupLog.Info("Initializing Dataplane Synchronizer")
-	synchronizer, err := setupDataplaneSynchronizer(setupLog, deprecatedLogger, mgr, dataplaneClient, c.ProxySyncSeconds)
+	synchronizer, err := setupDataplaneSynchronizer(setupLog, deprecatedLogger, mgr, dataplaneClient, c)
 	if err != nil {
 		return fmt.Errorf("unable to initialize dataplane synchronizer: %w", err)
 	}
 
+	dataplaneClient.SetReadinessQuorum(c.ProxyReadinessQuorum)
+
 	if enabled, ok := featureGates[combinedRoutesFeature]; ok && enabled {
 		dataplaneClient.EnableCombinedServiceRoutes()
 		setupLog.Info("combined routes mode has been enabled")
 	}
 
+	if routerFlavor == kongconfig.RouterFlavorExpressions {
+		dataplaneClient.EnableExpressionRoutes()
+		setupLog.Info("Kong runs the expressions router, HTTPRoutes will be translated into expression routes")
+	}
+
+	// the HTTPRoute filters which Kong has no plugin for are translated into pre-function plugins,
+	// which can only be used when Kong allows them to run the Lua code they're generated with.
+	untrustedLua := kongconfig.UntrustedLuaFromRoot(kongRoots[0])
+	if untrustedLua.Enabled() {
+		dataplaneClient.EnablePreFunctionFilters()
+	} else {
+		setupLog.Info("Kong's untrusted_lua setting is off, HTTPRoute RequestRedirect, URLRewrite and RequestMirror filters will fail translation")
+	}
+	if untrustedLua.CanRequire("resty.http") && untrustedLua.CanAccess("ngx.timer.at") {
+		dataplaneClient.EnableRequestMirrorFilters()
+	} else if untrustedLua.Enabled() {
+		setupLog.Info("Kong's Lua sandbox doesn't allow requiring resty.http and accessing ngx.timer, HTTPRoute RequestMirror filters will fail translation")
+	}
+
+	if enabled, ok := featureGates[incrementalTranslationFeature]; ok && enabled {
+		dataplaneClient.EnableTranslationCache()
+		setupLog.Info("incremental translation has been enabled")
+	}
+
+	if c.DryRun {
+		dataplaneClient.EnableDryRun()
+		setupLog.Info("dry-run mode has been enabled, configuration will not be applied to Kong nor Kubernetes objects written")
+		c.UpdateStatus = false
+	}
+
 	var kubernetesStatusQueue *status.Queue
 	if c.UpdateStatus {
 		setupLog.Info("Starting Status Updater")
@@ -7,12 +7,15 @@ import (
 	"io"
 	"time"
 
+	"github.com/avast/retry-go/v4"
 	"github.com/bombsimon/logrusr/v2"
 	"github.com/go-logr/logr"
 	"github.com/kong/deck/cprint"
 	"github.com/sirupsen/logrus"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/rest"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/cache"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -20,7 +23,9 @@ import (
 
 	"github.com/kong/kubernetes-ingress-controller/v2/internal/adminapi"
 	"github.com/kong/kubernetes-ingress-controller/v2/internal/admission"
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/controllers/configuration"
 	"github.com/kong/kubernetes-ingress-controller/v2/internal/dataplane"
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/manager/utils/kongconfig"
 	"github.com/kong/kubernetes-ingress-controller/v2/internal/util"
 )
 
@@ -89,6 +94,11 @@ func setupControllerOptions(logger logr.Logger, c *Config, dbmode string) (ctrl.
 		if c.PublishService.String() != "" {
 			watchNamespaces = append(c.WatchNamespaces, c.PublishService.Namespace)
 		}
+		// similarly the namespace of the Kong Admin API Service has to be watched so
+		// that its endpoints can be discovered.
+		if c.KongAdminSvc.Name != "" {
+			watchNamespaces = append(watchNamespaces, c.KongAdminSvc.Namespace)
+		}
 		controllerOpts.NewCache = cache.MultiNamespacedCacheBuilder(watchNamespaces)
 	}
 
@@ -96,10 +106,21 @@ func setupControllerOptions(logger logr.Logger, c *Config, dbmode string) (ctrl.
 		controllerOpts.LeaderElectionNamespace = c.LeaderElectionNamespace
 	}
 
+	if c.DryRun {
+		logger.Info("dry-run mode enabled, writes to Kubernetes objects will be discarded")
+		controllerOpts.NewClient = newDryRunClientFunc(logger.WithName("dry-run"))
+	}
+
 	return controllerOpts, nil
 }
 
 func leaderElectionEnabled(logger logr.Logger, c *Config, dbmode string) bool {
+	// a dry-run instance must neither take over nor hold up the instances applying configuration.
+	if c.DryRun {
+		logger.Info("dry-run mode enabled, disabling leader election")
+		return false
+	}
+
 	if c.Konnect.ConfigSynchronizationEnabled {
 		logger.Info("Konnect config synchronisation enabled, enabling leader election")
 		return true
@@ -119,20 +140,28 @@ func setupDataplaneSynchronizer(
 	fieldLogger logrus.FieldLogger,
 	mgr manager.Manager,
 	dataplaneClient dataplane.Client,
-	proxySyncSeconds float32,
+	c *Config,
 ) (*dataplane.Synchronizer, error) {
-	if proxySyncSeconds < dataplane.DefaultSyncSeconds {
+	if c.ProxySyncSeconds < dataplane.DefaultSyncSeconds {
 		logger.Info(fmt.Sprintf(
 			"WARNING: --proxy-sync-seconds is configured for %fs, in DBLESS mode this may result in"+
 				" problems of inconsistency in the proxy state. For DBLESS mode %fs+ is recommended (3s is the default).",
-			proxySyncSeconds, dataplane.DefaultSyncSeconds,
+			c.ProxySyncSeconds, dataplane.DefaultSyncSeconds,
 		))
 	}
 
+	opts := []dataplane.SynchronizerOption{
+		dataplane.WithStagger(time.Duration(c.ProxySyncSeconds * float32(time.Second))),
+	}
+	if c.ProxyEventDrivenSync {
+		logger.Info("event-driven proxy sync has been enabled",
+			"min_interval", c.ProxySyncMinInterval.String(), "max_latency", c.ProxySyncMaxLatency.String())
+		opts = append(opts, dataplane.WithEventDrivenSync(c.ProxySyncMinInterval, c.ProxySyncMaxLatency))
+	}
 	dataplaneSynchronizer, err := dataplane.NewSynchronizer(
 		fieldLogger.WithField("subsystem", "dataplane-synchronizer"),
 		dataplaneClient,
-		dataplane.WithStagger(time.Duration(proxySyncSeconds*float32(time.Second))),
+		opts...,
 	)
 	if err != nil {
 		return nil, err
@@ -150,6 +179,8 @@ func setupAdmissionServer(
 	ctx context.Context,
 	managerConfig *Config,
 	managerClient client.Client,
+	clientsProvider admission.AdminAPIClientsProvider,
+	routerFlavor string,
 	deprecatedLogger logrus.FieldLogger,
 ) error {
 	logger := deprecatedLogger.WithField("component", "admission-server")
@@ -159,25 +190,13 @@ func setupAdmissionServer(
 		return nil
 	}
 
-	kongclients, err := getKongClients(ctx, managerConfig)
-	if err != nil {
-		return err
-	}
 	srv, err := admission.MakeTLSServer(ctx, &managerConfig.AdmissionServer, &admission.RequestHandler{
 		Validator: admission.NewKongHTTPValidator(
-			// For now using first client is kind of OK. Using Consumer and Plugin
-			// services from first kong client should theoretically return the same
-			// results as for all other clients. There might be instances where
-			// configurations in different Kong Gateways are ever so slightly
-			// different but that shouldn't cause a fatal failure.
-			//
-			// TODO: We should take a look at this sooner rather than later.
-			// https://github.com/Kong/kubernetes-ingress-controller/issues/3363
-			kongclients[0].Consumers,
-			kongclients[0].Plugins,
+			admission.NewDefaultAdminAPIServicesProvider(clientsProvider),
 			logger,
 			managerClient,
 			managerConfig.IngressClassName,
+			routerFlavor == kongconfig.RouterFlavorExpressions,
 		),
 		Logger: logger,
 	}, logger)
@@ -258,16 +277,25 @@ func generateAddressFinderGetter(mgrc client.Client, publishServiceNn types.Name
 	}
 }
 
-// getKongClients returns the kong clients.
-func getKongClients(ctx context.Context, cfg *Config) ([]*adminapi.Client, error) {
-	httpclient, err := adminapi.MakeHTTPClient(&cfg.KongAdminAPIConfig)
+// getKongClients returns the kong clients. When the Kong Admin API Service is provided, clients are
+// created for its ready endpoints, otherwise for the provided Kong Admin API URLs.
+func getKongClients(ctx context.Context, cfg *Config, kubeconfig *rest.Config, log logr.Logger) ([]*adminapi.Client, error) {
+	clientFactory, err := adminAPIClientFactory(cfg)
 	if err != nil {
 		return nil, err
 	}
 
-	clients := make([]*adminapi.Client, 0, len(cfg.KongAdminURL))
-	for _, url := range cfg.KongAdminURL {
-		client, err := adminapi.NewKongClientForWorkspace(ctx, url, cfg.KongWorkspace, httpclient)
+	urls := cfg.KongAdminURL
+	if cfg.KongAdminSvc.Name != "" {
+		urls, err = discoverKongAdminURLs(ctx, cfg, kubeconfig, log)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	clients := make([]*adminapi.Client, 0, len(urls))
+	for _, url := range urls {
+		client, err := clientFactory(ctx, url)
 		if err != nil {
 			return nil, err
 		}
@@ -276,3 +304,55 @@ func getKongClients(ctx context.Context, cfg *Config) ([]*adminapi.Client, error
 
 	return clients, nil
 }
+
+// adminAPIClientFactory returns a function creating Kong Admin API clients configured according to the
+// provided config.
+func adminAPIClientFactory(cfg *Config) (configuration.AdminAPIClientFactory, error) {
+	httpclient, err := adminapi.MakeHTTPClient(&cfg.KongAdminAPIConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(ctx context.Context, url string) (*adminapi.Client, error) {
+		return adminapi.NewKongClientForWorkspace(ctx, url, cfg.KongWorkspace, httpclient)
+	}, nil
+}
+
+// discoverKongAdminURLs returns the Kong Admin API URLs of the ready endpoints of the Kong Admin API Service.
+// As the Service might have no ready endpoints yet, the discovery is retried until at least one is found.
+func discoverKongAdminURLs(ctx context.Context, cfg *Config, kubeconfig *rest.Config, log logr.Logger) ([]string, error) {
+	kubeClient, err := client.New(kubeconfig, client.Options{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create kubernetes client: %w", err)
+	}
+
+	var urls []string
+	err = retry.Do(
+		func() error {
+			discovered, err := adminapi.GetURLsForService(ctx, kubeClient, cfg.KongAdminSvc, sets.New(cfg.KongAdminSvcPortNames...))
+			if err != nil {
+				return err
+			}
+			if discovered.Len() == 0 {
+				return fmt.Errorf("no ready endpoints found for Kong Admin API Service %s", cfg.KongAdminSvc)
+			}
+			urls = sets.List(discovered)
+			return nil
+		},
+		retry.Context(ctx),
+		retry.Attempts(cfg.KongAdminInitializationRetries),
+		retry.Delay(cfg.KongAdminInitializationRetryDelay),
+		retry.DelayType(retry.FixedDelay),
+		retry.LastErrorOnly(true),
+		retry.OnRetry(func(n uint, err error) {
+			log.Info("Retrying Kong Admin API discovery after error",
+				"retries", fmt.Sprintf("%d/%d", n, cfg.KongAdminInitializationRetries),
+				"error", err.Error(),
+			)
+		}),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to discover Kong Admin API endpoints: %w", err)
+	}
+	return urls, nil
+}
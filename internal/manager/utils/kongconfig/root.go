@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
 	"sync"
 	"time"
 
@@ -73,6 +74,105 @@ func KongVersionFromRoot(r Root) (kong.Version, error) {
 	return kv, nil
 }
 
+// Router flavors Kong can be configured with, see the router_flavor setting of Kong 3.x.
+const (
+	RouterFlavorTraditional           = "traditional"
+	RouterFlavorTraditionalCompatible = "traditional_compatible"
+	RouterFlavorExpressions           = "expressions"
+)
+
+// RouterFlavorFromRoot returns the router flavor of the provided configuration root.
+// Kong versions which don't report it (older than 3.0) only have the traditional router.
+func RouterFlavorFromRoot(r Root) string {
+	rootConfig, ok := r["configuration"].(map[string]any)
+	if !ok {
+		return RouterFlavorTraditional
+	}
+	flavor, ok := rootConfig["router_flavor"].(string)
+	if !ok || flavor == "" {
+		return RouterFlavorTraditional
+	}
+	return flavor
+}
+
+// Modes of the untrusted_lua setting of Kong, restricting the Lua code run by the serverless plugins (e.g. pre-function).
+const (
+	UntrustedLuaOn      = "on"
+	UntrustedLuaOff     = "off"
+	UntrustedLuaSandbox = "sandbox"
+)
+
+// UntrustedLua holds the settings of Kong restricting the Lua code run by the serverless plugins.
+type UntrustedLua struct {
+	Mode               string
+	SandboxRequires    []string
+	SandboxEnvironment []string
+}
+
+// UntrustedLuaFromRoot returns the untrusted Lua settings of the provided configuration root.
+// Kong runs the untrusted Lua code in a sandbox unless configured otherwise.
+func UntrustedLuaFromRoot(r Root) UntrustedLua {
+	settings := UntrustedLua{Mode: UntrustedLuaSandbox}
+	rootConfig, ok := r["configuration"].(map[string]any)
+	if !ok {
+		return settings
+	}
+	if mode, ok := rootConfig["untrusted_lua"].(string); ok && mode != "" {
+		settings.Mode = mode
+	}
+	settings.SandboxRequires = stringsFromRootList(rootConfig["untrusted_lua_sandbox_requires"])
+	settings.SandboxEnvironment = stringsFromRootList(rootConfig["untrusted_lua_sandbox_environment"])
+	return settings
+}
+
+// stringsFromRootList returns the strings of a list setting of a configuration root. Kong encodes
+// the empty lists as empty objects, which have no strings.
+func stringsFromRootList(v any) []string {
+	list, ok := v.([]any)
+	if !ok {
+		return nil
+	}
+	return lo.FilterMap(list, func(item any, _ int) (string, bool) {
+		s, ok := item.(string)
+		return s, ok
+	})
+}
+
+// Enabled returns true if the serverless plugins may run Lua code at all.
+func (l UntrustedLua) Enabled() bool {
+	return l.Mode != UntrustedLuaOff
+}
+
+// CanRequire returns true if the Lua code run by the serverless plugins may require the provided module.
+func (l UntrustedLua) CanRequire(module string) bool {
+	if l.Mode == UntrustedLuaOn {
+		return true
+	}
+	return l.Mode == UntrustedLuaSandbox && lo.Contains(l.SandboxRequires, module)
+}
+
+// CanAccess returns true if the Lua code run by the serverless plugins may access the provided global
+// variable (e.g. ngx.timer.at). In the sandbox, making a variable available makes its fields available too.
+func (l UntrustedLua) CanAccess(global string) bool {
+	if l.Mode == UntrustedLuaOn {
+		return true
+	}
+	if l.Mode != UntrustedLuaSandbox {
+		return false
+	}
+	for name := global; name != ""; {
+		if lo.Contains(l.SandboxEnvironment, name) {
+			return true
+		}
+		i := strings.LastIndex(name, ".")
+		if i < 0 {
+			break
+		}
+		name = name[:i]
+	}
+	return false
+}
+
 // Root represents Kong Gateway configuration root.
 type Root map[string]any
 
@@ -24,6 +24,48 @@ func TestValidateRoots(t *testing.T) {
 	assert.Equal(t, "3.1.1", v.String())
 }
 
+func TestRouterFlavorFromRoot(t *testing.T) {
+	var root Root
+	require.NoError(t, json.Unmarshal([]byte(dblessConfigJSON), &root))
+	assert.Equal(t, RouterFlavorTraditional, RouterFlavorFromRoot(root))
+
+	root["configuration"].(map[string]any)["router_flavor"] = RouterFlavorExpressions
+	assert.Equal(t, RouterFlavorExpressions, RouterFlavorFromRoot(root))
+
+	delete(root["configuration"].(map[string]any), "router_flavor")
+	assert.Equal(t, RouterFlavorTraditional, RouterFlavorFromRoot(root), "Kong 2.x doesn't report the router flavor")
+}
+
+func TestUntrustedLuaFromRoot(t *testing.T) {
+	var root Root
+	require.NoError(t, json.Unmarshal([]byte(dblessConfigJSON), &root))
+	untrustedLua := UntrustedLuaFromRoot(root)
+	assert.Equal(t, UntrustedLua{Mode: UntrustedLuaSandbox}, untrustedLua, "empty lists are encoded as objects")
+	assert.True(t, untrustedLua.Enabled())
+	assert.False(t, untrustedLua.CanRequire("resty.http"))
+	assert.False(t, untrustedLua.CanAccess("ngx.timer.at"))
+
+	rootConfig := root["configuration"].(map[string]any)
+	rootConfig["untrusted_lua_sandbox_requires"] = []any{"resty.http"}
+	rootConfig["untrusted_lua_sandbox_environment"] = []any{"ngx.timer"}
+	untrustedLua = UntrustedLuaFromRoot(root)
+	assert.True(t, untrustedLua.CanRequire("resty.http"))
+	assert.False(t, untrustedLua.CanRequire("resty.dns.client"))
+	assert.True(t, untrustedLua.CanAccess("ngx.timer.at"), "fields of the available variables are available")
+	assert.False(t, untrustedLua.CanAccess("ngx.req.set_uri"))
+
+	rootConfig["untrusted_lua"] = UntrustedLuaOn
+	untrustedLua = UntrustedLuaFromRoot(root)
+	assert.True(t, untrustedLua.CanRequire("resty.dns.client"))
+	assert.True(t, untrustedLua.CanAccess("ngx.req.set_uri"))
+
+	rootConfig["untrusted_lua"] = UntrustedLuaOff
+	untrustedLua = UntrustedLuaFromRoot(root)
+	assert.False(t, untrustedLua.Enabled())
+	assert.False(t, untrustedLua.CanRequire("resty.http"))
+	assert.False(t, untrustedLua.CanAccess("ngx.timer.at"))
+}
+
 const dblessConfigJSON = `{
 	"plugins": {
 		"enabled_in_cluster": [],
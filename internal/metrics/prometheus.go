@@ -16,6 +16,15 @@ type CtrlFuncMetrics struct {
 
 	// ConfigPushDuration is a Prometheus metric with semantics defined by its help string in NewCtrlFuncMetrics().
 	ConfigPushDuration *prometheus.HistogramVec
+
+	// InstanceConsecutiveFailures is a Prometheus metric with semantics defined by its help string in NewCtrlFuncMetrics().
+	InstanceConsecutiveFailures *prometheus.GaugeVec
+
+	// InstanceLastSuccess is a Prometheus metric with semantics defined by its help string in NewCtrlFuncMetrics().
+	InstanceLastSuccess *prometheus.GaugeVec
+
+	// DryRunEntityChanges is a Prometheus metric with semantics defined by its help string in NewCtrlFuncMetrics().
+	DryRunEntityChanges *prometheus.GaugeVec
 }
 
 const (
@@ -52,10 +61,35 @@ const (
 	FailureReasonKey string = "failure_reason"
 )
 
+const (
+	// InstanceURLKey defines the key of the metric label indicating the Kong Admin API URL of a Kong instance.
+	InstanceURLKey string = "kong_url"
+)
+
+const (
+	// OperationCreate indicates that an entity would be created.
+	OperationCreate string = "create"
+	// OperationUpdate indicates that an entity would be updated.
+	OperationUpdate string = "update"
+	// OperationDelete indicates that an entity would be deleted.
+	OperationDelete string = "delete"
+
+	// OperationKey defines the key of the metric label indicating the operation which would be performed on entities.
+	OperationKey string = "operation"
+
+	// EntityKindKey defines the key of the metric label indicating the kind of Kong entities.
+	EntityKindKey string = "entity_kind"
+)
+
 const (
 	MetricNameConfigPushCount    = "ingress_controller_configuration_push_count"
 	MetricNameTranslationCount   = "ingress_controller_translation_count"
 	MetricNameConfigPushDuration = "ingress_controller_configuration_push_duration_milliseconds"
+
+	MetricNameInstanceConsecutiveFailures = "ingress_controller_kong_instance_consecutive_push_failures"
+	MetricNameInstanceLastSuccess         = "ingress_controller_kong_instance_last_successful_push_timestamp_seconds"
+
+	MetricNameDryRunEntityChanges = "ingress_controller_dry_run_entity_changes"
 )
 
 func NewCtrlFuncMetrics() *CtrlFuncMetrics {
@@ -104,7 +138,52 @@ func NewCtrlFuncMetrics() *CtrlFuncMetrics {
 		[]string{SuccessKey, ProtocolKey},
 	)
 
-	metrics.Registry.MustRegister(controllerMetrics.ConfigPushCount, controllerMetrics.TranslationCount, controllerMetrics.ConfigPushDuration)
+	controllerMetrics.InstanceConsecutiveFailures = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: MetricNameInstanceConsecutiveFailures,
+			Help: fmt.Sprintf(
+				"Number of configuration pushes to a Kong instance which failed in a row. "+
+					"`%s` describes the Kong Admin API URL of the instance.",
+				InstanceURLKey,
+			),
+		},
+		[]string{InstanceURLKey},
+	)
+
+	controllerMetrics.InstanceLastSuccess = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: MetricNameInstanceLastSuccess,
+			Help: fmt.Sprintf(
+				"Unix time of the last successful configuration push to a Kong instance. "+
+					"`%s` describes the Kong Admin API URL of the instance.",
+				InstanceURLKey,
+			),
+		},
+		[]string{InstanceURLKey},
+	)
+
+	controllerMetrics.DryRunEntityChanges = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: MetricNameDryRunEntityChanges,
+			Help: fmt.Sprintf(
+				"Number of Kong entities the last configuration would change in a Kong instance, when running in dry-run mode. "+
+					"`%s` describes the Kong Admin API URL of the instance. "+
+					"`%s` describes the operation (`%s`, `%s` or `%s`) which would be performed on the entities. "+
+					"`%s` describes the kind of the entities (e.g. `service` or `route`).",
+				InstanceURLKey, OperationKey, OperationCreate, OperationUpdate, OperationDelete, EntityKindKey,
+			),
+		},
+		[]string{InstanceURLKey, OperationKey, EntityKindKey},
+	)
+
+	metrics.Registry.MustRegister(
+		controllerMetrics.ConfigPushCount,
+		controllerMetrics.TranslationCount,
+		controllerMetrics.ConfigPushDuration,
+		controllerMetrics.InstanceConsecutiveFailures,
+		controllerMetrics.InstanceLastSuccess,
+		controllerMetrics.DryRunEntityChanges,
+	)
 
 	return controllerMetrics
 }
@@ -0,0 +1,72 @@
+package store
+
+import (
+	"reflect"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// Changes describes the objects which were added, updated or deleted in CacheStores
+// since the changes were last taken (see CacheStores.TakeChanges()).
+type Changes struct {
+	objects map[changeKey]struct{}
+	types   map[reflect.Type]struct{}
+}
+
+type changeKey struct {
+	typ       reflect.Type
+	namespace string
+	name      string
+}
+
+func newChanges() Changes {
+	return Changes{
+		objects: make(map[changeKey]struct{}),
+		types:   make(map[reflect.Type]struct{}),
+	}
+}
+
+// record records a change of the provided object.
+func (c Changes) record(obj runtime.Object) {
+	typ := reflect.TypeOf(obj)
+	c.types[typ] = struct{}{}
+	if m, err := meta.Accessor(obj); err == nil {
+		c.objects[changeKey{typ: typ, namespace: m.GetNamespace(), name: m.GetName()}] = struct{}{}
+	}
+}
+
+// Has returns true if the provided object has changed.
+func (c Changes) Has(obj runtime.Object) bool {
+	m, err := meta.Accessor(obj)
+	if err != nil {
+		return false
+	}
+	_, ok := c.objects[changeKey{typ: reflect.TypeOf(obj), namespace: m.GetNamespace(), name: m.GetName()}]
+	return ok
+}
+
+// HasType returns true if any object of the same type as the provided one has changed.
+func (c Changes) HasType(obj runtime.Object) bool {
+	_, ok := c.types[reflect.TypeOf(obj)]
+	return ok
+}
+
+// Len returns the number of objects which have changed.
+func (c Changes) Len() int {
+	return len(c.objects)
+}
+
+// TakeChanges returns the changes made to the CacheStores since they were last taken,
+// and starts recording changes anew.
+func (c CacheStores) TakeChanges() Changes {
+	c.l.Lock()
+	defer c.l.Unlock()
+
+	if c.changes == nil {
+		return newChanges()
+	}
+	changes := *c.changes
+	*c.changes = newChanges()
+	return changes
+}
@@ -0,0 +1,40 @@
+package store
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	netv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestCacheStoresChanges(t *testing.T) {
+	ingress := &netv1.Ingress{ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: "default"}}
+	service := &corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: "default"}}
+	otherService := &corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "bar", Namespace: "default"}}
+
+	s := NewCacheStores()
+	require.NoError(t, s.Add(ingress))
+	require.NoError(t, s.Add(service))
+
+	t.Log("verifying that added objects are recorded as changed")
+	changes := s.TakeChanges()
+	assert.Equal(t, 2, changes.Len())
+	assert.True(t, changes.Has(ingress))
+	assert.True(t, changes.Has(service))
+	assert.False(t, changes.Has(otherService))
+	assert.True(t, changes.HasType(otherService))
+	assert.False(t, changes.HasType(&netv1.IngressClass{}))
+
+	t.Log("verifying that changes are recorded anew once taken")
+	assert.Equal(t, 0, s.TakeChanges().Len())
+
+	t.Log("verifying that deleted objects are recorded as changed")
+	require.NoError(t, s.Delete(service))
+	changes = s.TakeChanges()
+	assert.Equal(t, 1, changes.Len())
+	assert.True(t, changes.Has(service))
+	assert.False(t, changes.Has(ingress))
+}
@@ -41,9 +41,11 @@ type FakeObjects struct {
 	TLSRoutes                      []*gatewayv1alpha2.TLSRoute
 	ReferenceGrants                []*gatewayv1alpha2.ReferenceGrant
 	Gateways                       []*gatewayv1beta1.Gateway
+	GatewayClasses                 []*gatewayv1beta1.GatewayClass
 	TCPIngresses                   []*configurationv1beta1.TCPIngress
 	UDPIngresses                   []*configurationv1beta1.UDPIngress
 	IngressClassParametersV1alpha1 []*configurationv1alpha1.IngressClassParameters
+	GatewayClassParametersV1alpha1 []*configurationv1alpha1.GatewayClassParameters
 	Services                       []*corev1.Service
 	Endpoints                      []*corev1.Endpoints
 	Secrets                        []*corev1.Secret
@@ -89,6 +91,12 @@ func NewFakeStore(
 			return nil, err
 		}
 	}
+	gatewayClassParametersV1alpha1Store := cache.NewStore(clusterResourceKeyFunc)
+	for _, gatewayClassParameters := range objects.GatewayClassParametersV1alpha1 {
+		if err := gatewayClassParametersV1alpha1Store.Add(gatewayClassParameters); err != nil {
+			return nil, err
+		}
+	}
 	httprouteStore := cache.NewStore(keyFunc)
 	for _, httproute := range objects.HTTPRoutes {
 		if err := httprouteStore.Add(httproute); err != nil {
@@ -125,6 +133,12 @@ func NewFakeStore(
 			return nil, err
 		}
 	}
+	gatewayClassStore := cache.NewStore(clusterResourceKeyFunc)
+	for _, gwc := range objects.GatewayClasses {
+		if err := gatewayClassStore.Add(gwc); err != nil {
+			return nil, err
+		}
+	}
 	tcpIngressStore := cache.NewStore(keyFunc)
 	for _, ingress := range objects.TCPIngresses {
 		err := tcpIngressStore.Add(ingress)
@@ -206,6 +220,7 @@ func NewFakeStore(
 			TLSRoute:       tlsrouteStore,
 			ReferenceGrant: referencegrantStore,
 			Gateway:        gatewayStore,
+			GatewayClass:   gatewayClassStore,
 			TCPIngress:     tcpIngressStore,
 			UDPIngress:     udpIngressStore,
 			Service:        serviceStore,
@@ -217,6 +232,7 @@ func NewFakeStore(
 			Consumer:                       consumerStore,
 			KongIngress:                    kongIngressStore,
 			IngressClassParametersV1alpha1: IngressClassParametersV1alpha1Store,
+			GatewayClassParametersV1alpha1: gatewayClassParametersV1alpha1Store,
 
 			KnativeIngress: knativeIngressStore,
 		},
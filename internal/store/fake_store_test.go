@@ -4,6 +4,7 @@ import (
 	"errors"
 	"testing"
 
+	"github.com/samber/lo"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	corev1 "k8s.io/api/core/v1"
@@ -17,6 +18,7 @@ import (
 
 	"github.com/kong/kubernetes-ingress-controller/v2/internal/annotations"
 	configurationv1 "github.com/kong/kubernetes-ingress-controller/v2/pkg/apis/configuration/v1"
+	configurationv1alpha1 "github.com/kong/kubernetes-ingress-controller/v2/pkg/apis/configuration/v1alpha1"
 	configurationv1beta1 "github.com/kong/kubernetes-ingress-controller/v2/pkg/apis/configuration/v1beta1"
 )
 
@@ -884,3 +886,88 @@ func TestFakeStoreGateway(t *testing.T) {
 	assert.Nil(err)
 	assert.Len(routes, 2, "expect two Gateways")
 }
+
+func TestFakeStoreGatewayClassParameters(t *testing.T) {
+	params := &configurationv1alpha1.GatewayClassParameters{
+		ObjectMeta: metav1.ObjectMeta{Name: "params"},
+		Spec:       configurationv1alpha1.GatewayClassParametersSpec{ServiceUpstream: true},
+	}
+	parametersRef := func(group, kind, name string) *gatewayv1beta1.ParametersReference {
+		return &gatewayv1beta1.ParametersReference{
+			Group: gatewayv1beta1.Group(group),
+			Kind:  gatewayv1beta1.Kind(kind),
+			Name:  name,
+		}
+	}
+	gatewayClass := func(name string, ref *gatewayv1beta1.ParametersReference) *gatewayv1beta1.GatewayClass {
+		return &gatewayv1beta1.GatewayClass{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec:       gatewayv1beta1.GatewayClassSpec{ParametersRef: ref},
+		}
+	}
+	namespacedRef := parametersRef(configurationv1alpha1.GroupVersion.Group, configurationv1alpha1.GatewayClassParametersKind, "params")
+	namespacedRef.Namespace = lo.ToPtr(gatewayv1beta1.Namespace("default"))
+
+	testCases := []struct {
+		name           string
+		gatewayClass   *gatewayv1beta1.GatewayClass
+		expectedSpec   configurationv1alpha1.GatewayClassParametersSpec
+		expectedErr    bool
+		expectNotFound bool
+	}{
+		{
+			name:         "no parametersRef",
+			gatewayClass: gatewayClass("no-params", nil),
+		},
+		{
+			name: "parametersRef to GatewayClassParameters",
+			gatewayClass: gatewayClass("params",
+				parametersRef(configurationv1alpha1.GroupVersion.Group, configurationv1alpha1.GatewayClassParametersKind, "params")),
+			expectedSpec: params.Spec,
+		},
+		{
+			name:         "parametersRef to another kind",
+			gatewayClass: gatewayClass("configmap", parametersRef("", "ConfigMap", "params")),
+			expectedErr:  true,
+		},
+		{
+			name:         "namespaced parametersRef",
+			gatewayClass: gatewayClass("namespaced", namespacedRef),
+			expectedErr:  true,
+		},
+		{
+			name: "missing GatewayClassParameters",
+			gatewayClass: gatewayClass("missing",
+				parametersRef(configurationv1alpha1.GroupVersion.Group, configurationv1alpha1.GatewayClassParametersKind, "missing")),
+			expectedErr:    true,
+			expectNotFound: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			store, err := NewFakeStore(FakeObjects{
+				GatewayClasses:                 []*gatewayv1beta1.GatewayClass{tc.gatewayClass},
+				GatewayClassParametersV1alpha1: []*configurationv1alpha1.GatewayClassParameters{params},
+			})
+			require.NoError(t, err)
+
+			gwc, err := store.GetGatewayClass(tc.gatewayClass.Name)
+			require.NoError(t, err)
+			got, err := store.GetGatewayClassParametersV1Alpha1(gwc)
+			if tc.expectedErr {
+				require.Error(t, err)
+				assert.Equal(t, tc.expectNotFound, errors.As(err, &ErrNotFound{}))
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedSpec, got.Spec)
+		})
+	}
+
+	store, err := NewFakeStore(FakeObjects{})
+	require.NoError(t, err)
+	_, err = store.GetGatewayClass("missing")
+	assert.True(t, errors.As(err, &ErrNotFound{}))
+}
@@ -24,6 +24,7 @@ import (
 	"strings"
 	"sync"
 
+	"github.com/samber/lo"
 	"github.com/sirupsen/logrus"
 	corev1 "k8s.io/api/core/v1"
 	extensions "k8s.io/api/extensions/v1beta1"
@@ -39,6 +40,7 @@ import (
 	"k8s.io/apimachinery/pkg/selection"
 	"k8s.io/client-go/tools/cache"
 	knative "knative.dev/networking/pkg/apis/networking/v1alpha1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
 	gatewayv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
 	"sigs.k8s.io/yaml"
@@ -83,6 +85,8 @@ type Storer interface {
 	GetIngressClassV1(name string) (*netv1.IngressClass, error)
 	GetIngressClassParametersV1Alpha1(ingressClass *netv1.IngressClass) (*kongv1alpha1.IngressClassParameters, error)
 	GetGateway(namespace string, name string) (*gatewayv1beta1.Gateway, error)
+	GetGatewayClass(name string) (*gatewayv1beta1.GatewayClass, error)
+	GetGatewayClassParametersV1Alpha1(gatewayClass *gatewayv1beta1.GatewayClass) (*kongv1alpha1.GatewayClassParameters, error)
 
 	ListIngressesV1beta1() []*netv1beta1.Ingress
 	ListIngressesV1() []*netv1.Ingress
@@ -144,6 +148,7 @@ type CacheStores struct {
 	TLSRoute       cache.Store
 	ReferenceGrant cache.Store
 	Gateway        cache.Store
+	GatewayClass   cache.Store
 
 	// Kong Stores
 	Plugin                         cache.Store
@@ -153,11 +158,15 @@ type CacheStores struct {
 	TCPIngress                     cache.Store
 	UDPIngress                     cache.Store
 	IngressClassParametersV1alpha1 cache.Store
+	GatewayClassParametersV1alpha1 cache.Store
 
 	// Knative Stores
 	KnativeIngress cache.Store
 
 	l *sync.RWMutex
+
+	// changes records the objects which were added, updated or deleted since the changes were last taken.
+	changes *Changes
 }
 
 // NewCacheStores is a convenience function for CacheStores to initialize all attributes with new cache stores.
@@ -177,6 +186,7 @@ func NewCacheStores() CacheStores {
 		TLSRoute:       cache.NewStore(keyFunc),
 		ReferenceGrant: cache.NewStore(keyFunc),
 		Gateway:        cache.NewStore(keyFunc),
+		GatewayClass:   cache.NewStore(clusterResourceKeyFunc),
 		// Kong Stores
 		Plugin:                         cache.NewStore(keyFunc),
 		ClusterPlugin:                  cache.NewStore(clusterResourceKeyFunc),
@@ -185,10 +195,12 @@ func NewCacheStores() CacheStores {
 		TCPIngress:                     cache.NewStore(keyFunc),
 		UDPIngress:                     cache.NewStore(keyFunc),
 		IngressClassParametersV1alpha1: cache.NewStore(keyFunc),
+		GatewayClassParametersV1alpha1: cache.NewStore(clusterResourceKeyFunc),
 		// Knative Stores
 		KnativeIngress: cache.NewStore(keyFunc),
 
-		l: &sync.RWMutex{},
+		l:       &sync.RWMutex{},
+		changes: lo.ToPtr(newChanges()),
 	}
 }
 
@@ -272,6 +284,8 @@ func (c CacheStores) Get(obj runtime.Object) (item interface{}, exists bool, err
 		return c.ReferenceGrant.Get(obj)
 	case *gatewayv1beta1.Gateway:
 		return c.Gateway.Get(obj)
+	case *gatewayv1beta1.GatewayClass:
+		return c.GatewayClass.Get(obj)
 	// ----------------------------------------------------------------------------
 	// Kong API Support
 	// ----------------------------------------------------------------------------
@@ -289,6 +303,8 @@ func (c CacheStores) Get(obj runtime.Object) (item interface{}, exists bool, err
 		return c.UDPIngress.Get(obj)
 	case *kongv1alpha1.IngressClassParameters:
 		return c.IngressClassParametersV1alpha1.Get(obj)
+	case *kongv1alpha1.GatewayClassParameters:
+		return c.GatewayClassParametersV1alpha1.Get(obj)
 	// ----------------------------------------------------------------------------
 	// 3rd Party API Support
 	// ----------------------------------------------------------------------------
@@ -303,6 +319,9 @@ func (c CacheStores) Get(obj runtime.Object) (item interface{}, exists bool, err
 func (c CacheStores) Add(obj runtime.Object) error {
 	c.l.Lock()
 	defer c.l.Unlock()
+	if c.changes != nil {
+		c.changes.record(obj)
+	}
 
 	switch obj := obj.(type) {
 	// ----------------------------------------------------------------------------
@@ -337,6 +356,8 @@ func (c CacheStores) Add(obj runtime.Object) error {
 		return c.ReferenceGrant.Add(obj)
 	case *gatewayv1beta1.Gateway:
 		return c.Gateway.Add(obj)
+	case *gatewayv1beta1.GatewayClass:
+		return c.GatewayClass.Add(obj)
 	// ----------------------------------------------------------------------------
 	// Kong API Support
 	// ----------------------------------------------------------------------------
@@ -354,6 +375,8 @@ func (c CacheStores) Add(obj runtime.Object) error {
 		return c.UDPIngress.Add(obj)
 	case *kongv1alpha1.IngressClassParameters:
 		return c.IngressClassParametersV1alpha1.Add(obj)
+	case *kongv1alpha1.GatewayClassParameters:
+		return c.GatewayClassParametersV1alpha1.Add(obj)
 	// ----------------------------------------------------------------------------
 	// 3rd Party API Support
 	// ----------------------------------------------------------------------------
@@ -369,6 +392,9 @@ func (c CacheStores) Add(obj runtime.Object) error {
 func (c CacheStores) Delete(obj runtime.Object) error {
 	c.l.Lock()
 	defer c.l.Unlock()
+	if c.changes != nil {
+		c.changes.record(obj)
+	}
 
 	switch obj := obj.(type) {
 	// ----------------------------------------------------------------------------
@@ -403,6 +429,8 @@ func (c CacheStores) Delete(obj runtime.Object) error {
 		return c.ReferenceGrant.Delete(obj)
 	case *gatewayv1beta1.Gateway:
 		return c.Gateway.Delete(obj)
+	case *gatewayv1beta1.GatewayClass:
+		return c.GatewayClass.Delete(obj)
 	// ----------------------------------------------------------------------------
 	// Kong API Support
 	// ----------------------------------------------------------------------------
@@ -420,6 +448,8 @@ func (c CacheStores) Delete(obj runtime.Object) error {
 		return c.UDPIngress.Delete(obj)
 	case *kongv1alpha1.IngressClassParameters:
 		return c.IngressClassParametersV1alpha1.Delete(obj)
+	case *kongv1alpha1.GatewayClassParameters:
+		return c.GatewayClassParametersV1alpha1.Delete(obj)
 	// ----------------------------------------------------------------------------
 	// 3rd Party API Support
 	// ----------------------------------------------------------------------------
@@ -430,6 +460,201 @@ func (c CacheStores) Delete(obj runtime.Object) error {
 	}
 }
 
+// CopyWithout provides a copy of the CacheStores which leaves out the provided objects. Objects are
+// matched by their group, kind, namespace and name, so the provided objects can be incomplete (e.g.
+// reconstructed from the tags of Kong entities). The copy has its own stores, which can be modified
+// without affecting the original ones, but shares the stored objects with the original: these must not
+// be modified. The stored objects which were left out are returned along with the copy.
+// The objects the left out KongPlugins and KongClusterPlugins are attached to are left out as well, so
+// that they aren't exposed without the plugins (e.g. authentication) configured for them.
+func (c CacheStores) CopyWithout(objs ...client.Object) (CacheStores, []client.Object, error) {
+	excluded := make(map[string]struct{}, len(objs))
+	for _, obj := range objs {
+		excluded[copyKey(obj)] = struct{}{}
+	}
+
+	c.l.RLock()
+	defer c.l.RUnlock()
+
+	attachments := c.pluginAttachments(excluded)
+
+	var leftOut []client.Object
+	cp := NewCacheStores()
+	for _, s := range c.stores() {
+		for _, item := range s.List() {
+			obj, ok := item.(client.Object)
+			if !ok {
+				return CacheStores{}, nil, fmt.Errorf("%T is not a supported cache object type", item)
+			}
+			if _, ok := excluded[copyKey(obj)]; ok || attachments.attachedTo(obj) {
+				leftOut = append(leftOut, obj)
+				continue
+			}
+			if err := cp.Add(obj); err != nil {
+				return CacheStores{}, nil, err
+			}
+		}
+	}
+	return cp, leftOut, nil
+}
+
+// stores lists all the stores of the CacheStores.
+func (c CacheStores) stores() []cache.Store {
+	return []cache.Store{
+		// Core Kubernetes Stores
+		c.IngressV1beta1,
+		c.IngressV1,
+		c.IngressClassV1,
+		c.Service,
+		c.Secret,
+		c.Endpoint,
+		// Gateway API Stores
+		c.HTTPRoute,
+		c.UDPRoute,
+		c.TCPRoute,
+		c.TLSRoute,
+		c.ReferenceGrant,
+		c.Gateway,
+		c.GatewayClass,
+		// Kong Stores
+		c.Plugin,
+		c.ClusterPlugin,
+		c.Consumer,
+		c.KongIngress,
+		c.TCPIngress,
+		c.UDPIngress,
+		c.IngressClassParametersV1alpha1,
+		c.GatewayClassParametersV1alpha1,
+		// Knative Stores
+		c.KnativeIngress,
+	}
+}
+
+// copyKey identifies an object for the purpose of CopyWithout().
+func copyKey(obj client.Object) string {
+	gvk := obj.GetObjectKind().GroupVersionKind()
+	return gvk.Group + "/" + gvk.Kind + "/" + obj.GetNamespace() + "/" + obj.GetName()
+}
+
+// pluginAttachments identifies the objects a set of KongPlugins and KongClusterPlugins are attached to.
+type pluginAttachments struct {
+	// plugins are the namespaced names of the KongPlugins.
+	plugins map[string]struct{}
+	// clusterPlugins are the names of the KongClusterPlugins.
+	clusterPlugins map[string]struct{}
+	// policyTargets are the targetRefs of the KongPlugins attached as policies.
+	policyTargets []policyTarget
+}
+
+// policyTarget is the targetRef of a KongPlugin along with the namespace of the KongPlugin.
+type policyTarget struct {
+	namespace string
+	kongv1.PolicyTargetReference
+}
+
+// pluginAttachments collects the stored KongPlugins and KongClusterPlugins matching the provided copyKey()s.
+func (c CacheStores) pluginAttachments(keys map[string]struct{}) pluginAttachments {
+	attachments := pluginAttachments{
+		plugins:        make(map[string]struct{}),
+		clusterPlugins: make(map[string]struct{}),
+	}
+	for _, item := range c.Plugin.List() {
+		plugin, ok := item.(*kongv1.KongPlugin)
+		if !ok {
+			continue
+		}
+		if _, ok := keys[kongv1.GroupVersion.Group+"/KongPlugin/"+plugin.Namespace+"/"+plugin.Name]; !ok {
+			continue
+		}
+		attachments.plugins[plugin.Namespace+"/"+plugin.Name] = struct{}{}
+		if plugin.TargetRef != nil {
+			attachments.policyTargets = append(attachments.policyTargets, policyTarget{
+				namespace:             plugin.Namespace,
+				PolicyTargetReference: *plugin.TargetRef,
+			})
+		}
+	}
+	for _, item := range c.ClusterPlugin.List() {
+		plugin, ok := item.(*kongv1.KongClusterPlugin)
+		if !ok {
+			continue
+		}
+		if _, ok := keys[kongv1.GroupVersion.Group+"/KongClusterPlugin//"+plugin.Name]; ok {
+			attachments.clusterPlugins[plugin.Name] = struct{}{}
+		}
+	}
+	return attachments
+}
+
+// attachedTo returns true if any of the plugins is attached to the provided object: through its plugins
+// annotation, the ExtensionRef filters of an HTTPRoute or the targetRef of a KongPlugin. The HTTPRoutes
+// attached to a Gateway are considered attached to the KongPlugins targeting the Gateway.
+func (a pluginAttachments) attachedTo(obj client.Object) bool {
+	if len(a.plugins) == 0 && len(a.clusterPlugins) == 0 {
+		return false
+	}
+	if a.references(obj.GetNamespace(), annotations.ExtractKongPluginsFromAnnotations(obj.GetAnnotations())...) {
+		return true
+	}
+
+	switch obj := obj.(type) {
+	case *corev1.Service:
+		return a.targets(corev1.GroupName, "Service", obj.Namespace, obj.Name)
+	case *gatewayv1beta1.HTTPRoute:
+		if a.targets(gatewayv1beta1.GroupName, "HTTPRoute", obj.Namespace, obj.Name) {
+			return true
+		}
+		for _, parentRef := range obj.Spec.ParentRefs {
+			namespace := obj.Namespace
+			if parentRef.Namespace != nil {
+				namespace = string(*parentRef.Namespace)
+			}
+			if a.targets(gatewayv1beta1.GroupName, "Gateway", namespace, string(parentRef.Name)) {
+				return true
+			}
+		}
+		for _, rule := range obj.Spec.Rules {
+			for _, filter := range rule.Filters {
+				if filter.Type != gatewayv1beta1.HTTPRouteFilterExtensionRef || filter.ExtensionRef == nil {
+					continue
+				}
+				switch ref := filter.ExtensionRef; ref.Kind {
+				case "KongPlugin":
+					if _, ok := a.plugins[obj.Namespace+"/"+string(ref.Name)]; ok {
+						return true
+					}
+				case "KongClusterPlugin":
+					if _, ok := a.clusterPlugins[string(ref.Name)]; ok {
+						return true
+					}
+				}
+			}
+		}
+	}
+	return false
+}
+
+// references returns true if any of the provided plugin names, referenced from the provided namespace,
+// resolves to one of the plugins.
+func (a pluginAttachments) references(namespace string, names ...string) bool {
+	for _, name := range names {
+		if _, ok := a.plugins[namespace+"/"+name]; ok {
+			return true
+		}
+		if _, ok := a.clusterPlugins[name]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// targets returns true if any of the KongPlugins attached as policies targets the provided object.
+func (a pluginAttachments) targets(group, kind, namespace, name string) bool {
+	return lo.ContainsBy(a.policyTargets, func(target policyTarget) bool {
+		return target.Group == group && target.Kind == kind && target.namespace == namespace && target.Name == name
+	})
+}
+
 // New creates a new object store to be used in the ingress controller.
 func New(cs CacheStores, ingressClass string, processClasslessIngressV1Beta1 bool, processClasslessIngressV1 bool,
 	processClasslessKongConsumer bool, logger logrus.FieldLogger,
@@ -900,6 +1125,53 @@ func (s Store) GetGateway(namespace string, name string) (*gatewayv1beta1.Gatewa
 	return obj.(*gatewayv1beta1.Gateway), nil
 }
 
+// GetGatewayClass returns the GatewayClass resource having the specified name.
+func (s Store) GetGatewayClass(name string) (*gatewayv1beta1.GatewayClass, error) {
+	obj, exists, err := s.stores.GatewayClass.GetByKey(name)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, ErrNotFound{fmt.Sprintf("GatewayClass %v not found", name)}
+	}
+	return obj.(*gatewayv1beta1.GatewayClass), nil
+}
+
+// GetGatewayClassParametersV1Alpha1 returns GatewayClassParameters for provided
+// GatewayClass.
+func (s Store) GetGatewayClassParametersV1Alpha1(gatewayClass *gatewayv1beta1.GatewayClass) (*kongv1alpha1.GatewayClassParameters, error) {
+	if gatewayClass == nil {
+		return nil, fmt.Errorf("provided GatewayClass is nil")
+	}
+
+	ref := gatewayClass.Spec.ParametersRef
+	if ref == nil {
+		return &kongv1alpha1.GatewayClassParameters{}, nil
+	}
+
+	if string(ref.Group) != kongv1alpha1.GroupVersion.Group || string(ref.Kind) != kongv1alpha1.GatewayClassParametersKind {
+		return nil, fmt.Errorf(
+			"GatewayClass %s should reference parameters with group:%s and kind:%s",
+			gatewayClass.Name,
+			kongv1alpha1.GroupVersion.Group,
+			kongv1alpha1.GatewayClassParametersKind,
+		)
+	}
+
+	if ref.Namespace != nil {
+		return nil, fmt.Errorf("GatewayClass %s should reference cluster-scoped parameters", gatewayClass.Name)
+	}
+
+	params, exists, err := s.stores.GatewayClassParametersV1alpha1.GetByKey(ref.Name)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, ErrNotFound{fmt.Sprintf("GatewayClassParameters %v not found", ref.Name)}
+	}
+	return params.(*kongv1alpha1.GatewayClassParameters), nil
+}
+
 // ListKongConsumers returns all KongConsumers filtered by the ingress.class
 // annotation.
 func (s Store) ListKongConsumers() []*kongv1.KongConsumer {
@@ -1113,6 +1385,8 @@ func mkObjFromGVK(gvk schema.GroupVersionKind) (runtime.Object, error) {
 		return &kongv1.KongConsumer{}, nil
 	case kongv1alpha1.SchemeGroupVersion.WithKind("IngressClassParameters"):
 		return &kongv1alpha1.IngressClassParameters{}, nil
+	case kongv1alpha1.SchemeGroupVersion.WithKind("GatewayClassParameters"):
+		return &kongv1alpha1.GatewayClassParameters{}, nil
 	// ----------------------------------------------------------------------------
 	// Knative APIs
 	// ----------------------------------------------------------------------------
@@ -15,8 +15,11 @@ import (
 	netv1beta1 "k8s.io/api/networking/v1beta1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	gatewayv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
 
 	"github.com/kong/kubernetes-ingress-controller/v2/internal/annotations"
+	kongv1 "github.com/kong/kubernetes-ingress-controller/v2/pkg/apis/configuration/v1"
 )
 
 func TestNetworkingIngressV1Beta1(t *testing.T) {
@@ -204,6 +207,139 @@ spec:
 	assert.True(t, exists)
 }
 
+func TestCacheStoresCopyWithout(t *testing.T) {
+	svcYAML := []byte(`---
+apiVersion: v1
+kind: Service
+metadata:
+  name: httpbin
+  namespace: default
+spec:
+  ports:
+  - port: 80
+`)
+	pluginYAML := []byte(`---
+apiVersion: configuration.konghq.com/v1
+kind: KongPlugin
+metadata:
+  name: httpbin
+  namespace: default
+plugin: key-auth
+`)
+	cs, err := NewCacheStoresFromObjYAML(svcYAML, pluginYAML)
+	require.NoError(t, err)
+
+	t.Log("leaving out an object identified only by its metadata")
+	excluded := &metav1.PartialObjectMetadata{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "httpbin"}}
+	excluded.SetGroupVersionKind(kongv1.SchemeGroupVersion.WithKind("KongPlugin"))
+	cp, leftOut, err := cs.CopyWithout(excluded)
+	require.NoError(t, err)
+
+	require.Len(t, leftOut, 1)
+	assert.IsType(t, &kongv1.KongPlugin{}, leftOut[0])
+	assert.Len(t, cp.Service.List(), 1)
+	assert.Len(t, cp.Plugin.List(), 0)
+
+	t.Log("verifying that the original stores are unaffected by changes to the copy")
+	require.NoError(t, cp.Delete(cp.Service.List()[0].(*corev1.Service)))
+	assert.Len(t, cp.Service.List(), 0)
+	assert.Len(t, cs.Service.List(), 1)
+	assert.Len(t, cs.Plugin.List(), 1)
+}
+
+func TestCacheStoresCopyWithout_PluginAttachments(t *testing.T) {
+	plugin := &kongv1.KongPlugin{
+		TypeMeta:   metav1.TypeMeta{APIVersion: kongv1.GroupVersion.String(), Kind: "KongPlugin"},
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "key-auth"},
+		PluginName: "key-auth",
+	}
+	policy := &kongv1.KongPlugin{
+		TypeMeta:   metav1.TypeMeta{APIVersion: kongv1.GroupVersion.String(), Kind: "KongPlugin"},
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "gateway-acl"},
+		PluginName: "acl",
+		TargetRef:  &kongv1.PolicyTargetReference{Group: gatewayv1beta1.GroupName, Kind: "Gateway", Name: "gateway"},
+	}
+	clusterPlugin := &kongv1.KongClusterPlugin{
+		TypeMeta:   metav1.TypeMeta{APIVersion: kongv1.GroupVersion.String(), Kind: "KongClusterPlugin"},
+		ObjectMeta: metav1.ObjectMeta{Name: "basic-auth"},
+		PluginName: "basic-auth",
+	}
+	service := func(name string, plugins string) *corev1.Service {
+		return &corev1.Service{
+			TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "Service"},
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:   "default",
+				Name:        name,
+				Annotations: map[string]string{annotations.AnnotationPrefix + annotations.PluginsKey: plugins},
+			},
+		}
+	}
+	httpRoute := func(name, gateway string, filters ...gatewayv1beta1.HTTPRouteFilter) *gatewayv1beta1.HTTPRoute {
+		return &gatewayv1beta1.HTTPRoute{
+			TypeMeta:   metav1.TypeMeta{APIVersion: gatewayv1beta1.GroupVersion.String(), Kind: "HTTPRoute"},
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: name},
+			Spec: gatewayv1beta1.HTTPRouteSpec{
+				CommonRouteSpec: gatewayv1beta1.CommonRouteSpec{
+					ParentRefs: []gatewayv1beta1.ParentReference{{Name: gatewayv1beta1.ObjectName(gateway)}},
+				},
+				Rules: []gatewayv1beta1.HTTPRouteRule{{Filters: filters}},
+			},
+		}
+	}
+	extensionRef := func(kind, name string) gatewayv1beta1.HTTPRouteFilter {
+		return gatewayv1beta1.HTTPRouteFilter{
+			Type: gatewayv1beta1.HTTPRouteFilterExtensionRef,
+			ExtensionRef: &gatewayv1beta1.LocalObjectReference{
+				Kind: gatewayv1beta1.Kind(kind),
+				Name: gatewayv1beta1.ObjectName(name),
+			},
+		}
+	}
+
+	cs := NewCacheStores()
+	for _, obj := range []client.Object{
+		plugin,
+		policy,
+		clusterPlugin,
+		service("annotated", "rate-limiting, key-auth"),
+		service("cluster-annotated", "basic-auth"),
+		service("unrelated", "rate-limiting"),
+		httpRoute("extension-ref", "other-gateway", extensionRef("KongPlugin", "key-auth")),
+		httpRoute("cluster-extension-ref", "other-gateway", extensionRef("KongClusterPlugin", "basic-auth")),
+		httpRoute("gateway-policy", "gateway"),
+		httpRoute("other-gateway", "other-gateway"),
+	} {
+		require.NoError(t, cs.Add(obj))
+	}
+
+	excluded := func(kind, namespace, name string) client.Object {
+		obj := &metav1.PartialObjectMetadata{ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name}}
+		obj.SetGroupVersionKind(kongv1.GroupVersion.WithKind(kind))
+		return obj
+	}
+	_, leftOut, err := cs.CopyWithout(
+		excluded("KongPlugin", "default", "key-auth"),
+		excluded("KongPlugin", "default", "gateway-acl"),
+		excluded("KongClusterPlugin", "", "basic-auth"),
+	)
+	require.NoError(t, err)
+
+	leftOutNames := make([]string, 0, len(leftOut))
+	for _, obj := range leftOut {
+		leftOutNames = append(leftOutNames, obj.GetName())
+	}
+	assert.ElementsMatch(t, []string{
+		"key-auth",
+		"gateway-acl",
+		"basic-auth",
+		"annotated",
+		"cluster-annotated",
+		"extension-ref",
+		"cluster-extension-ref",
+		"gateway-policy",
+	}, leftOutNames)
+}
+
 func TestGetIngressClassHandling(t *testing.T) {
 	tests := []struct {
 		name string
@@ -0,0 +1,129 @@
+package tracing
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/go-logr/logr"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.12.0"
+	tracepb "go.opentelemetry.io/proto/otlp/trace/v1"
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	// DefaultOTLPServiceName is the service name spans are exported under by default.
+	DefaultOTLPServiceName = "kong-ingress-controller"
+
+	otlpTimeout = 10 * time.Second
+)
+
+// Setup sets the global tracer provider to one exporting spans in batches to the OpenTelemetry
+// collector at the provided endpoint (e.g. http://otel-collector:4318/v1/traces), under the
+// provided service name, and propagates traces to remote services with the W3C Trace Context
+// headers. The returned function exports the remaining spans and stops the exporter.
+func Setup(ctx context.Context, logger logr.Logger, endpoint, serviceName string) (func(context.Context) error, error) {
+	exporter, err := NewOTLPExporter(ctx, endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create the OTLP exporter: %w", err)
+	}
+	res, err := resource.Merge(resource.Default(), resource.NewWithAttributes(
+		semconv.SchemaURL,
+		semconv.ServiceNameKey.String(serviceName),
+	))
+	if err != nil {
+		return nil, fmt.Errorf("failed to build the tracing resource: %w", err)
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+	)
+	otel.SetErrorHandler(otel.ErrorHandlerFunc(func(err error) {
+		logger.Error(err, "tracing failure")
+	}))
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	return provider.Shutdown, nil
+}
+
+// NewOTLPExporter provides a new exporter sending spans to the provided endpoint, using
+// the OTLP/HTTP protocol with protobuf encoding.
+func NewOTLPExporter(ctx context.Context, endpoint string) (*otlptrace.Exporter, error) {
+	return otlptrace.New(ctx, &otlpHTTPClient{
+		endpoint: endpoint,
+		client:   &http.Client{Timeout: otlpTimeout},
+	})
+}
+
+// otlpHTTPClient uploads the spans transformed by the OTLP exporter to the collector over HTTP.
+//
+// TODO: replace it with the client of the otlptracehttp module, which retries, compresses and reads
+// the OTEL_EXPORTER_OTLP_* environment variables. It requires grpc-gateway v2.15.2 or later, hence
+// genproto v0.0.0-20230223222841-637eb2293923 or later, which in turn requires upgrading gRPC to
+// v1.54 and cloud.google.com/go/container (used by the e2e tests) to v1.15.
+type otlpHTTPClient struct {
+	endpoint string
+	client   *http.Client
+}
+
+// Start satisfies the otlptrace.Client interface.
+func (c *otlpHTTPClient) Start(_ context.Context) error {
+	return nil
+}
+
+// Stop satisfies the otlptrace.Client interface.
+func (c *otlpHTTPClient) Stop(_ context.Context) error {
+	c.client.CloseIdleConnections()
+	return nil
+}
+
+// UploadTraces satisfies the otlptrace.Client interface.
+func (c *otlpHTTPClient) UploadTraces(ctx context.Context, protoSpans []*tracepb.ResourceSpans) error {
+	body, err := marshalExportTraceServiceRequest(protoSpans)
+	if err != nil {
+		return fmt.Errorf("encoding spans: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-protobuf")
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("collector responded with %s: %s", resp.Status, msg)
+	}
+	return nil
+}
+
+// exportTraceServiceRequestResourceSpansField is the number of the resource_spans field of the
+// opentelemetry.proto.collector.trace.v1.ExportTraceServiceRequest message.
+const exportTraceServiceRequestResourceSpansField = 1
+
+// marshalExportTraceServiceRequest encodes an ExportTraceServiceRequest holding the spans.
+func marshalExportTraceServiceRequest(protoSpans []*tracepb.ResourceSpans) ([]byte, error) {
+	var body []byte
+	for _, rs := range protoSpans {
+		b, err := proto.Marshal(rs)
+		if err != nil {
+			return nil, err
+		}
+		body = protowire.AppendTag(body, exportTraceServiceRequestResourceSpansField, protowire.BytesType)
+		body = protowire.AppendBytes(body, b)
+	}
+	return body, nil
+}
@@ -0,0 +1,64 @@
+// Package tracing traces the stages of the pipeline turning Kubernetes objects into Kong
+// configuration: reconciling the objects, translating them, generating the configuration,
+// and pushing it to each of the Kong instances (including the Admin API calls it takes).
+//
+// Spans are started with the global OpenTelemetry tracer provider, which doesn't record
+// anything until Setup replaces it with one exporting the spans to a collector.
+package tracing
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// TracerName is the name of the tracer the spans of the pipeline are started with.
+const TracerName = "github.com/kong/kubernetes-ingress-controller/v2"
+
+// Attribute keys shared by the spans of the pipeline.
+const (
+	KongURLKey                   = attribute.Key("kong.url")
+	ConfigSHAKey                 = attribute.Key("kong.config.sha")
+	FailureReasonKey             = attribute.Key("kong.config.failure_reason")
+	TranslationFailuresKey       = attribute.Key("translation.failures")
+	TranslationFailureReasonsKey = attribute.Key("translation.failure_reasons")
+	K8sKindKey                   = attribute.Key("k8s.kind")
+	K8sNamespaceKey              = attribute.Key("k8s.namespace")
+	K8sNameKey                   = attribute.Key("k8s.name")
+)
+
+// Start starts a span which is a child of the span in ctx, if there's any, and returns
+// a context containing the started span.
+func Start(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return otel.Tracer(TracerName).Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// StartReconcile starts the span of a reconciliation of the Kubernetes object of the provided kind, like Start does.
+func StartReconcile(ctx context.Context, kind string, nn types.NamespacedName) (context.Context, trace.Span) {
+	return Start(ctx, "reconcile",
+		K8sKindKey.String(kind),
+		K8sNamespaceKey.String(nn.Namespace),
+		K8sNameKey.String(nn.Name),
+	)
+}
+
+// StartClient starts a span of a request made to a remote service, like Start does.
+func StartClient(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return otel.Tracer(TracerName).Start(ctx, name,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attrs...),
+	)
+}
+
+// RecordError records the error on the span and marks the operation as failed. It's a no-op for nil errors.
+func RecordError(span trace.Span, err error) {
+	if err == nil {
+		return
+	}
+	span.RecordError(err)
+	span.SetStatus(codes.Error, err.Error())
+}
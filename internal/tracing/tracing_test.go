@@ -0,0 +1,160 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	tracepb "go.opentelemetry.io/proto/otlp/trace/v1"
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/proto"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestSpans(t *testing.T) {
+	t.Log("verifying that spans are not recorded until a tracer provider is set")
+	_, span := Start(context.Background(), "disabled")
+	assert.False(t, span.IsRecording())
+	RecordError(span, errors.New("failure"))
+	span.End()
+
+	exporter := tracetest.NewInMemoryExporter()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter)))
+	t.Cleanup(func() { otel.SetTracerProvider(trace.NewNoopTracerProvider()) })
+
+	t.Log("verifying that child spans belong to the trace of their parents")
+	ctx, parent := StartReconcile(context.Background(), "Ingress", types.NamespacedName{Namespace: "default", Name: "foo"})
+	_, child := StartClient(ctx, "child", attribute.Int("count", 2))
+	RecordError(child, nil)
+	RecordError(child, errors.New("failure"))
+	child.End()
+	parent.End()
+
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 2)
+	childStub, parentStub := spans[0], spans[1]
+	assert.Equal(t, "reconcile", parentStub.Name)
+	assert.Equal(t, []attribute.KeyValue{
+		K8sKindKey.String("Ingress"),
+		K8sNamespaceKey.String("default"),
+		K8sNameKey.String("foo"),
+	}, parentStub.Attributes)
+	assert.False(t, parentStub.Parent.IsValid())
+	assert.Equal(t, parentStub.SpanContext.TraceID(), childStub.SpanContext.TraceID())
+	assert.Equal(t, parentStub.SpanContext.SpanID(), childStub.Parent.SpanID())
+	assert.Equal(t, trace.SpanKindClient, childStub.SpanKind)
+	assert.Equal(t, sdktrace.Status{Code: codes.Error, Description: "failure"}, childStub.Status)
+	require.Len(t, childStub.Events, 1, "the error should be recorded once")
+
+	t.Log("verifying that spans without a parent start new traces")
+	_, other := Start(context.Background(), "other")
+	other.End()
+	assert.NotEqual(t, parentStub.SpanContext.TraceID(), exporter.GetSpans()[2].SpanContext.TraceID())
+}
+
+func TestOTLPExporter(t *testing.T) {
+	requests := make(chan []*tracepb.ResourceSpans, 1)
+	collector := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/traces", r.URL.Path)
+		assert.Equal(t, "application/x-protobuf", r.Header.Get("Content-Type"))
+		body, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		resourceSpans, err := unmarshalExportTraceServiceRequest(body)
+		assert.NoError(t, err)
+		requests <- resourceSpans
+	}))
+	defer collector.Close()
+
+	ctx := context.Background()
+	exporter, err := NewOTLPExporter(ctx, collector.URL+"/v1/traces")
+	require.NoError(t, err)
+	start := time.Unix(0, 1000)
+	stub := tracetest.SpanStub{
+		Name: "sendconfig.PerformUpdate",
+		SpanContext: trace.NewSpanContext(trace.SpanContextConfig{
+			TraceID: trace.TraceID{1},
+			SpanID:  trace.SpanID{2},
+		}),
+		Parent: trace.NewSpanContext(trace.SpanContextConfig{
+			TraceID: trace.TraceID{1},
+			SpanID:  trace.SpanID{3},
+		}),
+		SpanKind:   trace.SpanKindClient,
+		StartTime:  start,
+		EndTime:    start.Add(time.Microsecond),
+		Attributes: []attribute.KeyValue{KongURLKey.String("http://kong:8001")},
+		Status:     sdktrace.Status{Code: codes.Error, Description: "failure"},
+	}
+	require.NoError(t, exporter.ExportSpans(ctx, tracetest.SpanStubs{stub}.Snapshots()))
+	require.NoError(t, exporter.Shutdown(ctx))
+
+	var resourceSpans []*tracepb.ResourceSpans
+	select {
+	case resourceSpans = <-requests:
+	default:
+		t.Fatal("spans were not exported")
+	}
+	require.Len(t, resourceSpans, 1)
+	require.Len(t, resourceSpans[0].ScopeSpans, 1)
+	require.Len(t, resourceSpans[0].ScopeSpans[0].Spans, 1)
+
+	span := resourceSpans[0].ScopeSpans[0].Spans[0]
+	assert.Equal(t, "sendconfig.PerformUpdate", span.Name)
+	assert.Equal(t, tracepb.Span_SPAN_KIND_CLIENT, span.Kind)
+	assert.Equal(t, []byte{1, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0}, span.TraceId)
+	assert.Equal(t, []byte{2, 0, 0, 0, 0, 0, 0, 0}, span.SpanId)
+	assert.Equal(t, []byte{3, 0, 0, 0, 0, 0, 0, 0}, span.ParentSpanId)
+	assert.Equal(t, uint64(1000), span.StartTimeUnixNano)
+	assert.Equal(t, uint64(2000), span.EndTimeUnixNano)
+	assert.Equal(t, tracepb.Status_STATUS_CODE_ERROR, span.Status.Code)
+	assert.Equal(t, "failure", span.Status.Message)
+	require.Len(t, span.Attributes, 1)
+	assert.Equal(t, "http://kong:8001", span.Attributes[0].Value.GetStringValue())
+
+	t.Log("verifying that collector errors are reported")
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer failing.Close()
+	exporter, err = NewOTLPExporter(ctx, failing.URL)
+	require.NoError(t, err)
+	assert.Error(t, exporter.ExportSpans(ctx, tracetest.SpanStubs{stub}.Snapshots()))
+}
+
+// unmarshalExportTraceServiceRequest decodes the resource spans of an ExportTraceServiceRequest.
+func unmarshalExportTraceServiceRequest(body []byte) ([]*tracepb.ResourceSpans, error) {
+	var resourceSpans []*tracepb.ResourceSpans
+	for len(body) > 0 {
+		num, typ, n := protowire.ConsumeTag(body)
+		if n < 0 {
+			return nil, protowire.ParseError(n)
+		}
+		body = body[n:]
+		if num != exportTraceServiceRequestResourceSpansField || typ != protowire.BytesType {
+			return nil, errors.New("unexpected field")
+		}
+		b, n := protowire.ConsumeBytes(body)
+		if n < 0 {
+			return nil, protowire.ParseError(n)
+		}
+		body = body[n:]
+		rs := &tracepb.ResourceSpans{}
+		if err := proto.Unmarshal(b, rs); err != nil {
+			return nil, err
+		}
+		resourceSpans = append(resourceSpans, rs)
+	}
+	return resourceSpans, nil
+}
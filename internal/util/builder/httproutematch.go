@@ -54,6 +54,16 @@ func (b *HTTPRouteMatchBuilder) WithQueryParam(name, value string) *HTTPRouteMat
 	return b
 }
 
+func (b *HTTPRouteMatchBuilder) WithQueryParamRegex(name, regex string) *HTTPRouteMatchBuilder {
+	queryParamMatchType := gatewayv1beta1.QueryParamMatchRegularExpression
+	b.httpRouteMatch.QueryParams = append(b.httpRouteMatch.QueryParams, gatewayv1beta1.HTTPQueryParamMatch{
+		Type:  &queryParamMatchType,
+		Name:  name,
+		Value: regex,
+	})
+	return b
+}
+
 func (b *HTTPRouteMatchBuilder) WithMethod(method gatewayv1beta1.HTTPMethod) *HTTPRouteMatchBuilder {
 	b.httpRouteMatch.Method = &method
 	return b
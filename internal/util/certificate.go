@@ -0,0 +1,21 @@
+package util
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+)
+
+// GetCertificateKeyAlgorithm returns the algorithm of the public key of the first certificate
+// of the provided PEM encoded certificate chain.
+func GetCertificateKeyAlgorithm(cert []byte) (x509.PublicKeyAlgorithm, error) {
+	block, _ := pem.Decode(cert)
+	if block == nil {
+		return x509.UnknownPublicKeyAlgorithm, fmt.Errorf("no PEM encoded certificate found")
+	}
+	parsed, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return x509.UnknownPublicKeyAlgorithm, fmt.Errorf("failed to parse certificate: %w", err)
+	}
+	return parsed.PublicKeyAlgorithm, nil
+}
@@ -0,0 +1,24 @@
+package util_test
+
+import (
+	"crypto/x509"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/util"
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/util/test/certificate"
+)
+
+func TestGetCertificateKeyAlgorithm(t *testing.T) {
+	for _, algorithm := range []x509.PublicKeyAlgorithm{x509.RSA, x509.ECDSA} {
+		cert, _ := certificate.SelfSigned(t, "example.com", algorithm)
+		got, err := util.GetCertificateKeyAlgorithm(cert)
+		require.NoError(t, err)
+		assert.Equal(t, algorithm, got)
+	}
+
+	_, err := util.GetCertificateKeyAlgorithm([]byte("not a certificate"))
+	assert.Error(t, err)
+}
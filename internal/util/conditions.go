@@ -27,3 +27,18 @@ func CheckCondition(
 	}
 	return false
 }
+
+// IsConditionSet tells if there's a condition matching the type, reason, status and message of the given condition
+// in conditions, observed for the resource's actual generation.
+func IsConditionSet(conditions []metav1.Condition, condition metav1.Condition, resourceGeneration int64) bool {
+	for _, cond := range conditions {
+		if cond.Type == condition.Type &&
+			cond.Reason == condition.Reason &&
+			cond.Status == condition.Status &&
+			cond.Message == condition.Message &&
+			cond.ObservedGeneration == resourceGeneration {
+			return true
+		}
+	}
+	return false
+}
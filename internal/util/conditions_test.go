@@ -124,3 +124,57 @@ func TestCheckCondition_observed_generations_lower_than_actual_are_ignored(t *te
 	)
 	require.False(t, ok, "expected to not match any condition due to low observed generation")
 }
+
+func TestIsConditionSet(t *testing.T) {
+	generation := int64(2)
+	condition := metav1.Condition{
+		Type:    "Accepted",
+		Status:  metav1.ConditionFalse,
+		Reason:  "Invalid",
+		Message: "targetRef isn't supported",
+	}
+	observed := condition
+	observed.ObservedGeneration = generation
+
+	testCases := []struct {
+		name            string
+		givenConditions []metav1.Condition
+		expectedResult  bool
+	}{
+		{
+			name:            "condition_observed_for_actual_generation_should_give_true",
+			givenConditions: []metav1.Condition{observed},
+			expectedResult:  true,
+		},
+		{
+			name: "condition_with_other_message_should_give_false",
+			givenConditions: []metav1.Condition{func() metav1.Condition {
+				c := observed
+				c.Message = "target not found"
+				return c
+			}()},
+			expectedResult: false,
+		},
+		{
+			name: "condition_observed_for_older_generation_should_give_false",
+			givenConditions: []metav1.Condition{func() metav1.Condition {
+				c := observed
+				c.ObservedGeneration = 1
+				return c
+			}()},
+			expectedResult: false,
+		},
+		{
+			name:            "no_conditions_should_give_false",
+			givenConditions: nil,
+			expectedResult:  false,
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			require.Equal(t, testCase.expectedResult, util.IsConditionSet(testCase.givenConditions, condition, generation))
+		})
+	}
+}
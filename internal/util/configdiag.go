@@ -1,15 +1,32 @@
 package util
 
-import "github.com/kong/deck/file"
+import (
+	"time"
 
-// ConfigDump contains a config dump and a flag indicating that the config was not successfully applid.
+	"github.com/kong/deck/file"
+)
+
+// ConfigDump contains a config dump and a flag indicating that the config was not successfully applid,
+// along with the details of the push of the config to Kong.
 type ConfigDump struct {
 	Config file.Content
 	Failed bool
+
+	// Time is the time the config was pushed at.
+	Time time.Time
+	// URL is the base URL of the Kong Admin API the config was pushed to.
+	URL string
+	// SHA is the checksum of the config.
+	SHA string
+	// Error is the error the config failed to apply with.
+	Error string
 }
 
 // ConfigDumpDiagnostic contains settings and channels for receiving diagnostic configuration dumps.
 type ConfigDumpDiagnostic struct {
 	DumpsIncludeSensitive bool
 	Configs               chan ConfigDump
+	InstancesStatus       chan []KongInstanceStatus
+	DryRuns               chan []KongInstanceDryRun
+	Explanations          chan KubernetesObjectExplanations
 }
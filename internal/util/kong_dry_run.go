@@ -0,0 +1,31 @@
+package util
+
+import "time"
+
+// KongEntityChange describes a change of a single Kong entity.
+type KongEntityChange struct {
+	// Operation is the operation which would be performed on the entity (create, update or delete).
+	Operation string `json:"operation"`
+	// Kind is the kind of the entity (e.g. service or route).
+	Kind string `json:"kind"`
+	// Entity identifies the entity in a human-readable form.
+	Entity string `json:"entity"`
+}
+
+// KongInstanceDryRun describes the changes applying a configuration would make to a single Kong instance.
+type KongInstanceDryRun struct {
+	// URL is the base URL of the Kong Admin API of the instance.
+	URL string `json:"url"`
+	// Time is the time the changes were computed at.
+	Time time.Time `json:"time"`
+	// ConfigSHA is the checksum of the configuration which would be applied.
+	ConfigSHA string `json:"config_sha,omitempty"`
+	// LiveConfigHash is the hash of the configuration the instance is currently serving, as reported by the instance.
+	LiveConfigHash string `json:"live_config_hash,omitempty"`
+	// PayloadSize is the size (in bytes) of the declarative configuration which would be sent to a DB-less instance.
+	PayloadSize int `json:"payload_size,omitempty"`
+	// Changes are the changes of the entities the instance is currently serving.
+	Changes []KongEntityChange `json:"changes"`
+	// Error is the error computing the changes failed with, if it failed.
+	Error string `json:"error,omitempty"`
+}
@@ -0,0 +1,55 @@
+package util
+
+import "strings"
+
+// KongEntityInfluence describes a setting of a Kubernetes object which influenced a generated Kong entity.
+type KongEntityInfluence struct {
+	// Object identifies the Kubernetes object the setting is defined on, in the kind/namespace/name form.
+	Object string `json:"object"`
+	// Setting is the key of the annotation or the path of the KongIngress field (e.g. proxy.read_timeout).
+	Setting string `json:"setting"`
+	// Value is the value of the setting. KongIngress field values are JSON encoded.
+	Value string `json:"value"`
+}
+
+// ExplainedKongEntity is a Kong entity generated for a Kubernetes object.
+type ExplainedKongEntity struct {
+	// Name identifies the entity in a human-readable form.
+	Name string `json:"name"`
+	// Entity is the generated entity. Sensitive values (e.g. certificate keys) are redacted.
+	Entity interface{} `json:"entity"`
+	// Influences are the annotations and KongIngress fields which influenced the entity.
+	Influences []KongEntityInfluence `json:"influences,omitempty"`
+}
+
+// KubernetesObjectExplanation describes the Kong entities generated for a Kubernetes object, along with
+// the failures its translation resulted in.
+type KubernetesObjectExplanation struct {
+	Kind      string `json:"kind"`
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+
+	Services     []ExplainedKongEntity `json:"services,omitempty"`
+	Routes       []ExplainedKongEntity `json:"routes,omitempty"`
+	Upstreams    []ExplainedKongEntity `json:"upstreams,omitempty"`
+	Targets      []ExplainedKongEntity `json:"targets,omitempty"`
+	Plugins      []ExplainedKongEntity `json:"plugins,omitempty"`
+	Certificates []ExplainedKongEntity `json:"certificates,omitempty"`
+
+	TranslationFailures []string `json:"translation_failures,omitempty"`
+}
+
+// KubernetesObjectExplanations is an index of the explanations of Kubernetes objects.
+type KubernetesObjectExplanations map[string]*KubernetesObjectExplanation
+
+// KubernetesObjectExplanationKey returns the key the explanation of a Kubernetes object is indexed by.
+// Kinds are matched case-insensitively.
+func KubernetesObjectExplanationKey(kind, namespace, name string) string {
+	return strings.ToLower(kind) + "/" + namespace + "/" + name
+}
+
+// Get returns the explanation of the Kubernetes object of the provided kind, namespace and name.
+func (e KubernetesObjectExplanations) Get(kind, namespace, name string) (*KubernetesObjectExplanation, bool) {
+	explanation, ok := e[KubernetesObjectExplanationKey(kind, namespace, name)]
+	return explanation, ok
+}
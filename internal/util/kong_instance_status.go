@@ -0,0 +1,19 @@
+package util
+
+import "time"
+
+// KongInstanceStatus describes the state of the configuration updates sent to a single Kong instance.
+type KongInstanceStatus struct {
+	// URL is the base URL of the Kong Admin API of the instance.
+	URL string `json:"url"`
+	// LastConfigSHA is the checksum of the configuration last successfully applied to the instance.
+	LastConfigSHA string `json:"last_config_sha,omitempty"`
+	// LastSuccess is the time the configuration was last successfully applied to the instance.
+	LastSuccess *time.Time `json:"last_success,omitempty"`
+	// LastError is the error the last update of the instance failed with, if it failed.
+	LastError string `json:"last_error,omitempty"`
+	// ConsecutiveFailures is the number of updates of the instance which failed in a row.
+	ConsecutiveFailures int `json:"consecutive_failures"`
+	// BackoffUntil is the time until which the configuration the last update failed with is not sent again.
+	BackoffUntil *time.Time `json:"backoff_until,omitempty"`
+}
@@ -1,14 +1,40 @@
 package util
 
 import (
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
+const (
+	// K8sNameTagPrefix is the prefix of the Kong entity tag holding the name of the Kubernetes object
+	// the entity was generated from.
+	K8sNameTagPrefix = "k8s-name:"
+	// K8sNamespaceTagPrefix is the prefix of the Kong entity tag holding the namespace of the Kubernetes
+	// object the entity was generated from.
+	K8sNamespaceTagPrefix = "k8s-namespace:"
+	// K8sKindTagPrefix is the prefix of the Kong entity tag holding the kind of the Kubernetes object
+	// the entity was generated from.
+	K8sKindTagPrefix = "k8s-kind:"
+	// K8sUIDTagPrefix is the prefix of the Kong entity tag holding the UID of the Kubernetes object
+	// the entity was generated from.
+	K8sUIDTagPrefix = "k8s-uid:"
+	// K8sGroupTagPrefix is the prefix of the Kong entity tag holding the API group of the Kubernetes
+	// object the entity was generated from.
+	K8sGroupTagPrefix = "k8s-group:"
+	// K8sVersionTagPrefix is the prefix of the Kong entity tag holding the API version of the Kubernetes
+	// object the entity was generated from.
+	K8sVersionTagPrefix = "k8s-version:"
+)
+
 // K8sObjectInfo describes a Kubernetes object.
 type K8sObjectInfo struct {
 	Name             string
 	Namespace        string
+	UID              types.UID
 	Annotations      map[string]string
 	GroupVersionKind schema.GroupVersionKind
 }
@@ -25,6 +51,7 @@ func FromK8sObject(obj client.Object) K8sObjectInfo {
 	ret := K8sObjectInfo{
 		Name:        obj.GetName(),
 		Namespace:   obj.GetNamespace(),
+		UID:         obj.GetUID(),
 		Annotations: deepCopy(obj.GetAnnotations()),
 	}
 	if gvk := obj.GetObjectKind().GroupVersionKind(); gvk.String() != "" {
@@ -32,3 +59,67 @@ func FromK8sObject(obj client.Object) K8sObjectInfo {
 	}
 	return ret
 }
+
+// Tags returns Kong entity tags identifying the described Kubernetes object. Objects without a name
+// or kind can't be identified, so no tags are returned for them.
+func (o K8sObjectInfo) Tags() []*string {
+	if o.Name == "" || o.GroupVersionKind.Kind == "" {
+		return nil
+	}
+
+	var tags []*string
+	for _, tag := range []struct{ prefix, value string }{
+		{K8sNameTagPrefix, o.Name},
+		{K8sNamespaceTagPrefix, o.Namespace},
+		{K8sKindTagPrefix, o.GroupVersionKind.Kind},
+		{K8sUIDTagPrefix, string(o.UID)},
+		{K8sGroupTagPrefix, o.GroupVersionKind.Group},
+		{K8sVersionTagPrefix, o.GroupVersionKind.Version},
+	} {
+		if tag.value != "" {
+			value := tag.prefix + tag.value
+			tags = append(tags, &value)
+		}
+	}
+	return tags
+}
+
+// GenerateTagsForObject returns Kong entity tags identifying the provided Kubernetes object, so that
+// Kong entities generated from it can be traced back to it (e.g. when Kong rejects them).
+func GenerateTagsForObject(obj client.Object) []*string {
+	if obj == nil {
+		return nil
+	}
+	return FromK8sObject(obj).Tags()
+}
+
+// ObjectFromTags reconstructs a reference to the Kubernetes object a Kong entity was generated from,
+// using the tags generated by GenerateTagsForObject. It returns false if the tags don't identify
+// an object.
+func ObjectFromTags(tags []string) (client.Object, bool) {
+	var (
+		obj metav1.PartialObjectMetadata
+		gvk schema.GroupVersionKind
+	)
+	for _, tag := range tags {
+		switch {
+		case strings.HasPrefix(tag, K8sNameTagPrefix):
+			obj.Name = strings.TrimPrefix(tag, K8sNameTagPrefix)
+		case strings.HasPrefix(tag, K8sNamespaceTagPrefix):
+			obj.Namespace = strings.TrimPrefix(tag, K8sNamespaceTagPrefix)
+		case strings.HasPrefix(tag, K8sKindTagPrefix):
+			gvk.Kind = strings.TrimPrefix(tag, K8sKindTagPrefix)
+		case strings.HasPrefix(tag, K8sUIDTagPrefix):
+			obj.UID = types.UID(strings.TrimPrefix(tag, K8sUIDTagPrefix))
+		case strings.HasPrefix(tag, K8sGroupTagPrefix):
+			gvk.Group = strings.TrimPrefix(tag, K8sGroupTagPrefix)
+		case strings.HasPrefix(tag, K8sVersionTagPrefix):
+			gvk.Version = strings.TrimPrefix(tag, K8sVersionTagPrefix)
+		}
+	}
+	if obj.Name == "" || gvk.Kind == "" {
+		return nil, false
+	}
+	obj.SetGroupVersionKind(gvk)
+	return &obj, true
+}
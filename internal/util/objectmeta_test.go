@@ -4,6 +4,8 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	netv1 "k8s.io/api/networking/v1"
 	netv1beta1 "k8s.io/api/networking/v1beta1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -51,3 +53,46 @@ func TestFromK8sObject(t *testing.T) {
 		})
 	}
 }
+
+func TestGenerateTagsForObject(t *testing.T) {
+	ingress := &netv1.Ingress{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "name",
+			Namespace: "namespace",
+			UID:       "a6a4a9c0-1a4b-4f2f-9d6e-0f0c2b0d2b1e",
+		},
+	}
+
+	t.Log("an object without a kind can't be identified")
+	assert.Nil(t, GenerateTagsForObject(ingress))
+
+	ingress.SetGroupVersionKind(netv1.SchemeGroupVersion.WithKind("Ingress"))
+	tags := GenerateTagsForObject(ingress)
+	assert.Equal(t, []string{
+		"k8s-name:name",
+		"k8s-namespace:namespace",
+		"k8s-kind:Ingress",
+		"k8s-uid:a6a4a9c0-1a4b-4f2f-9d6e-0f0c2b0d2b1e",
+		"k8s-group:networking.k8s.io",
+		"k8s-version:v1",
+	}, stringsFromPointers(tags))
+
+	t.Log("the object reference can be reconstructed from the tags")
+	obj, ok := ObjectFromTags(append(stringsFromPointers(tags), "managed-by-ingress-controller"))
+	require.True(t, ok)
+	assert.Equal(t, ingress.GetObjectKind().GroupVersionKind(), obj.GetObjectKind().GroupVersionKind())
+	assert.Equal(t, ingress.Name, obj.GetName())
+	assert.Equal(t, ingress.Namespace, obj.GetNamespace())
+	assert.Equal(t, ingress.UID, obj.GetUID())
+
+	_, ok = ObjectFromTags([]string{"managed-by-ingress-controller"})
+	assert.False(t, ok)
+}
+
+func stringsFromPointers(ptrs []*string) []string {
+	result := make([]string, 0, len(ptrs))
+	for _, p := range ptrs {
+		result = append(result, *p)
+	}
+	return result
+}
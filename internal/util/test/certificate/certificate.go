@@ -0,0 +1,54 @@
+package certificate
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// SelfSigned generates a PEM encoded self-signed certificate for the provided hostname along
+// with its PEM encoded private key. The key algorithm must be either x509.RSA or x509.ECDSA.
+func SelfSigned(t *testing.T, hostname string, algorithm x509.PublicKeyAlgorithm) (cert []byte, key []byte) {
+	t.Helper()
+
+	var (
+		signer crypto.Signer
+		err    error
+	)
+	switch algorithm { //nolint:exhaustive
+	case x509.RSA:
+		signer, err = rsa.GenerateKey(rand.Reader, 2048)
+	case x509.ECDSA:
+		signer, err = ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	default:
+		t.Fatalf("unsupported key algorithm %s", algorithm)
+	}
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: hostname},
+		DNSNames:     []string{hostname},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(24 * time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, signer.Public(), signer)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalPKCS8PrivateKey(signer)
+	require.NoError(t, err)
+
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyDER})
+}
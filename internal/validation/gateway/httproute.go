@@ -2,8 +2,12 @@ package gateway
 
 import (
 	"fmt"
+	"regexp"
 
 	gatewayv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/versions"
+	configurationv1 "github.com/kong/kubernetes-ingress-controller/v2/pkg/apis/configuration/v1"
 )
 
 // -----------------------------------------------------------------------------
@@ -12,8 +16,14 @@ import (
 
 // ValidateHTTPRoute provides a suite of validation for a given HTTPRoute and
 // any number of Gateway resources it's attached to that the caller wants to
-// have it validated against.
-func ValidateHTTPRoute(httproute *gatewayv1beta1.HTTPRoute, attachedGateways ...*gatewayv1beta1.Gateway) (bool, string, error) {
+// have it validated against. Query param matches, which can only be translated
+// into routes matching requests with expressions, are only valid when
+// queryParamMatches is set.
+func ValidateHTTPRoute(
+	httproute *gatewayv1beta1.HTTPRoute,
+	queryParamMatches bool,
+	attachedGateways ...*gatewayv1beta1.Gateway,
+) (bool, string, error) {
 	// perform Gateway validations for the HTTPRoute (e.g. listener validation, namespace validation, e.t.c.)
 	for _, gateway := range attachedGateways {
 		// TODO: validate that the namespace is supported by the linked Gateway objects
@@ -40,13 +50,112 @@ func ValidateHTTPRoute(httproute *gatewayv1beta1.HTTPRoute, attachedGateways ...
 	}
 
 	// validate that no unsupported features are in use
-	if err := validateHTTPRouteFeatures(httproute); err != nil {
+	if err := validateHTTPRouteFeatures(httproute, queryParamMatches); err != nil {
 		return false, "httproute spec did not pass validation", err
 	}
 
 	return true, "", nil
 }
 
+// supportedHTTPRouteFilterTypes are the types of the HTTPRoute filters which
+// can be translated into Kong configuration. ResponseHeaderModifier filters are
+// not part of the Gateway API version this is built with (it requires v0.6.0).
+var supportedHTTPRouteFilterTypes = map[gatewayv1beta1.HTTPRouteFilterType]struct{}{
+	gatewayv1beta1.HTTPRouteFilterRequestHeaderModifier: {},
+	gatewayv1beta1.HTTPRouteFilterRequestRedirect:       {},
+	gatewayv1beta1.HTTPRouteFilterURLRewrite:            {},
+	gatewayv1beta1.HTTPRouteFilterRequestMirror:         {},
+	gatewayv1beta1.HTTPRouteFilterExtensionRef:          {},
+}
+
+// ValidateHTTPRouteFilters verifies that the filters of the rules of an HTTPRoute
+// are supported, alone and combined with the other filters and matches of their
+// rules, so that they can be translated into Kong configuration.
+func ValidateHTTPRouteFilters(httproute *gatewayv1beta1.HTTPRoute) error {
+	for ruleNumber, rule := range httproute.Spec.Rules {
+		filterTypes := make(map[gatewayv1beta1.HTTPRouteFilterType]struct{}, len(rule.Filters))
+		for _, filter := range rule.Filters {
+			if _, ok := supportedHTTPRouteFilterTypes[filter.Type]; !ok {
+				return fmt.Errorf("rule %d: %s filters are not supported", ruleNumber, filter.Type)
+			}
+			// rules can attach any number of plugins with ExtensionRef filters.
+			if _, ok := filterTypes[filter.Type]; ok && filter.Type != gatewayv1beta1.HTTPRouteFilterExtensionRef {
+				return fmt.Errorf("rule %d: only one %s filter is allowed per rule", ruleNumber, filter.Type)
+			}
+			filterTypes[filter.Type] = struct{}{}
+
+			var err error
+			switch filter.Type {
+			case gatewayv1beta1.HTTPRouteFilterRequestRedirect:
+				err = validateHTTPRouteRequestRedirect(filter.RequestRedirect, rule.Matches)
+			case gatewayv1beta1.HTTPRouteFilterURLRewrite:
+				err = validateHTTPRouteURLRewrite(filter.URLRewrite, rule.Matches)
+			case gatewayv1beta1.HTTPRouteFilterRequestMirror:
+				err = validateHTTPRouteRequestMirror(filter.RequestMirror)
+			case gatewayv1beta1.HTTPRouteFilterExtensionRef:
+				err = validateHTTPRouteExtensionRef(filter.ExtensionRef)
+			}
+			if err != nil {
+				return fmt.Errorf("rule %d: %w", ruleNumber, err)
+			}
+		}
+
+		// requests are either redirected or rewritten and proxied, not both.
+		_, redirect := filterTypes[gatewayv1beta1.HTTPRouteFilterRequestRedirect]
+		_, rewrite := filterTypes[gatewayv1beta1.HTTPRouteFilterURLRewrite]
+		if redirect && rewrite {
+			return fmt.Errorf("rule %d: RequestRedirect and URLRewrite filters can't be combined", ruleNumber)
+		}
+	}
+	return nil
+}
+
+// ValidateHTTPRouteHeaderMatches verifies that the header matches of the rules of an
+// HTTPRoute are supported by the version of Kong the controller is configuring.
+func ValidateHTTPRouteHeaderMatches(httproute *gatewayv1beta1.HTTPRoute) error {
+	kongVersion := versions.GetKongVersion()
+	for ruleNumber, rule := range httproute.Spec.Rules {
+		for _, match := range rule.Matches {
+			for _, hdr := range match.Headers {
+				if hdr.Type != nil && *hdr.Type == gatewayv1beta1.HeaderMatchRegularExpression &&
+					!kongVersion.MajorMinorOnly().GTE(versions.RegexHeaderVersionCutoff) {
+					return fmt.Errorf("rule %d: regex header matching requires Kong %s or later, the version of Kong is %s",
+						ruleNumber, versions.RegexHeaderVersionCutoff, kongVersion.Full())
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// queryParamNameRegex matches the query param names which can be part of the http.queries
+// fields of the expressions router.
+var queryParamNameRegex = regexp.MustCompile(`^[A-Za-z0-9_]+$`)
+
+// ValidateHTTPRouteQueryParamMatches verifies that the query param matches of the rules of an HTTPRoute
+// can be translated into expressions: query param matches must be supported, i.e. Kong runs the expressions
+// router of a version with the http.queries field, and the names of the query params must be usable in it.
+func ValidateHTTPRouteQueryParamMatches(httproute *gatewayv1beta1.HTTPRoute, queryParamMatches bool) error {
+	for ruleNumber, rule := range httproute.Spec.Rules {
+		for _, match := range rule.Matches {
+			if len(match.QueryParams) == 0 {
+				continue
+			}
+			if !queryParamMatches {
+				return fmt.Errorf("rule %d: queryparam matching is only supported for httproute with the expressions router of Kong %s or later",
+					ruleNumber, versions.QueryParamMatchVersionCutoff)
+			}
+			for _, queryParam := range match.QueryParams {
+				if !queryParamNameRegex.MatchString(queryParam.Name) {
+					return fmt.Errorf("rule %d: queryparam %q can't be matched, only letters, digits and underscores are supported in names",
+						ruleNumber, queryParam.Name)
+				}
+			}
+		}
+	}
+	return nil
+}
+
 // -----------------------------------------------------------------------------
 // Validation - HTTPRoute - Private Functions
 // -----------------------------------------------------------------------------
@@ -77,28 +186,30 @@ func validateHTTPRouteListener(listener *gatewayv1beta1.Listener) error {
 // validateHTTPRouteFeatures checks for features that are not supported by this
 // HTTPRoute implementation and validates that the provided object is not using
 // any of those unsupported features.
-func validateHTTPRouteFeatures(httproute *gatewayv1beta1.HTTPRoute) error {
+func validateHTTPRouteFeatures(httproute *gatewayv1beta1.HTTPRoute, queryParamMatches bool) error {
+	// we don't support all the filters nor all their combinations
+	if err := ValidateHTTPRouteFilters(httproute); err != nil {
+		return err
+	}
+
+	// regex header matching rules depend on the version of Kong
+	if err := ValidateHTTPRouteHeaderMatches(httproute); err != nil {
+		return err
+	}
+
+	// queryparam matching rules can only be translated into expressions
+	if err := ValidateHTTPRouteQueryParamMatches(httproute, queryParamMatches); err != nil {
+		return err
+	}
+
 	for _, rule := range httproute.Spec.Rules {
 		for _, match := range rule.Matches {
-			// we don't support queryparam matching rules
-			// See: https://github.com/Kong/kubernetes-ingress-controller/issues/2152
-			if len(match.QueryParams) != 0 {
-				return fmt.Errorf("queryparam matching is not yet supported for httproute")
-			}
-
 			// we don't support regex path matching rules
 			// See: https://github.com/Kong/kubernetes-ingress-controller/issues/2153
 			if match.Path != nil && match.Path.Type != nil && *match.Path.Type == gatewayv1beta1.PathMatchRegularExpression {
 				return fmt.Errorf("regex path matching is not yet supported for httproute")
 			}
 
-			// we don't support regex header matching rules
-			// See: https://github.com/Kong/kubernetes-ingress-controller/issues/2154
-			for _, hdr := range match.Headers {
-				if hdr.Type != nil && *hdr.Type == gatewayv1beta1.HeaderMatchRegularExpression {
-					return fmt.Errorf("regex header matching is not yet supported for httproute")
-				}
-			}
 		}
 
 		// we don't support any backendRef types except Kubernetes Services
@@ -114,6 +225,87 @@ func validateHTTPRouteFeatures(httproute *gatewayv1beta1.HTTPRoute) error {
 	return nil
 }
 
+// validateHTTPRouteRequestRedirect verifies that a RequestRedirect filter can be
+// applied to the requests matching the provided matches.
+func validateHTTPRouteRequestRedirect(redirect *gatewayv1beta1.HTTPRequestRedirectFilter, matches []gatewayv1beta1.HTTPRouteMatch) error {
+	if redirect == nil {
+		return fmt.Errorf("RequestRedirect filter has no configuration")
+	}
+	if redirect.Path == nil {
+		return nil
+	}
+	return validateHTTPPathModifier(gatewayv1beta1.HTTPRouteFilterRequestRedirect, *redirect.Path, matches)
+}
+
+// validateHTTPRouteURLRewrite verifies that a URLRewrite filter can be applied
+// to the requests matching the provided matches.
+func validateHTTPRouteURLRewrite(rewrite *gatewayv1beta1.HTTPURLRewriteFilter, matches []gatewayv1beta1.HTTPRouteMatch) error {
+	if rewrite == nil {
+		return fmt.Errorf("URLRewrite filter has no configuration")
+	}
+	if rewrite.Path == nil {
+		return nil
+	}
+	return validateHTTPPathModifier(gatewayv1beta1.HTTPRouteFilterURLRewrite, *rewrite.Path, matches)
+}
+
+// validateHTTPRouteRequestMirror verifies that a RequestMirror filter references
+// a Service port, which is the only backend requests can be mirrored to.
+func validateHTTPRouteRequestMirror(mirror *gatewayv1beta1.HTTPRequestMirrorFilter) error {
+	if mirror == nil {
+		return fmt.Errorf("RequestMirror filter has no configuration")
+	}
+	ref := mirror.BackendRef
+	if (ref.Group != nil && *ref.Group != "" && *ref.Group != "core") || (ref.Kind != nil && *ref.Kind != "Service") {
+		return fmt.Errorf("RequestMirror filter backendRef %s must reference a Service", ref.Name)
+	}
+	if ref.Port == nil {
+		return fmt.Errorf("RequestMirror filter backendRef %s has no port", ref.Name)
+	}
+	return nil
+}
+
+// validateHTTPRouteExtensionRef verifies that an ExtensionRef filter references
+// a KongPlugin or a KongClusterPlugin, the only extensions of HTTPRoutes.
+func validateHTTPRouteExtensionRef(ref *gatewayv1beta1.LocalObjectReference) error {
+	if ref == nil {
+		return fmt.Errorf("ExtensionRef filter has no configuration")
+	}
+	if string(ref.Group) != configurationv1.GroupVersion.Group || (ref.Kind != "KongPlugin" && ref.Kind != "KongClusterPlugin") {
+		return fmt.Errorf("ExtensionRef filter %s %s/%s is not supported, only KongPlugins and KongClusterPlugins can be referenced",
+			ref.Name, ref.Group, ref.Kind)
+	}
+	return nil
+}
+
+// validateHTTPPathModifier verifies that the path modifier of a filter of the provided
+// type can be applied to the paths of the requests matching the provided matches.
+func validateHTTPPathModifier(
+	filterType gatewayv1beta1.HTTPRouteFilterType,
+	modifier gatewayv1beta1.HTTPPathModifier,
+	matches []gatewayv1beta1.HTTPRouteMatch,
+) error {
+	switch modifier.Type {
+	case gatewayv1beta1.FullPathHTTPPathModifier:
+		if modifier.ReplaceFullPath == nil {
+			return fmt.Errorf("%s filter replacing the full path has no replacement path", filterType)
+		}
+	case gatewayv1beta1.PrefixMatchHTTPPathModifier:
+		if modifier.ReplacePrefixMatch == nil {
+			return fmt.Errorf("%s filter replacing the prefix match has no replacement prefix", filterType)
+		}
+		// the prefix to replace is the path matched by the rule, so it must be a prefix.
+		for _, match := range matches {
+			if match.Path != nil && match.Path.Type != nil && *match.Path.Type != gatewayv1beta1.PathMatchPathPrefix {
+				return fmt.Errorf("%s filter replacing the prefix match requires PathPrefix path matches, got %s", filterType, *match.Path.Type)
+			}
+		}
+	default:
+		return fmt.Errorf("%s filter path modifier type %s is not supported", filterType, modifier.Type)
+	}
+	return nil
+}
+
 // -----------------------------------------------------------------------------
 // Validation - HTTPRoute - Private Utility Functions
 // -----------------------------------------------------------------------------
@@ -4,11 +4,14 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/blang/semver/v4"
 	"github.com/kong/go-kong/kong"
 	"github.com/stretchr/testify/assert"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	gatewayv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/versions"
 )
 
 func TestValidateHTTPRoute(t *testing.T) {
@@ -23,12 +26,13 @@ func TestValidateHTTPRoute(t *testing.T) {
 	)
 
 	for _, tt := range []struct {
-		msg           string
-		route         *gatewayv1beta1.HTTPRoute
-		gateways      []*gatewayv1beta1.Gateway
-		valid         bool
-		validationMsg string
-		err           error
+		msg               string
+		route             *gatewayv1beta1.HTTPRoute
+		gateways          []*gatewayv1beta1.Gateway
+		queryParamMatches bool
+		valid             bool
+		validationMsg     string
+		err               error
 	}{
 		{
 			msg: "if you provide errant gateways for validation, it fails validation",
@@ -203,7 +207,7 @@ func TestValidateHTTPRoute(t *testing.T) {
 			err:           fmt.Errorf("HTTPRoute not supported by listener http-alternate"),
 		},
 		{
-			msg: "if an HTTPRoute is using queryparams matching it fails validation due to lack of support",
+			msg: "if an HTTPRoute is using queryparams matching it fails validation without the expressions router",
 			route: &gatewayv1beta1.HTTPRoute{
 				ObjectMeta: metav1.ObjectMeta{
 					Namespace: corev1.NamespaceDefault,
@@ -253,7 +257,113 @@ func TestValidateHTTPRoute(t *testing.T) {
 			}},
 			valid:         false,
 			validationMsg: "httproute spec did not pass validation",
-			err:           fmt.Errorf("queryparam matching is not yet supported for httproute"),
+			err:           fmt.Errorf("rule 0: queryparam matching is only supported for httproute with the expressions router of Kong 3.4.0 or later"),
+		},
+		{
+			msg: "if an HTTPRoute is using queryparams matching it passes validation with the expressions router",
+			route: &gatewayv1beta1.HTTPRoute{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: corev1.NamespaceDefault,
+					Name:      "testing-httproute",
+				},
+				Spec: gatewayv1beta1.HTTPRouteSpec{
+					CommonRouteSpec: gatewayv1beta1.CommonRouteSpec{
+						ParentRefs: []gatewayv1beta1.ParentReference{{
+							Name: "testing-gateway",
+						}},
+					},
+					Rules: []gatewayv1beta1.HTTPRouteRule{{
+						Matches: []gatewayv1beta1.HTTPRouteMatch{{
+							QueryParams: []gatewayv1beta1.HTTPQueryParamMatch{{
+								Name:  "user_agent",
+								Value: "netscape navigator",
+							}},
+						}},
+						BackendRefs: []gatewayv1beta1.HTTPBackendRef{{
+							BackendRef: gatewayv1beta1.BackendRef{
+								BackendObjectReference: gatewayv1beta1.BackendObjectReference{
+									Namespace: &defaultGWNamespace,
+								},
+							},
+						}},
+					}},
+				},
+			},
+			gateways: []*gatewayv1beta1.Gateway{{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: corev1.NamespaceDefault,
+					Name:      "testing-gateway",
+				},
+				Spec: gatewayv1beta1.GatewaySpec{
+					Listeners: []gatewayv1beta1.Listener{{
+						Name:     "http",
+						Port:     80,
+						Protocol: (gatewayv1beta1.HTTPProtocolType),
+						AllowedRoutes: &gatewayv1beta1.AllowedRoutes{
+							Kinds: []gatewayv1beta1.RouteGroupKind{{
+								Group: &group,
+								Kind:  "HTTPRoute",
+							}},
+						},
+					}},
+				},
+			}},
+			queryParamMatches: true,
+			valid:             true,
+		},
+		{
+			msg: "if an HTTPRoute is using queryparams matching with a name which can't be used in expressions it fails validation",
+			route: &gatewayv1beta1.HTTPRoute{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: corev1.NamespaceDefault,
+					Name:      "testing-httproute",
+				},
+				Spec: gatewayv1beta1.HTTPRouteSpec{
+					CommonRouteSpec: gatewayv1beta1.CommonRouteSpec{
+						ParentRefs: []gatewayv1beta1.ParentReference{{
+							Name: "testing-gateway",
+						}},
+					},
+					Rules: []gatewayv1beta1.HTTPRouteRule{{
+						Matches: []gatewayv1beta1.HTTPRouteMatch{{
+							QueryParams: []gatewayv1beta1.HTTPQueryParamMatch{{
+								Name:  "user-agent",
+								Value: "netscape navigator",
+							}},
+						}},
+						BackendRefs: []gatewayv1beta1.HTTPBackendRef{{
+							BackendRef: gatewayv1beta1.BackendRef{
+								BackendObjectReference: gatewayv1beta1.BackendObjectReference{
+									Namespace: &defaultGWNamespace,
+								},
+							},
+						}},
+					}},
+				},
+			},
+			gateways: []*gatewayv1beta1.Gateway{{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: corev1.NamespaceDefault,
+					Name:      "testing-gateway",
+				},
+				Spec: gatewayv1beta1.GatewaySpec{
+					Listeners: []gatewayv1beta1.Listener{{
+						Name:     "http",
+						Port:     80,
+						Protocol: (gatewayv1beta1.HTTPProtocolType),
+						AllowedRoutes: &gatewayv1beta1.AllowedRoutes{
+							Kinds: []gatewayv1beta1.RouteGroupKind{{
+								Group: &group,
+								Kind:  "HTTPRoute",
+							}},
+						},
+					}},
+				},
+			}},
+			queryParamMatches: true,
+			valid:             false,
+			validationMsg:     "httproute spec did not pass validation",
+			err:               fmt.Errorf("rule 0: queryparam \"user-agent\" can't be matched, only letters, digits and underscores are supported in names"),
 		},
 		{
 			msg: "if an HTTPRoute is using regex path matching it fails validation due to lack of support",
@@ -309,7 +419,7 @@ func TestValidateHTTPRoute(t *testing.T) {
 			err:           fmt.Errorf("regex path matching is not yet supported for httproute"),
 		},
 		{
-			msg: "if an HTTPRoute is using regex header matching it fails validation due to lack of support",
+			msg: "if an HTTPRoute is using regex header matching with an old version of Kong it fails validation",
 			route: &gatewayv1beta1.HTTPRoute{
 				ObjectMeta: metav1.ObjectMeta{
 					Namespace: corev1.NamespaceDefault,
@@ -360,7 +470,7 @@ func TestValidateHTTPRoute(t *testing.T) {
 			}},
 			valid:         false,
 			validationMsg: "httproute spec did not pass validation",
-			err:           fmt.Errorf("regex header matching is not yet supported for httproute"),
+			err:           fmt.Errorf("rule 0: regex header matching requires Kong 2.8.0 or later, the version of Kong is 0.0.0"),
 		},
 		{
 			msg: "we don't support any group except core kubernetes for backendRefs",
@@ -478,9 +588,208 @@ func TestValidateHTTPRoute(t *testing.T) {
 			err:           fmt.Errorf("Pod is not a supported kind for httproute backendRefs, only Service is supported"),
 		},
 	} {
-		valid, validMsg, err := ValidateHTTPRoute(tt.route, tt.gateways...)
+		valid, validMsg, err := ValidateHTTPRoute(tt.route, tt.queryParamMatches, tt.gateways...)
 		assert.Equal(t, tt.valid, valid, tt.msg)
 		assert.Equal(t, tt.validationMsg, validMsg, tt.msg)
 		assert.Equal(t, tt.err, err, tt.msg)
 	}
 }
+
+func TestValidateHTTPRouteFilters(t *testing.T) {
+	var (
+		pathMatchExact  = gatewayv1beta1.PathMatchExact
+		port            = gatewayv1beta1.PortNumber(80)
+		pathMatchPrefix = gatewayv1beta1.PathMatchPathPrefix
+		redirect        = gatewayv1beta1.HTTPRouteFilter{
+			Type: gatewayv1beta1.HTTPRouteFilterRequestRedirect,
+			RequestRedirect: &gatewayv1beta1.HTTPRequestRedirectFilter{
+				Scheme: kong.String("https"),
+			},
+		}
+		replacePrefixRedirect = gatewayv1beta1.HTTPRouteFilter{
+			Type: gatewayv1beta1.HTTPRouteFilterRequestRedirect,
+			RequestRedirect: &gatewayv1beta1.HTTPRequestRedirectFilter{
+				Path: &gatewayv1beta1.HTTPPathModifier{
+					Type:               gatewayv1beta1.PrefixMatchHTTPPathModifier,
+					ReplacePrefixMatch: kong.String("/v2"),
+				},
+			},
+		}
+		replaceFullPathRewrite = gatewayv1beta1.HTTPRouteFilter{
+			Type: gatewayv1beta1.HTTPRouteFilterURLRewrite,
+			URLRewrite: &gatewayv1beta1.HTTPURLRewriteFilter{
+				Path: &gatewayv1beta1.HTTPPathModifier{
+					Type:            gatewayv1beta1.FullPathHTTPPathModifier,
+					ReplaceFullPath: kong.String("/v2"),
+				},
+			},
+		}
+	)
+
+	for _, tc := range []struct {
+		name        string
+		rule        gatewayv1beta1.HTTPRouteRule
+		expectedErr string
+	}{
+		{
+			name: "redirect",
+			rule: gatewayv1beta1.HTTPRouteRule{
+				Filters: []gatewayv1beta1.HTTPRouteFilter{redirect},
+			},
+		},
+		{
+			name: "redirect replacing a prefix match",
+			rule: gatewayv1beta1.HTTPRouteRule{
+				Matches: []gatewayv1beta1.HTTPRouteMatch{{
+					Path: &gatewayv1beta1.HTTPPathMatch{Type: &pathMatchPrefix, Value: kong.String("/v1")},
+				}},
+				Filters: []gatewayv1beta1.HTTPRouteFilter{replacePrefixRedirect},
+			},
+		},
+		{
+			name: "redirect replacing a prefix match of an exact path match",
+			rule: gatewayv1beta1.HTTPRouteRule{
+				Matches: []gatewayv1beta1.HTTPRouteMatch{{
+					Path: &gatewayv1beta1.HTTPPathMatch{Type: &pathMatchExact, Value: kong.String("/v1")},
+				}},
+				Filters: []gatewayv1beta1.HTTPRouteFilter{replacePrefixRedirect},
+			},
+			expectedErr: "rule 0: RequestRedirect filter replacing the prefix match requires PathPrefix path matches, got Exact",
+		},
+		{
+			name: "rewrite replacing the full path",
+			rule: gatewayv1beta1.HTTPRouteRule{
+				Matches: []gatewayv1beta1.HTTPRouteMatch{{
+					Path: &gatewayv1beta1.HTTPPathMatch{Type: &pathMatchExact, Value: kong.String("/v1")},
+				}},
+				Filters: []gatewayv1beta1.HTTPRouteFilter{replaceFullPathRewrite},
+			},
+		},
+		{
+			name: "rewrite replacing a prefix match without replacement",
+			rule: gatewayv1beta1.HTTPRouteRule{
+				Filters: []gatewayv1beta1.HTTPRouteFilter{{
+					Type: gatewayv1beta1.HTTPRouteFilterURLRewrite,
+					URLRewrite: &gatewayv1beta1.HTTPURLRewriteFilter{
+						Path: &gatewayv1beta1.HTTPPathModifier{Type: gatewayv1beta1.PrefixMatchHTTPPathModifier},
+					},
+				}},
+			},
+			expectedErr: "rule 0: URLRewrite filter replacing the prefix match has no replacement prefix",
+		},
+		{
+			name: "redirect and rewrite",
+			rule: gatewayv1beta1.HTTPRouteRule{
+				Filters: []gatewayv1beta1.HTTPRouteFilter{redirect, replaceFullPathRewrite},
+			},
+			expectedErr: "rule 0: RequestRedirect and URLRewrite filters can't be combined",
+		},
+		{
+			name: "mirror",
+			rule: gatewayv1beta1.HTTPRouteRule{
+				Filters: []gatewayv1beta1.HTTPRouteFilter{{
+					Type: gatewayv1beta1.HTTPRouteFilterRequestMirror,
+					RequestMirror: &gatewayv1beta1.HTTPRequestMirrorFilter{
+						BackendRef: gatewayv1beta1.BackendObjectReference{Name: "canary", Port: &port},
+					},
+				}},
+			},
+		},
+		{
+			name: "mirror without port",
+			rule: gatewayv1beta1.HTTPRouteRule{
+				Filters: []gatewayv1beta1.HTTPRouteFilter{{
+					Type: gatewayv1beta1.HTTPRouteFilterRequestMirror,
+					RequestMirror: &gatewayv1beta1.HTTPRequestMirrorFilter{
+						BackendRef: gatewayv1beta1.BackendObjectReference{Name: "canary"},
+					},
+				}},
+			},
+			expectedErr: "rule 0: RequestMirror filter backendRef canary has no port",
+		},
+		{
+			name: "multiple redirects",
+			rule: gatewayv1beta1.HTTPRouteRule{
+				Filters: []gatewayv1beta1.HTTPRouteFilter{redirect, redirect},
+			},
+			expectedErr: "rule 0: only one RequestRedirect filter is allowed per rule",
+		},
+		{
+			name: "multiple plugins",
+			rule: gatewayv1beta1.HTTPRouteRule{
+				Filters: []gatewayv1beta1.HTTPRouteFilter{
+					{
+						Type: gatewayv1beta1.HTTPRouteFilterExtensionRef,
+						ExtensionRef: &gatewayv1beta1.LocalObjectReference{
+							Group: "configuration.konghq.com", Kind: "KongPlugin", Name: "auth",
+						},
+					},
+					{
+						Type: gatewayv1beta1.HTTPRouteFilterExtensionRef,
+						ExtensionRef: &gatewayv1beta1.LocalObjectReference{
+							Group: "configuration.konghq.com", Kind: "KongClusterPlugin", Name: "rate-limit",
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "unsupported extension",
+			rule: gatewayv1beta1.HTTPRouteRule{
+				Filters: []gatewayv1beta1.HTTPRouteFilter{{
+					Type: gatewayv1beta1.HTTPRouteFilterExtensionRef,
+					ExtensionRef: &gatewayv1beta1.LocalObjectReference{
+						Group: "example.com", Kind: "Extension", Name: "ext",
+					},
+				}},
+			},
+			expectedErr: "rule 0: ExtensionRef filter ext example.com/Extension is not supported, only KongPlugins and KongClusterPlugins can be referenced",
+		},
+		{
+			name: "unsupported filter",
+			rule: gatewayv1beta1.HTTPRouteRule{
+				Filters: []gatewayv1beta1.HTTPRouteFilter{{Type: "ResponseHeaderModifier"}},
+			},
+			expectedErr: "rule 0: ResponseHeaderModifier filters are not supported",
+		},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			httproute := &gatewayv1beta1.HTTPRoute{
+				Spec: gatewayv1beta1.HTTPRouteSpec{
+					Rules: []gatewayv1beta1.HTTPRouteRule{tc.rule},
+				},
+			}
+			err := ValidateHTTPRouteFilters(httproute)
+			if tc.expectedErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, tc.expectedErr)
+		})
+	}
+}
+
+func TestValidateHTTPRouteHeaderMatches(t *testing.T) {
+	headerMatchRegex := gatewayv1beta1.HeaderMatchRegularExpression
+	httproute := &gatewayv1beta1.HTTPRoute{
+		Spec: gatewayv1beta1.HTTPRouteSpec{
+			Rules: []gatewayv1beta1.HTTPRouteRule{{
+				Matches: []gatewayv1beta1.HTTPRouteMatch{{
+					Headers: []gatewayv1beta1.HTTPHeaderMatch{{
+						Type:  &headerMatchRegex,
+						Name:  "Content-Type",
+						Value: "^audio/.*",
+					}},
+				}},
+			}},
+		},
+	}
+
+	// versions reports Kong version 0.0.0 when not initialized
+	assert.EqualError(t, ValidateHTTPRouteHeaderMatches(httproute),
+		"rule 0: regex header matching requires Kong 2.8.0 or later, the version of Kong is 0.0.0")
+
+	versions.SetKongVersion(semver.MustParse("3.0.0"))
+	assert.NoError(t, ValidateHTTPRouteHeaderMatches(httproute))
+}
@@ -18,6 +18,14 @@ var (
 	// PluginOrderingVersionCutoff is the Kong version prior to the addition of plugin ordering.
 	PluginOrderingVersionCutoff = semver.Version{Major: 3}
 
+	// DualCertificateVersionCutoff is the Kong version prior to the addition of alternative certificates
+	// (cert_alt and key_alt) to certificates.
+	DualCertificateVersionCutoff = semver.Version{Major: 2, Minor: 3}
+
+	// QueryParamMatchVersionCutoff is the Kong version prior to the addition of the http.queries field to the
+	// expressions router, which query param matches are translated into.
+	QueryParamMatchVersionCutoff = semver.Version{Major: 3, Minor: 4}
+
 	// MTLSCredentialVersionCutoff is the minimum Kong version that support mTLS credentials. This is a patch version
 	// because the original version of the mTLS credential was not compatible with KIC.
 	MTLSCredentialVersionCutoff = semver.Version{Major: 2, Minor: 3, Patch: 2}
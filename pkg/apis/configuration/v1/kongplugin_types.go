@@ -80,8 +80,65 @@ type KongPlugin struct {
 	// For example, a KongPlugin with `plugin: rate-limiting` and `before.access: ["key-auth"]`
 	// will create a rate limiting plugin that limits requests _before_ they are authenticated.
 	Ordering *kong.PluginOrdering `json:"ordering,omitempty"`
+
+	// TargetRef attaches the plugin to a Gateway, one of its listeners, an HTTPRoute or a Service of the
+	// namespace of the KongPlugin, as a Gateway API policy. It's an alternative to referencing the plugin
+	// from the konghq.com/plugins annotation of the target.
+	TargetRef *PolicyTargetReference `json:"targetRef,omitempty"`
+
+	// Override makes the plugin attached with TargetRef take precedence over the plugins of the same type
+	// attached to the objects lower in the Gateway > listener > HTTPRoute > Service hierarchy. Otherwise,
+	// the plugin is a default which the plugins attached lower in the hierarchy take precedence over.
+	Override bool `json:"override,omitempty"`
+
+	// Status is the status of the policy attachment of the plugin.
+	Status KongPluginStatus `json:"status,omitempty"`
+}
+
+// PolicyTargetReference identifies the object a KongPlugin is attached to as a policy.
+type PolicyTargetReference struct {
+	// Group is the group of the target object: "gateway.networking.k8s.io" for Gateways and
+	// HTTPRoutes, or the empty core group for Services.
+	Group string `json:"group"`
+
+	// Kind is the kind of the target object.
+	// +kubebuilder:validation:Enum=Gateway;HTTPRoute;Service
+	Kind string `json:"kind"`
+
+	// Name is the name of the target object, which is in the namespace of the KongPlugin.
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+
+	// SectionName is the name of the listener the plugin is attached to when the target is a Gateway.
+	// The plugin is then applied only to the routes attached to that listener through the sectionName
+	// of their parentRefs.
+	SectionName *string `json:"sectionName,omitempty"`
 }
 
+// KongPluginStatus represents the current status of the policy attachment of a KongPlugin.
+type KongPluginStatus struct {
+	// Conditions describe the current conditions of the policy attachment.
+	// +listType=map
+	// +listMapKey=type
+	// +kubebuilder:validation:MaxItems=8
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+const (
+	// KongPluginConditionAccepted indicates whether the target of a KongPlugin policy attachment
+	// has been resolved.
+	KongPluginConditionAccepted = "Accepted"
+
+	// KongPluginReasonAccepted is used with the Accepted condition when it's true.
+	KongPluginReasonAccepted = "Accepted"
+
+	// KongPluginReasonInvalid is used with the Accepted condition when the targetRef isn't supported.
+	KongPluginReasonInvalid = "Invalid"
+
+	// KongPluginReasonTargetNotFound is used with the Accepted condition when the target doesn't exist.
+	KongPluginReasonTargetNotFound = "TargetNotFound"
+)
+
 // +kubebuilder:object:root=true
 
 // KongPluginList contains a list of KongPlugin.
@@ -23,6 +23,7 @@ package v1
 
 import (
 	"github.com/kong/go-kong/kong"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
@@ -498,6 +499,12 @@ func (in *KongPlugin) DeepCopyInto(out *KongPlugin) {
 		*out = new(kong.PluginOrdering)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.TargetRef != nil {
+		in, out := &in.TargetRef, &out.TargetRef
+		*out = new(PolicyTargetReference)
+		(*in).DeepCopyInto(*out)
+	}
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KongPlugin.
@@ -550,6 +557,28 @@ func (in *KongPluginList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KongPluginStatus) DeepCopyInto(out *KongPluginStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KongPluginStatus.
+func (in *KongPluginStatus) DeepCopy() *KongPluginStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(KongPluginStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NamespacedConfigSource) DeepCopyInto(out *NamespacedConfigSource) {
 	*out = *in
@@ -581,6 +610,26 @@ func (in *NamespacedSecretValueFromSource) DeepCopy() *NamespacedSecretValueFrom
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PolicyTargetReference) DeepCopyInto(out *PolicyTargetReference) {
+	*out = *in
+	if in.SectionName != nil {
+		in, out := &in.SectionName, &out.SectionName
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PolicyTargetReference.
+func (in *PolicyTargetReference) DeepCopy() *PolicyTargetReference {
+	if in == nil {
+		return nil
+	}
+	out := new(PolicyTargetReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SecretValueFromSource) DeepCopyInto(out *SecretValueFromSource) {
 	*out = *in
@@ -0,0 +1,103 @@
+/*
+Copyright 2022 Kong, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	GatewayClassParametersKind = "GatewayClassParameters"
+)
+
+// +kubebuilder:object:root=true
+
+// GatewayClassParametersList contains a list of GatewayClassParameters.
+type GatewayClassParametersList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []GatewayClassParameters `json:"items"`
+}
+
+// +genclient
+// +genclient:nonNamespaced
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:object:root=true
+// +kubebuilder:storageversion
+// +kubebuilder:resource:scope=Cluster,categories=kong-ingress-controller
+// +kubebuilder:resource:path=gatewayclassparameterses
+
+// GatewayClassParameters is the Schema for the GatewayClassParameters API. It's referenced by the parametersRef
+// of GatewayClasses to configure the defaults of the Gateways of the class and of the routes attached to them.
+type GatewayClassParameters struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// Spec is the GatewayClassParameters specification.
+	Spec GatewayClassParametersSpec `json:"spec,omitempty"`
+}
+
+// GatewayClassParametersSpec defines the desired state of GatewayClassParameters.
+type GatewayClassParametersSpec struct {
+	// PublishService is the "namespace/name" of the Kong proxy Service which addresses and listeners are
+	// published to the unmanaged Gateways of the class. The --publish-service of the controller is used
+	// when it's empty.
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?/[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	PublishService string `json:"publishService,omitempty"`
+
+	// Offload load-balancing of the routes attached to the Gateways of the class to kube-proxy or sidecar.
+	// +kubebuilder:default:=false
+	ServiceUpstream bool `json:"serviceUpstream,omitempty"`
+
+	// EnableLegacyRegexDetection automatically detects if the paths of the HTTPRoutes attached to the Gateways of
+	// the class are regular expression paths using the legacy 2.x heuristic. The controller adds the "~" prefix
+	// to those paths if the Kong version is 3.0 or higher.
+	// +kubebuilder:default:=false
+	EnableLegacyRegexDetection bool `json:"enableLegacyRegexDetection,omitempty"`
+
+	// Upstream holds the defaults of the Kong services generated for the routes attached to the Gateways of the
+	// class. KongIngresses and annotations take precedence over them.
+	Upstream *GatewayClassUpstreamDefaults `json:"upstream,omitempty"`
+
+	// Plugins are the names of the KongClusterPlugins applied by default to the Kong services generated for the
+	// routes attached to the Gateways of the class. Plugins of the same type attached to the routes take
+	// precedence over them.
+	Plugins []string `json:"plugins,omitempty"`
+}
+
+// GatewayClassUpstreamDefaults defines the defaults of the Kong services generated for Gateway API routes.
+type GatewayClassUpstreamDefaults struct {
+	// ConnectTimeout is the timeout in milliseconds for establishing a connection to the upstream.
+	// +kubebuilder:validation:Minimum=0
+	ConnectTimeout *int `json:"connectTimeout,omitempty"`
+
+	// ReadTimeout is the timeout in milliseconds between two successive read operations on the upstream.
+	// +kubebuilder:validation:Minimum=0
+	ReadTimeout *int `json:"readTimeout,omitempty"`
+
+	// WriteTimeout is the timeout in milliseconds between two successive write operations on the upstream.
+	// +kubebuilder:validation:Minimum=0
+	WriteTimeout *int `json:"writeTimeout,omitempty"`
+
+	// Retries is the number of retries to execute upon failure to proxy.
+	// +kubebuilder:validation:Minimum=0
+	Retries *int `json:"retries,omitempty"`
+}
+
+func init() {
+	SchemeBuilder.Register(&GatewayClassParameters{}, &GatewayClassParametersList{})
+}
@@ -28,6 +28,7 @@ import (
 
 type ConfigurationV1alpha1Interface interface {
 	RESTClient() rest.Interface
+	GatewayClassParametersesGetter
 	IngressClassParametersesGetter
 }
 
@@ -36,6 +37,10 @@ type ConfigurationV1alpha1Client struct {
 	restClient rest.Interface
 }
 
+func (c *ConfigurationV1alpha1Client) GatewayClassParameterses() GatewayClassParametersInterface {
+	return newGatewayClassParameterses(c)
+}
+
 func (c *ConfigurationV1alpha1Client) IngressClassParameterses(namespace string) IngressClassParametersInterface {
 	return newIngressClassParameterses(c, namespace)
 }
@@ -28,6 +28,10 @@ type FakeConfigurationV1alpha1 struct {
 	*testing.Fake
 }
 
+func (c *FakeConfigurationV1alpha1) GatewayClassParameterses() v1alpha1.GatewayClassParametersInterface {
+	return &FakeGatewayClassParameterses{c}
+}
+
 func (c *FakeConfigurationV1alpha1) IngressClassParameterses(namespace string) v1alpha1.IngressClassParametersInterface {
 	return &FakeIngressClassParameterses{c, namespace}
 }
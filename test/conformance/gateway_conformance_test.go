@@ -21,6 +21,7 @@ import (
 	"github.com/kong/kubernetes-ingress-controller/v2/internal/controllers/gateway"
 	testutils "github.com/kong/kubernetes-ingress-controller/v2/internal/util/test"
 	"github.com/kong/kubernetes-ingress-controller/v2/test/consts"
+	"github.com/kong/kubernetes-ingress-controller/v2/test/internal/testenv"
 )
 
 var (
@@ -68,6 +69,14 @@ func TestGatewayConformance(t *testing.T) {
 	require.NoError(t, client.Create(ctx, gwc))
 	t.Cleanup(func() { assert.NoError(t, client.Delete(ctx, gwc)) })
 
+	supportedFeatures := []suite.SupportedFeature{
+		suite.SupportReferenceGrant,
+	}
+	// query param matches can only be translated into routes for the expressions router.
+	if testenv.KongRouterFlavor() == "expressions" {
+		supportedFeatures = append(supportedFeatures, suite.SupportHTTPRouteQueryParamMatching)
+	}
+
 	t.Log("starting the gateway conformance test suite")
 	cSuite := suite.New(suite.Options{
 		Client:               client,
@@ -75,11 +84,7 @@ func TestGatewayConformance(t *testing.T) {
 		Debug:                showDebug,
 		CleanupBaseResources: shouldCleanup,
 		BaseManifests:        conformanceTestsBaseManifests,
-		SupportedFeatures: []suite.SupportedFeature{
-			suite.SupportReferenceGrant,
-			// TODO: https://github.com/Kong/kubernetes-ingress-controller/issues/2778
-			// suite.SupportHTTPRouteQueryParamMatching,
-		},
+		SupportedFeatures:    supportedFeatures,
 	})
 	cSuite.Setup(t)
 
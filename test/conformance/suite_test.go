@@ -18,6 +18,7 @@ import (
 	"github.com/sirupsen/logrus"
 
 	testutils "github.com/kong/kubernetes-ingress-controller/v2/internal/util/test"
+	"github.com/kong/kubernetes-ingress-controller/v2/test/internal/testenv"
 )
 
 var (
@@ -51,7 +52,11 @@ func TestMain(m *testing.M) {
 	globalDeprecatedLogger = deprecatedLogger
 	globalLogger = logger
 
-	kongAddon := kong.NewBuilder().WithControllerDisabled().WithProxyAdminServiceTypeLoadBalancer().Build()
+	kongBuilder := kong.NewBuilder().WithControllerDisabled().WithProxyAdminServiceTypeLoadBalancer()
+	if flavor := testenv.KongRouterFlavor(); flavor != "" {
+		kongBuilder = kongBuilder.WithProxyEnvVar("router_flavor", flavor)
+	}
+	kongAddon := kongBuilder.Build()
 	builder := environments.NewBuilder().WithAddons(metallb.New(), kongAddon)
 	useExistingClusterIfPresent(builder)
 
@@ -215,9 +215,38 @@ func TestTranslationFailures(t *testing.T) {
 
 				gateway := deployGatewayReferringSecrets(ctx, t, cleaner, ns, secret1, secret2)
 
+				// two certificateRefs are supported, but the certificates can't be constructed from empty Secrets.
 				return expectedTranslationFailure{
-					causingObjects: []client.Object{gateway},
-					reasonContains: "more than one certificateRef",
+					causingObjects: []client.Object{gateway, secret1},
+					reasonContains: "failed to construct certificate from secret",
+				}
+			},
+		},
+		{
+			name: "certificates with the same key type referred by a gateway listener",
+			translationFailureTrigger: func(t *testing.T, cleaner *clusters.Cleaner, ns string) expectedTranslationFailure {
+				secrets := make([]*corev1.Secret, 0, len(tlsRouteTLSPairs))
+				for _, pair := range tlsRouteTLSPairs {
+					secret := &corev1.Secret{
+						ObjectMeta: metav1.ObjectMeta{Name: testutils.RandomName(testTranslationFailuresObjectsPrefix)},
+						Type:       corev1.SecretTypeTLS,
+						Data: map[string][]byte{
+							"tls.crt": []byte(pair.Cert),
+							"tls.key": []byte(pair.Key),
+						},
+					}
+					secret, err := env.Cluster().Client().CoreV1().Secrets(ns).Create(ctx, secret, metav1.CreateOptions{})
+					require.NoError(t, err)
+					cleaner.Add(secret)
+					secrets = append(secrets, secret)
+				}
+
+				// both certificates have ECDSA keys.
+				gateway := deployGatewayReferringSecrets(ctx, t, cleaner, ns, secrets...)
+
+				return expectedTranslationFailure{
+					causingObjects: []client.Object{gateway, secrets[0], secrets[1]},
+					reasonContains: "must have different key types",
 				}
 			},
 		},
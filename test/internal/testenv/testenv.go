@@ -33,10 +33,11 @@ func KongTag() string {
 
 // KongRouterFlavor returns router mode of Kong in tests. Currently supports:
 // - `traditional`
-// - `traditional_compatible`.
+// - `traditional_compatible`
+// - `expressions`.
 func KongRouterFlavor() string {
 	rf := os.Getenv("TEST_KONG_ROUTER_FLAVOR")
-	if rf != "" && rf != "traditional" && rf != "traditional_compatible" {
+	if rf != "" && rf != "traditional" && rf != "traditional_compatible" && rf != "expressions" {
 		// TODO
 		os.Exit(1)
 	}